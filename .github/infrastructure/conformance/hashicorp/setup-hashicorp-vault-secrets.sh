@@ -10,6 +10,18 @@ set -eu
 
 MAX_ATTEMPTS=30
 
+# Enables the AppRole auth method, with a role that has fixed credentials
+# matching tests/config/secretstores/hashicorp/vault/approle/hashicorp-vault.yml.
+# Tokens are short-lived, so they are renewed during the tests.
+setup_approle() {
+    (vault auth list | grep -q '^approle/' || vault auth enable approle) &&
+    printf 'path "secret/*" {\n  capabilities = ["create", "read", "update", "delete", "list"]\n}\n' | vault policy write dapr-conformance - &&
+    vault write auth/approle/role/dapr-conformance token_policies=dapr-conformance token_ttl=1m token_max_ttl=3m &&
+    vault write auth/approle/role/dapr-conformance/role-id role_id=dapr-conformance-role-id &&
+    (vault write auth/approle/role/dapr-conformance/custom-secret-id secret_id=dapr-conformance-secret-id ||
+        vault list auth/approle/role/dapr-conformance/secret-id-accessors > /dev/null)
+}
+
 for attempt in `seq $MAX_ATTEMPTS`; do
     # Test connectivity to vault server and create secrets to match
     # conformance tests / contents from tests/conformance/secrets.json
@@ -18,9 +30,13 @@ for attempt in `seq $MAX_ATTEMPTS`; do
         vault kv put secret/dapr/secondsecret secondsecret=efgh &&
         vault kv put secret/secretWithNoPrefix noPrefixKey=noProblem &&
         vault kv put secret/alternativePrefix/secretUnderAlternativePrefix altPrefixKey=altPrefixValue &&
-        vault kv put secret/dapr/multiplekeyvaluessecret first=1 second=2 third=3;
+        vault kv put secret/dapr/multiplekeyvaluessecret first=1 second=2 third=3 &&
+        (vault secrets list | grep -q '^transit/' || vault secrets enable transit) &&
+        vault write -f transit/keys/rsakey type=rsa-2048 &&
+        vault write -f transit/keys/ec256key type=ecdsa-p256 &&
+        setup_approle;
     then
-        echo ✅ secrets set;
+        echo ✅ secrets and transit keys set;
         sleep 1;
         exit 0;
     else
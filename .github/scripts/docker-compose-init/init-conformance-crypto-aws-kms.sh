@@ -0,0 +1,17 @@
+#!/bin/bash
+
+set -e
+
+# KMS keys are either for encryption or for signing, so RSA keys are created for each usage
+create_key() {
+    local alias=$1
+    local spec=$2
+    local usage=$3
+    local key_id
+    key_id=$(awslocal kms create-key --key-spec "$spec" --key-usage "$usage" --query KeyMetadata.KeyId --output text)
+    awslocal kms create-alias --alias-name "alias/$alias" --target-key-id "$key_id"
+}
+
+create_key ec256key ECC_NIST_P256 SIGN_VERIFY
+create_key rsasignkey RSA_2048 SIGN_VERIFY
+create_key rsaenckey RSA_2048 ENCRYPT_DECRYPT
@@ -225,6 +225,10 @@ const components = {
         conformanceSetup: 'docker-compose.sh redis7 redis',
         sourcePkg: ['bindings/redis', 'common/component/redis'],
     },
+    'configuration.local.file': {
+        conformance: true,
+        sourcePkg: ['configuration/local/file'],
+    },
     'configuration.postgres': {
         certification: true,
         sourcePkg: [
@@ -267,6 +271,15 @@ const components = {
         certification: true,
         sourcePkg: ['configuration/redis', 'configuration/redis/internal'],
     },
+    'configuration.sqlite': {
+        conformance: true,
+        sourcePkg: ['configuration/sqlite'],
+    },
+    'crypto.aws.kms': {
+        conformance: true,
+        conformanceSetup: 'docker-compose.sh kms',
+        sourcePkg: ['crypto/aws/kms', 'common/authentication/aws'],
+    },
     'crypto.azure.keyvault': {
         conformance: true,
         requiredSecrets: [
@@ -276,6 +289,11 @@ const components = {
             'AzureKeyVaultServicePrincipalClientSecret',
         ],
     },
+    'crypto.hashicorp.vault': {
+        conformance: true,
+        conformanceSetup: 'docker-compose.sh hashicorp-vault vault',
+        sourcePkg: ['crypto/hashicorp/vault', 'common/component/hashicorp/vault'],
+    },
     'crypto.localstorage': {
         conformance: true,
     },
@@ -489,6 +507,7 @@ const components = {
         conformance: true,
         certification: true,
         conformanceSetup: 'docker-compose.sh hashicorp-vault vault',
+        sourcePkg: ['secretstores/hashicorp/vault', 'common/component/hashicorp/vault'],
     },
     'secretstores.kubernetes': {
         conformance: true,
@@ -837,6 +856,10 @@ const components = {
         requireGCPCredentials: true,
         certificationSetup: 'certification-state.gcp.firestore-setup.sh',
     },
+    'workflows.sqlite': {
+        conformance: true,
+        sourcePkg: ['workflows/sqlite', 'common/component/sql'],
+    },
 }
 
 /**
@@ -40,8 +40,7 @@ import (
 const ExecuteOperation bindings.OperationKind = "execute"
 
 type outputBinding struct {
-	logger        logger.Logger
-	runtimeConfig wazero.RuntimeConfig
+	logger logger.Logger
 
 	meta    *wasm.InitMetadata
 	runtime wazero.Runtime
@@ -58,10 +57,6 @@ var (
 func NewWasmOutput(logger logger.Logger) bindings.OutputBinding {
 	return &outputBinding{
 		logger: logger,
-
-		// The below ensures context cancels in-flight wasm functions.
-		runtimeConfig: wazero.NewRuntimeConfig().
-			WithCloseOnContextDone(true),
 	}
 }
 
@@ -70,8 +65,14 @@ func (out *outputBinding) Init(ctx context.Context, metadata bindings.Metadata)
 		return fmt.Errorf("wasm: failed to parse metadata: %w", err)
 	}
 
+	runtimeConfig, err := wasm.NewRuntimeConfig(out.meta)
+	if err != nil {
+		return fmt.Errorf("wasm: %w", err)
+	}
+
 	// Create the runtime, which when closed releases any resources associated with it.
-	out.runtime = wazero.NewRuntimeWithConfig(ctx, out.runtimeConfig)
+	// The below ensures context cancels in-flight wasm functions.
+	out.runtime = wazero.NewRuntimeWithConfig(ctx, runtimeConfig.WithCloseOnContextDone(true))
 
 	// Compile the module, which reduces execution time of Invoke
 	out.module, err = out.runtime.CompileModule(ctx, out.meta.Guest)
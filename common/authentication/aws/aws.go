@@ -78,6 +78,7 @@ type Provider interface {
 	ParameterStore() *ParameterStoreClients
 	Kinesis() *KinesisClients
 	Ses() *SesClients
+	KMS() *KMSClients
 
 	// Postgres is an outlier to the others in the sense that we can update only it's config,
 	// as we use a max connection time of 8 minutes.
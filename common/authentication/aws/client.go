@@ -25,6 +25,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/kinesis"
 	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
@@ -51,6 +53,7 @@ type Clients struct {
 	ParameterStore *ParameterStoreClients
 	kinesis        *KinesisClients
 	ses            *SesClients
+	KMS            *KMSClients
 }
 
 func newClients() *Clients {
@@ -79,6 +82,8 @@ func (c *Clients) refresh(session *session.Session) error {
 		c.kinesis.New(session)
 	case c.ses != nil:
 		c.ses.New(session)
+	case c.KMS != nil:
+		c.KMS.New(session)
 	}
 	return nil
 }
@@ -125,6 +130,10 @@ type SesClients struct {
 	Ses *ses.SES
 }
 
+type KMSClients struct {
+	KMS kmsiface.KMSAPI
+}
+
 func (c *S3Clients) New(session *session.Session) {
 	refreshedS3 := s3.New(session, session.Config)
 	c.S3 = refreshedS3
@@ -208,3 +217,7 @@ func (c *KinesisClients) WorkerCfg(ctx context.Context, stream, consumer, mode s
 func (c *SesClients) New(session *session.Session) {
 	c.Ses = ses.New(session, session.Config)
 }
+
+func (c *KMSClients) New(session *session.Session) {
+	c.KMS = kms.New(session, session.Config)
+}
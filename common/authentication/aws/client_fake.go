@@ -20,6 +20,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/ssm"
@@ -44,7 +46,11 @@ type MockSecretManager struct {
 	GetSecretValueFn func(context.Context, *secretsmanager.GetSecretValueInput, ...request.Option) (*secretsmanager.GetSecretValueOutput, error)
 	secretsmanageriface.SecretsManagerAPI
 
-	ListSecretsFn func(context.Context, *secretsmanager.ListSecretsInput, ...request.Option) (*secretsmanager.ListSecretsOutput, error)
+	ListSecretsFn    func(context.Context, *secretsmanager.ListSecretsInput, ...request.Option) (*secretsmanager.ListSecretsOutput, error)
+	CreateSecretFn   func(context.Context, *secretsmanager.CreateSecretInput, ...request.Option) (*secretsmanager.CreateSecretOutput, error)
+	PutSecretValueFn func(context.Context, *secretsmanager.PutSecretValueInput, ...request.Option) (*secretsmanager.PutSecretValueOutput, error)
+	DeleteSecretFn   func(context.Context, *secretsmanager.DeleteSecretInput, ...request.Option) (*secretsmanager.DeleteSecretOutput, error)
+	DescribeSecretFn func(context.Context, *secretsmanager.DescribeSecretInput, ...request.Option) (*secretsmanager.DescribeSecretOutput, error)
 }
 
 func (m *MockSecretManager) GetSecretValueWithContext(ctx context.Context, input *secretsmanager.GetSecretValueInput, option ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
@@ -55,6 +61,22 @@ func (m *MockSecretManager) ListSecretsWithContext(ctx context.Context, input *s
 	return m.ListSecretsFn(ctx, input, option...)
 }
 
+func (m *MockSecretManager) CreateSecretWithContext(ctx context.Context, input *secretsmanager.CreateSecretInput, option ...request.Option) (*secretsmanager.CreateSecretOutput, error) {
+	return m.CreateSecretFn(ctx, input, option...)
+}
+
+func (m *MockSecretManager) PutSecretValueWithContext(ctx context.Context, input *secretsmanager.PutSecretValueInput, option ...request.Option) (*secretsmanager.PutSecretValueOutput, error) {
+	return m.PutSecretValueFn(ctx, input, option...)
+}
+
+func (m *MockSecretManager) DeleteSecretWithContext(ctx context.Context, input *secretsmanager.DeleteSecretInput, option ...request.Option) (*secretsmanager.DeleteSecretOutput, error) {
+	return m.DeleteSecretFn(ctx, input, option...)
+}
+
+func (m *MockSecretManager) DescribeSecretWithContext(ctx context.Context, input *secretsmanager.DescribeSecretInput, option ...request.Option) (*secretsmanager.DescribeSecretOutput, error) {
+	return m.DescribeSecretFn(ctx, input, option...)
+}
+
 type MockDynamoDB struct {
 	GetItemWithContextFn            func(ctx context.Context, input *dynamodb.GetItemInput, op ...request.Option) (*dynamodb.GetItemOutput, error)
 	PutItemWithContextFn            func(ctx context.Context, input *dynamodb.PutItemInput, op ...request.Option) (*dynamodb.PutItemOutput, error)
@@ -83,3 +105,32 @@ func (m *MockDynamoDB) BatchWriteItemWithContext(ctx context.Context, input *dyn
 func (m *MockDynamoDB) TransactWriteItemsWithContext(ctx context.Context, input *dynamodb.TransactWriteItemsInput, op ...request.Option) (*dynamodb.TransactWriteItemsOutput, error) {
 	return m.TransactWriteItemsWithContextFn(ctx, input, op...)
 }
+
+type MockKMS struct {
+	GetPublicKeyFn func(context.Context, *kms.GetPublicKeyInput, ...request.Option) (*kms.GetPublicKeyOutput, error)
+	EncryptFn      func(context.Context, *kms.EncryptInput, ...request.Option) (*kms.EncryptOutput, error)
+	DecryptFn      func(context.Context, *kms.DecryptInput, ...request.Option) (*kms.DecryptOutput, error)
+	SignFn         func(context.Context, *kms.SignInput, ...request.Option) (*kms.SignOutput, error)
+	VerifyFn       func(context.Context, *kms.VerifyInput, ...request.Option) (*kms.VerifyOutput, error)
+	kmsiface.KMSAPI
+}
+
+func (m *MockKMS) GetPublicKeyWithContext(ctx context.Context, input *kms.GetPublicKeyInput, option ...request.Option) (*kms.GetPublicKeyOutput, error) {
+	return m.GetPublicKeyFn(ctx, input, option...)
+}
+
+func (m *MockKMS) EncryptWithContext(ctx context.Context, input *kms.EncryptInput, option ...request.Option) (*kms.EncryptOutput, error) {
+	return m.EncryptFn(ctx, input, option...)
+}
+
+func (m *MockKMS) DecryptWithContext(ctx context.Context, input *kms.DecryptInput, option ...request.Option) (*kms.DecryptOutput, error) {
+	return m.DecryptFn(ctx, input, option...)
+}
+
+func (m *MockKMS) SignWithContext(ctx context.Context, input *kms.SignInput, option ...request.Option) (*kms.SignOutput, error) {
+	return m.SignFn(ctx, input, option...)
+}
+
+func (m *MockKMS) VerifyWithContext(ctx context.Context, input *kms.VerifyInput, option ...request.Option) (*kms.VerifyOutput, error) {
+	return m.VerifyFn(ctx, input, option...)
+}
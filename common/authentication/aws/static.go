@@ -233,6 +233,20 @@ func (a *StaticAuth) Ses() *SesClients {
 	return a.clients.ses
 }
 
+func (a *StaticAuth) KMS() *KMSClients {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.clients.KMS != nil {
+		return a.clients.KMS
+	}
+
+	clients := KMSClients{}
+	a.clients.KMS = &clients
+	a.clients.KMS.New(a.session)
+	return a.clients.KMS
+}
+
 func (a *StaticAuth) UpdatePostgres(ctx context.Context, poolConfig *pgxpool.Config) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
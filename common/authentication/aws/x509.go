@@ -292,6 +292,20 @@ func (a *x509) Ses() *SesClients {
 	return a.clients.ses
 }
 
+func (a *x509) KMS() *KMSClients {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.clients.KMS != nil {
+		return a.clients.KMS
+	}
+
+	clients := KMSClients{}
+	a.clients.KMS = &clients
+	a.clients.KMS.New(a.session)
+	return a.clients.KMS
+}
+
 // https://docs.aws.amazon.com/AmazonRDS/latest/AuroraUserGuide/UsingWithRDS.IAMDBAuth.Connecting.Go.html
 func (a *x509) getDatabaseToken(ctx context.Context, poolConfig *pgxpool.Config) (string, error) {
 	dbEndpoint := poolConfig.ConnConfig.Host + ":" + strconv.Itoa(int(poolConfig.ConnConfig.Port))
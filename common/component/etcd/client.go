@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	kitstrings "github.com/dapr/kit/strings"
+)
+
+// ClientMetadata contains the metadata used to connect to etcd, shared by the etcd components.
+type ClientMetadata struct {
+	Endpoints string `json:"endpoints"`
+	// TLS
+	TLSEnable string `json:"tlsEnable"`
+	CA        string `json:"ca"`
+	Cert      string `json:"cert"`
+	Key       string `json:"key"`
+}
+
+// NewClient returns a new etcd client.
+func NewClient(md ClientMetadata) (*clientv3.Client, error) {
+	endpoints := strings.Split(md.Endpoints, ",")
+	if len(endpoints) == 0 || endpoints[0] == "" {
+		return nil, errors.New("endpoints required")
+	}
+
+	var tlsConfig *tls.Config
+	if kitstrings.IsTruthy(md.TLSEnable) {
+		if md.Cert != "" && md.Key != "" && md.CA != "" {
+			var err error
+			tlsConfig, err = NewTLSConfig(md.Cert, md.Key, md.CA)
+			if err != nil {
+				return nil, fmt.Errorf("tls authentication error: %w", err)
+			}
+		} else {
+			return nil, errors.New("tls authentication information is incomplete")
+		}
+	}
+
+	config := clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second, // TODO: make this configurable
+		TLS:         tlsConfig,
+	}
+	client, err := clientv3.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// NewTLSConfig returns the TLS configuration for a client certificate and key, and a CA certificate, all PEM-encoded.
+func NewTLSConfig(clientCert, clientKey, caCert string) (*tls.Config, error) {
+	valid := false
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if clientCert != "" && clientKey != "" {
+		key := []byte(clientKey)
+		cert, err := tls.X509KeyPair([]byte(clientCert), key)
+		if err != nil {
+			return nil, fmt.Errorf("error parse X509KeyPair: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+		valid = true
+	}
+
+	if caCert != "" {
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM([]byte(caCert))
+		config.RootCAs = caCertPool
+		valid = true
+	}
+
+	if !valid {
+		config = nil
+	}
+
+	return config, nil
+}
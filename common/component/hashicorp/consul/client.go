@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"github.com/hashicorp/consul/api"
+)
+
+// ClientMetadata contains the metadata used to connect to Consul, shared by the Consul components.
+type ClientMetadata struct {
+	Datacenter string `json:"datacenter"`
+	HTTPAddr   string `json:"httpAddr"`
+	ACLToken   string `json:"aclToken"`
+	Scheme     string `json:"scheme"`
+}
+
+// NewClient returns a new Consul client.
+func NewClient(md ClientMetadata) (*api.Client, error) {
+	config := &api.Config{
+		Datacenter: md.Datacenter,
+		Address:    md.HTTPAddr,
+		Token:      md.ACLToken,
+		Scheme:     md.Scheme,
+	}
+
+	return api.NewClient(config)
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/dapr/kit/logger"
+)
+
+// Auth methods supported by the client.
+const (
+	// AuthMethodToken uses a static token, set in vaultToken or read from vaultTokenMountPath.
+	AuthMethodToken = "token"
+	// AuthMethodAppRole logs in with a role ID and secret ID.
+	AuthMethodAppRole = "approle"
+	// AuthMethodKubernetes logs in with the token of the Kubernetes service account of the pod.
+	AuthMethodKubernetes = "kubernetes"
+	// AuthMethodJWT logs in with a JWT, for example an OIDC ID token.
+	AuthMethodJWT = "jwt"
+
+	// DefaultKubernetesTokenPath is the path of the token of the service account, mounted in Kubernetes pods.
+	DefaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token" //nolint:gosec
+)
+
+// AuthMetadata contains the metadata properties used to authenticate with Vault, in addition to the token ones.
+type AuthMetadata struct {
+	// Auth method: "token" (default), "approle", "kubernetes" or "jwt".
+	VaultAuthMethod string `json:"vaultAuthMethod" mapstructure:"vaultAuthMethod"`
+	// Path where the auth method is mounted. Defaults to the name of the auth method.
+	VaultAuthPath string `json:"vaultAuthPath" mapstructure:"vaultAuthPath"`
+	// Role to log in with, for the "kubernetes" and "jwt" auth methods.
+	VaultRole string `json:"vaultRole" mapstructure:"vaultRole"`
+	// Role ID, for the "approle" auth method.
+	VaultAppRoleID string `json:"vaultAppRoleID" mapstructure:"vaultAppRoleID"`
+	// Secret ID, for the "approle" auth method.
+	VaultAppRoleSecretID string `json:"vaultAppRoleSecretID" mapstructure:"vaultAppRoleSecretID"`
+	// Path to a file containing the secret ID, for the "approle" auth method.
+	VaultAppRoleSecretIDPath string `json:"vaultAppRoleSecretIDPath" mapstructure:"vaultAppRoleSecretIDPath"`
+	// JWT, for the "jwt" auth method.
+	VaultJWT string `json:"vaultJWT" mapstructure:"vaultJWT"`
+	// Path to a file containing the JWT, for the "kubernetes" and "jwt" auth methods.
+	// Defaults to the token of the service account with the "kubernetes" auth method.
+	VaultJWTPath string `json:"vaultJWTPath" mapstructure:"vaultJWTPath"`
+	// Vault Enterprise namespace of the requests.
+	VaultNamespace string `json:"vaultNamespace" mapstructure:"vaultNamespace"`
+}
+
+// loginResponse is the response data from Vault when logging in or renewing a token.
+type loginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+		Renewable     bool   `json:"renewable"`
+	} `json:"auth"`
+}
+
+// sendFn sends a request to Vault with the given token.
+type sendFn func(ctx context.Context, method string, path string, token string, body []byte) (int, []byte, error)
+
+// authenticator keeps the token used by the client.
+// Tokens obtained by logging in are renewed when two thirds of their lease have elapsed, and the client logs in again
+// when they can't be renewed anymore. Static tokens are used as-is, but the token file is read again if Vault rejects the token.
+type authenticator struct {
+	method        string
+	loginPath     string
+	md            ClientMetadata
+	send          sendFn
+	clock         clock.Clock
+	logger        logger.Logger
+	lock          sync.Mutex
+	token         string
+	leaseDuration time.Duration
+	renewable     bool
+	renewAt       time.Time
+	expiresAt     time.Time
+}
+
+func newAuthenticator(md ClientMetadata, send sendFn, log logger.Logger) (*authenticator, error) {
+	a := &authenticator{
+		method: strings.ToLower(md.VaultAuthMethod),
+		md:     md,
+		send:   send,
+		clock:  clock.RealClock{},
+		logger: log,
+	}
+	if a.method == "" {
+		a.method = AuthMethodToken
+	}
+
+	switch a.method {
+	case AuthMethodToken:
+		token, err := ReadToken(md.VaultToken, md.VaultTokenMountPath)
+		if err != nil {
+			return nil, err
+		}
+		a.token = token
+		return a, nil
+	case AuthMethodAppRole:
+		if md.VaultAppRoleID == "" {
+			return nil, errors.New("vaultAppRoleID is required with the approle auth method")
+		}
+		if (md.VaultAppRoleSecretID == "") == (md.VaultAppRoleSecretIDPath == "") {
+			return nil, errors.New("exactly one of vaultAppRoleSecretID and vaultAppRoleSecretIDPath is required with the approle auth method")
+		}
+	case AuthMethodKubernetes:
+		if md.VaultRole == "" {
+			return nil, errors.New("vaultRole is required with the kubernetes auth method")
+		}
+		if md.VaultJWTPath == "" {
+			a.md.VaultJWTPath = DefaultKubernetesTokenPath
+		}
+	case AuthMethodJWT:
+		if (md.VaultJWT == "") == (md.VaultJWTPath == "") {
+			return nil, errors.New("exactly one of vaultJWT and vaultJWTPath is required with the jwt auth method")
+		}
+	default:
+		return nil, fmt.Errorf("invalid auth method '%s', accepted values are %s, %s, %s or %s", md.VaultAuthMethod, AuthMethodToken, AuthMethodAppRole, AuthMethodKubernetes, AuthMethodJWT)
+	}
+
+	if md.VaultToken != "" || md.VaultTokenMountPath != "" {
+		return nil, fmt.Errorf("vaultToken and vaultTokenMountPath can't be set with the %s auth method", a.method)
+	}
+
+	authPath := strings.Trim(md.VaultAuthPath, "/")
+	if authPath == "" {
+		authPath = a.method
+	}
+	a.loginPath = "auth/" + authPath + "/login"
+
+	return a, nil
+}
+
+// getToken returns the token to authenticate requests with, logging in or renewing it if needed.
+func (a *authenticator) getToken(ctx context.Context) (string, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := a.clock.Now()
+	if a.token != "" && (a.renewAt.IsZero() || now.Before(a.renewAt)) {
+		return a.token, nil
+	}
+
+	if a.token != "" && a.renewable && now.Before(a.expiresAt) {
+		err := a.renew(ctx)
+		if err == nil {
+			return a.token, nil
+		}
+		a.logger.Debugf("Couldn't renew the Vault token, logging in again: %v", err)
+	}
+
+	err := a.login(ctx)
+	if err != nil {
+		// Keep using the current token while it's valid, so a temporary failure doesn't interrupt requests
+		if a.token != "" && now.Before(a.expiresAt) {
+			a.logger.Warnf("Couldn't log in to Vault, using the current token until it expires: %v", err)
+			return a.token, nil
+		}
+		return "", err
+	}
+	return a.token, nil
+}
+
+// refresh is called when Vault rejects token; it returns true if a new token can be used to retry the request.
+func (a *authenticator) refresh(ctx context.Context, token string) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.token != token {
+		// Another request already got a new token
+		return true
+	}
+
+	if a.method == AuthMethodToken {
+		if a.md.VaultTokenMountPath == "" {
+			return false
+		}
+		// The token may have been rotated, for example by Vault Agent
+		newToken, err := ReadToken("", a.md.VaultTokenMountPath)
+		if err != nil || newToken == a.token {
+			return false
+		}
+		a.token = newToken
+		return true
+	}
+
+	err := a.login(ctx)
+	if err != nil {
+		a.logger.Warnf("Couldn't log in to Vault: %v", err)
+		return false
+	}
+	return true
+}
+
+// login obtains a new token with the auth method.
+// It must be called while holding the lock.
+func (a *authenticator) login(ctx context.Context) error {
+	if a.method == AuthMethodToken {
+		return errors.New("the Vault token has expired")
+	}
+
+	body := map[string]string{}
+	switch a.method {
+	case AuthMethodAppRole:
+		secretID, err := readValue(a.md.VaultAppRoleSecretID, a.md.VaultAppRoleSecretIDPath)
+		if err != nil {
+			return fmt.Errorf("couldn't read the secret ID: %w", err)
+		}
+		body["role_id"] = a.md.VaultAppRoleID
+		body["secret_id"] = secretID
+	case AuthMethodKubernetes, AuthMethodJWT:
+		// The JWT is read each time, as it's rotated
+		jwt, err := readValue(a.md.VaultJWT, a.md.VaultJWTPath)
+		if err != nil {
+			return fmt.Errorf("couldn't read the JWT: %w", err)
+		}
+		body["jwt"] = jwt
+		if a.md.VaultRole != "" {
+			body["role"] = a.md.VaultRole
+		}
+	}
+
+	res, err := a.authRequest(ctx, a.loginPath, "", body)
+	if err != nil {
+		return fmt.Errorf("couldn't log in to Vault with the %s auth method: %w", a.method, err)
+	}
+	if res.Auth.ClientToken == "" {
+		return errors.New("couldn't log in to Vault: response doesn't contain a token")
+	}
+
+	a.token = res.Auth.ClientToken
+	a.leaseDuration = time.Duration(res.Auth.LeaseDuration) * time.Second
+	a.setLease(res.Auth.LeaseDuration, res.Auth.Renewable)
+	return nil
+}
+
+// renew extends the lease of the current token.
+// It must be called while holding the lock.
+func (a *authenticator) renew(ctx context.Context) error {
+	res, err := a.authRequest(ctx, "auth/token/renew-self", a.token, map[string]string{})
+	if err != nil {
+		return err
+	}
+
+	// When the token reaches its max TTL, its lease can't be extended anymore
+	lease := time.Duration(res.Auth.LeaseDuration) * time.Second
+	if lease < a.leaseDuration/3 {
+		return errors.New("the token is close to its max TTL")
+	}
+
+	a.setLease(res.Auth.LeaseDuration, res.Auth.Renewable)
+	return nil
+}
+
+func (a *authenticator) setLease(leaseDuration int, renewable bool) {
+	a.renewable = renewable
+	if leaseDuration <= 0 {
+		// The token doesn't expire, for example a root token
+		a.renewAt = time.Time{}
+		a.expiresAt = time.Time{}
+		return
+	}
+	lease := time.Duration(leaseDuration) * time.Second
+	now := a.clock.Now()
+	a.renewAt = now.Add(lease * 2 / 3)
+	a.expiresAt = now.Add(lease)
+}
+
+func (a *authenticator) authRequest(ctx context.Context, path string, token string, body any) (*loginResponse, error) {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	status, respBody, err := a.send(ctx, http.MethodPost, path, token, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, newResponseError(status, respBody)
+	}
+
+	var res loginResponse
+	err = json.Unmarshal(respBody, &res)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode response body: %w", err)
+	}
+	return &res, nil
+}
+
+// readValue returns value, or the content of the file at path if value is empty.
+func readValue(value string, path string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(data)), nil
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/dapr/kit/logger"
+)
+
+// fakeVault is a Vault server that issues tokens with a login endpoint, and accepts them on the "secret/data/test" path.
+type fakeVault struct {
+	lock       sync.Mutex
+	tokens     map[string]bool
+	logins     []map[string]string
+	renewals   int
+	lease      int
+	renewLease int
+	namespace  string
+}
+
+func (f *fakeVault) issueToken() string {
+	token := "token-" + strconv.Itoa(len(f.tokens))
+	f.tokens[token] = true
+	return token
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.namespace = r.Header.Get(namespaceHeader)
+	token := r.Header.Get(tokenHeader)
+	writeAuth := func(token string, lease int) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"auth": map[string]any{
+				"client_token":   token,
+				"lease_duration": lease,
+				"renewable":      true,
+			},
+		})
+	}
+
+	switch r.URL.Path {
+	case "/v1/auth/approle/login", "/v1/auth/kubernetes/login", "/v1/auth/custom-jwt/login":
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		f.logins = append(f.logins, body)
+		writeAuth(f.issueToken(), f.lease)
+	case "/v1/auth/token/renew-self":
+		if !f.tokens[token] {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		f.renewals++
+		writeAuth(token, f.renewLease)
+	case "/v1/secret/data/test":
+		if !f.tokens[token] {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"key":"value"}}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, md ClientMetadata) (*Client, *fakeVault, *clocktesting.FakeClock) {
+	t.Helper()
+
+	f := &fakeVault{
+		tokens:     map[string]bool{"static": true},
+		lease:      3600,
+		renewLease: 3600,
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	md.VaultAddr = server.URL
+	c, err := NewClient(md, logger.NewLogger("test"))
+	require.NoError(t, err)
+
+	clock := clocktesting.NewFakeClock(time.Now())
+	c.auth.clock = clock
+	return c, f, clock
+}
+
+func writeTestFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, []byte(content+"\n"), 0o600))
+	return path
+}
+
+func TestAuthMethods(t *testing.T) {
+	get := func(t *testing.T, c *Client) {
+		t.Helper()
+		var res map[string]any
+		require.NoError(t, c.Do(t.Context(), http.MethodGet, "secret/data/test", nil, &res))
+	}
+
+	t.Run("approle", func(t *testing.T) {
+		c, f, _ := newTestClient(t, ClientMetadata{AuthMetadata: AuthMetadata{
+			VaultAuthMethod:          AuthMethodAppRole,
+			VaultAppRoleID:           "role-id",
+			VaultAppRoleSecretIDPath: writeTestFile(t, "secret-id"),
+			VaultNamespace:           "ns1/",
+		}})
+
+		get(t, c)
+		get(t, c)
+		require.Len(t, f.logins, 1)
+		assert.Equal(t, map[string]string{"role_id": "role-id", "secret_id": "secret-id"}, f.logins[0])
+		assert.Equal(t, "ns1", f.namespace)
+	})
+
+	t.Run("kubernetes", func(t *testing.T) {
+		c, f, _ := newTestClient(t, ClientMetadata{AuthMetadata: AuthMetadata{
+			VaultAuthMethod: AuthMethodKubernetes,
+			VaultRole:       "app",
+			VaultJWTPath:    writeTestFile(t, "sa-token"),
+		}})
+
+		get(t, c)
+		require.Len(t, f.logins, 1)
+		assert.Equal(t, map[string]string{"role": "app", "jwt": "sa-token"}, f.logins[0])
+	})
+
+	t.Run("jwt with custom path", func(t *testing.T) {
+		c, f, _ := newTestClient(t, ClientMetadata{AuthMetadata: AuthMetadata{
+			VaultAuthMethod: AuthMethodJWT,
+			VaultAuthPath:   "/custom-jwt/",
+			VaultJWT:        "id-token",
+		}})
+
+		get(t, c)
+		require.Len(t, f.logins, 1)
+		assert.Equal(t, map[string]string{"jwt": "id-token"}, f.logins[0])
+	})
+
+	t.Run("static token is read again when rejected", func(t *testing.T) {
+		tokenFile := writeTestFile(t, "static")
+		c, f, _ := newTestClient(t, ClientMetadata{VaultTokenMountPath: tokenFile})
+		get(t, c)
+
+		f.lock.Lock()
+		delete(f.tokens, "static")
+		f.tokens["rotated"] = true
+		f.lock.Unlock()
+		require.NoError(t, os.WriteFile(tokenFile, []byte("rotated"), 0o600))
+		get(t, c)
+
+		token, err := c.Token(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "rotated", token)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for name, md := range map[string]AuthMetadata{
+			"unknown method":          {VaultAuthMethod: "foo"},
+			"approle without role":    {VaultAuthMethod: AuthMethodAppRole, VaultAppRoleSecretID: "secret"},
+			"approle without ID":      {VaultAuthMethod: AuthMethodAppRole, VaultAppRoleID: "role"},
+			"kubernetes without role": {VaultAuthMethod: AuthMethodKubernetes},
+			"jwt without token":       {VaultAuthMethod: AuthMethodJWT},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := NewClient(ClientMetadata{AuthMetadata: md}, logger.NewLogger("test"))
+				require.Error(t, err)
+			})
+		}
+
+		_, err := NewClient(ClientMetadata{
+			AuthMetadata: AuthMetadata{VaultAuthMethod: AuthMethodJWT, VaultJWT: "jwt"},
+			VaultToken:   "token",
+		}, logger.NewLogger("test"))
+		require.ErrorContains(t, err, "can't be set")
+	})
+}
+
+func TestTokenRenewal(t *testing.T) {
+	newClient := func(t *testing.T) (*Client, *fakeVault, *clocktesting.FakeClock) {
+		return newTestClient(t, ClientMetadata{AuthMetadata: AuthMetadata{
+			VaultAuthMethod:      AuthMethodAppRole,
+			VaultAppRoleID:       "role-id",
+			VaultAppRoleSecretID: "secret-id",
+		}})
+	}
+
+	t.Run("renew before expiry", func(t *testing.T) {
+		c, f, clock := newClient(t)
+
+		token, err := c.Token(t.Context())
+		require.NoError(t, err)
+
+		// Not yet two thirds of the lease
+		clock.Step(30 * time.Minute)
+		_, err = c.Token(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, 0, f.renewals)
+
+		clock.Step(15 * time.Minute)
+		renewed, err := c.Token(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, token, renewed)
+		assert.Equal(t, 1, f.renewals)
+		assert.Len(t, f.logins, 1)
+	})
+
+	t.Run("log in again at max TTL", func(t *testing.T) {
+		c, f, clock := newClient(t)
+		f.renewLease = 60
+
+		token, err := c.Token(t.Context())
+		require.NoError(t, err)
+
+		clock.Step(45 * time.Minute)
+		newToken, err := c.Token(t.Context())
+		require.NoError(t, err)
+		assert.NotEqual(t, token, newToken)
+		assert.Len(t, f.logins, 2)
+	})
+
+	t.Run("log in again when the token is revoked", func(t *testing.T) {
+		c, f, _ := newClient(t)
+
+		var res map[string]any
+		require.NoError(t, c.Do(t.Context(), http.MethodGet, "secret/data/test", nil, &res))
+
+		f.lock.Lock()
+		for token := range f.tokens {
+			f.tokens[token] = false
+		}
+		f.lock.Unlock()
+
+		require.NoError(t, c.Do(t.Context(), http.MethodGet, "secret/data/test", nil, &res))
+		assert.Len(t, f.logins, 2)
+	})
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vault contains a minimal HTTP client for HashiCorp Vault that is shared by the Vault components.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dapr/kit/logger"
+)
+
+const (
+	// DefaultAddress is the address of the Vault server used when none is configured.
+	DefaultAddress = "https://127.0.0.1:8200"
+
+	tokenHeader     = "X-Vault-Token"
+	requestHeader   = "X-Vault-Request"
+	namespaceHeader = "X-Vault-Namespace"
+)
+
+// ClientMetadata contains the metadata properties used to connect to a Vault server.
+// Components embed it in their own metadata struct.
+type ClientMetadata struct {
+	AuthMetadata `mapstructure:",squash"`
+
+	// Address of the Vault server.
+	VaultAddr string `json:"vaultAddr" mapstructure:"vaultAddr"`
+	// Token for authenticating with Vault.
+	VaultToken string `json:"vaultToken" mapstructure:"vaultToken"`
+	// Path to a file containing the token for authenticating with Vault.
+	VaultTokenMountPath string `json:"vaultTokenMountPath" mapstructure:"vaultTokenMountPath"`
+	// Path to the CA certificate file.
+	CaCert string `json:"caCert" mapstructure:"caCert"`
+	// Path to a folder holding CA certificates.
+	CaPath string `json:"caPath" mapstructure:"caPath"`
+	// PEM-encoded CA certificate.
+	CaPem string `json:"caPem" mapstructure:"caPem"`
+	// Skip TLS verification of the server's certificate.
+	SkipVerify bool `json:"skipVerify" mapstructure:"skipVerify"`
+	// Server name used to verify the TLS certificate.
+	TLSServerName string `json:"tlsServerName" mapstructure:"tlsServerName"`
+}
+
+// TLSConfig returns the TLS configuration from the metadata.
+func (m ClientMetadata) TLSConfig() TLSConfig {
+	return TLSConfig{
+		CAPem:      m.CaPem,
+		CACert:     m.CaCert,
+		CAPath:     m.CaPath,
+		SkipVerify: m.SkipVerify,
+		ServerName: m.TLSServerName,
+	}
+}
+
+// Client performs requests against the Vault HTTP API.
+type Client struct {
+	httpClient *http.Client
+	address    string
+	namespace  string
+	auth       *authenticator
+}
+
+// NewClient returns a new Client for the server configured in the metadata.
+// With auth methods other than "token", the client logs in when sending the first request.
+func NewClient(md ClientMetadata, log logger.Logger) (*Client, error) {
+	if log == nil {
+		log = logger.NewLogger("dapr.components.hashicorp.vault")
+	}
+
+	c := &Client{
+		namespace: strings.Trim(md.VaultNamespace, "/"),
+	}
+
+	var err error
+	c.auth, err = newAuthenticator(md, c.send, log)
+	if err != nil {
+		return nil, err
+	}
+
+	c.httpClient, err = NewHTTPClient(md.TLSConfig(), log)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create client using config: %w", err)
+	}
+
+	address := md.VaultAddr
+	if address == "" {
+		address = DefaultAddress
+	}
+	c.address = strings.TrimSuffix(address, "/")
+
+	return c, nil
+}
+
+// ResponseError is returned by Client.Do when Vault responds with an unexpected status code.
+type ResponseError struct {
+	StatusCode int
+	Errors     []string
+}
+
+func newResponseError(status int, body []byte) *ResponseError {
+	respErr := &ResponseError{StatusCode: status}
+	var errBody struct {
+		Errors []string `json:"errors"`
+	}
+	if json.Unmarshal(body, &errBody) == nil {
+		respErr.Errors = errBody.Errors
+	}
+	return respErr
+}
+
+func (e *ResponseError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("unexpected response from Vault with status code %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected response from Vault with status code %d: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+// IsNotFound returns true if the error is a response from Vault with status code 404.
+func IsNotFound(err error) bool {
+	var respErr *ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// Do sends a request to the Vault API at path, which is relative to "/v1/".
+// If body is not nil, it is sent as JSON. If out is not nil, the response is decoded into it.
+func (c *Client) Do(ctx context.Context, method string, path string, body any, out any) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("couldn't serialize request: %w", err)
+		}
+	}
+
+	status, respBody, err := c.Request(ctx, method, path, reqBody)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status > 299 {
+		return newResponseError(status, respBody)
+	}
+
+	if out == nil || status == http.StatusNoContent {
+		return nil
+	}
+	err = json.Unmarshal(respBody, out)
+	if err != nil {
+		return fmt.Errorf("couldn't decode response body: %w", err)
+	}
+	return nil
+}
+
+// Request sends a request to the Vault API at path, which is relative to "/v1/", with body as JSON if not nil.
+// It returns the status code and body of the response, whatever the status code.
+// If Vault rejects the token, the client gets a new one, if possible, and retries once.
+func (c *Client) Request(ctx context.Context, method string, path string, body []byte) (int, []byte, error) {
+	token, err := c.auth.getToken(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	status, respBody, err := c.send(ctx, method, path, token, body)
+	if err == nil && status == http.StatusForbidden && c.auth.refresh(ctx, token) {
+		token, err = c.auth.getToken(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+		status, respBody, err = c.send(ctx, method, path, token, body)
+	}
+	return status, respBody, err
+}
+
+// Token returns the token the client currently authenticates with.
+func (c *Client) Token(ctx context.Context) (string, error) {
+	return c.auth.getToken(ctx)
+}
+
+func (c *Client) send(ctx context.Context, method string, path string, token string, body []byte) (int, []byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.address+"/v1/"+strings.TrimPrefix(path, "/"), reqBody)
+	if err != nil {
+		return 0, nil, fmt.Errorf("couldn't generate request: %w", err)
+	}
+	if token != "" {
+		httpReq.Header.Set(tokenHeader, token)
+	}
+	httpReq.Header.Set(requestHeader, "true")
+	if c.namespace != "" {
+		httpReq.Header.Set(namespaceHeader, c.namespace)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, nil, fmt.Errorf("couldn't send request to Vault: %w", err)
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("couldn't read response: %w", err)
+	}
+	return res.StatusCode, respBody, nil
+}
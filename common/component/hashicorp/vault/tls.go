@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/net/http2"
+
+	"github.com/dapr/kit/logger"
+)
+
+// TLSConfig is TLS configuration to interact with HashiCorp Vault.
+type TLSConfig struct {
+	CAPem      string
+	CACert     string
+	CAPath     string
+	SkipVerify bool
+	ServerName string
+}
+
+// NewHTTPClient returns a HTTP client configured with the TLS options.
+func NewHTTPClient(config TLSConfig, log logger.Logger) (*http.Client, error) {
+	tlsClientConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.SkipVerify && log != nil {
+		log.Infof("hashicorp vault: you are using 'skipVerify' to skip server config verify which is unsafe!")
+	}
+
+	tlsClientConfig.InsecureSkipVerify = config.SkipVerify
+	if !config.SkipVerify {
+		rootCAPools, err := GetRootCAsPool(config.CAPem, config.CAPath, config.CACert)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsClientConfig.RootCAs = rootCAPools
+
+		if config.ServerName != "" {
+			tlsClientConfig.ServerName = config.ServerName
+		}
+	}
+
+	// Setup http transport
+	transport := &http.Transport{
+		TLSClientConfig: tlsClientConfig,
+	}
+
+	// Configure http2 client
+	err := http2.ConfigureTransport(transport)
+	if err != nil {
+		return nil, errors.New("failed to configure http2")
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}, nil
+}
+
+// GetRootCAsPool returns root CAs when you give it CA Pem file, CA path, and CA Certificate. Default is system certificates.
+func GetRootCAsPool(caPem string, caPath string, caCert string) (*x509.CertPool, error) {
+	if caPem != "" {
+		certPool := x509.NewCertPool()
+		cert := []byte(caPem)
+		if ok := certPool.AppendCertsFromPEM(cert); !ok {
+			return nil, errors.New("couldn't read PEM")
+		}
+
+		return certPool, nil
+	}
+
+	if caPath != "" {
+		certPool := x509.NewCertPool()
+		if err := readCertificateFolder(certPool, caPath); err != nil {
+			return nil, err
+		}
+
+		return certPool, nil
+	}
+
+	if caCert != "" {
+		certPool := x509.NewCertPool()
+		if err := readCertificateFile(certPool, caCert); err != nil {
+			return nil, err
+		}
+
+		return certPool, nil
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read system certs: %s", err)
+	}
+
+	return certPool, nil
+}
+
+// readCertificateFile reads the certificate at given path.
+func readCertificateFile(certPool *x509.CertPool, path string) error {
+	// Read certificate file
+	pemFile, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("couldn't read CA file from disk: %s", err)
+	}
+
+	if ok := certPool.AppendCertsFromPEM(pemFile); !ok {
+		return errors.New("couldn't read PEM")
+	}
+
+	return nil
+}
+
+// readCertificateFolder scans a folder for certificates.
+func readCertificateFolder(certPool *x509.CertPool, path string) error {
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if info.IsDir() {
+			return nil
+		}
+
+		return readCertificateFile(certPool, p)
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't read certificates at %s: %s", path, err)
+	}
+
+	return nil
+}
+
+// ReadToken returns the Vault token, reading it from tokenMountPath if token is empty.
+// Exactly one of the two must be set.
+func ReadToken(token string, tokenMountPath string) (string, error) {
+	// Test that at least one of them are set if not return error
+	if token == "" && tokenMountPath == "" {
+		return "", errors.New("token mount path and token not set")
+	}
+
+	// Test that both are not set. If so return error
+	if token != "" && tokenMountPath != "" {
+		return "", errors.New("token mount path and token both set")
+	}
+
+	if token != "" {
+		return token, nil
+	}
+
+	data, err := os.ReadFile(tokenMountPath)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read vault token from mount path %s err: %s", tokenMountPath, err)
+	}
+	return string(bytes.TrimSpace(data)), nil
+}
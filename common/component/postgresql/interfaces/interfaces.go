@@ -35,6 +35,7 @@ type PGXPoolConn interface {
 
 	Begin(context.Context) (pgx.Tx, error)
 	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
 	Ping(context.Context) error
 	Close()
 }
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transactions
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
+)
+
+// BatchQuery is a query executed in a batch.
+type BatchQuery struct {
+	Query string
+	Args  []any
+	// Check returns an error if the result of the query isn't the expected one, such as when no row matched an ETag.
+	// It's optional.
+	Check func(tag pgconn.CommandTag) error
+}
+
+// ExecuteBatch sends queries in a batch, so they're executed in a single round trip, and returns the error of each query.
+// The batch is executed in an implicit transaction: if a query fails, none of the queries are applied and they all return that error.
+// Errors returned by Check don't abort the batch.
+func ExecuteBatch(ctx context.Context, db pginterfaces.PGXPoolConn, timeout time.Duration, queries []BatchQuery) []error {
+	errs := make([]error, len(queries))
+	if len(queries) == 0 {
+		return errs
+	}
+
+	batch := &pgx.Batch{}
+	for _, q := range queries {
+		batch.Queue(q.Query, q.Args...)
+	}
+
+	queryCtx, queryCancel := context.WithTimeout(ctx, timeout)
+	defer queryCancel()
+	res := db.SendBatch(queryCtx, batch)
+
+	var batchErr error
+	for i, q := range queries {
+		tag, err := res.Exec()
+		if err != nil {
+			batchErr = err
+			break
+		}
+		if q.Check != nil {
+			errs[i] = q.Check(tag)
+		}
+	}
+	if err := res.Close(); batchErr == nil {
+		batchErr = err
+	}
+	if batchErr != nil {
+		for i := range errs {
+			errs[i] = batchErr
+		}
+	}
+
+	return errs
+}
@@ -36,6 +36,7 @@ import (
 	commonsql "github.com/dapr/components-contrib/common/component/sql"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	stateutils "github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/ptr"
@@ -43,8 +44,6 @@ import (
 
 // PostgreSQL state store.
 type PostgreSQL struct {
-	state.BulkStore
-
 	logger   logger.Logger
 	metadata pgMetadata
 	db       pginterfaces.PGXPoolConn
@@ -58,6 +57,7 @@ type PostgreSQL struct {
 	enableAWSIAM  bool
 
 	awsAuthProvider awsAuth.Provider
+	compression     compression.Codec
 }
 
 type Options struct {
@@ -89,7 +89,6 @@ func NewPostgreSQLStateStore(logger logger.Logger, opts Options) state.Store {
 		enableAzureAD: opts.EnableAzureAD,
 		enableAWSIAM:  opts.EnableAWSIAM,
 	}
-	s.BulkStore = state.NewDefaultBulkStore(s)
 	return s
 }
 
@@ -105,6 +104,11 @@ func (p *PostgreSQL) Init(ctx context.Context, meta state.Metadata) error {
 	}
 
 	var err error
+	p.compression, err = p.metadata.Codec()
+	if err != nil {
+		return fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
 	config, err := p.metadata.GetPgxPoolConfig()
 	if err != nil {
 		return err
@@ -184,6 +188,7 @@ func (p *PostgreSQL) Features() []state.Feature {
 		state.FeatureTransactional,
 		state.FeatureTTL,
 		state.FeatureKeysLike,
+		state.FeatureRangeScan,
 	}
 }
 
@@ -198,30 +203,55 @@ func (p *PostgreSQL) Set(ctx context.Context, req *state.SetRequest) error {
 }
 
 func (p *PostgreSQL) doSet(parentCtx context.Context, db pginterfaces.DBQuerier, req *state.SetRequest) error {
-	err := state.CheckRequestOptions(req.Options)
+	query, params, err := p.setQuery(req)
 	if err != nil {
 		return err
 	}
 
+	result, err := db.Exec(parentCtx, query, params...)
+	if err != nil {
+		return err
+	}
+
+	return checkSetResult(req, result)
+}
+
+// setQuery returns the query that saves an item, and its parameters.
+func (p *PostgreSQL) setQuery(req *state.SetRequest) (string, []any, error) {
+	err := state.CheckRequestOptions(req.Options)
+	if err != nil {
+		return "", nil, err
+	}
+
 	if req.Key == "" {
-		return errors.New("missing key in set operation")
+		return "", nil, errors.New("missing key in set operation")
 	}
 
-	v := req.Value
+	// Convert to json string
+	// Binary and compressed values are stored as a base64-encoded string
+	var value string
 	byteArray, isBinary := req.Value.([]uint8)
+	if !isBinary {
+		bt, _ := stateutils.Marshal(req.Value, json.Marshal)
+		compressed := p.compression.Compress(bt)
+		if compression.IsCompressed(compressed) {
+			byteArray, isBinary = compressed, true
+		} else {
+			value = string(bt)
+		}
+	} else {
+		byteArray = p.compression.Compress(byteArray)
+	}
 	if isBinary {
-		v = base64.StdEncoding.EncodeToString(byteArray)
+		bt, _ := json.Marshal(base64.StdEncoding.EncodeToString(byteArray))
+		value = string(bt)
 	}
 
-	// Convert to json string
-	bt, _ := stateutils.Marshal(v, json.Marshal)
-	value := string(bt)
-
 	// TTL
 	var ttlSeconds int
 	ttl, ttlerr := stateutils.ParseTTL(req.Metadata)
 	if ttlerr != nil {
-		return fmt.Errorf("error parsing TTL: %w", ttlerr)
+		return "", nil, fmt.Errorf("error parsing TTL: %w", ttlerr)
 	}
 	if ttl != nil {
 		ttlSeconds = *ttl
@@ -238,7 +268,7 @@ func (p *PostgreSQL) doSet(parentCtx context.Context, db pginterfaces.DBQuerier,
 		var etag64 uint64
 		etag64, err = strconv.ParseUint(*req.ETag, 10, 32)
 		if err != nil {
-			return state.NewETagError(state.ETagInvalid, err)
+			return "", nil, state.NewETagError(state.ETagInvalid, err)
 		}
 		params = []any{req.Key, value, isBinary, uint32(etag64)}
 	}
@@ -254,10 +284,11 @@ func (p *PostgreSQL) doSet(parentCtx context.Context, db pginterfaces.DBQuerier,
 		ExpireDateValue: queryExpiredate,
 	})
 
-	result, err := db.Exec(parentCtx, query, params...)
-	if err != nil {
-		return err
-	}
+	return query, params, nil
+}
+
+// checkSetResult returns an error if the query that saved an item didn't update it.
+func checkSetResult(req *state.SetRequest, result pgconn.CommandTag) error {
 	if result.RowsAffected() != 1 {
 		if req.HasETag() {
 			return state.NewETagError(state.ETagMismatch, nil)
@@ -402,19 +433,15 @@ func readRow(row pgx.Row) (key string, value []byte, etagS *string, expireTime *
 	}
 
 	if isBinary {
-		var (
-			s    string
-			data []byte
-		)
-
-		err = json.Unmarshal(value, &s)
+		var data []byte
+		data, err = decodeBinaryValue(value)
 		if err != nil {
-			return key, nil, nil, nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+			return key, nil, nil, nil, err
 		}
 
-		data, err = base64.StdEncoding.DecodeString(s)
+		data, err = compression.Decompress(data)
 		if err != nil {
-			return key, nil, nil, nil, fmt.Errorf("failed to decode base64 data: %w", err)
+			return key, nil, nil, nil, err
 		}
 
 		return key, data, etagS, expireTime, nil
@@ -423,43 +450,217 @@ func readRow(row pgx.Row) (key string, value []byte, etagS *string, expireTime *
 	return key, value, etagS, expireTime, nil
 }
 
+// decodeBinaryValue decodes a binary value, which is stored as a base64-encoded JSON string.
+func decodeBinaryValue(value []byte) ([]byte, error) {
+	var s string
+	err := json.Unmarshal(value, &s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON data: %w", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 data: %w", err)
+	}
+
+	return data, nil
+}
+
+// Scan returns the items whose keys are in the requested range, in key order.
+func (p *PostgreSQL) Scan(parentCtx context.Context, req *state.ScanRequest) (*state.ScanResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys are compared with the "C" collation, so they're ordered byte-wise regardless of the database's locale
+	where := []string{
+		`key COLLATE "C" >= $1`,
+		`(expiredate IS NULL OR expiredate >= CURRENT_TIMESTAMP)`,
+	}
+	args := []any{req.Start}
+	if req.End != "" {
+		args = append(args, req.End)
+		where = append(where, fmt.Sprintf(`key COLLATE "C" < $%d`, len(args)))
+	}
+
+	// Pagination: resume strictly after the last returned key
+	order := "ASC"
+	if req.ContinuationToken != nil && *req.ContinuationToken != "" {
+		args = append(args, *req.ContinuationToken)
+		if req.Reverse {
+			where = append(where, fmt.Sprintf(`key COLLATE "C" < $%d`, len(args)))
+		} else {
+			where = append(where, fmt.Sprintf(`key COLLATE "C" > $%d`, len(args)))
+		}
+	}
+	if req.Reverse {
+		order = "DESC"
+	}
+
+	// Fetch one extra row to detect if there's a next page
+	limitClause := ""
+	if req.Limit > 0 {
+		args = append(args, req.Limit+1)
+		limitClause = fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	query := `SELECT
+			key, value, isbinary, ` + p.etagColumn + ` AS etag, expiredate
+		FROM ` + p.metadata.TableName + `
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY key COLLATE "C" ` + order + limitClause
+	ctx, cancel := context.WithTimeout(parentCtx, p.metadata.Timeout)
+	defer cancel()
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := &state.ScanResponse{
+		Items: make([]state.ScanItem, 0, min(req.Limit, 1024)),
+	}
+	for rows.Next() {
+		//nolint:gosec
+		if req.Limit > 0 && uint32(len(res.Items)) == req.Limit {
+			res.ContinuationToken = ptr.Of(res.Items[len(res.Items)-1].Key)
+			break
+		}
+
+		var (
+			item       state.ScanItem
+			expireTime *time.Time
+		)
+		item.Key, item.Data, item.ETag, expireTime, err = readRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		if expireTime != nil {
+			item.Metadata = map[string]string{
+				state.GetRespMetaKeyTTLExpireTime: expireTime.UTC().Format(time.RFC3339),
+			}
+		}
+		res.Items = append(res.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // Delete removes an item from the state store.
 func (p *PostgreSQL) Delete(ctx context.Context, req *state.DeleteRequest) (err error) {
 	return p.doDelete(ctx, p.db, req)
 }
 
 func (p *PostgreSQL) doDelete(parentCtx context.Context, db pginterfaces.DBQuerier, req *state.DeleteRequest) (err error) {
-	if req.Key == "" {
-		return errors.New("missing key in delete operation")
+	query, params, err := p.deleteQuery(req)
+	if err != nil {
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(parentCtx, p.metadata.Timeout)
 	defer cancel()
-	var result pgconn.CommandTag
-	if !req.HasETag() {
-		result, err = db.Exec(ctx, "DELETE FROM "+p.metadata.TableName+" WHERE key = $1", req.Key)
-	} else {
-		// Convert req.ETag to uint32 for postgres XID compatibility
-		var etag64 uint64
-		etag64, err = strconv.ParseUint(*req.ETag, 10, 32)
-		if err != nil {
-			return state.NewETagError(state.ETagInvalid, err)
-		}
+	result, err := db.Exec(ctx, query, params...)
+	if err != nil {
+		return err
+	}
+
+	return checkDeleteResult(req, result)
+}
 
-		result, err = db.Exec(ctx, "DELETE FROM "+p.metadata.TableName+" WHERE key = $1 AND $2 = "+p.etagColumn, req.Key, uint32(etag64))
+// deleteQuery returns the query that deletes an item, and its parameters.
+func (p *PostgreSQL) deleteQuery(req *state.DeleteRequest) (string, []any, error) {
+	if req.Key == "" {
+		return "", nil, errors.New("missing key in delete operation")
 	}
+
+	if !req.HasETag() {
+		return "DELETE FROM " + p.metadata.TableName + " WHERE key = $1", []any{req.Key}, nil
+	}
+
+	// Convert req.ETag to uint32 for postgres XID compatibility
+	etag64, err := strconv.ParseUint(*req.ETag, 10, 32)
 	if err != nil {
-		return err
+		return "", nil, state.NewETagError(state.ETagInvalid, err)
 	}
 
-	rows := result.RowsAffected()
-	if rows != 1 && req.ETag != nil && *req.ETag != "" {
+	return "DELETE FROM " + p.metadata.TableName + " WHERE key = $1 AND $2 = " + p.etagColumn, []any{req.Key, uint32(etag64)}, nil
+}
+
+// checkDeleteResult returns an error if the query that deleted an item with an ETag didn't delete it.
+func checkDeleteResult(req *state.DeleteRequest, result pgconn.CommandTag) error {
+	if result.RowsAffected() != 1 && req.HasETag() {
 		return state.NewETagError(state.ETagMismatch, nil)
 	}
 
 	return nil
 }
 
+// BulkSet saves multiple items, sending the queries in a batch so they're executed in a single round trip.
+// Unlike Multi, an item whose ETag doesn't match doesn't prevent the other items from being saved.
+func (p *PostgreSQL) BulkSet(ctx context.Context, req []state.SetRequest, _ state.BulkStoreOpts) error {
+	errs := make([]error, len(req))
+	queries := make([]pgtransactions.BatchQuery, 0, len(req))
+	queued := make([]int, 0, len(req))
+	for i := range req {
+		query, params, err := p.setQuery(&req[i])
+		if err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, err)
+			continue
+		}
+		queries = append(queries, pgtransactions.BatchQuery{
+			Query: query,
+			Args:  params,
+			Check: func(tag pgconn.CommandTag) error {
+				return checkSetResult(&req[i], tag)
+			},
+		})
+		queued = append(queued, i)
+	}
+
+	for j, err := range pgtransactions.ExecuteBatch(ctx, p.db, p.metadata.Timeout, queries) {
+		if err != nil {
+			errs[queued[j]] = state.NewBulkStoreError(req[queued[j]].Key, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// BulkDelete deletes multiple items, sending the queries in a batch so they're executed in a single round trip.
+// Unlike Multi, an item whose ETag doesn't match doesn't prevent the other items from being deleted.
+func (p *PostgreSQL) BulkDelete(ctx context.Context, req []state.DeleteRequest, _ state.BulkStoreOpts) error {
+	errs := make([]error, len(req))
+	queries := make([]pgtransactions.BatchQuery, 0, len(req))
+	queued := make([]int, 0, len(req))
+	for i := range req {
+		query, params, err := p.deleteQuery(&req[i])
+		if err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, err)
+			continue
+		}
+		queries = append(queries, pgtransactions.BatchQuery{
+			Query: query,
+			Args:  params,
+			Check: func(tag pgconn.CommandTag) error {
+				return checkDeleteResult(&req[i], tag)
+			},
+		})
+		queued = append(queued, i)
+	}
+
+	for j, err := range pgtransactions.ExecuteBatch(ctx, p.db, p.metadata.Timeout, queries) {
+		if err != nil {
+			errs[queued[j]] = state.NewBulkStoreError(req[queued[j]].Key, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func (p *PostgreSQL) Multi(parentCtx context.Context, request *state.TransactionalStateRequest) error {
 	if request == nil {
 		return nil
@@ -22,6 +22,7 @@ import (
 
 	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/components-contrib/state/query"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/ptr"
@@ -43,7 +44,6 @@ func NewPostgreSQLQueryStateStore(logger logger.Logger, opts Options) state.Stor
 			enableAzureAD: opts.EnableAzureAD,
 		},
 	}
-	s.BulkStore = state.NewDefaultBulkStore(s)
 	return s
 }
 
@@ -217,7 +217,7 @@ func (q *Query) VisitOR(f *query.OR) (string, error) {
 }
 
 func (q *Query) Finalize(filters string, qq *query.Query) error {
-	q.query = fmt.Sprintf("SELECT key, value, %s as etag FROM "+q.tableName, q.etagColumn)
+	q.query = fmt.Sprintf("SELECT key, value, isbinary, %s as etag FROM "+q.tableName, q.etagColumn)
 
 	if filters != "" {
 		q.query += " WHERE " + filters
@@ -264,13 +264,28 @@ func (q *Query) execute(ctx context.Context, db pginterfaces.DBQuerier) ([]state
 	ret := []state.QueryItem{}
 	for rows.Next() {
 		var (
-			key  string
-			data []byte
-			etag uint32
+			key      string
+			data     []byte
+			isBinary bool
+			etag     uint32
 		)
-		if err = rows.Scan(&key, &data, &etag); err != nil {
+		if err = rows.Scan(&key, &data, &isBinary, &etag); err != nil {
 			return nil, "", err
 		}
+		// Compressed values are stored as binary data and can't match filters, but are returned decompressed
+		if isBinary {
+			var bt []byte
+			bt, err = decodeBinaryValue(data)
+			if err != nil {
+				return nil, "", err
+			}
+			if compression.IsCompressed(bt) {
+				data, err = compression.Decompress(bt)
+				if err != nil {
+					return nil, "", err
+				}
+			}
+		}
 		result := state.QueryItem{
 			Key:  key,
 			Data: data,
@@ -18,16 +18,22 @@ package postgresql
 import (
 	"context"
 	"encoding/json"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	pgxmock "github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 )
 
 type mocks struct {
@@ -218,6 +224,69 @@ func TestMultiOperationOrder(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCompression(t *testing.T) {
+	m, _ := mockDatabase(t)
+	defer m.db.Close()
+
+	var err error
+	m.pg.compression, err = compression.Metadata{Compression: "gzip", CompressionThreshold: ptr.Of(16)}.Codec()
+	require.NoError(t, err)
+
+	value := []byte(`{"color":"` + strings.Repeat("red", 100) + `"}`)
+	_, params, err := m.pg.setQuery(&state.SetRequest{Key: "large", Value: value})
+	require.NoError(t, err)
+	// Compressed values are stored as binary data
+	require.Len(t, params, 3)
+	assert.Equal(t, true, params[2])
+	assert.Less(t, len(params[1].(string)), len(value))
+
+	_, smallParams, err := m.pg.setQuery(&state.SetRequest{Key: "small", Value: map[string]string{"color": "red"}})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"small", `{"color":"red"}`, false}, smallParams)
+
+	m.db.ExpectQuery("SELECT").
+		WithArgs("large").
+		WillReturnRows(pgxmock.NewRows([]string{"key", "value", "isbinary", "etag", "expiredate"}).
+			AddRow("large", []byte(params[1].(string)), true, pgtype.Int8{Int64: 1, Valid: true}, pgtype.Timestamp{}))
+	res, err := m.pg.Get(t.Context(), &state.GetRequest{Key: "large"})
+	require.NoError(t, err)
+	assert.Equal(t, value, res.Data)
+}
+
+func TestScan(t *testing.T) {
+	m, _ := mockDatabase(t)
+	defer m.db.Close()
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := m.pg.Scan(t.Context(), &state.ScanRequest{Start: "b", End: "a"})
+		require.ErrorIs(t, err, state.ErrScanInvalidRange)
+	})
+
+	t.Run("reverse with limit", func(t *testing.T) {
+		m.db.ExpectQuery(regexp.QuoteMeta(`WHERE key COLLATE "C" >= $1 AND (expiredate IS NULL OR expiredate >= CURRENT_TIMESTAMP) AND key COLLATE "C" < $2 AND key COLLATE "C" < $3 ORDER BY key COLLATE "C" DESC LIMIT $4`)).
+			WithArgs("device||", "device||~", "device||3", uint32(3)).
+			WillReturnRows(pgxmock.NewRows([]string{"key", "value", "isbinary", "etag", "expiredate"}).
+				AddRow("device||2", []byte(`"v2"`), false, pgtype.Int8{Int64: 2, Valid: true}, pgtype.Timestamp{}).
+				AddRow("device||1", []byte(`"v1"`), false, pgtype.Int8{Int64: 1, Valid: true}, pgtype.Timestamp{}).
+				AddRow("device||0", []byte(`"v0"`), false, pgtype.Int8{Int64: 1, Valid: true}, pgtype.Timestamp{}))
+
+		res, err := m.pg.Scan(t.Context(), &state.ScanRequest{
+			Start:             "device||",
+			End:               "device||~",
+			Limit:             2,
+			Reverse:           true,
+			ContinuationToken: ptr.Of("device||3"),
+		})
+		require.NoError(t, err)
+		require.NoError(t, m.db.ExpectationsWereMet())
+		assert.Equal(t, []state.ScanItem{
+			{Key: "device||2", Data: []byte(`"v2"`), ETag: ptr.Of("2")},
+			{Key: "device||1", Data: []byte(`"v1"`), ETag: ptr.Of("1")},
+		}, res.Items)
+		assert.Equal(t, ptr.Of("device||1"), res.ContinuationToken)
+	})
+}
+
 func createSetRequest() state.SetRequest {
 	return state.SetRequest{
 		Key:   randomKey(),
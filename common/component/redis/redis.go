@@ -66,6 +66,13 @@ type RedisXPendingExt struct {
 type RedisPipeliner interface {
 	Exec(ctx context.Context) error
 	Do(ctx context.Context, args ...interface{})
+	// DoResult queues a command like Do, returning its result, which is available once the pipeline is executed.
+	DoResult(ctx context.Context, args ...interface{}) RedisCmdResult
+}
+
+// RedisCmdResult is the result of a command queued in a pipeline.
+type RedisCmdResult interface {
+	Result() (interface{}, error)
 }
 
 //nolint:interfacebloat
@@ -89,6 +96,8 @@ type RedisClient interface {
 	XPendingExtResult(ctx context.Context, stream string, group string, start string, end string, count int64) ([]RedisXPendingExt, error)
 	XClaimResult(ctx context.Context, stream string, group string, consumer string, minIdleTime time.Duration, messageIDs []string) ([]RedisXMessage, error)
 	TxPipeline() RedisPipeliner
+	// Pipeline returns a non-transactional pipeline: the commands are sent in a single round trip, but each command can fail independently.
+	Pipeline() RedisPipeliner
 	TTLResult(ctx context.Context, key string) (time.Duration, error)
 	AuthACL(ctx context.Context, username, password string) error
 }
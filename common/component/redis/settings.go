@@ -90,6 +90,8 @@ type Settings struct {
 	// == state only properties ==
 	TTLInSeconds *int   `mapstructure:"ttlInSeconds" mdonly:"state"`
 	QueryIndexes string `mapstructure:"queryIndexes" mdonly:"state"`
+	// Key of the sorted set that indexes all state keys, to support range scans. If empty, range scans are disabled.
+	RangeScanIndex string `mapstructure:"rangeScanIndex" mdonly:"state"`
 
 	// == pubsub only properties ==
 	// The consumer identifier
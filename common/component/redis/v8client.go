@@ -41,6 +41,10 @@ func (p v8Pipeliner) Do(ctx context.Context, args ...interface{}) {
 	p.pipeliner.Do(ctx, args...)
 }
 
+func (p v8Pipeliner) DoResult(ctx context.Context, args ...interface{}) RedisCmdResult {
+	return p.pipeliner.Do(ctx, args...)
+}
+
 // v8Client is an interface implementation of RedisClient
 
 type v8Client struct {
@@ -304,6 +308,13 @@ func (c v8Client) TxPipeline() RedisPipeliner {
 	}
 }
 
+func (c v8Client) Pipeline() RedisPipeliner {
+	return v8Pipeliner{
+		pipeliner:    c.client.Pipeline(),
+		writeTimeout: c.writeTimeout,
+	}
+}
+
 func (c v8Client) TTLResult(ctx context.Context, key string) (time.Duration, error) {
 	var writeCtx context.Context
 	if c.writeTimeout > 0 {
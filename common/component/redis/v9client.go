@@ -41,6 +41,10 @@ func (p v9Pipeliner) Do(ctx context.Context, args ...interface{}) {
 	p.pipeliner.Do(ctx, args...)
 }
 
+func (p v9Pipeliner) DoResult(ctx context.Context, args ...interface{}) RedisCmdResult {
+	return p.pipeliner.Do(ctx, args...)
+}
+
 // v9Client is an interface implementation of RedisClient
 
 type v9Client struct {
@@ -304,6 +308,13 @@ func (c v9Client) TxPipeline() RedisPipeliner {
 	}
 }
 
+func (c v9Client) Pipeline() RedisPipeliner {
+	return v9Pipeliner{
+		pipeliner:    c.client.Pipeline(),
+		writeTimeout: c.writeTimeout,
+	}
+}
+
 func (c v9Client) TTLResult(ctx context.Context, key string) (time.Duration, error) {
 	var writeCtx context.Context
 	if c.writeTimeout > 0 {
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "file.json")
+
+	t.Run("new file", func(t *testing.T) {
+		require.NoError(t, WriteFileAtomic(name, []byte("one")))
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, "one", string(data))
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(name)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		}
+	})
+
+	t.Run("existing file keeps its permissions", func(t *testing.T) {
+		require.NoError(t, os.Chmod(name, 0o640))
+		require.NoError(t, WriteFileAtomic(name, []byte("two")))
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, "two", string(data))
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(name)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+		}
+	})
+
+	t.Run("no temporary files are left", func(t *testing.T) {
+		require.Error(t, WriteFileAtomic(filepath.Join(dir, "missing", "file.json"), []byte("three")))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "file.json", entries[0].Name())
+	})
+}
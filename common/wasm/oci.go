@@ -0,0 +1,550 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wasm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	commonutils "github.com/dapr/components-contrib/common/utils"
+)
+
+// Media types of OCI manifests and layers.
+const (
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeWasm               = "application/wasm"
+	mediaTypeWasmContentLayer   = "application/vnd.wasm.content.layer.v1+wasm"
+)
+
+const (
+	// Registry used for references without a registry host, like Docker does.
+	defaultRegistryHost = "registry-1.docker.io"
+	// Maximum size of manifests and image configs.
+	maxManifestSize = 4 << 20
+)
+
+// ErrDigestMismatch is returned when content doesn't match its expected digest.
+var ErrDigestMismatch = errors.New("digest mismatch")
+
+// ociReference is a reference to an image in an OCI registry, like "ghcr.io/org/image:tag" or "ghcr.io/org/image@sha256:...".
+type ociReference struct {
+	// Host of the registry, with the optional port.
+	host       string
+	repository string
+	tag        string
+	// Digest of the manifest, which pins the reference to a specific image.
+	digest string
+}
+
+func parseOCIReference(ref string) (*ociReference, error) {
+	r := &ociReference{}
+	name := ref
+	if before, after, ok := strings.Cut(name, "@"); ok {
+		name, r.digest = before, after
+		if !isSHA256Digest(r.digest) {
+			return nil, fmt.Errorf("invalid OCI reference %s: only sha256 digests are supported", ref)
+		}
+	}
+	// The tag is after the last colon, if it's after the last slash: otherwise, the colon separates the port of the registry
+	if i := strings.LastIndexByte(name, ':'); i > strings.LastIndexByte(name, '/') {
+		name, r.tag = name[:i], name[i+1:]
+		if r.tag == "" {
+			return nil, fmt.Errorf("invalid OCI reference: %s", ref)
+		}
+	}
+
+	// Like Docker, the first component is the registry host if it looks like a host name
+	host, repository, ok := strings.Cut(name, "/")
+	if !ok || !(strings.ContainsAny(host, ".:") || host == "localhost") {
+		host, repository = defaultRegistryHost, name
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	} else if host == "docker.io" {
+		host = defaultRegistryHost
+	}
+	if repository == "" {
+		return nil, fmt.Errorf("invalid OCI reference: %s", ref)
+	}
+	if r.tag == "" && r.digest == "" {
+		r.tag = "latest"
+	}
+
+	r.host = host
+	r.repository = repository
+	return r, nil
+}
+
+// baseURL returns the URL of the API of the registry.
+// Like Docker, registries on the loopback interface are accessed over plain HTTP, which allows testing with local registries.
+func (r *ociReference) baseURL() string {
+	host := r.host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return "http://" + r.host + "/v2/" + r.repository
+	}
+	return "https://" + r.host + "/v2/" + r.repository
+}
+
+// ociDescriptor describes content in a registry.
+type ociDescriptor struct {
+	MediaType string       `json:"mediaType"`
+	Digest    string       `json:"digest"`
+	Size      int64        `json:"size"`
+	Platform  *ociPlatform `json:"platform,omitempty"`
+}
+
+type ociPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// ociManifest is an image manifest or, if Manifests is not empty, an image index.
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Config    ociDescriptor   `json:"config"`
+	Layers    []ociDescriptor `json:"layers"`
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+// ociImageConfig contains the fields of an image config used to find the guest.
+type ociImageConfig struct {
+	Config struct {
+		Entrypoint []string `json:"Entrypoint"`
+		WorkingDir string   `json:"WorkingDir"`
+	} `json:"config"`
+}
+
+// ociClient pulls guests from OCI registries.
+type ociClient struct {
+	client   *http.Client
+	username string
+	password string
+	cache    *blobCache
+
+	// Authorization header, set after the registry challenged the client
+	authorization string
+}
+
+func newOCIClient(transport http.RoundTripper, m *InitMetadata) *ociClient {
+	return &ociClient{
+		client:   &http.Client{Transport: transport},
+		username: m.RegistryUsername,
+		password: m.RegistryPassword,
+		cache:    newBlobCache(m.CacheDir),
+	}
+}
+
+// pull returns the guest in the image, and its name.
+//
+// If the image is a Wasm OCI artifact, the guest is its Wasm layer.
+// Otherwise, the guest is the file at the ENTRYPOINT of the image, which is looked up in its layers.
+// Other files in the layers are not mounted.
+func (c *ociClient) pull(ctx context.Context, ref *ociReference) (guest []byte, name string, err error) {
+	manifest, err := c.getManifest(ctx, ref)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType == mediaTypeWasm || layer.MediaType == mediaTypeWasmContentLayer {
+			guest, err = c.getBlob(ctx, ref, layer, 0)
+			if err != nil {
+				return nil, "", err
+			}
+			return guest, path.Base(ref.repository), nil
+		}
+	}
+
+	configData, err := c.getBlob(ctx, ref, manifest.Config, maxManifestSize)
+	if err != nil {
+		return nil, "", err
+	}
+	var config ociImageConfig
+	err = json.Unmarshal(configData, &config)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse image config: %w", err)
+	}
+	if len(config.Config.Entrypoint) == 0 {
+		return nil, "", errors.New("image has neither a wasm layer nor an entrypoint")
+	}
+	entrypoint := config.Config.Entrypoint[0]
+	if !path.IsAbs(entrypoint) {
+		entrypoint = path.Join("/", config.Config.WorkingDir, entrypoint)
+	}
+
+	// Later layers override earlier ones
+	for i := len(manifest.Layers) - 1; i >= 0; i-- {
+		layer, err := c.getBlob(ctx, ref, manifest.Layers[i], 0)
+		if err != nil {
+			return nil, "", err
+		}
+		guest, err = findInLayer(layer, entrypoint)
+		if err != nil {
+			return nil, "", err
+		}
+		if guest != nil {
+			name, _ = strings.CutSuffix(path.Base(entrypoint), ".wasm")
+			return guest, name, nil
+		}
+	}
+	return nil, "", fmt.Errorf("entrypoint %s not found in the image layers", entrypoint)
+}
+
+// getManifest returns the image manifest for the reference, resolving image indexes to the manifest for the wasm architecture.
+func (c *ociClient) getManifest(ctx context.Context, ref *ociReference) (*ociManifest, error) {
+	desc := ociDescriptor{Digest: ref.digest}
+	reference := ref.digest
+	if reference == "" {
+		reference = ref.tag
+	}
+
+	// Follow at most one level of indexes
+	for range 2 {
+		data, err := c.fetch(ctx, ref, "manifests", reference, desc, maxManifestSize)
+		if err != nil {
+			return nil, err
+		}
+		var manifest ociManifest
+		err = json.Unmarshal(data, &manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %w", err)
+		}
+		if len(manifest.Manifests) == 0 {
+			return &manifest, nil
+		}
+
+		desc, err = selectManifest(manifest.Manifests)
+		if err != nil {
+			return nil, err
+		}
+		reference = desc.Digest
+	}
+	return nil, errors.New("nested image indexes are not supported")
+}
+
+func selectManifest(manifests []ociDescriptor) (ociDescriptor, error) {
+	for _, m := range manifests {
+		if m.Platform != nil && m.Platform.Architecture == "wasm" {
+			return m, nil
+		}
+	}
+	if len(manifests) == 1 {
+		return manifests[0], nil
+	}
+	return ociDescriptor{}, errors.New("image index has no manifest for the wasm architecture")
+}
+
+func (c *ociClient) getBlob(ctx context.Context, ref *ociReference, desc ociDescriptor, maxSize int64) ([]byte, error) {
+	if !isSHA256Digest(desc.Digest) {
+		return nil, fmt.Errorf("unsupported digest: %s", desc.Digest)
+	}
+	if maxSize > 0 && desc.Size > maxSize {
+		return nil, fmt.Errorf("blob %s is too large: %d bytes", desc.Digest, desc.Size)
+	}
+	return c.fetch(ctx, ref, "blobs", desc.Digest, desc, maxSize)
+}
+
+// fetch returns a manifest or blob from the registry or, if it's identified by its digest, from the cache.
+// Content identified by a digest is verified against it.
+func (c *ociClient) fetch(ctx context.Context, ref *ociReference, kind string, reference string, desc ociDescriptor, maxSize int64) ([]byte, error) {
+	if desc.Digest != "" {
+		if data, ok := c.cache.get(desc.Digest); ok {
+			return data, nil
+		}
+	}
+
+	accept := ""
+	if kind == "manifests" {
+		accept = strings.Join([]string{mediaTypeOCIManifest, mediaTypeOCIIndex, mediaTypeDockerManifest, mediaTypeDockerManifestList}, ", ")
+	}
+	resp, err := c.do(ctx, ref.baseURL()+"/"+kind+"/"+reference, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Don't read more than expected
+	limit := maxSize
+	if desc.Size > 0 && (limit <= 0 || desc.Size < limit) {
+		limit = desc.Size
+	}
+	var body io.Reader = resp.Body
+	if limit > 0 {
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Size > 0 && int64(len(data)) != desc.Size {
+		return nil, fmt.Errorf("%w: %s %s has a different size than expected", ErrDigestMismatch, kind, reference)
+	}
+	if maxSize > 0 && int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%s %s is too large", kind, reference)
+	}
+
+	if desc.Digest != "" {
+		err = verifySHA256(data, desc.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", kind, reference, err)
+		}
+		c.cache.put(desc.Digest, data)
+	}
+	return data, nil
+}
+
+// do sends a GET request to the registry, authenticating if the registry requires it.
+func (c *ociClient) do(ctx context.Context, u string, accept string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if c.authorization != "" {
+			req.Header.Set("Authorization", c.authorization)
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return resp, nil
+		case resp.StatusCode == http.StatusUnauthorized && attempt == 0:
+			challenge := resp.Header.Get("WWW-Authenticate")
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			err = c.authorize(ctx, challenge)
+			if err != nil {
+				return nil, fmt.Errorf("failed to authenticate with the registry: %w", err)
+			}
+		default:
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("received %v status code from %q", resp.StatusCode, u)
+		}
+	}
+}
+
+// authorize sets the authorization header requested by the challenge of the registry.
+// Bearer tokens are requested with the credentials, if any, or anonymously otherwise.
+func (c *ociClient) authorize(ctx context.Context, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.username == "" {
+			return errors.New("registry requires credentials")
+		}
+		c.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password))
+		return nil
+	case "bearer":
+		// Continue
+	default:
+		return fmt.Errorf("unsupported authentication challenge: %q", challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return fmt.Errorf("invalid realm in authentication challenge: %q", challenge)
+	}
+	q := realm.Query()
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	if params["scope"] != "" {
+		q.Set("scope", params["scope"])
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("received %v status code from %q", resp.StatusCode, realm.Redacted())
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token)
+	if err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return errors.New("token response is empty")
+	}
+	c.authorization = "Bearer " + token.Token
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header like `Bearer realm="https://auth.example.com/token",service="example.com"`.
+func parseChallenge(challenge string) (scheme string, params map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params = make(map[string]string)
+	for {
+		rest = strings.TrimLeft(rest, " ,")
+		key, after, ok := strings.Cut(rest, "=")
+		if !ok {
+			return scheme, params
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		var value string
+		if strings.HasPrefix(after, `"`) {
+			// Quoted values can contain commas
+			end := strings.IndexByte(after[1:], '"')
+			if end < 0 {
+				value, rest = after[1:], ""
+			} else {
+				value, rest = after[1:end+1], after[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(after, ",")
+		}
+		params[key] = value
+	}
+}
+
+// findInLayer returns the content of the regular file with the absolute path name in a tar layer, which can be compressed with gzip.
+// It returns nil if the file is not in the layer.
+func findInLayer(layer []byte, name string) ([]byte, error) {
+	var r io.Reader = bytes.NewReader(layer)
+	if len(layer) > 2 && layer[0] == 0x1f && layer[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress layer: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read layer: %w", err)
+		}
+		if h.Typeflag == tar.TypeReg && path.Join("/", h.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+func isSHA256Digest(digest string) bool {
+	hexSum, ok := strings.CutPrefix(digest, "sha256:")
+	if !ok || len(hexSum) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hexSum)
+	return err == nil && strings.ToLower(hexSum) == hexSum
+}
+
+// verifySHA256 returns ErrDigestMismatch if the SHA-256 digest of data, formatted as "sha256:<hex>", is not the expected one.
+func verifySHA256(data []byte, expected string) error {
+	sum := sha256.Sum256(data)
+	actual := "sha256:" + hex.EncodeToString(sum[:])
+	if actual != expected {
+		return fmt.Errorf("%w: expected %s, got %s", ErrDigestMismatch, expected, actual)
+	}
+	return nil
+}
+
+// blobCache stores manifests and blobs in a directory, by digest.
+// A nil blobCache doesn't cache anything.
+type blobCache struct {
+	dir string
+}
+
+func newBlobCache(cacheDir string) *blobCache {
+	if cacheDir == "" {
+		return nil
+	}
+	return &blobCache{dir: filepath.Join(cacheDir, "oci", "blobs", "sha256")}
+}
+
+func (c *blobCache) path(digest string) string {
+	return filepath.Join(c.dir, strings.TrimPrefix(digest, "sha256:"))
+}
+
+// get returns the content with the digest, if it's cached and not corrupted.
+func (c *blobCache) get(digest string) ([]byte, bool) {
+	if c == nil || !isSHA256Digest(digest) {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(digest))
+	if err != nil {
+		return nil, false
+	}
+	if verifySHA256(data, digest) != nil {
+		_ = os.Remove(c.path(digest))
+		return nil, false
+	}
+	return data, true
+}
+
+// put stores content that was verified against its digest.
+// Errors are ignored, as the content is fetched from the registry again when it's not cached.
+func (c *blobCache) put(digest string, data []byte) {
+	if c == nil {
+		return
+	}
+	if os.MkdirAll(c.dir, 0o700) != nil {
+		return
+	}
+
+	// Concurrent readers never see partial content
+	_ = commonutils.WriteFileAtomic(c.path(digest), data)
+}
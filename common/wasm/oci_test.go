@@ -0,0 +1,324 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wasm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/metadata"
+)
+
+// testRegistry is a minimal OCI registry serving manifests and blobs from memory.
+type testRegistry struct {
+	lock      sync.Mutex
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	// If set, clients must authenticate with this bearer token
+	token string
+
+	srv *httptest.Server
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	r := &testRegistry{
+		manifests: make(map[string][]byte),
+		blobs:     make(map[string][]byte),
+	}
+	r.srv = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	t.Cleanup(r.srv.Close)
+	return r
+}
+
+// host returns the host of the registry, which is on the loopback interface.
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(r.srv.URL, "http://")
+}
+
+func (r *testRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req.URL.Path == "/token" {
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": r.token})
+		return
+	}
+	if r.token != "" && req.Header.Get("Authorization") != "Bearer "+r.token {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+r.srv.URL+`/token",service="test",scope="repository:dapr/guest:pull"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var (
+		data []byte
+		ok   bool
+	)
+	if _, ref, found := strings.Cut(req.URL.Path, "/manifests/"); found {
+		data, ok = r.manifests[ref]
+	} else if _, digest, found := strings.Cut(req.URL.Path, "/blobs/"); found {
+		data, ok = r.blobs[digest]
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write(data)
+}
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func (r *testRegistry) addBlob(mediaType string, data []byte) ociDescriptor {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	digest := sha256Digest(data)
+	r.blobs[digest] = data
+	return ociDescriptor{MediaType: mediaType, Digest: digest, Size: int64(len(data))}
+}
+
+// addManifest stores the manifest by tag and by digest, and returns its descriptor.
+func (r *testRegistry) addManifest(t *testing.T, tag string, manifest ociManifest) ociDescriptor {
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	digest := sha256Digest(data)
+	r.manifests[digest] = data
+	if tag != "" {
+		r.manifests[tag] = data
+	}
+	return ociDescriptor{MediaType: manifest.MediaType, Digest: digest, Size: int64(len(data))}
+}
+
+// addWasmArtifact adds a Wasm OCI artifact with the guest.
+func (r *testRegistry) addWasmArtifact(t *testing.T, tag string, guest []byte) ociDescriptor {
+	return r.addManifest(t, tag, ociManifest{
+		MediaType: mediaTypeOCIManifest,
+		Config:    r.addBlob("application/vnd.wasm.config.v0+json", []byte("{}")),
+		Layers:    []ociDescriptor{r.addBlob(mediaTypeWasm, guest)},
+	})
+}
+
+func tarGzLayer(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, data := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestGetInitMetadataOCI(t *testing.T) {
+	reg := newTestRegistry(t)
+	artifact := reg.addWasmArtifact(t, "v1", binArgs)
+
+	load := func(t *testing.T, props map[string]string) (*InitMetadata, error) {
+		return GetInitMetadata(t.Context(), metadata.Base{Properties: props})
+	}
+
+	t.Run("wasm artifact by tag", func(t *testing.T) {
+		md, err := load(t, map[string]string{"url": "oci://" + reg.host() + "/dapr/guest:v1"})
+		require.NoError(t, err)
+		assert.Equal(t, binArgs, md.Guest)
+		assert.Equal(t, "guest", md.GuestName)
+	})
+
+	t.Run("oci scheme is required", func(t *testing.T) {
+		_, err := load(t, map[string]string{"url": reg.host() + "/dapr/guest:v1"})
+		require.ErrorContains(t, err, "invalid URL")
+	})
+
+	t.Run("pinned digest", func(t *testing.T) {
+		md, err := load(t, map[string]string{"url": "oci://" + reg.host() + "/dapr/guest@" + artifact.Digest})
+		require.NoError(t, err)
+		assert.Equal(t, binArgs, md.Guest)
+	})
+
+	t.Run("pinned digest mismatch", func(t *testing.T) {
+		// The registry returns a different manifest than the one pinned
+		other := reg.addWasmArtifact(t, "", binStrict)
+		pinned := sha256Digest([]byte("something else"))
+		reg.lock.Lock()
+		reg.manifests[pinned] = reg.manifests[other.Digest]
+		reg.lock.Unlock()
+
+		_, err := load(t, map[string]string{"url": "oci://" + reg.host() + "/dapr/guest@" + pinned})
+		require.ErrorIs(t, err, ErrDigestMismatch)
+	})
+
+	t.Run("tampered layer", func(t *testing.T) {
+		desc := reg.addWasmArtifact(t, "tampered", binStrict)
+		var manifest ociManifest
+		reg.lock.Lock()
+		require.NoError(t, json.Unmarshal(reg.manifests[desc.Digest], &manifest))
+		tampered := bytes.Clone(binStrict)
+		tampered[len(tampered)-1]++
+		reg.blobs[manifest.Layers[0].Digest] = tampered
+		reg.lock.Unlock()
+
+		_, err := load(t, map[string]string{"url": "oci://" + reg.host() + "/dapr/guest:tampered"})
+		require.ErrorIs(t, err, ErrDigestMismatch)
+	})
+
+	t.Run("sha256 of the guest", func(t *testing.T) {
+		sum := sha256.Sum256(binArgs)
+		md, err := load(t, map[string]string{
+			"url":    "oci://" + reg.host() + "/dapr/guest:v1",
+			"sha256": hex.EncodeToString(sum[:]),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, binArgs, md.Guest)
+
+		_, err = load(t, map[string]string{
+			"url":    "oci://" + reg.host() + "/dapr/guest:v1",
+			"sha256": strings.Repeat("0", 64),
+		})
+		require.ErrorIs(t, err, ErrDigestMismatch)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := load(t, map[string]string{"url": "oci://" + reg.host() + "/dapr/guest:notfound"})
+		require.ErrorContains(t, err, "received 404 status code")
+	})
+
+	t.Run("image index with entrypoint", func(t *testing.T) {
+		config := reg.addBlob("application/vnd.oci.image.config.v1+json", []byte(`{"config":{"Entrypoint":["main.wasm"],"WorkingDir":"/app"}}`))
+		image := reg.addManifest(t, "", ociManifest{
+			MediaType: mediaTypeOCIManifest,
+			Config:    config,
+			Layers: []ociDescriptor{
+				reg.addBlob("application/vnd.oci.image.layer.v1.tar+gzip", tarGzLayer(t, map[string][]byte{"app/main.wasm": binStrict})),
+				// Later layers override earlier ones
+				reg.addBlob("application/vnd.oci.image.layer.v1.tar+gzip", tarGzLayer(t, map[string][]byte{"app/main.wasm": binArgs, "app/data.txt": []byte("hi")})),
+			},
+		})
+		image.Platform = &ociPlatform{OS: "wasip1", Architecture: "wasm"}
+		reg.addManifest(t, "multi", ociManifest{
+			MediaType: mediaTypeOCIIndex,
+			Manifests: []ociDescriptor{
+				{MediaType: mediaTypeOCIManifest, Digest: sha256Digest([]byte("amd64")), Size: 10, Platform: &ociPlatform{OS: "linux", Architecture: "amd64"}},
+				image,
+			},
+		})
+
+		md, err := load(t, map[string]string{"url": "oci://" + reg.host() + "/dapr/guest:multi"})
+		require.NoError(t, err)
+		assert.Equal(t, binArgs, md.Guest)
+		assert.Equal(t, "main", md.GuestName)
+	})
+
+	t.Run("bearer token", func(t *testing.T) {
+		reg.lock.Lock()
+		reg.token = "secret"
+		reg.lock.Unlock()
+		t.Cleanup(func() {
+			reg.lock.Lock()
+			reg.token = ""
+			reg.lock.Unlock()
+		})
+
+		md, err := load(t, map[string]string{"url": "oci://" + reg.host() + "/dapr/guest:v1"})
+		require.NoError(t, err)
+		assert.Equal(t, binArgs, md.Guest)
+	})
+}
+
+func TestGetInitMetadataOCICache(t *testing.T) {
+	reg := newTestRegistry(t)
+	artifact := reg.addWasmArtifact(t, "v1", binArgs)
+	cacheDir := t.TempDir()
+	props := map[string]string{
+		"url":      "oci://" + reg.host() + "/dapr/guest@" + artifact.Digest,
+		"cacheDir": cacheDir,
+	}
+
+	md, err := GetInitMetadata(t.Context(), metadata.Base{Properties: props})
+	require.NoError(t, err)
+	assert.Equal(t, binArgs, md.Guest)
+
+	// Pinned references are loaded from the cache when the registry is not available
+	reg.srv.Close()
+	md, err = GetInitMetadata(t.Context(), metadata.Base{Properties: props})
+	require.NoError(t, err)
+	assert.Equal(t, binArgs, md.Guest)
+}
+
+func TestParseOCIReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+
+	tests := []struct {
+		ref         string
+		expected    *ociReference
+		expectedErr string
+	}{
+		{ref: "ghcr.io/org/image:v1", expected: &ociReference{host: "ghcr.io", repository: "org/image", tag: "v1"}},
+		{ref: "ghcr.io/org/image", expected: &ociReference{host: "ghcr.io", repository: "org/image", tag: "latest"}},
+		{ref: "localhost:5000/image", expected: &ociReference{host: "localhost:5000", repository: "image", tag: "latest"}},
+		{ref: "localhost:5000/image:v1@" + digest, expected: &ociReference{host: "localhost:5000", repository: "image", tag: "v1", digest: digest}},
+		{ref: "ghcr.io/org/image@" + digest, expected: &ociReference{host: "ghcr.io", repository: "org/image", digest: digest}},
+		{ref: "python-wasm:3.11", expected: &ociReference{host: defaultRegistryHost, repository: "library/python-wasm", tag: "3.11"}},
+		{ref: "vmware/python-wasm", expected: &ociReference{host: defaultRegistryHost, repository: "vmware/python-wasm", tag: "latest"}},
+		{ref: "docker.io/vmware/python-wasm", expected: &ociReference{host: defaultRegistryHost, repository: "vmware/python-wasm", tag: "latest"}},
+		{ref: "ghcr.io/org/image@sha1:abc", expectedErr: "only sha256 digests are supported"},
+		{ref: "ghcr.io/org/image:", expectedErr: "invalid OCI reference"},
+		{ref: "ghcr.io/", expectedErr: "invalid OCI reference"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ref, func(t *testing.T) {
+			ref, err := parseOCIReference(tc.ref)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:org/image:pull,push"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:org/image:pull,push",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	assert.Equal(t, "Basic", scheme)
+	assert.Equal(t, map[string]string{"realm": "registry"}, params)
+}
@@ -24,7 +24,9 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -39,14 +41,35 @@ type InitMetadata struct {
 	// URL is how to load a `%.wasm` file that implements a command, usually
 	// compiled to target WASI.
 	//
-	// If no scheme is present, this defaults to oci://, referencing an OCI
-	// image. The wasm is identified be ENTRYPOINT and any other files in the
-	// corresponding layers will be mounted read-only as the root file system.
+	// The oci:// scheme references an OCI image. The wasm is identified be
+	// ENTRYPOINT and any other files in the corresponding layers will be
+	// mounted read-only as the root file system. The scheme is required, so
+	// a URL without one is rejected rather than pulled from a registry.
 	//
 	// Other valid schemes are file:// for a local file or http[s]:// for one
 	// retrieved via HTTP. In these cases, no filesystem will be mounted.
+	//
+	// OCI references can be pinned to a manifest digest, for example
+	// oci://ghcr.io/org/image@sha256:<hex>, in which case the manifest and
+	// layers are verified against it.
 	URL string `mapstructure:"url"`
 
+	// SHA256 is the optional hex-encoded SHA-256 digest of the guest. When
+	// set, loading fails if the guest loaded from URL doesn't match it.
+	SHA256 string `mapstructure:"sha256"`
+
+	// CacheDir is an optional directory to cache the layers pulled from OCI
+	// registries and the compiled guests. It can be shared by components and
+	// processes, so guests are not downloaded nor compiled again on restart.
+	CacheDir string `mapstructure:"cacheDir"`
+
+	// RegistryUsername is the optional username to authenticate with OCI
+	// registries. Without it, images are pulled anonymously.
+	RegistryUsername string `mapstructure:"registryUsername"`
+
+	// RegistryPassword is the password or token for RegistryUsername.
+	RegistryPassword string `mapstructure:"registryPassword"`
+
 	// StrictSandbox when true uses fake sources to avoid vulnerabilities such
 	// as timing attacks.
 	//
@@ -69,8 +92,6 @@ type InitMetadata struct {
 
 // GetInitMetadata returns InitMetadata from the input metadata.
 func GetInitMetadata(ctx context.Context, md metadata.Base) (*InitMetadata, error) {
-	// Note: the ctx will be used for other schemes such as HTTP and OCI.
-
 	var m InitMetadata
 	// Decode the metadata
 	if err := kitmd.DecodeMetadata(md.Properties, &m); err != nil {
@@ -81,15 +102,22 @@ func GetInitMetadata(ctx context.Context, md metadata.Base) (*InitMetadata, erro
 		return nil, errors.New("missing url")
 	}
 
-	firstColon := strings.IndexByte(m.URL, ':')
-	if firstColon == -1 {
+	scheme, rest, ok := strings.Cut(m.URL, "://")
+	if !ok {
 		return nil, fmt.Errorf("invalid URL: %s", m.URL)
 	}
 
-	scheme := m.URL[:firstColon]
-	switch m.URL[:firstColon] {
+	switch scheme {
 	case "oci":
-		return nil, fmt.Errorf("TODO %s", scheme)
+		ref, err := parseOCIReference(rest)
+		if err != nil {
+			return nil, err
+		}
+		c := newOCIClient(http.DefaultTransport, &m)
+		m.Guest, m.GuestName, err = c.pull(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pull %s: %w", rest, err)
+		}
 	case "http", "https":
 		u, err := url.Parse(m.URL)
 		if err != nil {
@@ -102,7 +130,7 @@ func GetInitMetadata(ctx context.Context, md metadata.Base) (*InitMetadata, erro
 		}
 		m.GuestName, _ = strings.CutSuffix(path.Base(u.Path), ".wasm")
 	case "file":
-		guestPath := m.URL[7:]
+		guestPath := rest
 		guest, err := os.ReadFile(guestPath)
 		if err != nil {
 			return nil, err
@@ -114,6 +142,13 @@ func GetInitMetadata(ctx context.Context, md metadata.Base) (*InitMetadata, erro
 		return nil, fmt.Errorf("unsupported URL scheme: %s", scheme)
 	}
 
+	if m.SHA256 != "" {
+		expected := "sha256:" + strings.ToLower(strings.TrimPrefix(m.SHA256, "sha256:"))
+		if err := verifySHA256(m.Guest, expected); err != nil {
+			return nil, fmt.Errorf("guest loaded from %s: %w", m.URL, err)
+		}
+	}
+
 	return &m, nil
 }
 
@@ -137,6 +172,38 @@ func NewModuleConfig(m *InitMetadata) wazero.ModuleConfig {
 		WithWalltime(newFakeWalltime(), sys.ClockResolution(time.Millisecond))
 }
 
+var (
+	compilationCachesLock sync.Mutex
+	compilationCaches     = map[string]wazero.CompilationCache{}
+)
+
+// NewRuntimeConfig returns a new runtime config appropriate for the
+// initialized metadata.
+//
+// When CacheDir is set, the runtime uses a compilation cache persisted in
+// it, which is shared by all the components in the process.
+func NewRuntimeConfig(m *InitMetadata) (wazero.RuntimeConfig, error) {
+	cfg := wazero.NewRuntimeConfig()
+	if m.CacheDir == "" {
+		return cfg, nil
+	}
+
+	dir := filepath.Join(m.CacheDir, "compilation")
+
+	compilationCachesLock.Lock()
+	defer compilationCachesLock.Unlock()
+	cache, ok := compilationCaches[dir]
+	if !ok {
+		var err error
+		cache, err = wazero.NewCompilationCacheWithDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create compilation cache: %w", err)
+		}
+		compilationCaches[dir] = cache
+	}
+	return cfg.WithCompilationCache(cache), nil
+}
+
 func newFakeWalltime() sys.Walltime {
 	t := time.Now().Unix() * int64(time.Second)
 	return func() (sec int64, nsec int32) {
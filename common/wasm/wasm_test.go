@@ -18,7 +18,13 @@ package wasm
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -37,6 +43,11 @@ const (
 //go:embed testdata/args/main.wasm
 var binArgs []byte
 
+var sha256Args = func() string {
+	sum := sha256.Sum256(binArgs)
+	return hex.EncodeToString(sum[:])
+}()
+
 func TestGetInitMetadata(t *testing.T) {
 	testCtx, cancel := context.WithCancel(t.Context())
 	t.Cleanup(cancel)
@@ -93,11 +104,32 @@ func TestGetInitMetadata(t *testing.T) {
 			expectedErr: "parse \"https:// \": invalid character \" \" in host name",
 		},
 		{
-			name: "TODO oci",
+			name: "oci invalid digest",
+			metadata: metadata.Base{Properties: map[string]string{
+				"url": urlPythonOCI + "@sha1:0123",
+			}},
+			expectedErr: "only sha256 digests are supported",
+		},
+		{
+			name: "file valid - sha256",
+			metadata: metadata.Base{Properties: map[string]string{
+				"url":    urlArgsFile,
+				"sha256": sha256Args,
+			}},
+			expected: &InitMetadata{
+				URL:       urlArgsFile,
+				SHA256:    sha256Args,
+				Guest:     binArgs,
+				GuestName: "main",
+			},
+		},
+		{
+			name: "file sha256 mismatch",
 			metadata: metadata.Base{Properties: map[string]string{
-				"url": urlPythonOCI,
+				"url":    urlArgsFile,
+				"sha256": strings.Repeat("0", 64),
 			}},
-			expectedErr: "TODO oci",
+			expectedErr: "digest mismatch",
 		},
 		{
 			name: "TODO http",
@@ -113,6 +145,20 @@ func TestGetInitMetadata(t *testing.T) {
 			}},
 			expectedErr: "no such host",
 		},
+		{
+			name: "missing scheme",
+			metadata: metadata.Base{Properties: map[string]string{
+				"url": "ghcr.io/vmware-labs/python-wasm:3.11.3",
+			}},
+			expectedErr: "invalid URL: ghcr.io/vmware-labs/python-wasm:3.11.3",
+		},
+		{
+			name: "malformed URL",
+			metadata: metadata.Base{Properties: map[string]string{
+				"url": "file:testdata/args/main.wasm",
+			}},
+			expectedErr: "invalid URL: file:testdata/args/main.wasm",
+		},
 		{
 			name: "unsupported scheme",
 			metadata: metadata.Base{Properties: map[string]string{
@@ -223,3 +269,36 @@ func TestNewModuleConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRuntimeConfig(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("no cache dir", func(t *testing.T) {
+		cfg, err := NewRuntimeConfig(&InitMetadata{})
+		require.NoError(t, err)
+		require.NotNil(t, cfg)
+	})
+
+	t.Run("cache dir", func(t *testing.T) {
+		m := &InitMetadata{CacheDir: t.TempDir(), Guest: binArgs}
+
+		cfg1, err := NewRuntimeConfig(m)
+		require.NoError(t, err)
+		cfg2, err := NewRuntimeConfig(m)
+		require.NoError(t, err)
+
+		for _, cfg := range []wazero.RuntimeConfig{cfg1, cfg2} {
+			rt := wazero.NewRuntimeWithConfig(ctx, cfg)
+			_, err = rt.CompileModule(ctx, m.Guest)
+			require.NoError(t, err)
+			require.NoError(t, rt.Close(ctx))
+		}
+
+		// Compiled modules are persisted on the platforms supported by the compiler
+		if runtime.GOARCH == "amd64" || runtime.GOARCH == "arm64" {
+			entries, err := os.ReadDir(filepath.Join(m.CacheDir, "compilation"))
+			require.NoError(t, err)
+			require.NotEmpty(t, entries)
+		}
+	})
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	commonetcd "github.com/dapr/components-contrib/common/component/etcd"
+	"github.com/dapr/components-contrib/configuration"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+
+	// Delay before watching again after a watch was canceled by the server
+	rewatchDelay = time.Second
+)
+
+type metadata struct {
+	commonetcd.ClientMetadata `mapstructure:",squash"`
+
+	// Prefix of the keys of the configuration items in etcd, which is not part of the keys of the items.
+	KeyPrefixPath string `mapstructure:"keyPrefixPath"`
+	// Timeout for requests to etcd.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+var _ configuration.Store = (*ConfigurationStore)(nil)
+
+// ConfigurationStore is a configuration store backed by etcd.
+// The version of items is their mod revision.
+type ConfigurationStore struct {
+	metadata metadata
+	client   *clientv3.Client
+	prefix   string
+	logger   logger.Logger
+	subs     *configuration.Subscriptions
+
+	lock    sync.Mutex
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+	closed  bool
+}
+
+// NewEtcdConfigurationStore returns a new etcd configuration store.
+func NewEtcdConfigurationStore(logger logger.Logger) configuration.Store {
+	return &ConfigurationStore{
+		logger:  logger,
+		subs:    configuration.NewSubscriptions(logger),
+		cancels: map[string]context.CancelFunc{},
+	}
+}
+
+// Init parses the metadata and creates the etcd client.
+func (s *ConfigurationStore) Init(_ context.Context, md configuration.Metadata) error {
+	s.metadata = metadata{
+		Timeout: defaultTimeout,
+	}
+	err := kitmd.DecodeMetadata(md.Properties, &s.metadata)
+	if err != nil {
+		return err
+	}
+	if s.metadata.Timeout <= 0 {
+		return errors.New("invalid value for 'timeout': must be greater than 0")
+	}
+
+	s.prefix = ""
+	if p := strings.TrimSuffix(s.metadata.KeyPrefixPath, "/"); p != "" {
+		s.prefix = p + "/"
+	}
+
+	s.client, err = commonetcd.NewClient(s.metadata.ClientMetadata)
+	if err != nil {
+		return fmt.Errorf("initializing etcd client: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the items with the given keys, or all items under the key prefix if no key is given.
+// Items with different keys are read at the same revision.
+func (s *ConfigurationStore) Get(parentCtx context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, s.metadata.Timeout)
+	defer cancel()
+
+	items := make(map[string]*configuration.Item, len(req.Keys))
+	if len(req.Keys) == 0 {
+		resp, err := s.client.Get(ctx, s.prefix, clientv3.WithPrefix())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configuration items: %w", err)
+		}
+		for _, kv := range resp.Kvs {
+			items[strings.TrimPrefix(string(kv.Key), s.prefix)] = newItem(kv.Value, kv.ModRevision)
+		}
+	} else {
+		var opts []clientv3.OpOption
+		for _, key := range req.Keys {
+			resp, err := s.client.Get(ctx, s.prefix+key, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get configuration item %s: %w", key, err)
+			}
+			if opts == nil {
+				opts = []clientv3.OpOption{clientv3.WithRev(resp.Header.GetRevision())}
+			}
+			if len(resp.Kvs) > 0 {
+				items[key] = newItem(resp.Kvs[0].Value, resp.Kvs[0].ModRevision)
+			}
+		}
+	}
+
+	return &configuration.GetResponse{
+		Items: items,
+	}, nil
+}
+
+func newItem(value []byte, modRevision int64) *configuration.Item {
+	return &configuration.Item{
+		Value:    string(value),
+		Version:  strconv.FormatInt(modRevision, 10),
+		Metadata: map[string]string{},
+	}
+}
+
+// Subscribe watches the items with etcd watches.
+// Subscriptions to a single key watch that key, and other subscriptions watch all keys under the key prefix.
+func (s *ConfigurationStore) Subscribe(parentCtx context.Context, req *configuration.SubscribeRequest, handler configuration.UpdateHandler) (string, error) {
+	// Get the current revision, so changes made after Subscribe returns are notified
+	getCtx, getCancel := context.WithTimeout(parentCtx, s.metadata.Timeout)
+	resp, err := s.client.Get(getCtx, s.prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	getCancel()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the current revision: %w", err)
+	}
+
+	sub, err := s.subs.Add(req, handler)
+	if err != nil {
+		return "", err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		s.subs.Remove(sub.ID)
+		return "", errors.New("configuration store is closed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancels[sub.ID] = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.watch(ctx, sub, resp.Header.GetRevision()+1)
+	}()
+
+	return sub.ID, nil
+}
+
+// Unsubscribe stops the watch of a subscription.
+func (s *ConfigurationStore) Unsubscribe(_ context.Context, req *configuration.UnsubscribeRequest) error {
+	_, err := s.subs.Remove(req.ID)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	cancel := s.cancels[req.ID]
+	delete(s.cancels, req.ID)
+	s.lock.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return nil
+}
+
+// watch notifies a subscription of the changes from the given revision, until the context is canceled.
+// Deleted items are notified as empty items.
+func (s *ConfigurationStore) watch(ctx context.Context, sub *configuration.Subscription, rev int64) {
+	key := s.prefix
+	opts := []clientv3.OpOption{clientv3.WithPrefix()}
+	if len(sub.Keys) == 1 {
+		key = s.prefix + sub.Keys[0]
+		opts = nil
+	}
+
+	for {
+		wch := s.client.Watch(clientv3.WithRequireLeader(ctx), key, append(opts, clientv3.WithRev(rev))...)
+		for wresp := range wch {
+			err := wresp.Err()
+			if err != nil {
+				if wresp.CompactRevision > rev {
+					s.logger.Warnf("Changes to configuration items for subscription %s before revision %d were compacted and are not notified", sub.ID, wresp.CompactRevision)
+					rev = wresp.CompactRevision
+				}
+				if ctx.Err() == nil {
+					s.logger.Warnf("Error watching configuration items for subscription %s: %v", sub.ID, err)
+				}
+				continue
+			}
+
+			items := make(map[string]*configuration.Item, len(wresp.Events))
+			for _, ev := range wresp.Events {
+				if !strings.HasPrefix(string(ev.Kv.Key), s.prefix) {
+					continue
+				}
+				itemKey := strings.TrimPrefix(string(ev.Kv.Key), s.prefix)
+				if ev.Type == clientv3.EventTypeDelete {
+					items[itemKey] = &configuration.Item{}
+				} else {
+					items[itemKey] = newItem(ev.Kv.Value, ev.Kv.ModRevision)
+				}
+			}
+			if wresp.Header.GetRevision() >= rev {
+				rev = wresp.Header.GetRevision() + 1
+			}
+			sub.Notify(ctx, items)
+		}
+
+		// The channel is closed when the context is canceled, or when the watch is canceled by the server
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rewatchDelay):
+		}
+	}
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (s *ConfigurationStore) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
+	metadataStruct := metadata{}
+	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.ConfigurationStoreType)
+	return
+}
+
+// Close stops all subscriptions and closes the client.
+func (s *ConfigurationStore) Close() error {
+	s.lock.Lock()
+	s.closed = true
+	for id, cancel := range s.cancels {
+		cancel()
+		delete(s.cancels, id)
+	}
+	s.lock.Unlock()
+
+	s.wg.Wait()
+
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Close()
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/kit/logger"
+)
+
+// fakeKV implements Get of clientv3.KV on an in-memory map.
+type fakeKV struct {
+	clientv3.KV
+
+	kvs map[string]*mvccpb.KeyValue
+	rev int64
+}
+
+func (f *fakeKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	op := clientv3.OpGet(key, opts...)
+	start, end := op.KeyBytes(), op.RangeBytes()
+
+	resp := &clientv3.GetResponse{
+		Header: &etcdserverpb.ResponseHeader{Revision: f.rev},
+	}
+	for k, kv := range f.kvs {
+		kb := []byte(k)
+		match := bytes.Equal(kb, start)
+		if len(end) > 0 {
+			match = bytes.Compare(kb, start) >= 0 && (bytes.Equal(end, []byte{0}) || bytes.Compare(kb, end) < 0)
+		}
+		if match {
+			resp.Kvs = append(resp.Kvs, kv)
+		}
+	}
+	sort.Slice(resp.Kvs, func(i, j int) bool {
+		return bytes.Compare(resp.Kvs[i].Key, resp.Kvs[j].Key) < 0
+	})
+	resp.Count = int64(len(resp.Kvs))
+	if op.IsCountOnly() {
+		resp.Kvs = nil
+	}
+	return resp, nil
+}
+
+// fakeWatcher implements clientv3.Watcher, recording the watches so tests can send responses to them.
+type fakeWatcher struct {
+	lock    sync.Mutex
+	watches []fakeWatch
+}
+
+type fakeWatch struct {
+	key   string
+	rev   int64
+	ch    chan clientv3.WatchResponse
+	close func()
+}
+
+func (f *fakeWatcher) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	op := clientv3.OpGet(key, opts...)
+	ch := make(chan clientv3.WatchResponse, 10)
+	closeCh := sync.OnceFunc(func() { close(ch) })
+	f.lock.Lock()
+	f.watches = append(f.watches, fakeWatch{key: key, rev: op.Rev(), ch: ch, close: closeCh})
+	f.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		closeCh()
+	}()
+	return ch
+}
+
+func (f *fakeWatcher) send(t *testing.T, resp clientv3.WatchResponse) {
+	t.Helper()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	require.NotEmpty(t, f.watches)
+	f.watches[len(f.watches)-1].ch <- resp
+}
+
+func (f *fakeWatcher) lastWatch() (fakeWatch, int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if len(f.watches) == 0 {
+		return fakeWatch{}, 0
+	}
+	return f.watches[len(f.watches)-1], len(f.watches)
+}
+
+func (f *fakeWatcher) RequestProgress(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeWatcher) Close() error {
+	return nil
+}
+
+func newTestStore(t *testing.T, prefix string) (*ConfigurationStore, *fakeKV, *fakeWatcher) {
+	t.Helper()
+
+	kv := &fakeKV{
+		kvs: map[string]*mvccpb.KeyValue{
+			prefix + "key1": {Key: []byte(prefix + "key1"), Value: []byte("value1"), ModRevision: 5},
+			prefix + "key2": {Key: []byte(prefix + "key2"), Value: []byte("value2"), ModRevision: 7},
+		},
+		rev: 9,
+	}
+	watcher := &fakeWatcher{}
+	client := clientv3.NewCtxClient(t.Context())
+	client.KV = kv
+	client.Watcher = watcher
+
+	s := NewEtcdConfigurationStore(logger.NewLogger("test")).(*ConfigurationStore)
+	s.metadata.Timeout = time.Second
+	s.prefix = prefix
+	s.client = client
+	t.Cleanup(func() { s.Close() })
+
+	return s, kv, watcher
+}
+
+func TestInit(t *testing.T) {
+	s := NewEtcdConfigurationStore(logger.NewLogger("test")).(*ConfigurationStore)
+
+	err := s.Init(t.Context(), configuration.Metadata{})
+	require.ErrorContains(t, err, "endpoints required")
+
+	md := configuration.Metadata{}
+	md.Properties = map[string]string{
+		"endpoints":     "localhost:2379",
+		"keyPrefixPath": "/config/",
+		"tlsEnable":     "true",
+	}
+	err = s.Init(t.Context(), md)
+	require.ErrorContains(t, err, "tls authentication information is incomplete")
+	assert.Equal(t, "/config/", s.prefix)
+	assert.Equal(t, defaultTimeout, s.metadata.Timeout)
+}
+
+func TestGet(t *testing.T) {
+	t.Run("all keys", func(t *testing.T) {
+		s, kv, _ := newTestStore(t, "config/")
+		kv.kvs["other"] = &mvccpb.KeyValue{Key: []byte("other"), Value: []byte("other")}
+
+		res, err := s.Get(t.Context(), &configuration.GetRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{
+			"key1": {Value: "value1", Version: "5", Metadata: map[string]string{}},
+			"key2": {Value: "value2", Version: "7", Metadata: map[string]string{}},
+		}, res.Items)
+	})
+
+	t.Run("some keys", func(t *testing.T) {
+		s, _, _ := newTestStore(t, "")
+
+		res, err := s.Get(t.Context(), &configuration.GetRequest{Keys: []string{"key2", "missing"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{
+			"key2": {Value: "value2", Version: "7", Metadata: map[string]string{}},
+		}, res.Items)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	s, _, watcher := newTestStore(t, "config/")
+
+	events := make(chan *configuration.UpdateEvent, 10)
+	handler := func(ctx context.Context, e *configuration.UpdateEvent) error {
+		events <- e
+		return nil
+	}
+	id, err := s.Subscribe(t.Context(), &configuration.SubscribeRequest{Keys: []string{"key1", "key2"}}, handler)
+	require.NoError(t, err)
+
+	// The watch starts after the current revision
+	assert.Eventually(t, func() bool {
+		w, _ := watcher.lastWatch()
+		return w.key == "config/" && w.rev == 10
+	}, 5*time.Second, 10*time.Millisecond)
+
+	watcher.send(t, clientv3.WatchResponse{
+		Header: etcdserverpb.ResponseHeader{Revision: 12},
+		Events: []*clientv3.Event{
+			{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("config/key1"), Value: []byte("changed"), ModRevision: 10}},
+			{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("config/key2"), ModRevision: 11}},
+			{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("config/key3"), Value: []byte("ignored"), ModRevision: 12}},
+		},
+	})
+	select {
+	case e := <-events:
+		assert.Equal(t, id, e.ID)
+		assert.Equal(t, map[string]*configuration.Item{
+			"key1": {Value: "changed", Version: "10", Metadata: map[string]string{}},
+			"key2": {},
+		}, e.Items)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the update event")
+	}
+
+	// If the watch is canceled by the server, the changes are watched again from the compact revision
+	w, n := watcher.lastWatch()
+	watcher.send(t, clientv3.WatchResponse{Canceled: true, CompactRevision: 20})
+	w.close()
+	assert.Eventually(t, func() bool {
+		w, m := watcher.lastWatch()
+		return m == n+1 && w.rev == 20
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, s.Unsubscribe(t.Context(), &configuration.UnsubscribeRequest{ID: id}))
+	require.Error(t, s.Unsubscribe(t.Context(), &configuration.UnsubscribeRequest{ID: id}))
+}
+
+func TestSubscribeSingleKey(t *testing.T) {
+	s, _, watcher := newTestStore(t, "")
+
+	_, err := s.Subscribe(t.Context(), &configuration.SubscribeRequest{Keys: []string{"key1"}}, func(ctx context.Context, e *configuration.UpdateEvent) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		w, _ := watcher.lastWatch()
+		return w.key == "key1"
+	}, 5*time.Second, 10*time.Millisecond)
+}
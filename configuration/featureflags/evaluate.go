@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflags
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/spaolacci/murmur3"
+)
+
+// Reason of an evaluation result, as defined by OpenFeature.
+type Reason string
+
+const (
+	// The flag has no targeting rules.
+	ReasonStatic Reason = "STATIC"
+	// No targeting rule matched, so the default variant was selected.
+	ReasonDefault Reason = "DEFAULT"
+	// A targeting rule selected the variant.
+	ReasonTargetingMatch Reason = "TARGETING_MATCH"
+	// A fractional split selected the variant.
+	ReasonSplit Reason = "SPLIT"
+	// The flag is disabled.
+	ReasonDisabled Reason = "DISABLED"
+	// The flag couldn't be evaluated.
+	ReasonError Reason = "ERROR"
+)
+
+// ErrorCode of an evaluation that failed, as defined by OpenFeature.
+type ErrorCode string
+
+const (
+	ErrorFlagNotFound        ErrorCode = "FLAG_NOT_FOUND"
+	ErrorParseError          ErrorCode = "PARSE_ERROR"
+	ErrorTypeMismatch        ErrorCode = "TYPE_MISMATCH"
+	ErrorTargetingKeyMissing ErrorCode = "TARGETING_KEY_MISSING"
+	ErrorGeneral             ErrorCode = "GENERAL"
+)
+
+// TargetingKeyAttribute is the name of the attribute that refers to the targeting key in conditions.
+const TargetingKeyAttribute = "targetingKey"
+
+// EvaluationContext contains the attributes that flags are evaluated against.
+type EvaluationContext struct {
+	// Identifier of the subject of the evaluation, such as a user ID, used by default for fractional splits.
+	TargetingKey string
+	// Attributes of the subject.
+	Attributes map[string]any
+}
+
+// Resolution is the result of the evaluation of a flag.
+type Resolution struct {
+	// Value of the selected variant, or the default value of the caller if the evaluation failed or the flag is disabled.
+	Value        any
+	Variant      string
+	Reason       Reason
+	ErrorCode    ErrorCode
+	ErrorMessage string
+	FlagMetadata map[string]any
+}
+
+func errorResolution(code ErrorCode, format string, args ...any) Resolution {
+	return Resolution{
+		Reason:       ReasonError,
+		ErrorCode:    code,
+		ErrorMessage: fmt.Sprintf(format, args...),
+	}
+}
+
+// Evaluate evaluates a flag against an evaluation context.
+// The flag key is hashed with the bucketing attribute in fractional splits, so the splits of different flags are independent.
+func (f *Flag) Evaluate(flagKey string, evalCtx EvaluationContext) Resolution {
+	if f.State == StateDisabled {
+		return Resolution{
+			Reason:       ReasonDisabled,
+			FlagMetadata: f.Metadata,
+		}
+	}
+
+	variant, reason := f.DefaultVariant, ReasonStatic
+	if len(f.Targeting) > 0 {
+		reason = ReasonDefault
+	}
+	for _, rule := range f.Targeting {
+		if !rule.matches(evalCtx) {
+			continue
+		}
+		if rule.Fractional == nil {
+			variant, reason = rule.Variant, ReasonTargetingMatch
+			break
+		}
+
+		bucketBy := rule.Fractional.BucketBy
+		if bucketBy == "" {
+			bucketBy = TargetingKeyAttribute
+		}
+		value, ok := evalCtx.attribute(bucketBy)
+		if !ok || value == "" {
+			if bucketBy == TargetingKeyAttribute {
+				return errorResolution(ErrorTargetingKeyMissing, "flag %s requires a targeting key", flagKey)
+			}
+			return errorResolution(ErrorGeneral, "flag %s requires attribute %s", flagKey, bucketBy)
+		}
+		variant, reason = rule.Fractional.variant(flagKey+toString(value)), ReasonSplit
+		break
+	}
+
+	return Resolution{
+		Value:        f.Variants[variant],
+		Variant:      variant,
+		Reason:       reason,
+		FlagMetadata: f.Metadata,
+	}
+}
+
+func (r *Rule) matches(evalCtx EvaluationContext) bool {
+	for _, c := range r.Conditions {
+		if !c.matches(evalCtx) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Condition) matches(evalCtx EvaluationContext) bool {
+	attr, ok := evalCtx.attribute(c.Attribute)
+	if !ok {
+		return false
+	}
+
+	switch c.Operator {
+	case OperatorEquals:
+		return valuesEqual(attr, c.Value)
+	case OperatorNotEquals:
+		return !valuesEqual(attr, c.Value)
+	case OperatorIn, OperatorNotIn:
+		found := false
+		for _, v := range c.Value.([]any) {
+			if valuesEqual(attr, v) {
+				found = true
+				break
+			}
+		}
+		return found == (c.Operator == OperatorIn)
+	case OperatorStartsWith:
+		return strings.HasPrefix(toString(attr), c.Value.(string))
+	case OperatorEndsWith:
+		return strings.HasSuffix(toString(attr), c.Value.(string))
+	case OperatorContains:
+		return strings.Contains(toString(attr), c.Value.(string))
+	case OperatorMatches:
+		return c.re.MatchString(toString(attr))
+	case OperatorGt, OperatorGte, OperatorLt, OperatorLte:
+		a, ok := toFloat(attr)
+		if !ok {
+			return false
+		}
+		v, _ := toFloat(c.Value)
+		switch c.Operator {
+		case OperatorGt:
+			return a > v
+		case OperatorGte:
+			return a >= v
+		case OperatorLt:
+			return a < v
+		default:
+			return a <= v
+		}
+	default:
+		return false
+	}
+}
+
+// variant returns the variant of the split for a hashed value.
+// The hash is the same as the fractional operation of flagd.
+func (f *Fractional) variant(value string) string {
+	//nolint:gosec
+	hash := int32(murmur3.Sum32([]byte(value)))
+	bucket := math.Abs(float64(hash)) / math.MaxInt32 * 100
+
+	total := float64(f.totalWeight())
+	var cumulative float64
+	for _, wv := range f.Variants {
+		cumulative += float64(wv.Weight) * 100 / total
+		if bucket < cumulative {
+			return wv.Variant
+		}
+	}
+	return f.Variants[len(f.Variants)-1].Variant
+}
+
+func (e EvaluationContext) attribute(name string) (any, bool) {
+	if name == TargetingKeyAttribute {
+		return e.TargetingKey, e.TargetingKey != ""
+	}
+	v, ok := e.Attributes[name]
+	return v, ok && v != nil
+}
+
+// valuesEqual compares values, with numbers of any type being equal if they have the same value.
+func valuesEqual(a, b any) bool {
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok && bok {
+		return af == bf
+	}
+	if as, ok := a.(string); ok {
+		bs, ok := b.(string)
+		return ok && as == bs
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// toFloat converts numbers of any type to float64.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toString(v any) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
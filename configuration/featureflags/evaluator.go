@@ -0,0 +1,236 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/kit/logger"
+)
+
+// EvaluatorOptions contains the options of an Evaluator.
+type EvaluatorOptions struct {
+	// Keys of the configuration items containing the flags; all items of the store if empty.
+	Keys []string
+	// Metadata of the requests to the configuration store.
+	Metadata map[string]string
+}
+
+// Evaluator evaluates flags stored in the items of a configuration store.
+// The flags are read when the evaluator is started, and updated when the configuration store notifies changes.
+// It works with any configuration store.
+type Evaluator struct {
+	store  configuration.Store
+	opts   EvaluatorOptions
+	logger logger.Logger
+
+	lock  sync.RWMutex
+	flags map[string]*Flag
+	// Errors of the items that aren't valid flag definitions
+	errs map[string]error
+	// Keys updated by subscription events while the evaluator is starting
+	updated map[string]struct{}
+	subID   string
+}
+
+// NewEvaluator returns a new Evaluator of the flags of a configuration store.
+func NewEvaluator(store configuration.Store, opts EvaluatorOptions, logger logger.Logger) *Evaluator {
+	return &Evaluator{
+		store:  store,
+		opts:   opts,
+		logger: logger,
+		flags:  map[string]*Flag{},
+		errs:   map[string]error{},
+	}
+}
+
+// Start subscribes to the changes of the items, and reads the flags.
+func (e *Evaluator) Start(ctx context.Context) error {
+	e.lock.Lock()
+	if e.subID != "" {
+		e.lock.Unlock()
+		return errors.New("evaluator is already started")
+	}
+	e.updated = map[string]struct{}{}
+	e.lock.Unlock()
+
+	// Subscribe before reading the items, so no change is missed
+	subID, err := e.store.Subscribe(ctx, &configuration.SubscribeRequest{
+		Keys:     e.opts.Keys,
+		Metadata: e.opts.Metadata,
+	}, e.handleUpdate)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to configuration items: %w", err)
+	}
+
+	res, err := e.store.Get(ctx, &configuration.GetRequest{
+		Keys:     e.opts.Keys,
+		Metadata: e.opts.Metadata,
+	})
+	if err != nil {
+		_ = e.store.Unsubscribe(ctx, &configuration.UnsubscribeRequest{ID: subID})
+		return fmt.Errorf("failed to get configuration items: %w", err)
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for key, item := range res.Items {
+		// Items updated by subscription events are more recent
+		if _, ok := e.updated[key]; ok {
+			continue
+		}
+		e.setItem(key, item)
+	}
+	e.updated = nil
+	e.subID = subID
+
+	return nil
+}
+
+// Close unsubscribes from the changes of the items.
+func (e *Evaluator) Close(ctx context.Context) error {
+	e.lock.Lock()
+	subID := e.subID
+	e.subID = ""
+	e.lock.Unlock()
+
+	if subID == "" {
+		return nil
+	}
+	return e.store.Unsubscribe(ctx, &configuration.UnsubscribeRequest{ID: subID})
+}
+
+func (e *Evaluator) handleUpdate(_ context.Context, ev *configuration.UpdateEvent) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for key, item := range ev.Items {
+		if e.updated != nil {
+			e.updated[key] = struct{}{}
+		}
+		e.setItem(key, item)
+	}
+	return nil
+}
+
+// setItem parses the flag in an item, or deletes it if the item is empty.
+// Must be called with the lock held.
+func (e *Evaluator) setItem(key string, item *configuration.Item) {
+	delete(e.flags, key)
+	delete(e.errs, key)
+	if item == nil || item.Value == "" {
+		return
+	}
+
+	flag, err := ParseFlag([]byte(item.Value))
+	if err != nil {
+		e.logger.Warnf("Configuration item %s is not a valid flag: %v", key, err)
+		e.errs[key] = err
+		return
+	}
+	e.flags[key] = flag
+}
+
+// Evaluate evaluates a flag against an evaluation context.
+// The value of the resolution is nil if the evaluation failed or the flag is disabled.
+func (e *Evaluator) Evaluate(flagKey string, evalCtx EvaluationContext) Resolution {
+	e.lock.RLock()
+	flag, ok := e.flags[flagKey]
+	err := e.errs[flagKey]
+	e.lock.RUnlock()
+
+	switch {
+	case err != nil:
+		return errorResolution(ErrorParseError, "%v", err)
+	case !ok:
+		return errorResolution(ErrorFlagNotFound, "flag %s not found", flagKey)
+	default:
+		return flag.Evaluate(flagKey, evalCtx)
+	}
+}
+
+// BooleanEvaluation evaluates a flag with boolean variants.
+// The value of the resolution is the default value if the evaluation failed or the flag is disabled.
+func (e *Evaluator) BooleanEvaluation(flagKey string, defaultValue bool, evalCtx EvaluationContext) Resolution {
+	return e.typedEvaluation(flagKey, defaultValue, evalCtx, func(v any) (any, bool) {
+		b, ok := v.(bool)
+		return b, ok
+	})
+}
+
+// StringEvaluation evaluates a flag with string variants.
+// The value of the resolution is the default value if the evaluation failed or the flag is disabled.
+func (e *Evaluator) StringEvaluation(flagKey string, defaultValue string, evalCtx EvaluationContext) Resolution {
+	return e.typedEvaluation(flagKey, defaultValue, evalCtx, func(v any) (any, bool) {
+		s, ok := v.(string)
+		return s, ok
+	})
+}
+
+// IntEvaluation evaluates a flag with integer variants, returned as int64.
+// The value of the resolution is the default value if the evaluation failed or the flag is disabled.
+func (e *Evaluator) IntEvaluation(flagKey string, defaultValue int64, evalCtx EvaluationContext) Resolution {
+	return e.typedEvaluation(flagKey, defaultValue, evalCtx, func(v any) (any, bool) {
+		f, ok := toFloat(v)
+		if !ok || f != math.Trunc(f) || f < math.MinInt64 || f > math.MaxInt64 {
+			return nil, false
+		}
+		return int64(f), true
+	})
+}
+
+// FloatEvaluation evaluates a flag with number variants, returned as float64.
+// The value of the resolution is the default value if the evaluation failed or the flag is disabled.
+func (e *Evaluator) FloatEvaluation(flagKey string, defaultValue float64, evalCtx EvaluationContext) Resolution {
+	return e.typedEvaluation(flagKey, defaultValue, evalCtx, toFloatAny)
+}
+
+// ObjectEvaluation evaluates a flag with variants of any type, such as JSON objects.
+// The value of the resolution is the default value if the evaluation failed or the flag is disabled.
+func (e *Evaluator) ObjectEvaluation(flagKey string, defaultValue any, evalCtx EvaluationContext) Resolution {
+	return e.typedEvaluation(flagKey, defaultValue, evalCtx, func(v any) (any, bool) {
+		return v, true
+	})
+}
+
+func (e *Evaluator) typedEvaluation(flagKey string, defaultValue any, evalCtx EvaluationContext, convert func(any) (any, bool)) Resolution {
+	res := e.Evaluate(flagKey, evalCtx)
+	if res.ErrorCode != "" || res.Reason == ReasonDisabled {
+		res.Value = defaultValue
+		return res
+	}
+
+	v, ok := convert(res.Value)
+	if !ok {
+		return Resolution{
+			Value:        defaultValue,
+			Reason:       ReasonError,
+			ErrorCode:    ErrorTypeMismatch,
+			ErrorMessage: fmt.Sprintf("variant %s of flag %s has type %T", res.Variant, flagKey, res.Value),
+			FlagMetadata: res.FlagMetadata,
+		}
+	}
+	res.Value = v
+	return res
+}
+
+func toFloatAny(v any) (any, bool) {
+	f, ok := toFloat(v)
+	return f, ok
+}
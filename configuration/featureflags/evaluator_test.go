@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflags
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/kit/logger"
+)
+
+// memoryStore is a configuration store that keeps the items in memory.
+type memoryStore struct {
+	lock  sync.Mutex
+	items map[string]*configuration.Item
+	subs  *configuration.Subscriptions
+}
+
+func newMemoryStore(items map[string]string) *memoryStore {
+	s := &memoryStore{
+		items: map[string]*configuration.Item{},
+		subs:  configuration.NewSubscriptions(logger.NewLogger("test")),
+	}
+	for key, value := range items {
+		s.items[key] = &configuration.Item{Value: value}
+	}
+	return s
+}
+
+func (s *memoryStore) Init(context.Context, configuration.Metadata) error {
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	items := map[string]*configuration.Item{}
+	for key, item := range s.items {
+		items[key] = item
+	}
+	return &configuration.GetResponse{Items: items}, nil
+}
+
+func (s *memoryStore) Subscribe(_ context.Context, req *configuration.SubscribeRequest, handler configuration.UpdateHandler) (string, error) {
+	sub, err := s.subs.Add(req, handler)
+	if err != nil {
+		return "", err
+	}
+	return sub.ID, nil
+}
+
+func (s *memoryStore) Unsubscribe(_ context.Context, req *configuration.UnsubscribeRequest) error {
+	_, err := s.subs.Remove(req.ID)
+	return err
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+func (s *memoryStore) set(key, value string) {
+	s.lock.Lock()
+	item := &configuration.Item{Value: value}
+	if value == "" {
+		delete(s.items, key)
+		item = &configuration.Item{}
+	} else {
+		s.items[key] = item
+	}
+	s.lock.Unlock()
+
+	s.subs.Notify(context.Background(), map[string]*configuration.Item{key: item})
+}
+
+func TestEvaluator(t *testing.T) {
+	store := newMemoryStore(map[string]string{
+		"new-ui":   `{"variants": {"on": true, "off": false}, "defaultVariant": "off", "targeting": [{"conditions": [{"attribute": "plan", "operator": "equals", "value": "premium"}], "variant": "on"}]}`,
+		"limit":    `{"variants": {"low": 10, "high": 100.5}, "defaultVariant": "low"}`,
+		"theme":    `{"variants": {"dark": {"background": "black"}}, "defaultVariant": "dark"}`,
+		"disabled": `{"state": "DISABLED", "variants": {"on": "yes"}, "defaultVariant": "on"}`,
+		"invalid":  `plain value`,
+	})
+	e := NewEvaluator(store, EvaluatorOptions{}, logger.NewLogger("test"))
+	require.NoError(t, e.Start(t.Context()))
+	t.Cleanup(func() { e.Close(context.Background()) })
+	require.Error(t, e.Start(t.Context()))
+
+	premium := EvaluationContext{TargetingKey: "user", Attributes: map[string]any{"plan": "premium"}}
+
+	t.Run("typed evaluations", func(t *testing.T) {
+		res := e.BooleanEvaluation("new-ui", false, premium)
+		assert.Equal(t, true, res.Value)
+		assert.Equal(t, ReasonTargetingMatch, res.Reason)
+
+		res = e.IntEvaluation("limit", 1, premium)
+		assert.Equal(t, int64(10), res.Value)
+		assert.Equal(t, ReasonStatic, res.Reason)
+
+		res = e.FloatEvaluation("limit", 1, premium)
+		assert.InDelta(t, 10.0, res.Value, 0)
+
+		res = e.ObjectEvaluation("theme", nil, premium)
+		assert.Equal(t, map[string]any{"background": "black"}, res.Value)
+
+		res = e.StringEvaluation("disabled", "default", premium)
+		assert.Equal(t, "default", res.Value)
+		assert.Equal(t, ReasonDisabled, res.Reason)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		res := e.StringEvaluation("new-ui", "default", premium)
+		assert.Equal(t, "default", res.Value)
+		assert.Equal(t, ErrorTypeMismatch, res.ErrorCode)
+
+		res = e.BooleanEvaluation("missing", true, premium)
+		assert.Equal(t, true, res.Value)
+		assert.Equal(t, ErrorFlagNotFound, res.ErrorCode)
+
+		res = e.BooleanEvaluation("invalid", true, premium)
+		assert.Equal(t, true, res.Value)
+		assert.Equal(t, ReasonError, res.Reason)
+		assert.Equal(t, ErrorParseError, res.ErrorCode)
+	})
+
+	t.Run("updates", func(t *testing.T) {
+		store.set("limit", `{"variants": {"low": 10, "high": 100.5}, "defaultVariant": "high"}`)
+		res := e.FloatEvaluation("limit", 1, premium)
+		assert.InDelta(t, 100.5, res.Value, 0)
+		res = e.IntEvaluation("limit", 1, premium)
+		assert.Equal(t, int64(1), res.Value)
+		assert.Equal(t, ErrorTypeMismatch, res.ErrorCode)
+
+		store.set("invalid", `{"variants": {"on": true}, "defaultVariant": "on"}`)
+		assert.Equal(t, true, e.BooleanEvaluation("invalid", false, premium).Value)
+
+		store.set("new-ui", "")
+		assert.Equal(t, ErrorFlagNotFound, e.BooleanEvaluation("new-ui", false, premium).ErrorCode)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		require.NoError(t, e.Close(t.Context()))
+		assert.Equal(t, 0, store.subs.Len())
+
+		store.set("limit", `{"variants": {"low": 10}, "defaultVariant": "low"}`)
+		assert.InDelta(t, 100.5, e.FloatEvaluation("limit", 1, premium).Value, 0)
+	})
+}
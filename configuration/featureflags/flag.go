@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflags
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// State of a flag.
+type State string
+
+const (
+	StateEnabled  State = "ENABLED"
+	StateDisabled State = "DISABLED"
+)
+
+// Operator of a targeting condition.
+type Operator string
+
+const (
+	OperatorEquals     Operator = "equals"
+	OperatorNotEquals  Operator = "not_equals"
+	OperatorIn         Operator = "in"
+	OperatorNotIn      Operator = "not_in"
+	OperatorStartsWith Operator = "starts_with"
+	OperatorEndsWith   Operator = "ends_with"
+	OperatorContains   Operator = "contains"
+	OperatorMatches    Operator = "matches"
+	OperatorGt         Operator = "gt"
+	OperatorGte        Operator = "gte"
+	OperatorLt         Operator = "lt"
+	OperatorLte        Operator = "lte"
+)
+
+// Flag is the definition of a feature flag, stored as JSON in the value of a configuration item.
+// State, variants, default variant and metadata follow the flag definitions of flagd, the OpenFeature reference provider.
+// Targeting is a list of rules instead of a JSONLogic expression.
+//
+// For example:
+//
+//	{
+//	  "state": "ENABLED",
+//	  "variants": {"on": true, "off": false},
+//	  "defaultVariant": "off",
+//	  "targeting": [
+//	    {"conditions": [{"attribute": "email", "operator": "ends_with", "value": "@example.com"}], "variant": "on"},
+//	    {"fractional": {"variants": [{"variant": "on", "weight": 10}, {"variant": "off", "weight": 90}]}}
+//	  ]
+//	}
+type Flag struct {
+	// State of the flag; defaults to ENABLED.
+	// Evaluating a disabled flag returns the default value of the caller.
+	State State `json:"state,omitempty"`
+	// Values of the flag, by variant name.
+	Variants map[string]any `json:"variants"`
+	// Variant returned when no targeting rule matches.
+	DefaultVariant string `json:"defaultVariant"`
+	// Targeting rules, evaluated in order: the first rule whose conditions match selects the variant.
+	Targeting []Rule `json:"targeting,omitempty"`
+	// Metadata of the flag, returned with the evaluation results.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// Rule is a targeting rule of a flag.
+// It selects either a variant or, for percentage rollouts, a fractional split of variants.
+type Rule struct {
+	// Optional name of the rule, for troubleshooting.
+	Name string `json:"name,omitempty"`
+	// Conditions on the evaluation context; the rule matches if all conditions match.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Variant selected by the rule.
+	Variant string `json:"variant,omitempty"`
+	// Fractional split of variants selected by the rule.
+	Fractional *Fractional `json:"fractional,omitempty"`
+}
+
+// Condition compares an attribute of the evaluation context with a value.
+// Conditions on attributes missing from the evaluation context never match.
+type Condition struct {
+	// Name of the attribute; "targetingKey" is the targeting key of the evaluation context.
+	Attribute string `json:"attribute"`
+	// Comparison operator.
+	Operator Operator `json:"operator"`
+	// Value compared with the attribute; it's a list for the "in" and "not_in" operators.
+	Value any `json:"value"`
+
+	re *regexp.Regexp
+}
+
+// Fractional splits the evaluation contexts between variants, with stable hashing.
+// The same evaluation context always gets the same variant, as long as the weights don't change.
+type Fractional struct {
+	// Attribute hashed to split the evaluation contexts; defaults to the targeting key.
+	BucketBy string `json:"bucketBy,omitempty"`
+	// Variants and their relative weights.
+	Variants []WeightedVariant `json:"variants"`
+}
+
+// WeightedVariant is a variant of a fractional split.
+type WeightedVariant struct {
+	Variant string `json:"variant"`
+	Weight  int    `json:"weight"`
+}
+
+// ParseFlag parses and validates the definition of a flag.
+func ParseFlag(value []byte) (*Flag, error) {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.DisallowUnknownFields()
+	flag := &Flag{}
+	err := dec.Decode(flag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flag definition: %w", err)
+	}
+
+	err = flag.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid flag definition: %w", err)
+	}
+	return flag, nil
+}
+
+func (f *Flag) validate() error {
+	switch f.State {
+	case "":
+		f.State = StateEnabled
+	case StateEnabled, StateDisabled:
+		// Nop
+	default:
+		return fmt.Errorf("invalid state %q", f.State)
+	}
+
+	if len(f.Variants) == 0 {
+		return errors.New("at least one variant is required")
+	}
+	if _, ok := f.Variants[f.DefaultVariant]; !ok {
+		return fmt.Errorf("default variant %q is not a variant", f.DefaultVariant)
+	}
+
+	for i := range f.Targeting {
+		err := f.validateRule(&f.Targeting[i])
+		if err != nil {
+			return fmt.Errorf("targeting rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (f *Flag) validateRule(rule *Rule) error {
+	for i := range rule.Conditions {
+		err := rule.Conditions[i].validate()
+		if err != nil {
+			return fmt.Errorf("condition %d: %w", i, err)
+		}
+	}
+
+	switch {
+	case rule.Variant != "" && rule.Fractional != nil:
+		return errors.New("variant and fractional can't be both set")
+	case rule.Variant != "":
+		if _, ok := f.Variants[rule.Variant]; !ok {
+			return fmt.Errorf("%q is not a variant", rule.Variant)
+		}
+	case rule.Fractional != nil:
+		if len(rule.Fractional.Variants) == 0 {
+			return errors.New("fractional requires at least one variant")
+		}
+		for _, wv := range rule.Fractional.Variants {
+			if _, ok := f.Variants[wv.Variant]; !ok {
+				return fmt.Errorf("%q is not a variant", wv.Variant)
+			}
+			if wv.Weight < 0 {
+				return fmt.Errorf("weight of variant %q is negative", wv.Variant)
+			}
+		}
+		if rule.Fractional.totalWeight() == 0 {
+			return errors.New("fractional requires a positive total weight")
+		}
+	default:
+		return errors.New("either variant or fractional is required")
+	}
+	return nil
+}
+
+func (c *Condition) validate() error {
+	if c.Attribute == "" {
+		return errors.New("attribute is required")
+	}
+
+	switch c.Operator {
+	case OperatorEquals, OperatorNotEquals:
+		// Nop
+	case OperatorIn, OperatorNotIn:
+		if _, ok := c.Value.([]any); !ok {
+			return fmt.Errorf("operator %s requires a list value", c.Operator)
+		}
+	case OperatorStartsWith, OperatorEndsWith, OperatorContains:
+		if _, ok := c.Value.(string); !ok {
+			return fmt.Errorf("operator %s requires a string value", c.Operator)
+		}
+	case OperatorMatches:
+		s, ok := c.Value.(string)
+		if !ok {
+			return fmt.Errorf("operator %s requires a string value", c.Operator)
+		}
+		var err error
+		c.re, err = regexp.Compile(s)
+		if err != nil {
+			return fmt.Errorf("invalid regular expression: %w", err)
+		}
+	case OperatorGt, OperatorGte, OperatorLt, OperatorLte:
+		if _, ok := toFloat(c.Value); !ok {
+			return fmt.Errorf("operator %s requires a number value", c.Operator)
+		}
+	default:
+		return fmt.Errorf("invalid operator %q", c.Operator)
+	}
+	return nil
+}
+
+func (f *Fractional) totalWeight() int {
+	total := 0
+	for _, wv := range f.Variants {
+		total += wv.Weight
+	}
+	return total
+}
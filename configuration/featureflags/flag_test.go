@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featureflags
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/spaolacci/murmur3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFlag(t *testing.T) {
+	flag, err := ParseFlag([]byte(`{"variants": {"on": true, "off": false}, "defaultVariant": "off"}`))
+	require.NoError(t, err)
+	assert.Equal(t, StateEnabled, flag.State)
+
+	tests := map[string]struct {
+		flag string
+		err  string
+	}{
+		"not JSON":             {`on`, "invalid character"},
+		"unknown field":        {`{"variants": {"on": true}, "defaultVariant": "on", "other": 1}`, "unknown field"},
+		"invalid state":        {`{"state": "ON", "variants": {"on": true}, "defaultVariant": "on"}`, "invalid state"},
+		"no variants":          {`{"defaultVariant": "on"}`, "at least one variant"},
+		"bad default variant":  {`{"variants": {"on": true}, "defaultVariant": "off"}`, "default variant"},
+		"bad rule variant":     {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{"variant": "off"}]}`, `"off" is not a variant`},
+		"no rule result":       {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{}]}`, "either variant or fractional"},
+		"both rule results":    {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{"variant": "on", "fractional": {"variants": [{"variant": "on", "weight": 1}]}}]}`, "can't be both set"},
+		"zero weights":         {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{"fractional": {"variants": [{"variant": "on", "weight": 0}]}}]}`, "positive total weight"},
+		"invalid operator":     {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{"conditions": [{"attribute": "a", "operator": "like", "value": "x"}], "variant": "on"}]}`, "invalid operator"},
+		"in without list":      {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{"conditions": [{"attribute": "a", "operator": "in", "value": "x"}], "variant": "on"}]}`, "requires a list"},
+		"invalid regexp":       {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{"conditions": [{"attribute": "a", "operator": "matches", "value": "("}], "variant": "on"}]}`, "invalid regular expression"},
+		"gt without number":    {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{"conditions": [{"attribute": "a", "operator": "gt", "value": "x"}], "variant": "on"}]}`, "requires a number"},
+		"condition attributes": {`{"variants": {"on": true}, "defaultVariant": "on", "targeting": [{"conditions": [{"operator": "equals", "value": "x"}], "variant": "on"}]}`, "attribute is required"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseFlag([]byte(tt.flag))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	flag, err := ParseFlag([]byte(`{
+		"variants": {"blue": "#00f", "red": "#f00", "green": "#0f0"},
+		"defaultVariant": "blue",
+		"metadata": {"owner": "team"},
+		"targeting": [
+			{"conditions": [{"attribute": "email", "operator": "ends_with", "value": "@example.com"}, {"attribute": "age", "operator": "gte", "value": 18}], "variant": "red"},
+			{"conditions": [{"attribute": "country", "operator": "in", "value": ["fr", "de"]}], "variant": "green"},
+			{"conditions": [{"attribute": "targetingKey", "operator": "matches", "value": "^beta-"}], "fractional": {"variants": [{"variant": "red", "weight": 50}, {"variant": "green", "weight": 50}]}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		evalCtx EvaluationContext
+		variant string
+		reason  Reason
+	}{
+		"all conditions match": {
+			evalCtx: EvaluationContext{Attributes: map[string]any{"email": "a@example.com", "age": 30}},
+			variant: "red",
+			reason:  ReasonTargetingMatch,
+		},
+		"one condition doesn't match": {
+			evalCtx: EvaluationContext{Attributes: map[string]any{"email": "a@example.com", "age": int64(17)}},
+			variant: "blue",
+			reason:  ReasonDefault,
+		},
+		"in list": {
+			evalCtx: EvaluationContext{Attributes: map[string]any{"country": "de"}},
+			variant: "green",
+			reason:  ReasonTargetingMatch,
+		},
+		"missing attribute": {
+			evalCtx: EvaluationContext{TargetingKey: "user"},
+			variant: "blue",
+			reason:  ReasonDefault,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := flag.Evaluate("color", tt.evalCtx)
+			assert.Equal(t, tt.variant, res.Variant)
+			assert.Equal(t, flag.Variants[tt.variant], res.Value)
+			assert.Equal(t, tt.reason, res.Reason)
+			assert.Equal(t, map[string]any{"owner": "team"}, res.FlagMetadata)
+		})
+	}
+
+	t.Run("static", func(t *testing.T) {
+		flag, err := ParseFlag([]byte(`{"variants": {"on": true, "off": false}, "defaultVariant": "on"}`))
+		require.NoError(t, err)
+		res := flag.Evaluate("static", EvaluationContext{})
+		assert.Equal(t, Resolution{Value: true, Variant: "on", Reason: ReasonStatic}, res)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		flag, err := ParseFlag([]byte(`{"state": "DISABLED", "variants": {"on": true}, "defaultVariant": "on"}`))
+		require.NoError(t, err)
+		res := flag.Evaluate("disabled", EvaluationContext{})
+		assert.Equal(t, Resolution{Reason: ReasonDisabled}, res)
+	})
+}
+
+func TestFractional(t *testing.T) {
+	flag, err := ParseFlag([]byte(`{
+		"variants": {"on": true, "off": false},
+		"defaultVariant": "off",
+		"targeting": [{"fractional": {"variants": [{"variant": "on", "weight": 1}, {"variant": "off", "weight": 3}]}}]
+	}`))
+	require.NoError(t, err)
+
+	res := flag.Evaluate("rollout", EvaluationContext{})
+	assert.Equal(t, ReasonError, res.Reason)
+	assert.Equal(t, ErrorTargetingKeyMissing, res.ErrorCode)
+
+	// The variants are split according to their weights, and are stable
+	counts := map[string]int{}
+	for i := range 10000 {
+		evalCtx := EvaluationContext{TargetingKey: "user-" + strconv.Itoa(i)}
+		res := flag.Evaluate("rollout", evalCtx)
+		require.Equal(t, ReasonSplit, res.Reason)
+		require.Equal(t, res, flag.Evaluate("rollout", evalCtx))
+		counts[res.Variant]++
+	}
+	assert.InDelta(t, 2500, counts["on"], 250)
+	assert.InDelta(t, 7500, counts["off"], 250)
+
+	// Splits are bucketed by a custom attribute
+	flag.Targeting[0].Fractional.BucketBy = "company"
+	res = flag.Evaluate("rollout", EvaluationContext{TargetingKey: "user"})
+	assert.Equal(t, ErrorGeneral, res.ErrorCode)
+	a := flag.Evaluate("rollout", EvaluationContext{TargetingKey: "user-1", Attributes: map[string]any{"company": "acme"}})
+	b := flag.Evaluate("rollout", EvaluationContext{TargetingKey: "user-2", Attributes: map[string]any{"company": "acme"}})
+	assert.Equal(t, a.Variant, b.Variant)
+}
+
+func TestMurmur3Sum32(t *testing.T) {
+	// Fractional splits match flagd only with MurmurHash3 x86_32 with seed 0
+	assert.Equal(t, uint32(0), murmur3.Sum32([]byte("")))
+	assert.Equal(t, uint32(0x3c2569b2), murmur3.Sum32([]byte("a")))
+	assert.Equal(t, uint32(0xb3dd93fa), murmur3.Sum32([]byte("abc")))
+	assert.Equal(t, uint32(0x2e4ff723), murmur3.Sum32([]byte("The quick brown fox jumps over the lazy dog")))
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+
+	commonconsul "github.com/dapr/components-contrib/common/component/hashicorp/consul"
+	"github.com/dapr/components-contrib/configuration"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultTimeout  = 5 * time.Second
+	defaultWaitTime = 5 * time.Minute
+
+	// Delay before retrying a blocking query that failed
+	retryDelay = time.Second
+)
+
+type metadata struct {
+	commonconsul.ClientMetadata `mapstructure:",squash"`
+
+	// Prefix of the keys of the configuration items in Consul, which is not part of the keys of the items.
+	KeyPrefixPath string `mapstructure:"keyPrefixPath"`
+	// Timeout for requests to Consul, other than blocking queries.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Maximum duration of the blocking queries used to watch the items.
+	WaitTime time.Duration `mapstructure:"waitTime"`
+}
+
+var _ configuration.Store = (*ConfigurationStore)(nil)
+
+// ConfigurationStore is a configuration store backed by the Consul KV store.
+// The version of items is their modify index.
+type ConfigurationStore struct {
+	metadata metadata
+	client   *api.Client
+	prefix   string
+	logger   logger.Logger
+	subs     *configuration.Subscriptions
+
+	lock    sync.Mutex
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+	closed  bool
+}
+
+// NewConsulConfigurationStore returns a new Consul configuration store.
+func NewConsulConfigurationStore(logger logger.Logger) configuration.Store {
+	return &ConfigurationStore{
+		logger:  logger,
+		subs:    configuration.NewSubscriptions(logger),
+		cancels: map[string]context.CancelFunc{},
+	}
+}
+
+// Init parses the metadata and creates the Consul client.
+func (s *ConfigurationStore) Init(_ context.Context, md configuration.Metadata) error {
+	s.metadata = metadata{
+		Timeout:  defaultTimeout,
+		WaitTime: defaultWaitTime,
+	}
+	err := kitmd.DecodeMetadata(md.Properties, &s.metadata)
+	if err != nil {
+		return err
+	}
+	if s.metadata.Timeout <= 0 {
+		return errors.New("invalid value for 'timeout': must be greater than 0")
+	}
+	if s.metadata.WaitTime <= 0 {
+		return errors.New("invalid value for 'waitTime': must be greater than 0")
+	}
+
+	s.prefix = ""
+	if p := strings.Trim(s.metadata.KeyPrefixPath, "/"); p != "" {
+		s.prefix = p + "/"
+	}
+
+	s.client, err = commonconsul.NewClient(s.metadata.ClientMetadata)
+	if err != nil {
+		return fmt.Errorf("initializing consul client: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the items with the given keys, or all items under the key prefix if no key is given.
+func (s *ConfigurationStore) Get(parentCtx context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, s.metadata.Timeout)
+	defer cancel()
+
+	opts := (&api.QueryOptions{}).WithContext(ctx)
+	var (
+		items map[string]*configuration.Item
+		err   error
+	)
+	if len(req.Keys) == 0 {
+		items, _, err = s.list(opts)
+	} else {
+		items = make(map[string]*configuration.Item, len(req.Keys))
+		for _, key := range req.Keys {
+			var item *configuration.Item
+			item, _, err = s.get(key, opts)
+			if err != nil {
+				break
+			}
+			if item != nil {
+				items[key] = item
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &configuration.GetResponse{
+		Items: items,
+	}, nil
+}
+
+// list returns all items under the key prefix.
+func (s *ConfigurationStore) list(opts *api.QueryOptions) (map[string]*configuration.Item, *api.QueryMeta, error) {
+	pairs, meta, err := s.client.KV().List(s.prefix, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get configuration items: %w", err)
+	}
+
+	items := make(map[string]*configuration.Item, len(pairs))
+	for _, pair := range pairs {
+		// Skip folders
+		if strings.HasSuffix(pair.Key, "/") {
+			continue
+		}
+		items[strings.TrimPrefix(pair.Key, s.prefix)] = newItem(pair)
+	}
+	return items, meta, nil
+}
+
+// get returns the item with the given key, or nil if it doesn't exist.
+func (s *ConfigurationStore) get(key string, opts *api.QueryOptions) (*configuration.Item, *api.QueryMeta, error) {
+	pair, meta, err := s.client.KV().Get(s.prefix+key, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get configuration item %s: %w", key, err)
+	}
+	if pair == nil {
+		return nil, meta, nil
+	}
+	return newItem(pair), meta, nil
+}
+
+func newItem(pair *api.KVPair) *configuration.Item {
+	return &configuration.Item{
+		Value:    string(pair.Value),
+		Version:  strconv.FormatUint(pair.ModifyIndex, 10),
+		Metadata: map[string]string{},
+	}
+}
+
+// Subscribe watches the items with blocking queries.
+// Subscriptions to a single key watch that key, and other subscriptions watch all keys under the key prefix.
+func (s *ConfigurationStore) Subscribe(parentCtx context.Context, req *configuration.SubscribeRequest, handler configuration.UpdateHandler) (string, error) {
+	sub, err := s.subs.Add(req, handler)
+	if err != nil {
+		return "", err
+	}
+
+	// Read the current items, so changes made after Subscribe returns are notified
+	getCtx, getCancel := context.WithTimeout(parentCtx, s.metadata.Timeout)
+	items, index, err := s.query(sub, (&api.QueryOptions{}).WithContext(getCtx))
+	getCancel()
+	if err != nil {
+		s.subs.Remove(sub.ID)
+		return "", err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		s.subs.Remove(sub.ID)
+		return "", errors.New("configuration store is closed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancels[sub.ID] = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.watch(ctx, sub, items, index)
+	}()
+
+	return sub.ID, nil
+}
+
+// Unsubscribe stops the watch of a subscription.
+func (s *ConfigurationStore) Unsubscribe(_ context.Context, req *configuration.UnsubscribeRequest) error {
+	_, err := s.subs.Remove(req.ID)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	cancel := s.cancels[req.ID]
+	delete(s.cancels, req.ID)
+	s.lock.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return nil
+}
+
+// query reads the items watched by a subscription, returning them with the index of the query.
+func (s *ConfigurationStore) query(sub *configuration.Subscription, opts *api.QueryOptions) (map[string]*configuration.Item, uint64, error) {
+	var (
+		items map[string]*configuration.Item
+		meta  *api.QueryMeta
+		err   error
+	)
+	if len(sub.Keys) == 1 {
+		var item *configuration.Item
+		item, meta, err = s.get(sub.Keys[0], opts)
+		items = make(map[string]*configuration.Item, 1)
+		if item != nil {
+			items[sub.Keys[0]] = item
+		}
+	} else {
+		items, meta, err = s.list(opts)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, meta.LastIndex, nil
+}
+
+// watch notifies a subscription of the changes after the given index, until the context is canceled.
+// Deleted items are notified as empty items.
+func (s *ConfigurationStore) watch(ctx context.Context, sub *configuration.Subscription, items map[string]*configuration.Item, index uint64) {
+	for {
+		opts := (&api.QueryOptions{
+			WaitIndex: index,
+			WaitTime:  s.metadata.WaitTime,
+		}).WithContext(ctx)
+		current, newIndex, err := s.query(sub, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Warnf("Error watching configuration items for subscription %s: %v", sub.ID, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+
+		// The index can go backwards, for example after a snapshot is restored, in which case the watch restarts from 0
+		if newIndex < index {
+			newIndex = 0
+		}
+		index = newIndex
+
+		changed := configuration.ChangedItems(items, current)
+		items = current
+		sub.Notify(ctx, changed)
+	}
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (s *ConfigurationStore) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
+	metadataStruct := metadata{}
+	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.ConfigurationStoreType)
+	return
+}
+
+// Close stops all subscriptions.
+func (s *ConfigurationStore) Close() error {
+	s.lock.Lock()
+	s.closed = true
+	for id, cancel := range s.cancels {
+		cancel()
+		delete(s.cancels, id)
+	}
+	s.lock.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
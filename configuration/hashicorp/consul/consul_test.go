@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/configuration"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+// fakeConsul serves the KV endpoints of the Consul HTTP API from an in-memory map, with blocking queries.
+type fakeConsul struct {
+	lock    sync.Mutex
+	pairs   map[string]*api.KVPair
+	index   uint64
+	changed chan struct{}
+}
+
+func newFakeConsul(t *testing.T) (*fakeConsul, string) {
+	t.Helper()
+
+	f := &fakeConsul{
+		pairs:   map[string]*api.KVPair{},
+		index:   1,
+		changed: make(chan struct{}),
+	}
+	server := httptest.NewServer(http.HandlerFunc(f.serveKV))
+	t.Cleanup(server.Close)
+	return f, server.URL
+}
+
+func (f *fakeConsul) put(key, value string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.index++
+	f.pairs[key] = &api.KVPair{Key: key, Value: []byte(value), ModifyIndex: f.index}
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsul) delete(key string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.index++
+	delete(f.pairs, key)
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsul) serveKV(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/v1/kv/")
+	if !ok || r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	q := r.URL.Query()
+	_, recurse := q["recurse"]
+
+	f.lock.Lock()
+	if waitIndex, _ := strconv.ParseUint(q.Get("index"), 10, 64); waitIndex >= f.index {
+		changed := f.changed
+		f.lock.Unlock()
+		wait, _ := time.ParseDuration(q.Get("wait"))
+		select {
+		case <-changed:
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+		f.lock.Lock()
+	}
+	defer f.lock.Unlock()
+
+	pairs := []*api.KVPair{}
+	for k, pair := range f.pairs {
+		if k == key || (recurse && strings.HasPrefix(k, key)) {
+			pairs = append(pairs, pair)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	if len(pairs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pairs)
+}
+
+func newTestStore(t *testing.T, prefix string) (*ConfigurationStore, *fakeConsul) {
+	t.Helper()
+
+	f, url := newFakeConsul(t)
+	f.put(prefix+"key1", "value1")
+	f.put(prefix+"key2", "value2")
+
+	s := NewConsulConfigurationStore(logger.NewLogger("test")).(*ConfigurationStore)
+	err := s.Init(t.Context(), configuration.Metadata{Base: contribMetadata.Base{
+		Properties: map[string]string{
+			"httpAddr":      strings.TrimPrefix(url, "http://"),
+			"keyPrefixPath": prefix,
+			"waitTime":      "1s",
+		},
+	}})
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	return s, f
+}
+
+func TestInit(t *testing.T) {
+	s := NewConsulConfigurationStore(logger.NewLogger("test")).(*ConfigurationStore)
+
+	err := s.Init(t.Context(), configuration.Metadata{Base: contribMetadata.Base{
+		Properties: map[string]string{"waitTime": "0"},
+	}})
+	require.ErrorContains(t, err, "waitTime")
+
+	err = s.Init(t.Context(), configuration.Metadata{Base: contribMetadata.Base{
+		Properties: map[string]string{"keyPrefixPath": "/config/"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "config/", s.prefix)
+	assert.Equal(t, defaultTimeout, s.metadata.Timeout)
+	assert.Equal(t, defaultWaitTime, s.metadata.WaitTime)
+}
+
+func TestGet(t *testing.T) {
+	t.Run("all keys", func(t *testing.T) {
+		s, f := newTestStore(t, "config/")
+		f.put("other", "other")
+		f.put("config/folder/", "")
+
+		res, err := s.Get(t.Context(), &configuration.GetRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{
+			"key1": {Value: "value1", Version: "2", Metadata: map[string]string{}},
+			"key2": {Value: "value2", Version: "3", Metadata: map[string]string{}},
+		}, res.Items)
+	})
+
+	t.Run("some keys", func(t *testing.T) {
+		s, _ := newTestStore(t, "")
+
+		res, err := s.Get(t.Context(), &configuration.GetRequest{Keys: []string{"key2", "missing"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{
+			"key2": {Value: "value2", Version: "3", Metadata: map[string]string{}},
+		}, res.Items)
+	})
+
+	t.Run("no items", func(t *testing.T) {
+		s, f := newTestStore(t, "config/")
+		f.delete("config/key1")
+		f.delete("config/key2")
+
+		res, err := s.Get(t.Context(), &configuration.GetRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, res.Items)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	for _, keys := range [][]string{{"key1", "key2"}, {"key1"}} {
+		t.Run(strings.Join(keys, ","), func(t *testing.T) {
+			s, f := newTestStore(t, "config")
+
+			events := make(chan *configuration.UpdateEvent, 10)
+			handler := func(ctx context.Context, e *configuration.UpdateEvent) error {
+				events <- e
+				return nil
+			}
+			id, err := s.Subscribe(t.Context(), &configuration.SubscribeRequest{Keys: keys}, handler)
+			require.NoError(t, err)
+
+			// "key3" is not watched
+			f.put("config/key3", "ignored")
+			f.put("config/key1", "changed")
+			select {
+			case e := <-events:
+				assert.Equal(t, id, e.ID)
+				assert.Equal(t, map[string]*configuration.Item{
+					"key1": {Value: "changed", Version: "5", Metadata: map[string]string{}},
+				}, e.Items)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the update event")
+			}
+
+			f.delete("config/key1")
+			select {
+			case e := <-events:
+				assert.Equal(t, map[string]*configuration.Item{"key1": {}}, e.Items)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the update event")
+			}
+
+			require.NoError(t, s.Unsubscribe(t.Context(), &configuration.UnsubscribeRequest{ID: id}))
+			require.Error(t, s.Unsubscribe(t.Context(), &configuration.UnsubscribeRequest{ID: id}))
+
+			f.put("config/key1", "again")
+			select {
+			case e := <-events:
+				t.Fatalf("unexpected update event after unsubscribing: %v", e)
+			case <-time.After(500 * time.Millisecond):
+			}
+		})
+	}
+}
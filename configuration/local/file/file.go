@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dapr/components-contrib/configuration"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+type metadata struct {
+	// Path to the JSON or YAML file containing the configuration items.
+	ConfigFile string `mapstructure:"configFile"`
+}
+
+// fileItem is an item in the configuration file, when it has a version or metadata.
+// Items that only have a value are stored as the value itself.
+type fileItem struct {
+	Value    string            `json:"value" yaml:"value"`
+	Version  string            `json:"version,omitempty" yaml:"version,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+var (
+	_ configuration.Store  = (*ConfigurationStore)(nil)
+	_ configuration.Writer = (*ConfigurationStore)(nil)
+)
+
+// ConfigurationStore is a configuration store backed by a local JSON or YAML file, meant for local development.
+type ConfigurationStore struct {
+	metadata metadata
+	logger   logger.Logger
+	subs     *configuration.Subscriptions
+
+	// Serializes writes to the configuration file
+	lock sync.Mutex
+
+	// Items last sent to subscribers, only used by the watcher
+	items       map[string]*configuration.Item
+	watchLock   sync.Mutex
+	watchCancel context.CancelFunc
+	watchDone   chan struct{}
+}
+
+// NewLocalFileConfigurationStore returns a new local file configuration store.
+func NewLocalFileConfigurationStore(logger logger.Logger) configuration.Store {
+	return &ConfigurationStore{
+		logger: logger,
+		subs:   configuration.NewSubscriptions(logger),
+	}
+}
+
+// Init reads the configuration file, which must exist.
+func (s *ConfigurationStore) Init(_ context.Context, md configuration.Metadata) error {
+	s.metadata = metadata{}
+	err := kitmd.DecodeMetadata(md.Properties, &s.metadata)
+	if err != nil {
+		return err
+	}
+	if s.metadata.ConfigFile == "" {
+		return errors.New("missing configuration file in metadata")
+	}
+
+	_, err = s.readItems()
+	return err
+}
+
+// Get returns the items with the given keys, or all items if no key is given.
+// The configuration file is read on each request, so changes made to it are returned immediately.
+func (s *ConfigurationStore) Get(_ context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	items, err := s.readItems()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Keys) > 0 {
+		filtered := make(map[string]*configuration.Item, len(req.Keys))
+		for _, key := range req.Keys {
+			if item, ok := items[key]; ok {
+				filtered[key] = item
+			}
+		}
+		items = filtered
+	}
+
+	return &configuration.GetResponse{
+		Items: items,
+	}, nil
+}
+
+// readItems reads and decodes the configuration file.
+func (s *ConfigurationStore) readItems() (map[string]*configuration.Item, error) {
+	data, err := os.ReadFile(s.metadata.ConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	items, err := decodeItems(data, isYAMLFile(s.metadata.ConfigFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode configuration file %s: %w", s.metadata.ConfigFile, err)
+	}
+	return items, nil
+}
+
+// isYAMLFile returns true if the file is YAML, based on its extension; other files are JSON.
+func isYAMLFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// decodeItems decodes the content of a configuration file.
+// The file contains an object whose properties are the items, either as values or as objects with a value, a version and metadata.
+func decodeItems(data []byte, yamlFile bool) (map[string]*configuration.Item, error) {
+	var raw map[string]any
+	if len(bytes.TrimSpace(data)) > 0 {
+		var err error
+		if yamlFile {
+			err = yaml.Unmarshal(data, &raw)
+		} else {
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.UseNumber()
+			err = dec.Decode(&raw)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items := make(map[string]*configuration.Item, len(raw))
+	for key, v := range raw {
+		item, err := decodeItem(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid item %s: %w", key, err)
+		}
+		items[key] = item
+	}
+	return items, nil
+}
+
+func decodeItem(v any) (*configuration.Item, error) {
+	item := &configuration.Item{
+		Metadata: map[string]string{},
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		value, err := scalarString(v)
+		if err != nil {
+			return nil, err
+		}
+		item.Value = value
+		return item, nil
+	}
+
+	for field, fv := range obj {
+		var err error
+		switch field {
+		case "value":
+			item.Value, err = scalarString(fv)
+		case "version":
+			item.Version, err = scalarString(fv)
+		case "metadata":
+			md, ok := fv.(map[string]any)
+			if !ok && fv != nil {
+				return nil, errors.New("metadata must be an object")
+			}
+			for mk, mv := range md {
+				item.Metadata[mk], err = scalarString(mv)
+				if err != nil {
+					break
+				}
+			}
+		default:
+			err = fmt.Errorf("unknown field %s", field)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+// scalarString returns the string representation of a scalar value.
+func scalarString(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", v)
+	}
+}
+
+// encodeItems encodes items in the format of the configuration file.
+func encodeItems(items map[string]*configuration.Item, yamlFile bool) ([]byte, error) {
+	raw := make(map[string]any, len(items))
+	for key, item := range items {
+		if item.Version == "" && len(item.Metadata) == 0 {
+			raw[key] = item.Value
+			continue
+		}
+		raw[key] = fileItem{
+			Value:    item.Value,
+			Version:  item.Version,
+			Metadata: item.Metadata,
+		}
+	}
+
+	if yamlFile {
+		return yaml.Marshal(raw)
+	}
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (s *ConfigurationStore) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
+	metadataStruct := metadata{}
+	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.ConfigurationStoreType)
+	return
+}
+
+func (s *ConfigurationStore) Close() error {
+	s.stopWatcher()
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/configuration"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+const testYAML = `
+plain: hello
+number: 42
+flag: true
+full:
+  value: world
+  version: "3"
+  metadata:
+    owner: team
+`
+
+const testJSON = `{
+  "plain": "hello",
+  "number": 42,
+  "ratio": 1.5,
+  "full": {"value": "world", "version": "3", "metadata": {"owner": "team"}}
+}`
+
+func newTestStore(t *testing.T, name string, content string) (*ConfigurationStore, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	s := NewLocalFileConfigurationStore(logger.NewLogger("test")).(*ConfigurationStore)
+	err := s.Init(t.Context(), configuration.Metadata{Base: contribMetadata.Base{
+		Properties: map[string]string{"configFile": path},
+	}})
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	return s, path
+}
+
+func TestInit(t *testing.T) {
+	s := NewLocalFileConfigurationStore(logger.NewLogger("test"))
+
+	err := s.Init(t.Context(), configuration.Metadata{})
+	require.Error(t, err)
+
+	err = s.Init(t.Context(), configuration.Metadata{Base: contribMetadata.Base{
+		Properties: map[string]string{"configFile": filepath.Join(t.TempDir(), "missing.json")},
+	}})
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"key": {"value": "v", "other": 1}}`), 0o600))
+	err = s.Init(t.Context(), configuration.Metadata{Base: contribMetadata.Base{
+		Properties: map[string]string{"configFile": path},
+	}})
+	require.ErrorContains(t, err, "unknown field other")
+}
+
+func TestGet(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		s, _ := newTestStore(t, "config.yaml", testYAML)
+
+		res, err := s.Get(t.Context(), &configuration.GetRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{
+			"plain":  {Value: "hello", Metadata: map[string]string{}},
+			"number": {Value: "42", Metadata: map[string]string{}},
+			"flag":   {Value: "true", Metadata: map[string]string{}},
+			"full":   {Value: "world", Version: "3", Metadata: map[string]string{"owner": "team"}},
+		}, res.Items)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		s, _ := newTestStore(t, "config.json", testJSON)
+
+		res, err := s.Get(t.Context(), &configuration.GetRequest{Keys: []string{"number", "ratio", "full", "missing"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{
+			"number": {Value: "42", Metadata: map[string]string{}},
+			"ratio":  {Value: "1.5", Metadata: map[string]string{}},
+			"full":   {Value: "world", Version: "3", Metadata: map[string]string{"owner": "team"}},
+		}, res.Items)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		s, _ := newTestStore(t, "config.yaml", "")
+
+		res, err := s.Get(t.Context(), &configuration.GetRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, res.Items)
+	})
+}
+
+func TestWriter(t *testing.T) {
+	for _, name := range []string{"config.yaml", "config.json"} {
+		t.Run(name, func(t *testing.T) {
+			content := testYAML
+			if name == "config.json" {
+				content = testJSON
+			}
+			s, path := newTestStore(t, name, content)
+
+			res, err := s.Set(t.Context(), &configuration.SetRequest{
+				Items: map[string]*configuration.Item{
+					"full": {Value: "updated", Version: "3"},
+					"new":  {Value: "created", Metadata: map[string]string{"a": "b"}},
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"full": "4", "new": "1"}, res.Versions)
+
+			_, err = s.Set(t.Context(), &configuration.SetRequest{
+				Items: map[string]*configuration.Item{
+					"full":  {Value: "stale", Version: "3"},
+					"plain": {Value: "not-written"},
+				},
+			})
+			require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+
+			err = s.Delete(t.Context(), &configuration.DeleteRequest{
+				Keys:     []string{"plain", "new"},
+				Versions: map[string]string{"new": "2"},
+			})
+			require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+
+			err = s.Delete(t.Context(), &configuration.DeleteRequest{
+				Keys:     []string{"plain", "new", "missing"},
+				Versions: map[string]string{"new": "1"},
+			})
+			require.NoError(t, err)
+
+			// Read the file with a new store, to check it's written in the right format
+			s2 := NewLocalFileConfigurationStore(logger.NewLogger("test"))
+			err = s2.Init(t.Context(), configuration.Metadata{Base: contribMetadata.Base{
+				Properties: map[string]string{"configFile": path},
+			}})
+			require.NoError(t, err)
+			get, err := s2.Get(t.Context(), &configuration.GetRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, "updated", get.Items["full"].Value)
+			assert.Equal(t, "4", get.Items["full"].Version)
+			assert.Equal(t, map[string]string{}, get.Items["full"].Metadata)
+			assert.Equal(t, "42", get.Items["number"].Value)
+			assert.NotContains(t, get.Items, "plain")
+			assert.NotContains(t, get.Items, "new")
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	s, path := newTestStore(t, "config.yaml", testYAML)
+
+	events := make(chan *configuration.UpdateEvent, 10)
+	handler := func(ctx context.Context, e *configuration.UpdateEvent) error {
+		events <- e
+		return nil
+	}
+	id, err := s.Subscribe(t.Context(), &configuration.SubscribeRequest{Keys: []string{"plain", "full"}}, handler)
+	require.NoError(t, err)
+
+	// Edit the file directly: "number" is not watched
+	require.NoError(t, os.WriteFile(path, []byte("plain: changed\nnumber: 43\n"), 0o600))
+	select {
+	case e := <-events:
+		assert.Equal(t, id, e.ID)
+		assert.Equal(t, map[string]*configuration.Item{
+			"plain": {Value: "changed", Metadata: map[string]string{}},
+			"full":  {},
+		}, e.Items)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the update event")
+	}
+
+	// Changes made with the writer are notified too
+	_, err = s.Set(t.Context(), &configuration.SetRequest{
+		Items: map[string]*configuration.Item{"full": {Value: "back"}},
+	})
+	require.NoError(t, err)
+	select {
+	case e := <-events:
+		assert.Equal(t, map[string]*configuration.Item{
+			"full": {Value: "back", Version: "1", Metadata: map[string]string{}},
+		}, e.Items)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the update event")
+	}
+
+	require.NoError(t, s.Unsubscribe(t.Context(), &configuration.UnsubscribeRequest{ID: id}))
+	require.Error(t, s.Unsubscribe(t.Context(), &configuration.UnsubscribeRequest{ID: id}))
+
+	require.NoError(t, os.WriteFile(path, []byte("plain: again\n"), 0o600))
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected update event after unsubscribing: %v", e)
+	case <-time.After(time.Second):
+	}
+}
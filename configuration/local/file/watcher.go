@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/kit/fswatcher"
+)
+
+// Subscribe notifies changes to the items, made with Set or Delete or by editing the file.
+// The file is watched from the first subscription until the store is closed.
+func (s *ConfigurationStore) Subscribe(ctx context.Context, req *configuration.SubscribeRequest, handler configuration.UpdateHandler) (string, error) {
+	sub, err := s.subs.Add(req, handler)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.startWatcher()
+	if err != nil {
+		s.subs.Remove(sub.ID)
+		return "", err
+	}
+
+	return sub.ID, nil
+}
+
+// Unsubscribe implements configuration.Store.
+func (s *ConfigurationStore) Unsubscribe(ctx context.Context, req *configuration.UnsubscribeRequest) error {
+	_, err := s.subs.Remove(req.ID)
+	return err
+}
+
+// startWatcher starts watching the configuration file, if not already watched.
+func (s *ConfigurationStore) startWatcher() error {
+	s.watchLock.Lock()
+	defer s.watchLock.Unlock()
+
+	if s.watchCancel != nil {
+		return nil
+	}
+
+	items, err := s.readItems()
+	if err != nil {
+		return err
+	}
+	s.items = items
+
+	// The folder is watched, as the file may be replaced rather than written to
+	watcher, err := fswatcher.New(fswatcher.Options{
+		Targets: []string{filepath.Dir(s.metadata.ConfigFile)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch configuration file: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventCh := make(chan struct{})
+	done := make(chan struct{})
+	s.watchCancel = cancel
+	s.watchDone = done
+
+	go func() {
+		err := watcher.Run(ctx, eventCh)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Errorf("Error watching configuration file %s: %v", s.metadata.ConfigFile, err)
+		}
+	}()
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-eventCh:
+				s.notifyChangedItems(ctx)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// stopWatcher stops watching the configuration file.
+func (s *ConfigurationStore) stopWatcher() {
+	s.watchLock.Lock()
+	defer s.watchLock.Unlock()
+
+	if s.watchCancel == nil {
+		return
+	}
+	s.watchCancel()
+	<-s.watchDone
+	s.watchCancel = nil
+	s.watchDone = nil
+}
+
+// notifyChangedItems reads the configuration file after it changed, and notifies the subscribers of the items that changed.
+// Deleted items are notified as empty items.
+// If the file can't be read, for example while it's being written, it's read again at the next change.
+func (s *ConfigurationStore) notifyChangedItems(ctx context.Context) {
+	items, err := s.readItems()
+	if err != nil {
+		s.logger.Warnf("Failed to reload configuration file %s: %v", s.metadata.ConfigFile, err)
+		return
+	}
+
+	changed := configuration.ChangedItems(s.items, items)
+	s.items = items
+	s.subs.Notify(ctx, changed)
+}
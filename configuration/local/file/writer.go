@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	commonutils "github.com/dapr/components-contrib/common/utils"
+	"github.com/dapr/components-contrib/configuration"
+)
+
+// Set writes items to the configuration file, which is replaced atomically.
+// If the current version of an item is an integer, the new version is incremented, otherwise it's 1.
+func (s *ConfigurationStore) Set(_ context.Context, req *configuration.SetRequest) (*configuration.SetResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+	for key, item := range req.Items {
+		if key == "" {
+			return nil, errors.New("key can't be empty")
+		}
+		if item == nil {
+			return nil, fmt.Errorf("item of key %s is nil", key)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	items, err := s.readItems()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string, len(req.Items))
+	for key, item := range req.Items {
+		var version string
+		if current, ok := items[key]; ok {
+			version = current.Version
+		}
+		if item.Version != "" && item.Version != version {
+			return nil, fmt.Errorf("%w: key %s", configuration.ErrVersionMismatch, key)
+		}
+		versions[key] = nextVersion(version)
+	}
+
+	for key, item := range req.Items {
+		md := item.Metadata
+		if md == nil {
+			md = map[string]string{}
+		}
+		items[key] = &configuration.Item{
+			Value:    item.Value,
+			Version:  versions[key],
+			Metadata: md,
+		}
+	}
+
+	err = s.writeItems(items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &configuration.SetResponse{
+		Versions: versions,
+	}, nil
+}
+
+// Delete deletes items from the configuration file, which is replaced atomically.
+func (s *ConfigurationStore) Delete(_ context.Context, req *configuration.DeleteRequest) error {
+	if len(req.Keys) == 0 {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	items, err := s.readItems()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range req.Keys {
+		expected := req.Versions[key]
+		if expected == "" {
+			continue
+		}
+		current, ok := items[key]
+		if !ok || current.Version != expected {
+			return fmt.Errorf("%w: key %s", configuration.ErrVersionMismatch, key)
+		}
+	}
+
+	n := len(items)
+	for _, key := range req.Keys {
+		delete(items, key)
+	}
+	if len(items) == n {
+		// Nothing to delete
+		return nil
+	}
+
+	return s.writeItems(items)
+}
+
+func (s *ConfigurationStore) writeItems(items map[string]*configuration.Item) error {
+	data, err := encodeItems(items, isYAMLFile(s.metadata.ConfigFile))
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration items: %w", err)
+	}
+
+	err = commonutils.WriteFileAtomic(s.metadata.ConfigFile, data)
+	if err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+	return nil
+}
+
+// nextVersion returns the version of an item after an update.
+func nextVersion(version string) string {
+	n, err := strconv.Atoi(version)
+	if err != nil || n < 0 {
+		return "1"
+	}
+	return strconv.Itoa(n + 1)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
+	"github.com/dapr/components-contrib/common/component/postgresql/transactions"
+	"github.com/dapr/components-contrib/configuration"
+)
+
+var _ configuration.Writer = (*ConfigurationStore)(nil)
+
+// Set writes items in a transaction, so all items are written atomically.
+// If the current version of an item is an integer, the new version is incremented, otherwise it's 1.
+func (p *ConfigurationStore) Set(ctx context.Context, req *configuration.SetRequest) (*configuration.SetResponse, error) {
+	return p.setItems(ctx, p.client, req)
+}
+
+// Delete deletes items in a transaction, so all items are deleted atomically.
+func (p *ConfigurationStore) Delete(ctx context.Context, req *configuration.DeleteRequest) error {
+	return p.deleteItems(ctx, p.client, req)
+}
+
+func (p *ConfigurationStore) setItems(ctx context.Context, db pginterfaces.PGXPoolConn, req *configuration.SetRequest) (*configuration.SetResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+	keys := make([]string, 0, len(req.Items))
+	for key, item := range req.Items {
+		if item == nil {
+			return nil, fmt.Errorf("item of key %s is nil", key)
+		}
+		keys = append(keys, key)
+	}
+	err := validateWriteKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := transactions.ExecuteInTransaction(ctx, p.logger, db, p.metadata.Timeout, func(ctx context.Context, tx pgx.Tx) (map[string]string, error) {
+		current, err := p.lockItems(ctx, tx, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		versions := make(map[string]string, len(keys))
+		for _, key := range keys {
+			item := req.Items[key]
+			version, exists := current[key]
+			if item.Version != "" && item.Version != version {
+				return nil, fmt.Errorf("%w: key %s", configuration.ErrVersionMismatch, key)
+			}
+			versions[key] = nextVersion(version)
+
+			md := item.Metadata
+			if md == nil {
+				md = map[string]string{}
+			}
+			if exists {
+				_, err = tx.Exec(ctx, "UPDATE "+p.metadata.ConfigTable+" SET value = $2, version = $3, metadata = $4 WHERE key = $1", key, item.Value, versions[key], md)
+			} else {
+				_, err = tx.Exec(ctx, "INSERT INTO "+p.metadata.ConfigTable+" (key, value, version, metadata) VALUES ($1, $2, $3, $4)", key, item.Value, versions[key], md)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to write configuration item %s: %w", key, err)
+			}
+		}
+		return versions, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &configuration.SetResponse{
+		Versions: versions,
+	}, nil
+}
+
+func (p *ConfigurationStore) deleteItems(ctx context.Context, db pginterfaces.PGXPoolConn, req *configuration.DeleteRequest) error {
+	if len(req.Keys) == 0 {
+		return nil
+	}
+	err := validateWriteKeys(req.Keys)
+	if err != nil {
+		return err
+	}
+
+	_, err = transactions.ExecuteInTransaction(ctx, p.logger, db, p.metadata.Timeout, func(ctx context.Context, tx pgx.Tx) (struct{}, error) {
+		if len(req.Versions) > 0 {
+			current, err := p.lockItems(ctx, tx, req.Keys)
+			if err != nil {
+				return struct{}{}, err
+			}
+			for _, key := range req.Keys {
+				expected, ok := req.Versions[key]
+				if ok && expected != "" && expected != current[key] {
+					return struct{}{}, fmt.Errorf("%w: key %s", configuration.ErrVersionMismatch, key)
+				}
+			}
+		}
+
+		_, err := tx.Exec(ctx, "DELETE FROM "+p.metadata.ConfigTable+" WHERE key = ANY($1)", req.Keys)
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to delete configuration items: %w", err)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// lockItems locks the keys until the end of the transaction, and returns the current version of those that exist.
+// Keys are locked with advisory locks, as they may not exist yet, in a consistent order to avoid deadlocks.
+func (p *ConfigurationStore) lockItems(ctx context.Context, tx pgx.Tx, keys []string) (map[string]string, error) {
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+	for _, key := range sorted {
+		_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", p.metadata.ConfigTable+":"+key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock configuration item %s: %w", key, err)
+		}
+	}
+
+	rows, err := tx.Query(ctx, "SELECT key, version FROM "+p.metadata.ConfigTable+" WHERE key = ANY($1)", keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration items: %w", err)
+	}
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (pgResponse, error) {
+		r := pgResponse{
+			item: new(configuration.Item),
+		}
+		return r, row.Scan(&r.key, &r.item.Version)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration items: %w", err)
+	}
+
+	// The table may contain multiple rows for the same key, in which case the one with the highest version is used
+	versions := make(map[string]string, len(res))
+	for key, item := range getUniqueItemPerKey(res) {
+		versions[key] = item.Version
+	}
+	return versions, nil
+}
+
+func validateWriteKeys(keys []string) error {
+	for _, key := range keys {
+		if key == "" {
+			return errors.New("key can't be empty")
+		}
+	}
+	return validateInput(keys)
+}
+
+// nextVersion returns the version of an item after an update.
+func nextVersion(version string) string {
+	n := getNumericVersion(version)
+	if n < 0 {
+		return "1"
+	}
+	return strconv.Itoa(n + 1)
+}
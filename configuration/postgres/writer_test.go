@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/kit/logger"
+)
+
+func newWriterTestStore(t *testing.T) (*ConfigurationStore, pgxmock.PgxPoolIface) {
+	t.Helper()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	p := &ConfigurationStore{
+		logger: logger.NewLogger("test"),
+		metadata: metadata{
+			ConfigTable: "cfgtbl",
+			Timeout:     time.Minute,
+		},
+	}
+	return p, mock
+}
+
+func expectLock(mock pgxmock.PgxPoolIface, keys []string, rows *pgxmock.Rows) {
+	for _, key := range keys {
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock(hashtext($1))")).
+			WithArgs("cfgtbl:" + key).
+			WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT key, version FROM cfgtbl WHERE key = ANY($1)")).
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(rows)
+}
+
+func TestSetItems(t *testing.T) {
+	t.Run("insert and update", func(t *testing.T) {
+		p, mock := newWriterTestStore(t)
+		mock.ExpectBegin()
+		expectLock(mock, []string{"key1", "key2"}, pgxmock.NewRows([]string{"key", "version"}).
+			AddRow("key1", "1").
+			AddRow("key1", "4"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE cfgtbl SET value = $2, version = $3, metadata = $4 WHERE key = $1")).
+			WithArgs("key1", "value1", "5", map[string]string{}).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO cfgtbl (key, value, version, metadata) VALUES ($1, $2, $3, $4)")).
+			WithArgs("key2", "value2", "1", map[string]string{"a": "b"}).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectCommit()
+
+		res, err := p.setItems(t.Context(), mock, &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key1": {Value: "value1", Version: "4"},
+				"key2": {Value: "value2", Metadata: map[string]string{"a": "b"}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key1": "5", "key2": "1"}, res.Versions)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		p, mock := newWriterTestStore(t)
+		mock.ExpectBegin()
+		expectLock(mock, []string{"key1"}, pgxmock.NewRows([]string{"key", "version"}))
+		mock.ExpectRollback()
+
+		_, err := p.setItems(t.Context(), mock, &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key1": {Value: "value1", Version: "1"},
+			},
+		})
+		require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		p, mock := newWriterTestStore(t)
+		_, err := p.setItems(t.Context(), mock, &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key 1=1": {Value: "value1"},
+			},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestDeleteItems(t *testing.T) {
+	t.Run("conditional", func(t *testing.T) {
+		p, mock := newWriterTestStore(t)
+		mock.ExpectBegin()
+		expectLock(mock, []string{"key1", "key2"}, pgxmock.NewRows([]string{"key", "version"}).
+			AddRow("key1", "2"))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM cfgtbl WHERE key = ANY($1)")).
+			WithArgs([]string{"key2", "key1"}).
+			WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectCommit()
+
+		err := p.deleteItems(t.Context(), mock, &configuration.DeleteRequest{
+			Keys:     []string{"key2", "key1"},
+			Versions: map[string]string{"key1": "2"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		p, mock := newWriterTestStore(t)
+		mock.ExpectBegin()
+		expectLock(mock, []string{"key1"}, pgxmock.NewRows([]string{"key", "version"}).
+			AddRow("key1", "3"))
+		mock.ExpectRollback()
+
+		err := p.deleteItems(t.Context(), mock, &configuration.DeleteRequest{
+			Keys:     []string{"key1"},
+			Versions: map[string]string{"key1": "2"},
+		})
+		require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNextVersion(t *testing.T) {
+	assert.Equal(t, "1", nextVersion(""))
+	assert.Equal(t, "1", nextVersion("1.0.0"))
+	assert.Equal(t, "8", nextVersion("7"))
+}
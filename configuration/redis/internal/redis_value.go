@@ -21,11 +21,12 @@ import (
 
 const (
 	keySpacePrefix = "__keyspace@"
-	separator      = "||"
+	// Separator separates the value and the version of configuration items stored in Redis.
+	Separator = "||"
 )
 
 func GetRedisValueAndVersion(redisValue string) (string, string) {
-	valueAndRevision := strings.Split(redisValue, separator)
+	valueAndRevision := strings.Split(redisValue, Separator)
 	if len(valueAndRevision) == 0 {
 		return "", ""
 	}
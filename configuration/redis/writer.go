@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/components-contrib/configuration/redis/internal"
+)
+
+// currentVersionScript defines currentVersion, which returns the version of a key stored in the "value||version" format.
+const currentVersionScript = `
+local function currentVersion(key)
+	local current = redis.call("GET", key)
+	if not current then
+		return ""
+	end
+	local s = string.find(current, "||", 1, true)
+	if not s then
+		return ""
+	end
+	local version = string.sub(current, s + 2)
+	local e = string.find(version, "||", 1, true)
+	if e then
+		version = string.sub(version, 1, e - 1)
+	end
+	return version
+end
+`
+
+// setScript sets all keys if their version matches the expected one, returning their new versions.
+// Versions that are integers are incremented, otherwise the new version is 1.
+// If a version doesn't match, nothing is written and the key is returned.
+// ARGV contains the expected version and the value of each key; an empty expected version means any.
+const setScript = currentVersionScript + `
+local versions = {}
+for i, key in ipairs(KEYS) do
+	local expected = ARGV[i * 2 - 1]
+	local version = currentVersion(key)
+	if expected ~= "" and expected ~= version then
+		return key
+	end
+	local n = tonumber(version)
+	if n and n >= 0 and n == math.floor(n) then
+		versions[i] = string.format("%d", n + 1)
+	else
+		versions[i] = "1"
+	end
+end
+for i, key in ipairs(KEYS) do
+	redis.call("SET", key, ARGV[i * 2] .. "||" .. versions[i])
+end
+return versions
+`
+
+// deleteScript deletes all keys if their version matches the expected one, which is in ARGV.
+// If a version doesn't match, nothing is deleted and the key is returned.
+const deleteScript = currentVersionScript + `
+for i, key in ipairs(KEYS) do
+	local expected = ARGV[i]
+	if expected ~= "" and expected ~= currentVersion(key) then
+		return key
+	end
+end
+redis.call("DEL", unpack(KEYS))
+return 0
+`
+
+var _ configuration.Writer = (*ConfigurationStore)(nil)
+
+// Set writes items in the "value||version" format, in a Lua script so all items are written atomically.
+// With Redis Cluster, all keys must be in the same hash slot. The metadata of items is not stored.
+func (r *ConfigurationStore) Set(ctx context.Context, req *configuration.SetRequest) (*configuration.SetResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+
+	keys := sortedKeys(req.Items)
+	args := make([]interface{}, 0, 3+len(keys)*3)
+	args = append(args, "EVAL", setScript, len(keys))
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	for _, key := range keys {
+		item := req.Items[key]
+		if item == nil || item.Value == "" {
+			return nil, fmt.Errorf("value of key %s is empty", key)
+		}
+		if strings.Contains(item.Value, internal.Separator) {
+			return nil, fmt.Errorf("value of key %s can't contain '%s'", key, internal.Separator)
+		}
+		args = append(args, item.Version, item.Value)
+	}
+
+	res, err := r.client.DoRead(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set configuration items: %w", err)
+	}
+
+	switch res := res.(type) {
+	case string:
+		return nil, fmt.Errorf("%w: key %s", configuration.ErrVersionMismatch, res)
+	case []interface{}:
+		versions := make(map[string]string, len(keys))
+		for i, key := range keys {
+			if i < len(res) {
+				versions[key] = fmt.Sprint(res[i])
+			}
+		}
+		return &configuration.SetResponse{
+			Versions: versions,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected response from Redis: %v", res)
+	}
+}
+
+// Delete deletes items, in a Lua script so all items are deleted atomically.
+// With Redis Cluster, all keys must be in the same hash slot.
+func (r *ConfigurationStore) Delete(ctx context.Context, req *configuration.DeleteRequest) error {
+	if len(req.Keys) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, 3+len(req.Keys)*2)
+	args = append(args, "EVAL", deleteScript, len(req.Keys))
+	for _, key := range req.Keys {
+		args = append(args, key)
+	}
+	for _, key := range req.Keys {
+		args = append(args, req.Versions[key])
+	}
+
+	res, err := r.client.DoRead(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete configuration items: %w", err)
+	}
+	if key, ok := res.(string); ok {
+		return fmt.Errorf("%w: key %s", configuration.ErrVersionMismatch, key)
+	}
+	return nil
+}
+
+func sortedKeys(items map[string]*configuration.Item) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/kit/logger"
+)
+
+func TestConfigurationStore_Set(t *testing.T) {
+	s, c := setupMiniredis()
+	defer s.Close()
+	store := &ConfigurationStore{client: c, logger: logger.NewLogger("test")}
+
+	t.Run("create items", func(t *testing.T) {
+		res, err := store.Set(t.Context(), &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key1": {Value: "value1"},
+				"key2": {Value: "value2"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key1": "1", "key2": "1"}, res.Versions)
+
+		got, err := s.Get("key1")
+		require.NoError(t, err)
+		assert.Equal(t, "value1||1", got)
+
+		get, err := store.Get(t.Context(), &configuration.GetRequest{Keys: []string{"key2"}})
+		require.NoError(t, err)
+		assert.Equal(t, "value2", get.Items["key2"].Value)
+		assert.Equal(t, "1", get.Items["key2"].Version)
+	})
+
+	t.Run("update with the current version", func(t *testing.T) {
+		res, err := store.Set(t.Context(), &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key1": {Value: "value1b", Version: "1"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key1": "2"}, res.Versions)
+	})
+
+	t.Run("version mismatch writes nothing", func(t *testing.T) {
+		_, err := store.Set(t.Context(), &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key1": {Value: "value1c", Version: "1"},
+				"key2": {Value: "value2c"},
+			},
+		})
+		require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+
+		got, err := s.Get("key2")
+		require.NoError(t, err)
+		assert.Equal(t, "value2||1", got)
+	})
+
+	t.Run("non-numeric versions are replaced", func(t *testing.T) {
+		require.NoError(t, s.Set("key3", "value3||v1.0.0"))
+		res, err := store.Set(t.Context(), &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key3": {Value: "value3b", Version: "v1.0.0"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key3": "1"}, res.Versions)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		_, err := store.Set(t.Context(), &configuration.SetRequest{
+			Items: map[string]*configuration.Item{"key1": {Value: "a||b"}},
+		})
+		require.Error(t, err)
+		_, err = store.Set(t.Context(), &configuration.SetRequest{
+			Items: map[string]*configuration.Item{"key1": {}},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestConfigurationStore_Delete(t *testing.T) {
+	s, c := setupMiniredis()
+	defer s.Close()
+	store := &ConfigurationStore{client: c, logger: logger.NewLogger("test")}
+	require.NoError(t, s.Set("key1", "value1||3"))
+	require.NoError(t, s.Set("key2", "value2"))
+
+	err := store.Delete(t.Context(), &configuration.DeleteRequest{
+		Keys:     []string{"key1", "key2"},
+		Versions: map[string]string{"key1": "2"},
+	})
+	require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+	assert.True(t, s.Exists("key2"))
+
+	err = store.Delete(t.Context(), &configuration.DeleteRequest{
+		Keys:     []string{"key1", "key2", "missing"},
+		Versions: map[string]string{"key1": "3"},
+	})
+	require.NoError(t, err)
+	assert.False(t, s.Exists("key1"))
+	assert.False(t, s.Exists("key2"))
+}
@@ -32,6 +32,21 @@ type SubscribeRequest struct {
 	Metadata map[string]string `json:"metadata"`
 }
 
+// SetRequest is the object describing a request to set configuration items.
+type SetRequest struct {
+	// Items to set. The version of an item, if set, is the version it must currently have.
+	Items    map[string]*Item  `json:"items"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// DeleteRequest is the object describing a request to delete configuration items.
+type DeleteRequest struct {
+	Keys []string `json:"keys"`
+	// Versions the items must currently have, for the keys whose deletion is conditional.
+	Versions map[string]string `json:"versions,omitempty"`
+	Metadata map[string]string `json:"metadata"`
+}
+
 // UnsubscribeRequest is the object describing a request to unsubscribe configuration.
 type UnsubscribeRequest struct {
 	ID string `json:"id"`
@@ -17,3 +17,9 @@ package configuration
 type GetResponse struct {
 	Items map[string]*Item `json:"items"`
 }
+
+// SetResponse is the response object for setting configuration items.
+type SetResponse struct {
+	// New versions of the items.
+	Versions map[string]string `json:"versions"`
+}
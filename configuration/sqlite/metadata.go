@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	authSqlite "github.com/dapr/components-contrib/common/authentication/sqlite"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultTableName         = "configuration"
+	defaultMetadataTableName = "metadata"
+	defaultPollInterval      = 5 * time.Second
+)
+
+type metadata struct {
+	authSqlite.SqliteAuthMetadata `mapstructure:",squash"`
+
+	TableName         string `mapstructure:"tableName"`
+	MetadataTableName string `mapstructure:"metadataTableName"`
+	// Interval between polls of the table for changes, while there are subscriptions.
+	PollInterval time.Duration `mapstructure:"pollInterval"`
+}
+
+func (m *metadata) InitWithMetadata(meta map[string]string) error {
+	// Reset the object
+	m.SqliteAuthMetadata.Reset()
+	m.TableName = defaultTableName
+	m.MetadataTableName = defaultMetadataTableName
+	m.PollInterval = defaultPollInterval
+
+	err := kitmd.DecodeMetadata(meta, &m)
+	if err != nil {
+		return err
+	}
+
+	// Validate and sanitize input
+	err = m.SqliteAuthMetadata.Validate()
+	if err != nil {
+		return err
+	}
+	if !authSqlite.ValidIdentifier(m.TableName) {
+		return fmt.Errorf("invalid identifier: %s", m.TableName)
+	}
+	if !authSqlite.ValidIdentifier(m.MetadataTableName) {
+		return fmt.Errorf("invalid identifier: %s", m.MetadataTableName)
+	}
+	if m.PollInterval < 100*time.Millisecond {
+		return errors.New("invalid value for 'pollInterval': must be at least 100ms")
+	}
+
+	return nil
+}
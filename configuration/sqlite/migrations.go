@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	commonsql "github.com/dapr/components-contrib/common/component/sql"
+	sqlitemigrations "github.com/dapr/components-contrib/common/component/sql/migrations/sqlite"
+	"github.com/dapr/kit/logger"
+)
+
+type migrationOptions struct {
+	TableName         string
+	MetadataTableName string
+}
+
+// Perform the required migrations
+func performMigrations(ctx context.Context, db *sql.DB, logger logger.Logger, opts migrationOptions) error {
+	m := sqlitemigrations.Migrations{
+		Pool:              db,
+		Logger:            logger,
+		MetadataTableName: opts.MetadataTableName,
+		MetadataKey:       "configuration-migrations-" + opts.TableName,
+	}
+
+	return m.Perform(ctx, []commonsql.MigrationFn{
+		// Migration 0: create the configuration table
+		func(ctx context.Context) error {
+			logger.Infof("Creating configuration table '%s'", opts.TableName)
+			_, err := m.GetConn().ExecContext(
+				ctx,
+				fmt.Sprintf(
+					`CREATE TABLE IF NOT EXISTS %s (
+							key TEXT NOT NULL PRIMARY KEY,
+							value TEXT NOT NULL,
+							version TEXT NOT NULL DEFAULT '',
+							metadata TEXT NOT NULL DEFAULT '{}',
+							update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+						)`,
+					opts.TableName,
+				),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create configuration table: %w", err)
+			}
+			return nil
+		},
+	})
+}
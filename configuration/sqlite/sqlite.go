@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	// Blank import for the underlying SQLite Driver.
+	_ "modernc.org/sqlite"
+
+	authSqlite "github.com/dapr/components-contrib/common/authentication/sqlite"
+	"github.com/dapr/components-contrib/configuration"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+// Interface for both sql.DB and sql.Tx
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+var (
+	_ configuration.Store  = (*ConfigurationStore)(nil)
+	_ configuration.Writer = (*ConfigurationStore)(nil)
+)
+
+// ConfigurationStore is a configuration store backed by a SQLite database.
+type ConfigurationStore struct {
+	metadata metadata
+	db       *sql.DB
+	logger   logger.Logger
+	subs     *configuration.Subscriptions
+
+	pollLock   sync.Mutex
+	pollCancel context.CancelFunc
+	pollDone   chan struct{}
+}
+
+// NewSQLiteConfigurationStore returns a new SQLite configuration store.
+func NewSQLiteConfigurationStore(logger logger.Logger) configuration.Store {
+	return &ConfigurationStore{
+		logger: logger,
+		subs:   configuration.NewSubscriptions(logger),
+	}
+}
+
+// Init connects to the database and creates the configuration table if needed.
+func (s *ConfigurationStore) Init(ctx context.Context, md configuration.Metadata) error {
+	err := s.metadata.InitWithMetadata(md.Properties)
+	if err != nil {
+		return err
+	}
+
+	connString, err := s.metadata.GetConnectionString(s.logger, authSqlite.GetConnectionStringOpts{})
+	if err != nil {
+		// Already logged
+		return err
+	}
+
+	s.db, err = sql.Open("sqlite", connString)
+	if err != nil {
+		return fmt.Errorf("failed to create connection: %w", err)
+	}
+
+	// If the database is in-memory, we can't have more than 1 open connection
+	if s.metadata.IsInMemoryDB() {
+		s.db.SetMaxOpenConns(1)
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	err = s.db.PingContext(pingCtx)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to ping: %w", err)
+	}
+
+	err = performMigrations(ctx, s.db, s.logger, migrationOptions{
+		TableName:         s.metadata.TableName,
+		MetadataTableName: s.metadata.MetadataTableName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to perform migrations: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the items with the given keys, or all items if no key is given.
+func (s *ConfigurationStore) Get(parentCtx context.Context, req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, s.metadata.Timeout)
+	defer cancel()
+
+	items, err := s.getItems(ctx, s.db, req.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &configuration.GetResponse{
+		Items: items,
+	}, nil
+}
+
+// getItems returns the items with the given keys, or all items if keys is empty.
+func (s *ConfigurationStore) getItems(ctx context.Context, db querier, keys []string) (map[string]*configuration.Item, error) {
+	//nolint:gosec
+	query := "SELECT key, value, version, metadata FROM " + s.metadata.TableName
+	args := make([]any, len(keys))
+	if len(keys) > 0 {
+		// SQLite doesn't support passing an array for an IN clause, so we need to build a custom query
+		query += " WHERE key IN (" + strings.Repeat("?,", len(keys)-1) + "?)"
+		for i, key := range keys {
+			args[i] = key
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration items: %w", err)
+	}
+	defer rows.Close()
+
+	items := make(map[string]*configuration.Item)
+	for rows.Next() {
+		var (
+			key    string
+			mdJSON string
+			item   configuration.Item
+		)
+		err = rows.Scan(&key, &item.Value, &item.Version, &mdJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration items: %w", err)
+		}
+		err = json.Unmarshal([]byte(mdJSON), &item.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata of configuration item %s: %w", key, err)
+		}
+		if item.Metadata == nil {
+			item.Metadata = map[string]string{}
+		}
+		items[key] = &item
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration items: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetComponentMetadata returns the metadata of the component.
+func (s *ConfigurationStore) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
+	metadataStruct := metadata{}
+	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.ConfigurationStoreType)
+	return
+}
+
+func (s *ConfigurationStore) Close() error {
+	s.stopPolling()
+
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
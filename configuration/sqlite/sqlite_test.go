@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/configuration"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+func newTestStore(t *testing.T) *ConfigurationStore {
+	t.Helper()
+
+	s := NewSQLiteConfigurationStore(logger.NewLogger("test")).(*ConfigurationStore)
+	err := s.Init(t.Context(), configuration.Metadata{Base: contribMetadata.Base{
+		Properties: map[string]string{
+			"connectionString": filepath.Join(t.TempDir(), "test.db"),
+			"pollInterval":     "100ms",
+		},
+	}})
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+
+	return s
+}
+
+func TestMetadata(t *testing.T) {
+	m := metadata{}
+
+	err := m.InitWithMetadata(map[string]string{"connectionString": "data.db"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultTableName, m.TableName)
+	assert.Equal(t, defaultMetadataTableName, m.MetadataTableName)
+	assert.Equal(t, defaultPollInterval, m.PollInterval)
+
+	err = m.InitWithMetadata(map[string]string{"connectionString": "data.db", "tableName": "bad-name"})
+	require.Error(t, err)
+
+	err = m.InitWithMetadata(map[string]string{"connectionString": "data.db", "pollInterval": "1ms"})
+	require.Error(t, err)
+}
+
+func TestGetAndWrite(t *testing.T) {
+	s := newTestStore(t)
+
+	res, err := s.Set(t.Context(), &configuration.SetRequest{
+		Items: map[string]*configuration.Item{
+			"key1": {Value: "value1"},
+			"key2": {Value: "value2", Metadata: map[string]string{"a": "b"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key1": "1", "key2": "1"}, res.Versions)
+
+	get, err := s.Get(t.Context(), &configuration.GetRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*configuration.Item{
+		"key1": {Value: "value1", Version: "1", Metadata: map[string]string{}},
+		"key2": {Value: "value2", Version: "1", Metadata: map[string]string{"a": "b"}},
+	}, get.Items)
+
+	t.Run("update with the current version", func(t *testing.T) {
+		res, err := s.Set(t.Context(), &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key1": {Value: "value1b", Version: "1"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key1": "2"}, res.Versions)
+
+		get, err := s.Get(t.Context(), &configuration.GetRequest{Keys: []string{"key1", "missing"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{
+			"key1": {Value: "value1b", Version: "2", Metadata: map[string]string{}},
+		}, get.Items)
+	})
+
+	t.Run("version mismatch writes nothing", func(t *testing.T) {
+		_, err := s.Set(t.Context(), &configuration.SetRequest{
+			Items: map[string]*configuration.Item{
+				"key1": {Value: "value1c", Version: "1"},
+				"key2": {Value: "value2c"},
+			},
+		})
+		require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+
+		get, err := s.Get(t.Context(), &configuration.GetRequest{Keys: []string{"key2"}})
+		require.NoError(t, err)
+		assert.Equal(t, "value2", get.Items["key2"].Value)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := s.Delete(t.Context(), &configuration.DeleteRequest{
+			Keys:     []string{"key1", "key2"},
+			Versions: map[string]string{"key1": "1"},
+		})
+		require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+
+		err = s.Delete(t.Context(), &configuration.DeleteRequest{
+			Keys:     []string{"key1", "key2", "missing"},
+			Versions: map[string]string{"key1": "2"},
+		})
+		require.NoError(t, err)
+
+		get, err := s.Get(t.Context(), &configuration.GetRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, get.Items)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	s := newTestStore(t)
+
+	_, err := s.Set(t.Context(), &configuration.SetRequest{
+		Items: map[string]*configuration.Item{
+			"key1": {Value: "value1"},
+			"key2": {Value: "value2"},
+		},
+	})
+	require.NoError(t, err)
+
+	events := make(chan *configuration.UpdateEvent, 10)
+	handler := func(ctx context.Context, e *configuration.UpdateEvent) error {
+		events <- e
+		return nil
+	}
+	id, err := s.Subscribe(t.Context(), &configuration.SubscribeRequest{Keys: []string{"key1", "key2"}}, handler)
+	require.NoError(t, err)
+
+	// Changes are made in a transaction so they're detected by the same poll.
+	// Changes to key3 are not notified, as it's not watched.
+	tx, err := s.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(t.Context(), "UPDATE configuration SET value = 'changed', version = '5' WHERE key = 'key1'")
+	require.NoError(t, err)
+	_, err = tx.ExecContext(t.Context(), "DELETE FROM configuration WHERE key = 'key2'")
+	require.NoError(t, err)
+	_, err = tx.ExecContext(t.Context(), "INSERT INTO configuration (key, value) VALUES ('key3', 'value3')")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	select {
+	case e := <-events:
+		assert.Equal(t, id, e.ID)
+		assert.Equal(t, map[string]*configuration.Item{
+			"key1": {Value: "changed", Version: "5", Metadata: map[string]string{}},
+			"key2": {},
+		}, e.Items)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the update event")
+	}
+
+	require.NoError(t, s.Unsubscribe(t.Context(), &configuration.UnsubscribeRequest{ID: id}))
+	require.Error(t, s.Unsubscribe(t.Context(), &configuration.UnsubscribeRequest{ID: id}))
+
+	_, err = s.Set(t.Context(), &configuration.SetRequest{
+		Items: map[string]*configuration.Item{"key1": {Value: "again"}},
+	})
+	require.NoError(t, err)
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected update event after unsubscribing: %v", e)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
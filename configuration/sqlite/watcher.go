@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/dapr/components-contrib/configuration"
+)
+
+// Subscribe notifies changes to the items, which are detected by polling the table.
+// The table is polled from the first subscription until the store is closed.
+func (s *ConfigurationStore) Subscribe(ctx context.Context, req *configuration.SubscribeRequest, handler configuration.UpdateHandler) (string, error) {
+	sub, err := s.subs.Add(req, handler)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.startPolling(ctx)
+	if err != nil {
+		s.subs.Remove(sub.ID)
+		return "", err
+	}
+
+	return sub.ID, nil
+}
+
+// Unsubscribe implements configuration.Store.
+func (s *ConfigurationStore) Unsubscribe(ctx context.Context, req *configuration.UnsubscribeRequest) error {
+	_, err := s.subs.Remove(req.ID)
+	return err
+}
+
+// startPolling starts polling the table, if not already polled.
+// The current items are read before returning, so changes made afterwards are notified.
+func (s *ConfigurationStore) startPolling(parentCtx context.Context) error {
+	s.pollLock.Lock()
+	defer s.pollLock.Unlock()
+
+	if s.pollCancel != nil {
+		return nil
+	}
+
+	getCtx, getCancel := context.WithTimeout(parentCtx, s.metadata.Timeout)
+	items, err := s.getItems(getCtx, s.db, nil)
+	getCancel()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.pollCancel = cancel
+	s.pollDone = done
+
+	go func() {
+		defer close(done)
+		s.poll(ctx, items)
+	}()
+
+	return nil
+}
+
+// stopPolling stops polling the table.
+func (s *ConfigurationStore) stopPolling() {
+	s.pollLock.Lock()
+	defer s.pollLock.Unlock()
+
+	if s.pollCancel == nil {
+		return
+	}
+	s.pollCancel()
+	<-s.pollDone
+	s.pollCancel = nil
+	s.pollDone = nil
+}
+
+// poll reads the items periodically, and notifies the subscribers of the items that changed since the previous poll.
+// Deleted items are notified as empty items.
+func (s *ConfigurationStore) poll(ctx context.Context, items map[string]*configuration.Item) {
+	ticker := time.NewTicker(s.metadata.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		getCtx, getCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+		current, err := s.getItems(getCtx, s.db, nil)
+		getCancel()
+		if err != nil {
+			if ctx.Err() == nil {
+				s.logger.Warnf("Failed to poll configuration table %s: %v", s.metadata.TableName, err)
+			}
+			continue
+		}
+
+		changed := configuration.ChangedItems(items, current)
+		items = current
+		s.subs.Notify(ctx, changed)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sqltransactions "github.com/dapr/components-contrib/common/component/sql/transactions"
+	"github.com/dapr/components-contrib/configuration"
+)
+
+// Set writes items in a transaction, so all items are written atomically.
+// If the current version of an item is an integer, the new version is incremented, otherwise it's 1.
+func (s *ConfigurationStore) Set(parentCtx context.Context, req *configuration.SetRequest) (*configuration.SetResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, errors.New("at least one item is required")
+	}
+	keys := make([]string, 0, len(req.Items))
+	for key, item := range req.Items {
+		if key == "" {
+			return nil, errors.New("key can't be empty")
+		}
+		if item == nil {
+			return nil, fmt.Errorf("item of key %s is nil", key)
+		}
+		keys = append(keys, key)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.metadata.Timeout)
+	defer cancel()
+
+	versions, err := sqltransactions.ExecuteInTransaction(ctx, s.logger, s.db, func(ctx context.Context, tx *sql.Tx) (map[string]string, error) {
+		current, err := s.getItems(ctx, tx, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		versions := make(map[string]string, len(keys))
+		for _, key := range keys {
+			var version string
+			if item, ok := current[key]; ok {
+				version = item.Version
+			}
+			if req.Items[key].Version != "" && req.Items[key].Version != version {
+				return nil, fmt.Errorf("%w: key %s", configuration.ErrVersionMismatch, key)
+			}
+			versions[key] = nextVersion(version)
+		}
+
+		//nolint:gosec
+		stmt := `INSERT INTO ` + s.metadata.TableName + ` (key, value, version, metadata, update_time)
+			VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+			ON CONFLICT (key) DO UPDATE SET
+				value = excluded.value,
+				version = excluded.version,
+				metadata = excluded.metadata,
+				update_time = CURRENT_TIMESTAMP`
+		for _, key := range keys {
+			item := req.Items[key]
+			md := item.Metadata
+			if md == nil {
+				md = map[string]string{}
+			}
+			mdJSON, err := json.Marshal(md)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode metadata of configuration item %s: %w", key, err)
+			}
+			_, err = tx.ExecContext(ctx, stmt, key, item.Value, versions[key], string(mdJSON))
+			if err != nil {
+				return nil, fmt.Errorf("failed to write configuration item %s: %w", key, err)
+			}
+		}
+		return versions, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &configuration.SetResponse{
+		Versions: versions,
+	}, nil
+}
+
+// Delete deletes items in a transaction, so all items are deleted atomically.
+func (s *ConfigurationStore) Delete(parentCtx context.Context, req *configuration.DeleteRequest) error {
+	if len(req.Keys) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, s.metadata.Timeout)
+	defer cancel()
+
+	_, err := sqltransactions.ExecuteInTransaction(ctx, s.logger, s.db, func(ctx context.Context, tx *sql.Tx) (struct{}, error) {
+		if len(req.Versions) > 0 {
+			current, err := s.getItems(ctx, tx, req.Keys)
+			if err != nil {
+				return struct{}{}, err
+			}
+			for _, key := range req.Keys {
+				expected := req.Versions[key]
+				if expected == "" {
+					continue
+				}
+				item, ok := current[key]
+				if !ok || item.Version != expected {
+					return struct{}{}, fmt.Errorf("%w: key %s", configuration.ErrVersionMismatch, key)
+				}
+			}
+		}
+
+		args := make([]any, len(req.Keys))
+		for i, key := range req.Keys {
+			args[i] = key
+		}
+		//nolint:gosec
+		stmt := "DELETE FROM " + s.metadata.TableName + " WHERE key IN (" + strings.Repeat("?,", len(req.Keys)-1) + "?)"
+		_, err := tx.ExecContext(ctx, stmt, args...)
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to delete configuration items: %w", err)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// nextVersion returns the version of an item after an update.
+func nextVersion(version string) string {
+	n, err := strconv.Atoi(version)
+	if err != nil || n < 0 {
+		return "1"
+	}
+	return strconv.Itoa(n + 1)
+}
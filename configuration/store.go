@@ -15,6 +15,7 @@ package configuration
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"github.com/dapr/components-contrib/metadata"
@@ -39,5 +40,18 @@ type Store interface {
 	io.Closer
 }
 
+// Writer is an optional interface for configuration stores that can set and delete items.
+type Writer interface {
+	// Set creates or updates items, assigning them a new version.
+	// Items with a Version are only written if it matches their current version: otherwise, no item is written and ErrVersionMismatch is returned.
+	Set(ctx context.Context, req *SetRequest) (*SetResponse, error)
+	// Delete deletes items. Deleting an item that doesn't exist is not an error.
+	// Keys with a version in req.Versions are only deleted if it matches their current version: otherwise, no item is deleted and ErrVersionMismatch is returned.
+	Delete(ctx context.Context, req *DeleteRequest) error
+}
+
+// ErrVersionMismatch is returned by Writer when the version of an item doesn't match the expected one.
+var ErrVersionMismatch = errors.New("configuration item version does not match the expected one")
+
 // UpdateHandler is the handler used to send event to daprd.
 type UpdateHandler func(ctx context.Context, e *UpdateEvent) error
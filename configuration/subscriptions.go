@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/dapr/kit/logger"
+)
+
+// Subscriptions keeps track of the subscriptions of a Store, and sends them the updates of the items they watch.
+type Subscriptions struct {
+	lock   sync.RWMutex
+	subs   map[string]*Subscription
+	logger logger.Logger
+}
+
+// Subscription is a subscription created with Store.Subscribe.
+type Subscription struct {
+	ID       string
+	Keys     []string
+	Metadata map[string]string
+
+	keys    map[string]struct{}
+	handler UpdateHandler
+	logger  logger.Logger
+}
+
+// NewSubscriptions returns a new Subscriptions object.
+func NewSubscriptions(logger logger.Logger) *Subscriptions {
+	return &Subscriptions{
+		subs:   map[string]*Subscription{},
+		logger: logger,
+	}
+}
+
+// Add creates a new subscription.
+func (s *Subscriptions) Add(req *SubscribeRequest, handler UpdateHandler) (*Subscription, error) {
+	if handler == nil {
+		return nil, errors.New("handler is required")
+	}
+
+	sub := &Subscription{
+		ID:       uuid.NewString(),
+		Keys:     req.Keys,
+		Metadata: req.Metadata,
+		handler:  handler,
+		logger:   s.logger,
+	}
+	if len(req.Keys) > 0 {
+		sub.keys = make(map[string]struct{}, len(req.Keys))
+		for _, key := range req.Keys {
+			sub.keys[key] = struct{}{}
+		}
+	}
+
+	s.lock.Lock()
+	s.subs[sub.ID] = sub
+	s.lock.Unlock()
+
+	return sub, nil
+}
+
+// Remove deletes a subscription.
+func (s *Subscriptions) Remove(id string) (*Subscription, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sub, ok := s.subs[id]
+	if !ok {
+		return nil, fmt.Errorf("subscription with ID %s does not exist", id)
+	}
+	delete(s.subs, id)
+	return sub, nil
+}
+
+// Len returns the number of subscriptions.
+func (s *Subscriptions) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.subs)
+}
+
+// Notify sends the updated items to each subscription that watches some of them.
+func (s *Subscriptions) Notify(ctx context.Context, items map[string]*Item) {
+	if len(items) == 0 {
+		return
+	}
+
+	s.lock.RLock()
+	subs := make([]*Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.lock.RUnlock()
+
+	for _, sub := range subs {
+		sub.Notify(ctx, items)
+	}
+}
+
+// Watches returns true if the subscription watches the item with the given key.
+func (sub *Subscription) Watches(key string) bool {
+	if sub.keys == nil {
+		return true
+	}
+	_, ok := sub.keys[key]
+	return ok
+}
+
+// Notify calls the handler of the subscription with the updated items it watches, if any.
+func (sub *Subscription) Notify(ctx context.Context, items map[string]*Item) {
+	e := &UpdateEvent{
+		ID:    sub.ID,
+		Items: make(map[string]*Item, len(items)),
+	}
+	for key, item := range items {
+		if sub.Watches(key) {
+			e.Items[key] = item
+		}
+	}
+	if len(e.Items) == 0 {
+		return
+	}
+
+	err := sub.handler(ctx, e)
+	if err != nil {
+		sub.logger.Errorf("Error handling update of configuration items for subscription %s: %v", sub.ID, err)
+	}
+}
+
+// ChangedItems compares two sets of items, returning those that were added or changed in current.
+// Items that were deleted are returned as empty items.
+func ChangedItems(previous, current map[string]*Item) map[string]*Item {
+	changed := make(map[string]*Item)
+	for key, item := range current {
+		old, ok := previous[key]
+		if !ok || old.Value != item.Value || old.Version != item.Version || !maps.Equal(old.Metadata, item.Metadata) {
+			changed[key] = item
+		}
+	}
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			changed[key] = &Item{}
+		}
+	}
+	return changed
+}
@@ -17,6 +17,7 @@ package anthropic
 import (
 	"context"
 	"reflect"
+	"slices"
 
 	"github.com/dapr/components-contrib/conversation"
 	"github.com/dapr/components-contrib/conversation/langchaingokit"
@@ -57,7 +58,8 @@ func (a *Anthropic) Init(ctx context.Context, meta conversation.Metadata) error
 	}
 
 	if httpClient := conversation.BuildHTTPClient(); httpClient != nil {
-		options = append(options, anthropic.WithHTTPClient(httpClient))
+		// langchaingo sends every binary part as an image, so document parts are rewritten as document blocks
+		options = append(options, anthropic.WithHTTPClient(conversation.NewAnthropicDocumentClient(httpClient)))
 	}
 
 	llm, err := anthropic.New(options...)
@@ -66,6 +68,10 @@ func (a *Anthropic) Init(ctx context.Context, meta conversation.Metadata) error
 	}
 
 	a.LLM.Model = llm
+	a.LLM.RateLimiter = conversation.NewRateLimiter(m.RateLimitMetadata, nil)
+	a.LLM.SupportedContent = conversation.Capabilities{
+		BinaryMIMETypes: slices.Concat(conversation.CommonImageMIMETypes, conversation.DocumentMIMETypes),
+	}
 
 	if m.ResponseCacheTTL != nil {
 		cachedModel, cacheErr := conversation.CacheResponses(ctx, m.ResponseCacheTTL, a.LLM.Model)
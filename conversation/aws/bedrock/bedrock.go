@@ -17,6 +17,8 @@ package bedrock
 import (
 	"context"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	awsCommon "github.com/dapr/components-contrib/common/aws"
@@ -29,6 +31,7 @@ import (
 	kmeta "github.com/dapr/kit/metadata"
 
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/smithy-go/middleware"
 	"github.com/tmc/langchaingo/llms/bedrock"
 )
 
@@ -52,6 +55,8 @@ type AWSBedrockMetadata struct {
 	AssumeRoleArn   string `json:"assumeRoleArn"`
 	TrustAnchorArn  string `json:"trustAnchorArn"`
 	TrustProfileArn string `json:"trustProfileArn"`
+
+	conversation.RateLimitMetadata `json:",inline" mapstructure:",squash"`
 }
 
 func NewAWSBedrock(logger logger.Logger) conversation.Conversation {
@@ -87,7 +92,21 @@ func (b *AWSBedrock) Init(ctx context.Context, meta conversation.Metadata) error
 		return err
 	}
 
-	bedrockClient := bedrockruntime.NewFromConfig(awsConfig)
+	var clientOpts []func(*bedrockruntime.Options)
+	supportedContent := conversation.Capabilities{
+		BinaryMIMETypes: conversation.CommonImageMIMETypes,
+	}
+	if isAnthropicModel(m.Model) {
+		// Anthropic models accept documents, which langchaingo sends as images in the request body
+		clientOpts = append(clientOpts, func(o *bedrockruntime.Options) {
+			o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+				return stack.Initialize.Add(anthropicDocumentsMiddleware, middleware.After)
+			})
+		})
+		supportedContent.BinaryMIMETypes = slices.Concat(conversation.CommonImageMIMETypes, conversation.DocumentMIMETypes)
+	}
+
+	bedrockClient := bedrockruntime.NewFromConfig(awsConfig, clientOpts...)
 
 	opts := []bedrock.Option{bedrock.WithClient(bedrockClient)}
 	if m.Model != "" {
@@ -103,6 +122,8 @@ func (b *AWSBedrock) Init(ctx context.Context, meta conversation.Metadata) error
 	}
 
 	b.LLM.Model = llm
+	b.LLM.RateLimiter = conversation.NewRateLimiter(m.RateLimitMetadata, nil)
+	b.LLM.SupportedContent = supportedContent
 
 	if m.ResponseCacheTTL != nil {
 		cachedModel, cacheErr := conversation.CacheResponses(ctx, m.ResponseCacheTTL, b.LLM.Model)
@@ -115,6 +136,35 @@ func (b *AWSBedrock) Init(ctx context.Context, meta conversation.Metadata) error
 	return nil
 }
 
+// isAnthropicModel returns true if the model ID, or inference profile ID, is of an Anthropic model.
+func isAnthropicModel(model string) bool {
+	return strings.HasPrefix(model, "anthropic.") || strings.Contains(model, ".anthropic.")
+}
+
+// anthropicDocumentsMiddleware rewrites the image blocks holding a document in the body of requests to Anthropic models as document blocks.
+// It runs before the request is signed.
+var anthropicDocumentsMiddleware = middleware.InitializeMiddlewareFunc("AnthropicDocuments", func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	switch params := in.Parameters.(type) {
+	case *bedrockruntime.InvokeModelInput:
+		body, err := conversation.AnthropicDocumentBlocks(params.Body)
+		if err != nil {
+			return middleware.InitializeOutput{}, middleware.Metadata{}, err
+		}
+		rewritten := *params
+		rewritten.Body = body
+		in.Parameters = &rewritten
+	case *bedrockruntime.InvokeModelWithResponseStreamInput:
+		body, err := conversation.AnthropicDocumentBlocks(params.Body)
+		if err != nil {
+			return middleware.InitializeOutput{}, middleware.Metadata{}, err
+		}
+		rewritten := *params
+		rewritten.Body = body
+		in.Parameters = &rewritten
+	}
+	return next.HandleInitialize(ctx, in)
+})
+
 func (b *AWSBedrock) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 	metadataStruct := AWSBedrockMetadata{}
 	_ = metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.ConversationType)
@@ -16,6 +16,10 @@ limitations under the License.
 package bedrock
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -115,6 +119,83 @@ func TestInit(t *testing.T) {
 	}
 }
 
+func TestDocuments(t *testing.T) {
+	var received struct {
+		Messages []struct {
+			Content []struct {
+				Type string `json:"type"`
+			} `json:"content"`
+		} `json:"messages"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, &received))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"type":"message","role":"assistant","content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}`))
+	}))
+	defer server.Close()
+
+	initBedrock := func(t *testing.T, model string) *AWSBedrock {
+		b := &AWSBedrock{
+			logger: logger.NewLogger("bedrock test"),
+		}
+		err := b.Init(t.Context(), conversation.Metadata{
+			Base: metadata.Base{
+				Properties: map[string]string{
+					"region":    "us-east-1",
+					"endpoint":  server.URL,
+					"accessKey": "test-key",
+					"secretKey": "test-secret",
+					"model":     model,
+				},
+			},
+		})
+		require.NoError(t, err)
+		return b
+	}
+
+	t.Run("anthropic models accept documents", func(t *testing.T) {
+		b := initBedrock(t, "us.anthropic.claude-3-5-sonnet-20241022-v2:0")
+		assert.True(t, b.Capabilities().SupportsMIMEType("application/pdf"))
+		assert.True(t, b.Capabilities().SupportsMIMEType("image/png"))
+
+		_, err := b.LLM.Model.GenerateContent(t.Context(), []llms.MessageContent{
+			{
+				Role: llms.ChatMessageTypeHuman,
+				Parts: []llms.ContentPart{
+					llms.TextPart("summarize"),
+					llms.BinaryPart("application/pdf", []byte("pdf")),
+					llms.BinaryPart("image/png", []byte("png")),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		var types []string
+		for _, message := range received.Messages {
+			for _, content := range message.Content {
+				types = append(types, content.Type)
+			}
+		}
+		assert.Equal(t, []string{"text", "document", "image"}, types)
+	})
+
+	t.Run("other models do not accept documents", func(t *testing.T) {
+		b := initBedrock(t, "amazon.titan-text-lite-v1")
+		assert.False(t, b.Capabilities().SupportsMIMEType("application/pdf"))
+		assert.True(t, b.Capabilities().SupportsMIMEType("image/png"))
+	})
+}
+
+func TestIsAnthropicModel(t *testing.T) {
+	assert.True(t, isAnthropicModel("anthropic.claude-3-haiku-20240307-v1:0"))
+	assert.True(t, isAnthropicModel("eu.anthropic.claude-3-5-sonnet-20240620-v1:0"))
+	assert.False(t, isAnthropicModel("amazon.nova-pro-v1:0"))
+	assert.False(t, isAnthropicModel(""))
+}
+
 func TestGetComponentMetadata(t *testing.T) {
 	b := &AWSBedrock{}
 	md := b.GetComponentMetadata()
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// ErrUnsupportedContent is returned when a request contains a content part the component cannot send to the LLM.
+var ErrUnsupportedContent = errors.New("unsupported content")
+
+// CommonImageMIMETypes are the image formats accepted by most vision capable LLM providers.
+var CommonImageMIMETypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+// Capabilities describes the input content a conversation component accepts on top of text and tool parts,
+// which are always supported.
+type Capabilities struct {
+	// ImageURL is true if llms.ImageURLContent parts are accepted.
+	ImageURL bool `json:"imageURL"`
+	// BinaryMIMETypes lists the MIME types accepted as llms.BinaryContent parts, for example "image/png" or "application/pdf".
+	// A "/*" suffix matches any subtype and "*/*" matches any MIME type.
+	BinaryMIMETypes []string `json:"binaryMIMETypes,omitempty"`
+}
+
+// CapabilitiesReporter is an optional interface implemented by conversation components to report the input content they accept.
+type CapabilitiesReporter interface {
+	Capabilities() Capabilities
+}
+
+// SupportsMIMEType returns true if BinaryContent with the given MIME type is accepted.
+func (c Capabilities) SupportsMIMEType(mimeType string) bool {
+	// ignore parameters such as "; charset=utf-8"
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	if mimeType == "" {
+		return false
+	}
+
+	for _, supported := range c.BinaryMIMETypes {
+		supported = strings.ToLower(supported)
+		switch {
+		case supported == "*/*", supported == mimeType:
+			return true
+		case strings.HasSuffix(supported, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(supported, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// UnsupportedContentError is returned by ValidateRequestContent. It wraps ErrUnsupportedContent.
+type UnsupportedContentError struct {
+	// ContentType is "image_url", the MIME type of a binary part, or the Go type of an unknown part.
+	ContentType string
+	// Reason is set when the part is malformed rather than unsupported.
+	Reason string
+}
+
+func (e *UnsupportedContentError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("%s '%s': %s", ErrUnsupportedContent, e.ContentType, e.Reason)
+	}
+	return fmt.Sprintf("%s '%s': the conversation component does not accept this content type", ErrUnsupportedContent, e.ContentType)
+}
+
+func (e *UnsupportedContentError) Unwrap() error {
+	return ErrUnsupportedContent
+}
+
+// ValidateRequestContent checks that all the message parts in a request are accepted by a component with the given capabilities.
+func ValidateRequestContent(r *Request, c Capabilities) error {
+	if r == nil || r.Message == nil {
+		return nil
+	}
+
+	for _, message := range *r.Message {
+		for _, part := range message.Parts {
+			switch p := part.(type) {
+			case llms.TextContent, llms.ToolCall, llms.ToolCallResponse:
+				// always supported
+			case llms.ImageURLContent:
+				if !c.ImageURL {
+					return &UnsupportedContentError{ContentType: "image_url"}
+				}
+				if p.URL == "" {
+					return &UnsupportedContentError{ContentType: "image_url", Reason: "url is empty"}
+				}
+			case llms.BinaryContent:
+				if p.MIMEType == "" {
+					return &UnsupportedContentError{ContentType: "binary", Reason: "MIME type is empty"}
+				}
+				if !c.SupportsMIMEType(p.MIMEType) {
+					return &UnsupportedContentError{ContentType: p.MIMEType}
+				}
+				if len(p.Data) == 0 {
+					return &UnsupportedContentError{ContentType: p.MIMEType, Reason: "data is empty"}
+				}
+			default:
+				return &UnsupportedContentError{ContentType: fmt.Sprintf("%T", part)}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func TestCapabilitiesSupportsMIMEType(t *testing.T) {
+	c := Capabilities{BinaryMIMETypes: []string{"image/*", "application/pdf"}}
+
+	assert.True(t, c.SupportsMIMEType("image/png"))
+	assert.True(t, c.SupportsMIMEType("IMAGE/JPEG"))
+	assert.True(t, c.SupportsMIMEType("application/pdf; version=1.7"))
+	assert.False(t, c.SupportsMIMEType("application/json"))
+	assert.False(t, c.SupportsMIMEType(""))
+	assert.False(t, Capabilities{}.SupportsMIMEType("image/png"))
+	assert.True(t, Capabilities{BinaryMIMETypes: []string{"*/*"}}.SupportsMIMEType("audio/wav"))
+}
+
+func TestValidateRequestContent(t *testing.T) {
+	request := func(parts ...llms.ContentPart) *Request {
+		return &Request{
+			Message: &[]llms.MessageContent{
+				{Role: llms.ChatMessageTypeHuman, Parts: parts},
+			},
+		}
+	}
+	vision := Capabilities{ImageURL: true, BinaryMIMETypes: CommonImageMIMETypes}
+
+	t.Run("text and tools are always supported", func(t *testing.T) {
+		r := request(
+			llms.TextContent{Text: "hi"},
+			llms.ToolCallResponse{ToolCallID: "1", Content: "done"},
+		)
+		require.NoError(t, ValidateRequestContent(r, Capabilities{}))
+		require.NoError(t, ValidateRequestContent(nil, Capabilities{}))
+	})
+
+	t.Run("images", func(t *testing.T) {
+		r := request(llms.ImageURLPart("https://example.com/a.png"), llms.BinaryPart("image/png", []byte{1}))
+		require.NoError(t, ValidateRequestContent(r, vision))
+
+		err := ValidateRequestContent(r, Capabilities{})
+		require.ErrorIs(t, err, ErrUnsupportedContent)
+		var contentErr *UnsupportedContentError
+		require.ErrorAs(t, err, &contentErr)
+		assert.Equal(t, "image_url", contentErr.ContentType)
+	})
+
+	t.Run("documents", func(t *testing.T) {
+		err := ValidateRequestContent(request(llms.BinaryPart("application/pdf", []byte{1})), vision)
+		require.ErrorIs(t, err, ErrUnsupportedContent)
+		assert.Contains(t, err.Error(), "application/pdf")
+	})
+
+	t.Run("malformed parts", func(t *testing.T) {
+		require.ErrorIs(t, ValidateRequestContent(request(llms.ImageURLContent{}), vision), ErrUnsupportedContent)
+		require.ErrorIs(t, ValidateRequestContent(request(llms.BinaryContent{Data: []byte{1}}), vision), ErrUnsupportedContent)
+		require.ErrorIs(t, ValidateRequestContent(request(llms.BinaryPart("image/png", nil)), vision), ErrUnsupportedContent)
+	})
+}
@@ -70,6 +70,7 @@ func (d *Deepseek) Init(ctx context.Context, meta conversation.Metadata) error {
 	}
 
 	d.LLM.Model = llm
+	d.LLM.RateLimiter = conversation.NewRateLimiter(md.RateLimitMetadata, nil)
 	d.md = md
 	return nil
 }
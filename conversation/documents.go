@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DocumentMIMETypes are the document formats accepted by LLM providers that support document inputs.
+var DocumentMIMETypes = []string{"application/pdf"}
+
+// AnthropicDocumentBlocks rewrites the image content blocks holding a document in an Anthropic Messages API request body as document blocks.
+// langchaingo sends every llms.BinaryContent part as an image block, while Anthropic expects documents in document blocks, which have the same source format.
+// Bodies without documents are returned unchanged.
+func AnthropicDocumentBlocks(body []byte) ([]byte, error) {
+	if !bytes.Contains(body, []byte(`"image"`)) {
+		return body, nil
+	}
+
+	var req map[string]json.RawMessage
+	err := json.Unmarshal(body, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request body: %w", err)
+	}
+	var messages []map[string]json.RawMessage
+	if json.Unmarshal(req["messages"], &messages) != nil {
+		return body, nil
+	}
+
+	changed := false
+	for _, message := range messages {
+		// Content can also be a string
+		var blocks []map[string]json.RawMessage
+		if json.Unmarshal(message["content"], &blocks) != nil {
+			continue
+		}
+
+		blocksChanged := false
+		for _, block := range blocks {
+			var blockType string
+			var source struct {
+				MediaType string `json:"media_type"`
+			}
+			if json.Unmarshal(block["type"], &blockType) != nil || blockType != "image" ||
+				json.Unmarshal(block["source"], &source) != nil || strings.HasPrefix(source.MediaType, "image/") {
+				continue
+			}
+			block["type"] = json.RawMessage(`"document"`)
+			blocksChanged = true
+		}
+		if blocksChanged {
+			message["content"], err = json.Marshal(blocks)
+			if err != nil {
+				return nil, err
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return body, nil
+	}
+
+	req["messages"], err = json.Marshal(messages)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(req)
+}
+
+// NewAnthropicDocumentClient returns a copy of client that rewrites the body of requests to the Anthropic Messages API with AnthropicDocumentBlocks.
+func NewAnthropicDocumentClient(client *http.Client) *http.Client {
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res := *client
+	res.Transport = &anthropicDocumentTransport{base: transport}
+	return &res
+}
+
+type anthropicDocumentTransport struct {
+	base http.RoundTripper
+}
+
+func (t *anthropicDocumentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	body, err = AnthropicDocumentBlocks(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// RoundTrippers must not modify the request
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	return t.base.RoundTrip(req)
+}
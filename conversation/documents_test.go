@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversation
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/anthropic"
+)
+
+func TestAnthropicDocumentBlocks(t *testing.T) {
+	t.Run("documents are rewritten", func(t *testing.T) {
+		body := []byte(`{"model":"claude","max_tokens":10,"messages":[` +
+			`{"role":"user","content":[` +
+			`{"type":"text","text":"summarize"},` +
+			`{"type":"image","source":{"type":"base64","media_type":"image/png","data":"cG5n"}},` +
+			`{"type":"image","source":{"type":"base64","media_type":"application/pdf","data":"cGRm"}}]},` +
+			`{"role":"assistant","content":"ok"}]}`)
+
+		res, err := AnthropicDocumentBlocks(body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"model":"claude","max_tokens":10,"messages":[`+
+			`{"role":"user","content":[`+
+			`{"type":"text","text":"summarize"},`+
+			`{"type":"image","source":{"type":"base64","media_type":"image/png","data":"cG5n"}},`+
+			`{"type":"document","source":{"type":"base64","media_type":"application/pdf","data":"cGRm"}}]},`+
+			`{"role":"assistant","content":"ok"}]}`, string(res))
+	})
+
+	t.Run("bodies without documents are unchanged", func(t *testing.T) {
+		for _, body := range []string{
+			`{"messages":[{"role":"user","content":"hi"}]}`,
+			`{"messages":[{"role":"user","content":[{"type":"image","source":{"type":"base64","media_type":"image/png","data":"cG5n"}}]}]}`,
+			`{"prompt":"image"}`,
+		} {
+			res, err := AnthropicDocumentBlocks([]byte(body))
+			require.NoError(t, err)
+			assert.Equal(t, body, string(res))
+		}
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		_, err := AnthropicDocumentBlocks([]byte(`{"type":"image"`))
+		require.Error(t, err)
+	})
+}
+
+func TestNewAnthropicDocumentClient(t *testing.T) {
+	var received struct {
+		Messages []struct {
+			Content []struct {
+				Type   string `json:"type"`
+				Source struct {
+					MediaType string `json:"media_type"`
+				} `json:"source"`
+			} `json:"content"`
+		} `json:"messages"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(body)), r.ContentLength)
+		assert.NoError(t, json.Unmarshal(body, &received))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude","content":[{"type":"text","text":"ok"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}`))
+	}))
+	defer server.Close()
+
+	original := BuildHTTPClient()
+	client := NewAnthropicDocumentClient(original)
+	assert.NotSame(t, original.Transport, client.Transport)
+
+	llm, err := anthropic.New(
+		anthropic.WithToken("key"),
+		anthropic.WithBaseURL(server.URL),
+		anthropic.WithHTTPClient(client),
+	)
+	require.NoError(t, err)
+
+	res, err := llm.GenerateContent(t.Context(), []llms.MessageContent{
+		{
+			Role: llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{
+				llms.TextPart("summarize"),
+				llms.BinaryPart("application/pdf", []byte("pdf")),
+				llms.BinaryPart("image/png", []byte("png")),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res.Choices[0].Content)
+
+	require.Len(t, received.Messages, 1)
+	require.Len(t, received.Messages[0].Content, 3)
+	assert.Equal(t, "document", received.Messages[0].Content[1].Type)
+	assert.Equal(t, "application/pdf", received.Messages[0].Content[1].Source.MediaType)
+	assert.Equal(t, "image", received.Messages[0].Content[2].Type)
+}
@@ -46,22 +46,19 @@ func (e *Echo) Init(ctx context.Context, meta conversation.Metadata) error {
 	return nil
 }
 
+// Capabilities implements conversation.CapabilitiesReporter. Echo accepts any content.
+func (e *Echo) Capabilities() conversation.Capabilities {
+	return conversation.Capabilities{
+		ImageURL:        true,
+		BinaryMIMETypes: []string{"*/*"},
+	}
+}
+
 func (e *Echo) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 	// Echo component has no metadata
 	return
 }
 
-// approximateTokensFromWords estimates the number of tokens based on word count.
-// ref: https://help.openai.com/en/articles/4936856-what-are-tokens-and-how-to-count-them
-func approximateTokensFromWords(text string) uint64 {
-	if text == "" {
-		return 0
-	}
-
-	// split on whitespace to count words
-	return uint64(len(strings.Fields(text)))
-}
-
 // Converse returns one output per input message.
 func (e *Echo) Converse(ctx context.Context, r *conversation.Request) (res *conversation.Response, err error) {
 	if r == nil || r.Message == nil {
@@ -75,6 +72,10 @@ func (e *Echo) Converse(ctx context.Context, r *conversation.Request) (res *conv
 		}, nil
 	}
 
+	if err = conversation.ValidateRequestContent(r, e.Capabilities()); err != nil {
+		return nil, err
+	}
+
 	// if we get tools, respond with tool calls for each tool
 	var toolCalls []llms.ToolCall
 	if r.Tools != nil {
@@ -137,6 +138,11 @@ func (e *Echo) Converse(ctx context.Context, r *conversation.Request) (res *conv
 			case llms.ToolCallResponse:
 				// show tool responses on the request like on multi-turn conversations
 				contentFromMessaged = append(contentFromMessaged, fmt.Sprintf("Tool Response for tool ID '%s' with name '%s': %s", p.ToolCallID, p.Name, p.Content))
+			case llms.ImageURLContent:
+				// describe images and documents instead of echoing their data back
+				contentFromMessaged = append(contentFromMessaged, fmt.Sprintf("Image URL: %s", p.URL))
+			case llms.BinaryContent:
+				contentFromMessaged = append(contentFromMessaged, fmt.Sprintf("Binary content of type '%s' (%d bytes)", p.MIMEType, len(p.Data)))
 			default:
 				return nil, fmt.Errorf("found invalid content type as input for %v", p)
 			}
@@ -166,7 +172,7 @@ func (e *Echo) Converse(ctx context.Context, r *conversation.Request) (res *conv
 		Choices:    []conversation.Choice{choice},
 	}
 
-	tokenCount := approximateTokensFromWords(responseContent)
+	tokenCount := conversation.ApproximateTokensFromWords(responseContent)
 	usage := &conversation.Usage{
 		CompletionTokens: tokenCount,
 		PromptTokens:     tokenCount,
@@ -334,3 +334,35 @@ func TestConverseAlpha2(t *testing.T) {
 		})
 	}
 }
+
+func TestConverseMultimodal(t *testing.T) {
+	e := NewEcho(logger.NewLogger("echo test"))
+	require.NoError(t, e.Init(t.Context(), conversation.Metadata{}))
+
+	messages := []llms.MessageContent{
+		{
+			Role: llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{
+				llms.TextContent{Text: "describe these"},
+				llms.ImageURLPart("https://example.com/cat.png"),
+				llms.BinaryPart("application/pdf", []byte("%PDF-1.7")),
+			},
+		},
+	}
+
+	r, err := e.Converse(t.Context(), &conversation.Request{Message: &messages})
+	require.NoError(t, err)
+	require.Len(t, r.Outputs, 1)
+	assert.Equal(t, "describe these\nImage URL: https://example.com/cat.png\nBinary content of type 'application/pdf' (8 bytes)", r.Outputs[0].Choices[0].Message.Content)
+
+	t.Run("empty binary content is rejected", func(t *testing.T) {
+		messages := []llms.MessageContent{
+			{
+				Role:  llms.ChatMessageTypeHuman,
+				Parts: []llms.ContentPart{llms.BinaryPart("image/png", nil)},
+			},
+		}
+		_, err := e.Converse(t.Context(), &conversation.Request{Message: &messages})
+		require.ErrorIs(t, err, conversation.ErrUnsupportedContent)
+	})
+}
@@ -17,6 +17,7 @@ package googleai
 import (
 	"context"
 	"reflect"
+	"slices"
 
 	"github.com/tmc/langchaingo/llms/openai"
 
@@ -62,6 +63,13 @@ func (g *GoogleAI) Init(ctx context.Context, meta conversation.Metadata) error {
 	}
 
 	g.LLM.Model = llm
+	g.LLM.RateLimiter = conversation.NewRateLimiter(md.RateLimitMetadata, nil)
+	g.LLM.SupportedContent = conversation.Capabilities{
+		ImageURL:        true,
+		BinaryMIMETypes: slices.Concat(conversation.CommonImageMIMETypes, conversation.DocumentMIMETypes),
+	}
+	// Gemini's OpenAI compatible API accepts both images and PDFs as data URLs
+	g.LLM.BinaryAsImageURL = true
 
 	if md.ResponseCacheTTL != nil {
 		cachedModel, cacheErr := conversation.CacheResponses(ctx, md.ResponseCacheTTL, g.LLM.Model)
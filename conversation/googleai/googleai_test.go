@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package googleai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/conversation"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+func TestCapabilities(t *testing.T) {
+	c := NewGoogleAI(logger.NewLogger("googleai test"))
+	err := c.Init(t.Context(), conversation.Metadata{
+		Base: metadata.Base{
+			Properties: map[string]string{"key": "test-key"},
+		},
+	})
+	require.NoError(t, err)
+
+	reporter, ok := c.(conversation.CapabilitiesReporter)
+	require.True(t, ok)
+	capabilities := reporter.Capabilities()
+	assert.True(t, capabilities.SupportsMIMEType("application/pdf"))
+	assert.True(t, capabilities.SupportsMIMEType("image/png"))
+	assert.False(t, capabilities.SupportsMIMEType("audio/wav"))
+}
@@ -69,6 +69,7 @@ func (h *Huggingface) Init(ctx context.Context, meta conversation.Metadata) erro
 	}
 
 	h.LLM.Model = llm
+	h.LLM.RateLimiter = conversation.NewRateLimiter(m.RateLimitMetadata, nil)
 
 	if m.ResponseCacheTTL != nil {
 		cachedModel, cacheErr := conversation.CacheResponses(ctx, m.ResponseCacheTTL, h.LLM.Model)
@@ -30,25 +30,65 @@ type LLM struct {
 	llms.Model
 	model  string
 	logger logger.Logger
+
+	// RateLimiter is optional and enforces the client-side limits configured in the component metadata.
+	RateLimiter *conversation.RateLimiter
+
+	// SupportedContent is the non-text input content the provider accepts.
+	SupportedContent conversation.Capabilities
+	// BinaryAsImageURL sends binary parts as base64 data URLs.
+	// This is needed for OpenAI compatible APIs, as langchaingo serializes BinaryContent in a format they do not accept.
+	BinaryAsImageURL bool
+}
+
+// Capabilities implements conversation.CapabilitiesReporter.
+func (a *LLM) Capabilities() conversation.Capabilities {
+	return a.SupportedContent
+}
+
+// SetRateLimitStore implements conversation.RateLimitStoreSetter.
+func (a *LLM) SetRateLimitStore(store conversation.RateLimitStore) {
+	if a.RateLimiter != nil {
+		a.RateLimiter.SetStore(store)
+	}
 }
 
 func (a *LLM) Converse(ctx context.Context, r *conversation.Request) (res *conversation.Response, err error) {
+	err = conversation.ValidateRequestContent(r, a.SupportedContent)
+	if err != nil {
+		return nil, err
+	}
+
+	var reservation *conversation.RateLimitReservation
+	if a.RateLimiter != nil {
+		reservation, err = a.RateLimiter.Reserve(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	opts := getOptionsFromRequest(r, a.logger)
 
 	var messages []llms.MessageContent
 	if r.Message != nil {
 		messages = *r.Message
+		if a.BinaryAsImageURL {
+			messages = binaryPartsToImageURLs(messages)
+		}
 	}
 
 	resp, err := a.GenerateContent(ctx, messages, opts...)
 	if err != nil {
+		a.commitRateLimit(ctx, reservation, nil)
 		return nil, err
 	}
 
 	outputs, usage, err := a.NormalizeConverseResult(resp.Choices)
 	if err != nil {
+		a.commitRateLimit(ctx, reservation, nil)
 		return nil, err
 	}
+	a.commitRateLimit(ctx, reservation, usage)
 
 	return &conversation.Response{
 		Model:   a.model,
@@ -57,6 +97,15 @@ func (a *LLM) Converse(ctx context.Context, r *conversation.Request) (res *conve
 	}, nil
 }
 
+func (a *LLM) commitRateLimit(ctx context.Context, reservation *conversation.RateLimitReservation, usage *conversation.Usage) {
+	if reservation == nil {
+		return
+	}
+	if err := a.RateLimiter.Commit(ctx, reservation, usage); err != nil && a.logger != nil {
+		a.logger.Warnf("failed to record token usage for rate limiting: %v", err)
+	}
+}
+
 // NOTE: ollama does not provide a stop reason at all,
 // so server side best we can do is say unknown if this is empty.
 func normalizeFinishReason(stopReason string) string {
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package langchaingokit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/dapr/components-contrib/conversation"
+)
+
+type fakeModel struct {
+	messages []llms.MessageContent
+}
+
+func (f *fakeModel) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
+	f.messages = messages
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{Content: "ok", StopReason: "stop"}},
+	}, nil
+}
+
+func (f *fakeModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, f, prompt, options...)
+}
+
+func TestConverseContent(t *testing.T) {
+	messages := []llms.MessageContent{
+		{
+			Role: llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{
+				llms.TextContent{Text: "what is this?"},
+				llms.BinaryPart("image/png", []byte("png")),
+			},
+		},
+	}
+
+	t.Run("unsupported content is rejected before calling the model", func(t *testing.T) {
+		model := &fakeModel{}
+		llm := &LLM{Model: model}
+
+		_, err := llm.Converse(t.Context(), &conversation.Request{Message: &messages})
+		require.ErrorIs(t, err, conversation.ErrUnsupportedContent)
+		assert.Nil(t, model.messages)
+	})
+
+	t.Run("binary images are sent as data URLs", func(t *testing.T) {
+		model := &fakeModel{}
+		llm := &LLM{
+			Model:            model,
+			SupportedContent: conversation.Capabilities{BinaryMIMETypes: conversation.CommonImageMIMETypes},
+			BinaryAsImageURL: true,
+		}
+
+		res, err := llm.Converse(t.Context(), &conversation.Request{Message: &messages})
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res.Outputs[0].Choices[0].Message.Content)
+		require.Len(t, model.messages, 1)
+		assert.Equal(t, llms.ImageURLContent{URL: "data:image/png;base64,cG5n"}, model.messages[0].Parts[1])
+		assert.Equal(t, conversation.CommonImageMIMETypes, llm.Capabilities().BinaryMIMETypes)
+	})
+}
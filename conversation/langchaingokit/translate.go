@@ -18,6 +18,8 @@ package langchaingokit
 import (
 	"fmt"
 
+	"github.com/tmc/langchaingo/llms"
+
 	"github.com/dapr/components-contrib/conversation"
 )
 
@@ -160,3 +162,21 @@ func extractUsageFromLangchainGenerationInfo(genInfo map[string]any) (*conversat
 
 	return nil, nil
 }
+
+// binaryPartsToImageURLs returns a copy of messages where binary parts are replaced with image URL parts holding a base64 data URL.
+// OpenAI compatible APIs expect images as data URLs, while langchaingo marshals llms.BinaryContent with its own "binary" type.
+// The original messages are not modified.
+func binaryPartsToImageURLs(messages []llms.MessageContent) []llms.MessageContent {
+	out := make([]llms.MessageContent, len(messages))
+	for i, message := range messages {
+		out[i] = message
+		out[i].Parts = make([]llms.ContentPart, len(message.Parts))
+		for j, part := range message.Parts {
+			if binary, ok := part.(llms.BinaryContent); ok {
+				part = llms.ImageURLContent{URL: binary.String()}
+			}
+			out[i].Parts[j] = part
+		}
+	}
+	return out
+}
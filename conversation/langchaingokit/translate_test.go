@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
 
 	"github.com/dapr/components-contrib/conversation"
 )
@@ -337,3 +338,23 @@ func TestStringMapToAny(t *testing.T) {
 		})
 	}
 }
+
+func TestBinaryPartsToImageURLs(t *testing.T) {
+	messages := []llms.MessageContent{
+		{
+			Role: llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{
+				llms.TextContent{Text: "what is this?"},
+				llms.BinaryPart("image/png", []byte("png")),
+			},
+		},
+	}
+
+	out := binaryPartsToImageURLs(messages)
+	require.Len(t, out, 1)
+	assert.Equal(t, llms.TextContent{Text: "what is this?"}, out[0].Parts[0])
+	assert.Equal(t, llms.ImageURLContent{URL: "data:image/png;base64,cG5n"}, out[0].Parts[1])
+
+	// the original request is not modified
+	assert.IsType(t, llms.BinaryContent{}, messages[0].Parts[1])
+}
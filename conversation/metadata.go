@@ -31,4 +31,6 @@ type LangchainMetadata struct {
 	Model            string         `json:"model" mapstructure:"model"`
 	ResponseCacheTTL *time.Duration `json:"responseCacheTTL,omitempty" mapstructure:"responseCacheTTL" mapstructurealiases:"cacheTTL"`
 	Endpoint         string         `json:"endpoint" mapstructure:"endpoint"`
+
+	RateLimitMetadata `json:",inline" mapstructure:",squash"`
 }
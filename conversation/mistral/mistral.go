@@ -70,6 +70,7 @@ func (m *Mistral) Init(ctx context.Context, meta conversation.Metadata) error {
 	}
 
 	m.LLM.Model = llm
+	m.LLM.RateLimiter = conversation.NewRateLimiter(md.RateLimitMetadata, nil)
 
 	if md.ResponseCacheTTL != nil {
 		cachedModel, cacheErr := conversation.CacheResponses(ctx, md.ResponseCacheTTL, m.LLM.Model)
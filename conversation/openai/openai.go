@@ -97,6 +97,12 @@ func (o *OpenAI) Init(ctx context.Context, meta conversation.Metadata) error {
 	}
 
 	o.LLM.Model = llm
+	o.LLM.RateLimiter = conversation.NewRateLimiter(md.RateLimitMetadata, nil)
+	o.LLM.SupportedContent = conversation.Capabilities{
+		ImageURL:        true,
+		BinaryMIMETypes: conversation.CommonImageMIMETypes,
+	}
+	o.LLM.BinaryAsImageURL = true
 
 	if md.ResponseCacheTTL != nil {
 		cachedModel, cacheErr := conversation.CacheResponses(ctx, md.ResponseCacheTTL, o.LLM.Model)
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRateLimitKeyMetadata is the request metadata field used to identify the caller when none is configured.
+	DefaultRateLimitKeyMetadata = "user"
+
+	// defaultRateLimitKey is used for requests that do not carry a caller key.
+	defaultRateLimitKey = "_default"
+
+	rateLimitWindow = time.Minute
+
+	// maxRateLimitAttempts is the number of times an update is retried when it conflicts with a concurrent one.
+	maxRateLimitAttempts = 5
+)
+
+var (
+	// ErrRequestRateExceeded is returned when a caller exceeds the configured requests per minute.
+	ErrRequestRateExceeded = errors.New("conversation requests per minute limit exceeded")
+	// ErrTokenRateExceeded is returned when a caller exceeds the configured tokens per minute.
+	ErrTokenRateExceeded = errors.New("conversation tokens per minute limit exceeded")
+	// ErrTokenBudgetExceeded is returned when a caller has spent its cumulative token budget.
+	ErrTokenBudgetExceeded = errors.New("conversation token budget exceeded")
+	// ErrRequestTooLarge is returned when the estimated tokens of a single request exceed the tokens per minute, so it can never be allowed.
+	ErrRequestTooLarge = errors.New("conversation request exceeds the tokens per minute limit")
+)
+
+// RateLimitMetadata contains the client-side rate limiting options shared by conversation components.
+// A zero value for any limit disables it.
+type RateLimitMetadata struct {
+	RequestsPerMinute uint64 `json:"requestsPerMinute,omitempty" mapstructure:"requestsPerMinute"`
+	TokensPerMinute   uint64 `json:"tokensPerMinute,omitempty" mapstructure:"tokensPerMinute"`
+	TokenBudget       uint64 `json:"tokenBudget,omitempty" mapstructure:"tokenBudget"`
+	// RateLimitKeyMetadata is the name of the request metadata field that identifies the caller.
+	RateLimitKeyMetadata string `json:"rateLimitKeyMetadata,omitempty" mapstructure:"rateLimitKeyMetadata"`
+	// RateLimitStateStore is the name of the state store used to share usage across replicas. Usage is kept in memory if empty.
+	// Components can't access other components, so the runtime resolves it: see RateLimitStoreSetter.
+	RateLimitStateStore string `json:"rateLimitStateStore,omitempty" mapstructure:"rateLimitStateStore"`
+}
+
+// Enabled returns true if at least one limit is configured.
+func (m RateLimitMetadata) Enabled() bool {
+	return m.RequestsPerMinute > 0 || m.TokensPerMinute > 0 || m.TokenBudget > 0
+}
+
+// RateLimitError is returned when a request is rejected by the RateLimiter.
+// It wraps one of ErrRequestRateExceeded, ErrTokenRateExceeded, ErrTokenBudgetExceeded or ErrRequestTooLarge.
+type RateLimitError struct {
+	Key   string
+	Limit uint64
+	// RetryAfter is the time until the limit resets, or zero if retrying can't succeed (token budget, request too large).
+	RetryAfter time.Duration
+
+	err error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s for key '%s' (limit %d), retry after %s", e.err, e.Key, e.Limit, e.RetryAfter)
+	}
+	return fmt.Sprintf("%s for key '%s' (limit %d)", e.err, e.Key, e.Limit)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.err
+}
+
+// RateLimitStoreSetter is implemented by conversation components that allow replacing the in-memory
+// rate limit store, for example with one returned by NewStateRateLimitStore to share limits across replicas.
+// When the rateLimitStateStore metadata field of a component is set, the runtime is expected to call SetRateLimitStore
+// after Init, with NewStateRateLimitStore for the state store of that name and the component name as key prefix.
+type RateLimitStoreSetter interface {
+	SetRateLimitStore(store RateLimitStore)
+}
+
+// RateLimitUsage is the usage recorded for a single caller key.
+type RateLimitUsage struct {
+	// WindowStart is the unix time in seconds of the start of the current one minute window.
+	WindowStart int64 `json:"windowStart"`
+	// Requests is the number of requests made in the current window.
+	Requests uint64 `json:"requests"`
+	// Tokens is the number of tokens used in the current window.
+	Tokens uint64 `json:"tokens"`
+	// TotalTokens is the cumulative number of tokens used by the caller.
+	TotalTokens uint64 `json:"totalTokens"`
+}
+
+// RateLimitReservation is returned by RateLimiter.Reserve and must be passed to RateLimiter.Commit
+// once the real usage is known.
+type RateLimitReservation struct {
+	Key             string
+	EstimatedTokens uint64
+}
+
+// RateLimiter enforces requests per minute, tokens per minute and token budgets per caller key.
+type RateLimiter struct {
+	md        RateLimitMetadata
+	store     RateLimitStore
+	storeLock sync.RWMutex
+	clock     func() time.Time
+}
+
+// NewRateLimiter returns a RateLimiter for the given metadata, or nil if no limits are configured.
+// If store is nil, usage is kept in memory.
+func NewRateLimiter(md RateLimitMetadata, store RateLimitStore) *RateLimiter {
+	if !md.Enabled() {
+		return nil
+	}
+	if md.RateLimitKeyMetadata == "" {
+		md.RateLimitKeyMetadata = DefaultRateLimitKeyMetadata
+	}
+	if store == nil {
+		store = NewInMemoryRateLimitStore()
+	}
+
+	return &RateLimiter{
+		md:    md,
+		store: store,
+		clock: time.Now,
+	}
+}
+
+// SetStore replaces the store used to persist usage.
+// It is safe to call while requests are in flight.
+func (l *RateLimiter) SetStore(store RateLimitStore) {
+	l.storeLock.Lock()
+	l.store = store
+	l.storeLock.Unlock()
+}
+
+func (l *RateLimiter) getStore() RateLimitStore {
+	l.storeLock.RLock()
+	defer l.storeLock.RUnlock()
+	return l.store
+}
+
+// KeyFor returns the caller key for a request.
+func (l *RateLimiter) KeyFor(r *Request) string {
+	if r != nil && r.Metadata != nil {
+		if key := r.Metadata[l.md.RateLimitKeyMetadata]; key != "" {
+			return key
+		}
+	}
+	return defaultRateLimitKey
+}
+
+// Reserve checks the request against the configured limits and, if allowed, records it using
+// the estimated number of prompt tokens.
+func (l *RateLimiter) Reserve(ctx context.Context, r *Request) (*RateLimitReservation, error) {
+	res := &RateLimitReservation{
+		Key:             l.KeyFor(r),
+		EstimatedTokens: EstimateRequestTokens(r),
+	}
+
+	// Retrying a request that is larger than the limit can never succeed
+	if l.md.TokensPerMinute > 0 && res.EstimatedTokens > l.md.TokensPerMinute {
+		return nil, &RateLimitError{Key: res.Key, Limit: l.md.TokensPerMinute, err: ErrRequestTooLarge}
+	}
+
+	err := l.update(ctx, res.Key, func(u *RateLimitUsage, now time.Time) error {
+		retryAfter := time.Unix(u.WindowStart, 0).Add(rateLimitWindow).Sub(now)
+		switch {
+		case l.md.RequestsPerMinute > 0 && u.Requests+1 > l.md.RequestsPerMinute:
+			return &RateLimitError{Key: res.Key, Limit: l.md.RequestsPerMinute, RetryAfter: retryAfter, err: ErrRequestRateExceeded}
+		case l.md.TokensPerMinute > 0 && u.Tokens+res.EstimatedTokens > l.md.TokensPerMinute:
+			return &RateLimitError{Key: res.Key, Limit: l.md.TokensPerMinute, RetryAfter: retryAfter, err: ErrTokenRateExceeded}
+		case l.md.TokenBudget > 0 && u.TotalTokens+res.EstimatedTokens > l.md.TokenBudget:
+			return &RateLimitError{Key: res.Key, Limit: l.md.TokenBudget, err: ErrTokenBudgetExceeded}
+		}
+
+		u.Requests++
+		u.Tokens += res.EstimatedTokens
+		u.TotalTokens += res.EstimatedTokens
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Commit replaces the estimated tokens of a reservation with the real usage reported by the provider.
+// If usage is nil (for example because the call failed), the estimated tokens are released.
+func (l *RateLimiter) Commit(ctx context.Context, res *RateLimitReservation, usage *Usage) error {
+	if res == nil {
+		return nil
+	}
+
+	var actual uint64
+	if usage != nil {
+		actual = usage.TotalTokens
+		if actual == 0 {
+			actual = usage.PromptTokens + usage.CompletionTokens
+		}
+	}
+	if actual == res.EstimatedTokens {
+		return nil
+	}
+
+	return l.update(ctx, res.Key, func(u *RateLimitUsage, _ time.Time) error {
+		u.Tokens = adjustTokens(u.Tokens, res.EstimatedTokens, actual)
+		u.TotalTokens = adjustTokens(u.TotalTokens, res.EstimatedTokens, actual)
+		return nil
+	})
+}
+
+func adjustTokens(current, estimated, actual uint64) uint64 {
+	if actual >= estimated {
+		return current + (actual - estimated)
+	}
+	diff := estimated - actual
+	if diff > current {
+		return 0
+	}
+	return current - diff
+}
+
+// update loads the usage for key, rolls the window if needed, applies fn and saves the result.
+// Conflicting concurrent updates are retried.
+func (l *RateLimiter) update(ctx context.Context, key string, fn func(u *RateLimitUsage, now time.Time) error) error {
+	store := l.getStore()
+	for range maxRateLimitAttempts {
+		usage, etag, err := store.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get rate limit usage: %w", err)
+		}
+		if usage == nil {
+			usage = &RateLimitUsage{}
+		}
+
+		now := l.clock()
+		window := now.Truncate(rateLimitWindow).Unix()
+		if usage.WindowStart != window {
+			usage.WindowStart = window
+			usage.Requests = 0
+			usage.Tokens = 0
+		}
+
+		if err = fn(usage, now); err != nil {
+			return err
+		}
+
+		err = store.Set(ctx, key, usage, etag)
+		if errors.Is(err, ErrRateLimitConflict) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save rate limit usage: %w", err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("failed to save rate limit usage for key '%s': %w", key, ErrRateLimitConflict)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// ErrRateLimitConflict is returned by a RateLimitStore when the usage was modified concurrently.
+var ErrRateLimitConflict = errors.New("rate limit usage was modified concurrently")
+
+// RateLimitStore persists the usage of each caller key.
+type RateLimitStore interface {
+	// Get returns the usage for key and an opaque etag, or a nil usage if there is none.
+	Get(ctx context.Context, key string) (*RateLimitUsage, string, error)
+	// Set saves the usage for key if the stored etag still matches etag (empty for a new key).
+	// It returns ErrRateLimitConflict otherwise.
+	Set(ctx context.Context, key string, usage *RateLimitUsage, etag string) error
+}
+
+type inMemoryRateLimitStore struct {
+	lock    sync.Mutex
+	entries map[string]inMemoryRateLimitEntry
+}
+
+type inMemoryRateLimitEntry struct {
+	usage   RateLimitUsage
+	version uint64
+}
+
+// NewInMemoryRateLimitStore returns a RateLimitStore that keeps usage in the memory of the current process.
+func NewInMemoryRateLimitStore() RateLimitStore {
+	return &inMemoryRateLimitStore{
+		entries: make(map[string]inMemoryRateLimitEntry),
+	}
+}
+
+func (s *inMemoryRateLimitStore) Get(_ context.Context, key string) (*RateLimitUsage, string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, "", nil
+	}
+	usage := entry.usage
+	return &usage, strconv.FormatUint(entry.version, 10), nil
+}
+
+func (s *inMemoryRateLimitStore) Set(_ context.Context, key string, usage *RateLimitUsage, etag string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entry, ok := s.entries[key]
+	switch {
+	case !ok && etag != "":
+		return ErrRateLimitConflict
+	case ok && strconv.FormatUint(entry.version, 10) != etag:
+		return ErrRateLimitConflict
+	}
+
+	s.entries[key] = inMemoryRateLimitEntry{
+		usage:   *usage,
+		version: entry.version + 1,
+	}
+	return nil
+}
+
+type stateRateLimitStore struct {
+	store     state.Store
+	keyPrefix string
+}
+
+// NewStateRateLimitStore returns a RateLimitStore backed by a state store, so limits hold across replicas.
+// Keys are prefixed with keyPrefix, which is usually the component name.
+// The state store must support ETags, and first-write concurrency without an ETag must only insert keys that don't exist
+// (the "first-write" operation of the state conformance tests): usage for a new key is saved that way, so replicas
+// that both see no usage can't overwrite each other's counts.
+func NewStateRateLimitStore(store state.Store, keyPrefix string) RateLimitStore {
+	return &stateRateLimitStore{
+		store:     store,
+		keyPrefix: keyPrefix,
+	}
+}
+
+func (s *stateRateLimitStore) stateKey(key string) string {
+	return s.keyPrefix + "||ratelimit||" + key
+}
+
+func (s *stateRateLimitStore) Get(ctx context.Context, key string) (*RateLimitUsage, string, error) {
+	res, err := s.store.Get(ctx, &state.GetRequest{
+		Key: s.stateKey(key),
+		Options: state.GetStateOption{
+			Consistency: state.Strong,
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if res == nil || len(res.Data) == 0 {
+		return nil, "", nil
+	}
+
+	usage := &RateLimitUsage{}
+	if err = json.Unmarshal(res.Data, usage); err != nil {
+		return nil, "", err
+	}
+
+	var etag string
+	if res.ETag != nil {
+		etag = *res.ETag
+	}
+	return usage, etag, nil
+}
+
+// Set saves usage with the ETag read by Get, or inserts it if there was no usage for key (empty etag).
+func (s *stateRateLimitStore) Set(ctx context.Context, key string, usage *RateLimitUsage, etag string) error {
+	data, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+
+	req := &state.SetRequest{
+		Key:   s.stateKey(key),
+		Value: data,
+		Options: state.SetStateOption{
+			Concurrency: state.FirstWrite,
+			Consistency: state.Strong,
+		},
+	}
+	if etag != "" {
+		req.ETag = &etag
+	}
+
+	err = s.store.Set(ctx, req)
+	if err == nil {
+		return nil
+	}
+	var etagErr *state.ETagError
+	if errors.As(err, &etagErr) {
+		return ErrRateLimitConflict
+	}
+	if etag == "" {
+		// Not all state stores return an ETag error when the key of an insert already exists
+		existing, _, getErr := s.Get(ctx, key)
+		if getErr == nil && existing != nil {
+			return ErrRateLimitConflict
+		}
+	}
+	return err
+}
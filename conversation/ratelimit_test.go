@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/dapr/components-contrib/state"
+	inmemory "github.com/dapr/components-contrib/state/in-memory"
+	"github.com/dapr/kit/logger"
+)
+
+func textRequest(text string, md map[string]string) *Request {
+	return &Request{
+		Message: &[]llms.MessageContent{
+			llms.TextParts(llms.ChatMessageTypeHuman, text),
+		},
+		Metadata: md,
+	}
+}
+
+func TestEstimateRequestTokens(t *testing.T) {
+	assert.Equal(t, uint64(0), EstimateRequestTokens(nil))
+	assert.Equal(t, uint64(0), EstimateRequestTokens(&Request{}))
+	assert.Equal(t, uint64(3), EstimateRequestTokens(textRequest("hello  dapr world", nil)))
+
+	r := &Request{
+		Message: &[]llms.MessageContent{
+			llms.TextParts(llms.ChatMessageTypeHuman, "what is the weather"),
+			{
+				Role: llms.ChatMessageTypeTool,
+				Parts: []llms.ContentPart{
+					llms.ToolCallResponse{ToolCallID: "1", Name: "weather", Content: "sunny and warm"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, uint64(7), EstimateRequestTokens(r))
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	assert.Nil(t, NewRateLimiter(RateLimitMetadata{}, nil))
+
+	l := NewRateLimiter(RateLimitMetadata{RequestsPerMinute: 1}, nil)
+	require.NotNil(t, l)
+	assert.Equal(t, "alice", l.KeyFor(textRequest("hi", map[string]string{"user": "alice"})))
+	assert.Equal(t, defaultRateLimitKey, l.KeyFor(textRequest("hi", nil)))
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 10, 0, 30, 0, time.UTC)
+	newLimiter := func(md RateLimitMetadata, store RateLimitStore) *RateLimiter {
+		l := NewRateLimiter(md, store)
+		l.clock = func() time.Time { return now }
+		return l
+	}
+
+	t.Run("requests per minute", func(t *testing.T) {
+		l := newLimiter(RateLimitMetadata{RequestsPerMinute: 2, RateLimitKeyMetadata: "tenant"}, nil)
+		ctx := t.Context()
+		alice := textRequest("hi", map[string]string{"tenant": "alice"})
+
+		for range 2 {
+			_, err := l.Reserve(ctx, alice)
+			require.NoError(t, err)
+		}
+
+		_, err := l.Reserve(ctx, alice)
+		require.ErrorIs(t, err, ErrRequestRateExceeded)
+		var rlErr *RateLimitError
+		require.ErrorAs(t, err, &rlErr)
+		assert.Equal(t, "alice", rlErr.Key)
+		assert.Equal(t, 30*time.Second, rlErr.RetryAfter)
+
+		// Other keys are not affected
+		_, err = l.Reserve(ctx, textRequest("hi", map[string]string{"tenant": "bob"}))
+		require.NoError(t, err)
+
+		// Next window
+		now = now.Add(time.Minute)
+		_, err = l.Reserve(ctx, alice)
+		require.NoError(t, err)
+	})
+
+	t.Run("tokens per minute uses real usage", func(t *testing.T) {
+		l := newLimiter(RateLimitMetadata{TokensPerMinute: 10}, nil)
+		ctx := t.Context()
+
+		res, err := l.Reserve(ctx, textRequest("one two three", nil))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), res.EstimatedTokens)
+		require.NoError(t, l.Commit(ctx, res, &Usage{PromptTokens: 4, CompletionTokens: 4, TotalTokens: 8}))
+
+		_, err = l.Reserve(ctx, textRequest("one two three", nil))
+		require.ErrorIs(t, err, ErrTokenRateExceeded)
+
+		_, err = l.Reserve(ctx, textRequest("one two", nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("request larger than tokens per minute", func(t *testing.T) {
+		l := newLimiter(RateLimitMetadata{TokensPerMinute: 2}, nil)
+		ctx := t.Context()
+
+		_, err := l.Reserve(ctx, textRequest("one two three", nil))
+		require.ErrorIs(t, err, ErrRequestTooLarge)
+		require.NotErrorIs(t, err, ErrTokenRateExceeded)
+		var rlErr *RateLimitError
+		require.ErrorAs(t, err, &rlErr)
+		assert.Zero(t, rlErr.RetryAfter)
+
+		// Nothing was reserved
+		_, err = l.Reserve(ctx, textRequest("one two", nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("set store while in use", func(t *testing.T) {
+		l := newLimiter(RateLimitMetadata{RequestsPerMinute: 1000}, nil)
+		ctx := t.Context()
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				l.SetStore(NewInMemoryRateLimitStore())
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := l.Reserve(ctx, textRequest("hi", nil))
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("failed calls release estimated tokens", func(t *testing.T) {
+		l := newLimiter(RateLimitMetadata{TokenBudget: 3}, nil)
+		ctx := t.Context()
+
+		res, err := l.Reserve(ctx, textRequest("one two three", nil))
+		require.NoError(t, err)
+		require.NoError(t, l.Commit(ctx, res, nil))
+
+		res, err = l.Reserve(ctx, textRequest("one two three", nil))
+		require.NoError(t, err)
+		require.NoError(t, l.Commit(ctx, res, &Usage{TotalTokens: 3}))
+
+		// The budget does not reset with the window
+		now = now.Add(time.Hour)
+		_, err = l.Reserve(ctx, textRequest("one", nil))
+		require.ErrorIs(t, err, ErrTokenBudgetExceeded)
+		var rlErr *RateLimitError
+		require.ErrorAs(t, err, &rlErr)
+		assert.Zero(t, rlErr.RetryAfter)
+	})
+
+	t.Run("state store is shared across limiters", func(t *testing.T) {
+		ss := inmemory.NewInMemoryStateStore(logger.NewLogger("test"))
+		require.NoError(t, ss.Init(t.Context(), state.Metadata{}))
+		t.Cleanup(func() { ss.Close() })
+
+		md := RateLimitMetadata{RequestsPerMinute: 2}
+		l1 := newLimiter(md, NewStateRateLimitStore(ss, "openai"))
+		l2 := newLimiter(md, NewStateRateLimitStore(ss, "openai"))
+		ctx := t.Context()
+
+		_, err := l1.Reserve(ctx, textRequest("hi", nil))
+		require.NoError(t, err)
+		_, err = l2.Reserve(ctx, textRequest("hi", nil))
+		require.NoError(t, err)
+		_, err = l1.Reserve(ctx, textRequest("hi", nil))
+		require.ErrorIs(t, err, ErrRequestRateExceeded)
+	})
+}
+
+func TestInMemoryRateLimitStoreConflict(t *testing.T) {
+	s := NewInMemoryRateLimitStore()
+	ctx := context.Background()
+
+	require.NoError(t, s.Set(ctx, "k", &RateLimitUsage{Requests: 1}, ""))
+	require.ErrorIs(t, s.Set(ctx, "k", &RateLimitUsage{Requests: 2}, ""), ErrRateLimitConflict)
+
+	usage, etag, err := s.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), usage.Requests)
+	require.NoError(t, s.Set(ctx, "k", &RateLimitUsage{Requests: 2}, etag))
+	require.ErrorIs(t, s.Set(ctx, "k", &RateLimitUsage{Requests: 3}, etag), ErrRateLimitConflict)
+}
+
+func TestStateRateLimitStoreConcurrentReplicas(t *testing.T) {
+	ss := inmemory.NewInMemoryStateStore(logger.NewLogger("test"))
+	require.NoError(t, ss.Init(t.Context(), state.Metadata{}))
+	t.Cleanup(func() { ss.Close() })
+
+	replicas := []RateLimitStore{
+		NewStateRateLimitStore(ss, "openai"),
+		NewStateRateLimitStore(ss, "openai"),
+	}
+	ctx := t.Context()
+
+	t.Run("only one replica inserts a new key", func(t *testing.T) {
+		for _, r := range replicas {
+			usage, etag, err := r.Get(ctx, "new")
+			require.NoError(t, err)
+			assert.Nil(t, usage)
+			assert.Empty(t, etag)
+		}
+
+		require.NoError(t, replicas[0].Set(ctx, "new", &RateLimitUsage{Requests: 1}, ""))
+		require.ErrorIs(t, replicas[1].Set(ctx, "new", &RateLimitUsage{Requests: 1}, ""), ErrRateLimitConflict)
+
+		usage, _, err := replicas[1].Get(ctx, "new")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), usage.Requests)
+	})
+
+	t.Run("no requests are lost", func(t *testing.T) {
+		const perReplica = 25
+
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for _, r := range replicas {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				for range perReplica {
+					for {
+						usage, etag, err := r.Get(ctx, "shared")
+						if !assert.NoError(t, err) {
+							return
+						}
+						if usage == nil {
+							usage = &RateLimitUsage{}
+						}
+						usage.Requests++
+						err = r.Set(ctx, "shared", usage, etag)
+						if errors.Is(err, ErrRateLimitConflict) {
+							continue
+						}
+						if !assert.NoError(t, err) {
+							return
+						}
+						break
+					}
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		usage, _, err := replicas[0].Get(ctx, "shared")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(replicas)*perReplica), usage.Requests)
+	})
+}
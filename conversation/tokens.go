@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversation
+
+import (
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// approximateTokensPerAttachment is a flat estimate used for image and binary parts, based on the cost of a low detail image.
+// ref: https://platform.openai.com/docs/guides/images-vision#calculating-costs
+const approximateTokensPerAttachment = 85
+
+// ApproximateTokensFromWords estimates the number of tokens based on word count.
+// ref: https://help.openai.com/en/articles/4936856-what-are-tokens-and-how-to-count-them
+func ApproximateTokensFromWords(text string) uint64 {
+	if text == "" {
+		return 0
+	}
+
+	// split on whitespace to count words
+	return uint64(len(strings.Fields(text)))
+}
+
+// EstimateRequestTokens returns a rough, provider agnostic estimate of the prompt tokens for a request.
+// It is used before calling the LLM, when the real usage is not known yet.
+func EstimateRequestTokens(r *Request) uint64 {
+	if r == nil || r.Message == nil {
+		return 0
+	}
+
+	var tokens uint64
+	for _, message := range *r.Message {
+		for _, part := range message.Parts {
+			switch p := part.(type) {
+			case llms.TextContent:
+				tokens += ApproximateTokensFromWords(p.Text)
+			case llms.ToolCall:
+				if p.FunctionCall != nil {
+					tokens += ApproximateTokensFromWords(p.FunctionCall.Name + " " + p.FunctionCall.Arguments)
+				}
+			case llms.ToolCallResponse:
+				tokens += ApproximateTokensFromWords(p.Content)
+			case llms.ImageURLContent, llms.BinaryContent:
+				tokens += approximateTokensPerAttachment
+			}
+		}
+	}
+
+	return tokens
+}
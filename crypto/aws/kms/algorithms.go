@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"slices"
+
+	awskms "github.com/aws/aws-sdk-go/service/kms"
+
+	internals "github.com/dapr/kit/crypto"
+)
+
+// Symmetric KMS keys use AES-256-GCM, which KMS calls "SYMMETRIC_DEFAULT".
+var encryptionAlgs = map[string]string{
+	internals.Algorithm_A256GCM:      awskms.EncryptionAlgorithmSpecSymmetricDefault,
+	internals.Algorithm_RSA_OAEP:     awskms.EncryptionAlgorithmSpecRsaesOaepSha1,
+	internals.Algorithm_RSA_OAEP_256: awskms.EncryptionAlgorithmSpecRsaesOaepSha256,
+}
+
+var signatureAlgs = map[string]string{
+	internals.Algorithm_RS256: awskms.SigningAlgorithmSpecRsassaPkcs1V15Sha256,
+	internals.Algorithm_RS384: awskms.SigningAlgorithmSpecRsassaPkcs1V15Sha384,
+	internals.Algorithm_RS512: awskms.SigningAlgorithmSpecRsassaPkcs1V15Sha512,
+	internals.Algorithm_PS256: awskms.SigningAlgorithmSpecRsassaPssSha256,
+	internals.Algorithm_PS384: awskms.SigningAlgorithmSpecRsassaPssSha384,
+	internals.Algorithm_PS512: awskms.SigningAlgorithmSpecRsassaPssSha512,
+	internals.Algorithm_ES256: awskms.SigningAlgorithmSpecEcdsaSha256,
+	internals.Algorithm_ES384: awskms.SigningAlgorithmSpecEcdsaSha384,
+	internals.Algorithm_ES512: awskms.SigningAlgorithmSpecEcdsaSha512,
+}
+
+var (
+	encryptionAlgsList = sortedKeys(encryptionAlgs)
+	signatureAlgsList  = sortedKeys(signatureAlgs)
+)
+
+func sortedKeys(m map[string]string) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	slices.Sort(res)
+	return res
+}
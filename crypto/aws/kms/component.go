@@ -0,0 +1,338 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awskms "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	awsAuth "github.com/dapr/components-contrib/common/authentication/aws"
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	internals "github.com/dapr/kit/crypto"
+	"github.com/dapr/kit/logger"
+)
+
+// KMS does not accept associated data as bytes: it is sent base64-encoded as this key of the encryption context.
+const associatedDataContextKey = "aad"
+
+type kmsCrypto struct {
+	keyCache     *contribCrypto.PubKeyCache
+	md           kmsMetadata
+	authProvider awsAuth.Provider
+	logger       logger.Logger
+}
+
+// NewAWSKMSCrypto returns a new AWS KMS crypto provider.
+func NewAWSKMSCrypto(logger logger.Logger) contribCrypto.SubtleCrypto {
+	return &kmsCrypto{
+		logger: logger,
+	}
+}
+
+// Init creates an AWS KMS client.
+func (k *kmsCrypto) Init(ctx context.Context, metadata contribCrypto.Metadata) error {
+	// Init the metadata
+	err := k.md.InitWithMetadata(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to load metadata: %w", err)
+	}
+
+	// Create a cache for keys
+	k.keyCache = contribCrypto.NewPubKeyCache(k.getKeyCacheFn)
+
+	opts := awsAuth.Options{
+		Logger:       k.logger,
+		Properties:   metadata.Properties,
+		Region:       k.md.Region,
+		AccessKey:    k.md.AccessKey,
+		SecretKey:    k.md.SecretKey,
+		SessionToken: k.md.SessionToken,
+		Endpoint:     k.md.Endpoint,
+	}
+	k.authProvider, err = awsAuth.NewProvider(ctx, opts, awsAuth.GetConfig(opts))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Features returns the features available in this crypto provider.
+func (k *kmsCrypto) Features() []contribCrypto.Feature {
+	return []contribCrypto.Feature{} // No Feature supported.
+}
+
+// GetKey returns the public part of a key stored in KMS.
+// This method returns an error if the key is symmetric.
+// The key argument can be a key ID, a key ARN, an alias name ("alias/name") or an alias ARN.
+func (k *kmsCrypto) GetKey(parentCtx context.Context, key string) (pubKey jwk.Key, err error) {
+	// The public key of a KMS key never changes, but aliases can be updated to point to a different key
+	if isAlias(key) {
+		return k.getKeyFromKMS(parentCtx, key)
+	}
+	return k.keyCache.GetKey(parentCtx, key)
+}
+
+func (k *kmsCrypto) getKeyFromKMS(parentCtx context.Context, key string) (pubKey jwk.Key, err error) {
+	ctx, cancel := context.WithTimeout(parentCtx, k.md.RequestTimeout)
+	res, err := k.authProvider.KMS().KMS.GetPublicKeyWithContext(ctx, &awskms.GetPublicKeyInput{
+		KeyId: aws.String(key),
+	})
+	cancel()
+	if err != nil {
+		return nil, kmsError(err)
+	}
+
+	if len(res.PublicKey) == 0 {
+		return nil, errors.New("response from KMS does not contain a valid public key")
+	}
+
+	// The public key is a DER-encoded X.509 SubjectPublicKeyInfo
+	rawKey, err := x509.ParsePKIXPublicKey(res.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	pubKey, err = jwk.FromRaw(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWK from public key: %w", err)
+	}
+
+	if res.KeyId != nil {
+		err = pubKey.Set(jwk.KeyIDKey, *res.KeyId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set key ID: %w", err)
+		}
+	}
+	return pubKey, nil
+}
+
+// Handler for the getKeyCacheFn method
+func (k *kmsCrypto) getKeyCacheFn(ctx context.Context, key string) func(resolve func(jwk.Key), reject func(error)) {
+	return func(resolve func(jwk.Key), reject func(error)) {
+		pk, err := k.getKeyFromKMS(ctx, key)
+		if err != nil {
+			reject(err)
+			return
+		}
+		resolve(pk)
+	}
+}
+
+// Encrypt a small message and returns the ciphertext.
+// The key argument can be a key ID, a key ARN, an alias name ("alias/name") or an alias ARN.
+// KMS generates the nonce and includes it in the ciphertext blob.
+func (k *kmsCrypto) Encrypt(parentCtx context.Context, plaintext []byte, algorithmStr string, key string, nonce []byte, associatedData []byte) (ciphertext []byte, tag []byte, err error) {
+	algorithm, encryptionContext, err := encryptionParams(algorithmStr, associatedData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, k.md.RequestTimeout)
+	res, err := k.authProvider.KMS().KMS.EncryptWithContext(ctx, &awskms.EncryptInput{
+		KeyId:               aws.String(key),
+		Plaintext:           plaintext,
+		EncryptionAlgorithm: aws.String(algorithm),
+		EncryptionContext:   encryptionContext,
+	})
+	cancel()
+	if err != nil {
+		return nil, nil, kmsError(err)
+	}
+
+	if len(res.CiphertextBlob) == 0 {
+		return nil, nil, errors.New("response from KMS does not contain a valid ciphertext")
+	}
+
+	return res.CiphertextBlob, nil, nil
+}
+
+// Decrypt a small message and returns the plaintext.
+// The key argument can be a key ID, a key ARN, an alias name ("alias/name") or an alias ARN.
+func (k *kmsCrypto) Decrypt(parentCtx context.Context, ciphertext []byte, algorithmStr string, key string, nonce []byte, tag []byte, associatedData []byte) (plaintext []byte, err error) {
+	algorithm, encryptionContext, err := encryptionParams(algorithmStr, associatedData)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, k.md.RequestTimeout)
+	res, err := k.authProvider.KMS().KMS.DecryptWithContext(ctx, &awskms.DecryptInput{
+		KeyId:               aws.String(key),
+		CiphertextBlob:      ciphertext,
+		EncryptionAlgorithm: aws.String(algorithm),
+		EncryptionContext:   encryptionContext,
+	})
+	cancel()
+	if err != nil {
+		return nil, kmsError(err)
+	}
+
+	if res.Plaintext == nil {
+		return nil, errors.New("response from KMS does not contain a valid plaintext")
+	}
+
+	return res.Plaintext, nil
+}
+
+// WrapKey wraps a symmetric key, such as a data encryption key.
+// The key argument can be a key ID, a key ARN, an alias name ("alias/name") or an alias ARN.
+func (k *kmsCrypto) WrapKey(parentCtx context.Context, plaintextKey jwk.Key, algorithm string, key string, nonce []byte, associatedData []byte) (wrappedKey []byte, tag []byte, err error) {
+	// Only symmetric keys can be wrapped, so unwrapped keys can be parsed from raw bytes
+	if plaintextKey.KeyType() != jwa.OctetSeq {
+		return nil, nil, errors.New("cannot wrap asymmetric keys")
+	}
+	plaintext, err := internals.SerializeKey(plaintextKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot serialize key: %w", err)
+	}
+
+	return k.Encrypt(parentCtx, plaintext, algorithm, key, nonce, associatedData)
+}
+
+// UnwrapKey unwraps a key.
+// The key argument can be a key ID, a key ARN, an alias name ("alias/name") or an alias ARN.
+func (k *kmsCrypto) UnwrapKey(parentCtx context.Context, wrappedKey []byte, algorithm string, key string, nonce []byte, tag []byte, associatedData []byte) (plaintextKey jwk.Key, err error) {
+	plaintext, err := k.Decrypt(parentCtx, wrappedKey, algorithm, key, nonce, tag, associatedData)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintextKey, err = jwk.FromRaw(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWK from raw key: %w", err)
+	}
+
+	return plaintextKey, nil
+}
+
+// Sign a digest.
+// The key argument can be a key ID, a key ARN, an alias name ("alias/name") or an alias ARN.
+// ECDSA signatures are ASN.1 DER-encoded.
+func (k *kmsCrypto) Sign(parentCtx context.Context, digest []byte, algorithmStr string, key string) (signature []byte, err error) {
+	algorithm, ok := signatureAlgs[algorithmStr]
+	if !ok {
+		return nil, fmt.Errorf("invalid algorithm: %s", algorithmStr)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, k.md.RequestTimeout)
+	res, err := k.authProvider.KMS().KMS.SignWithContext(ctx, &awskms.SignInput{
+		KeyId:            aws.String(key),
+		Message:          digest,
+		MessageType:      aws.String(awskms.MessageTypeDigest),
+		SigningAlgorithm: aws.String(algorithm),
+	})
+	cancel()
+	if err != nil {
+		return nil, kmsError(err)
+	}
+
+	if len(res.Signature) == 0 {
+		return nil, errors.New("response from KMS does not contain a valid signature")
+	}
+
+	return res.Signature, nil
+}
+
+// Verify a signature.
+// The key argument can be a key ID, a key ARN, an alias name ("alias/name") or an alias ARN.
+func (k *kmsCrypto) Verify(parentCtx context.Context, digest []byte, signature []byte, algorithmStr string, key string) (valid bool, err error) {
+	algorithm, ok := signatureAlgs[algorithmStr]
+	if !ok {
+		return false, fmt.Errorf("invalid algorithm: %s", algorithmStr)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, k.md.RequestTimeout)
+	res, err := k.authProvider.KMS().KMS.VerifyWithContext(ctx, &awskms.VerifyInput{
+		KeyId:            aws.String(key),
+		Message:          digest,
+		MessageType:      aws.String(awskms.MessageTypeDigest),
+		Signature:        signature,
+		SigningAlgorithm: aws.String(algorithm),
+	})
+	cancel()
+	if err != nil {
+		// KMS returns an error rather than a response when the signature is not valid
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && aerr.Code() == awskms.ErrCodeKMSInvalidSignatureException {
+			return false, nil
+		}
+		return false, kmsError(err)
+	}
+
+	return aws.BoolValue(res.SignatureValid), nil
+}
+
+func (k *kmsCrypto) Close() error {
+	if k.authProvider != nil {
+		return k.authProvider.Close()
+	}
+	return nil
+}
+
+func (*kmsCrypto) SupportedEncryptionAlgorithms() []string {
+	return encryptionAlgsList
+}
+
+func (*kmsCrypto) SupportedSignatureAlgorithms() []string {
+	return signatureAlgsList
+}
+
+func (*kmsCrypto) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
+	metadataStruct := kmsMetadata{}
+	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.CryptoType)
+	return
+}
+
+// encryptionParams returns the KMS encryption algorithm and the encryption context holding the associated data, if any.
+func encryptionParams(algorithmStr string, associatedData []byte) (string, map[string]*string, error) {
+	algorithm, ok := encryptionAlgs[algorithmStr]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid algorithm: %s", algorithmStr)
+	}
+
+	if len(associatedData) == 0 {
+		return algorithm, nil, nil
+	}
+	if algorithm != awskms.EncryptionAlgorithmSpecSymmetricDefault {
+		return "", nil, fmt.Errorf("associated data is not supported with algorithm '%s'", algorithmStr)
+	}
+	return algorithm, map[string]*string{
+		associatedDataContextKey: aws.String(base64.StdEncoding.EncodeToString(associatedData)),
+	}, nil
+}
+
+// kmsError converts errors returned by KMS.
+func kmsError(err error) error {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == awskms.ErrCodeNotFoundException {
+		return contribCrypto.ErrKeyNotFound
+	}
+	return fmt.Errorf("error from KMS: %w", err)
+}
+
+// isAlias returns true if the key is an alias name or an alias ARN.
+func isAlias(key string) bool {
+	return strings.HasPrefix(key, "alias/") || (strings.HasPrefix(key, "arn:") && strings.Contains(key, ":alias/"))
+}
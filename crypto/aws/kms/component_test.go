@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	awskms "github.com/aws/aws-sdk-go/service/kms"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	awsAuth "github.com/dapr/components-contrib/common/authentication/aws"
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/kit/logger"
+)
+
+// newTestComponent returns a component that uses a fake KMS.
+// Ciphertexts are not encrypted: they contain the key ID and the plaintext, to check they are passed correctly.
+func newTestComponent(t *testing.T, ecKey *ecdsa.PrivateKey) *kmsCrypto {
+	t.Helper()
+
+	pubDer, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+
+	notFound := awserr.New(awskms.ErrCodeNotFoundException, "key not found", nil)
+	getPublicKeyCalls := 0
+	mock := &awsAuth.MockKMS{
+		GetPublicKeyFn: func(_ context.Context, input *awskms.GetPublicKeyInput, _ ...request.Option) (*awskms.GetPublicKeyOutput, error) {
+			getPublicKeyCalls++
+			if *input.KeyId != "eckey" && *input.KeyId != "alias/eckey" {
+				return nil, notFound
+			}
+			return &awskms.GetPublicKeyOutput{
+				KeyId:     aws.String("arn:aws:kms:us-east-1:000000000000:key/eckey"),
+				PublicKey: pubDer,
+			}, nil
+		},
+		EncryptFn: func(_ context.Context, input *awskms.EncryptInput, _ ...request.Option) (*awskms.EncryptOutput, error) {
+			if *input.KeyId != "aeskey" {
+				return nil, notFound
+			}
+			assert.Equal(t, awskms.EncryptionAlgorithmSpecSymmetricDefault, *input.EncryptionAlgorithm)
+			var aad string
+			if input.EncryptionContext != nil {
+				aad = *input.EncryptionContext[associatedDataContextKey]
+			}
+			return &awskms.EncryptOutput{
+				CiphertextBlob: append([]byte(aad+"|"), input.Plaintext...),
+			}, nil
+		},
+		DecryptFn: func(_ context.Context, input *awskms.DecryptInput, _ ...request.Option) (*awskms.DecryptOutput, error) {
+			var aad string
+			if input.EncryptionContext != nil {
+				aad = *input.EncryptionContext[associatedDataContextKey]
+			}
+			before, after, _ := bytes.Cut(input.CiphertextBlob, []byte("|"))
+			if string(before) != aad {
+				return nil, awserr.New(awskms.ErrCodeInvalidCiphertextException, "invalid ciphertext", nil)
+			}
+			return &awskms.DecryptOutput{Plaintext: after}, nil
+		},
+		SignFn: func(_ context.Context, input *awskms.SignInput, _ ...request.Option) (*awskms.SignOutput, error) {
+			assert.Equal(t, awskms.MessageTypeDigest, *input.MessageType)
+			assert.Equal(t, awskms.SigningAlgorithmSpecEcdsaSha256, *input.SigningAlgorithm)
+			sig, err := ecdsa.SignASN1(rand.Reader, ecKey, input.Message)
+			require.NoError(t, err)
+			return &awskms.SignOutput{Signature: sig}, nil
+		},
+		VerifyFn: func(_ context.Context, input *awskms.VerifyInput, _ ...request.Option) (*awskms.VerifyOutput, error) {
+			if !ecdsa.VerifyASN1(&ecKey.PublicKey, input.Message, input.Signature) {
+				return nil, awserr.New(awskms.ErrCodeKMSInvalidSignatureException, "invalid signature", nil)
+			}
+			return &awskms.VerifyOutput{SignatureValid: aws.Bool(true)}, nil
+		},
+	}
+	t.Cleanup(func() {
+		// The public key of "eckey" is cached, while the alias is fetched each time
+		assert.Equal(t, 3, getPublicKeyCalls)
+	})
+
+	mockAuthProvider := &awsAuth.StaticAuth{}
+	mockAuthProvider.WithMockClients(&awsAuth.Clients{
+		KMS: &awsAuth.KMSClients{KMS: mock},
+	})
+
+	k := NewAWSKMSCrypto(logger.NewLogger("test")).(*kmsCrypto)
+	k.md.reset()
+	k.keyCache = contribCrypto.NewPubKeyCache(k.getKeyCacheFn)
+	k.authProvider = mockAuthProvider
+	return k
+}
+
+func TestKMSCrypto(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	component := newTestComponent(t, ecKey)
+
+	t.Run("get public key", func(t *testing.T) {
+		for _, name := range []string{"eckey", "eckey", "alias/eckey", "alias/eckey"} {
+			key, err := component.GetKey(t.Context(), name)
+			require.NoError(t, err)
+
+			var raw ecdsa.PublicKey
+			require.NoError(t, key.Raw(&raw))
+			assert.True(t, ecKey.PublicKey.Equal(&raw))
+			assert.Equal(t, "arn:aws:kms:us-east-1:000000000000:key/eckey", key.KeyID())
+		}
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, _, err := component.Encrypt(t.Context(), []byte("hello"), "A256GCM", "notfound", nil, nil)
+		require.ErrorIs(t, err, contribCrypto.ErrKeyNotFound)
+	})
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		ciphertext, tag, err := component.Encrypt(t.Context(), []byte("hello"), "A256GCM", "aeskey", nil, []byte("aad"))
+		require.NoError(t, err)
+		assert.Nil(t, tag)
+
+		plaintext, err := component.Decrypt(t.Context(), ciphertext, "A256GCM", "aeskey", nil, nil, []byte("aad"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(plaintext))
+
+		_, err = component.Decrypt(t.Context(), ciphertext, "A256GCM", "aeskey", nil, nil, []byte("other"))
+		require.ErrorContains(t, err, "invalid ciphertext")
+	})
+
+	t.Run("invalid algorithms", func(t *testing.T) {
+		_, _, err := component.Encrypt(t.Context(), []byte("hello"), "A128CBC", "aeskey", nil, nil)
+		require.ErrorContains(t, err, "invalid algorithm")
+
+		_, _, err = component.Encrypt(t.Context(), []byte("hello"), "RSA-OAEP-256", "rsakey", nil, []byte("aad"))
+		require.ErrorContains(t, err, "associated data is not supported")
+
+		_, err = component.Sign(t.Context(), []byte("hello"), "EdDSA", "eckey")
+		require.ErrorContains(t, err, "invalid algorithm")
+	})
+
+	t.Run("wrap and unwrap key", func(t *testing.T) {
+		dek, err := jwk.FromRaw([]byte("0123456789abcdef0123456789abcdef"))
+		require.NoError(t, err)
+
+		wrapped, _, err := component.WrapKey(t.Context(), dek, "A256GCM", "aeskey", nil, nil)
+		require.NoError(t, err)
+
+		unwrapped, err := component.UnwrapKey(t.Context(), wrapped, "A256GCM", "aeskey", nil, nil, nil)
+		require.NoError(t, err)
+		var raw []byte
+		require.NoError(t, unwrapped.Raw(&raw))
+		assert.Equal(t, "0123456789abcdef0123456789abcdef", string(raw))
+	})
+
+	t.Run("sign and verify", func(t *testing.T) {
+		digest := sha256.Sum256([]byte("hello"))
+		signature, err := component.Sign(t.Context(), digest[:], "ES256", "eckey")
+		require.NoError(t, err)
+
+		valid, err := component.Verify(t.Context(), digest[:], signature, "ES256", "eckey")
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		other := sha256.Sum256([]byte("world"))
+		valid, err = component.Verify(t.Context(), other[:], signature, "ES256", "eckey")
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+}
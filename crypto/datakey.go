@@ -0,0 +1,32 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"context"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// DefaultDataKeySize is the size, in bits, of data keys generated when no size is requested.
+const DefaultDataKeySize = 256
+
+// DataKeyGenerator is an optional interface for crypto providers that can generate data encryption keys, so they are created by the service that holds the wrapping key.
+// Components that implement it advertise FeatureDataKey.
+type DataKeyGenerator interface {
+	// GenerateDataKey generates a new random symmetric key with the given size in bits (DefaultDataKeySize if 0), and wraps it with the key.
+	// It returns the data key both in plaintext, to encrypt data, and wrapped, to be stored alongside the data.
+	// The wrapped key can be unwrapped with UnwrapKey, using the same key and algorithm.
+	GenerateDataKey(ctx context.Context, algorithm string, key string, bits int) (plaintextKey jwk.Key, wrappedKey []byte, err error)
+}
@@ -17,5 +17,14 @@ import (
 	"github.com/dapr/components-contrib/common/features"
 )
 
+const (
+	// FeatureKeyManagement is the feature that allows creating, listing and disabling keys through the KeyManager interface.
+	FeatureKeyManagement Feature = "KEY_MANAGEMENT"
+	// FeatureKeyRotation is the feature that allows rotating keys while keeping old versions available for decryption.
+	FeatureKeyRotation Feature = "KEY_ROTATION"
+	// FeatureDataKey is the feature that allows generating wrapped data encryption keys through the DataKeyGenerator interface.
+	FeatureDataKey Feature = "DATA_KEY"
+)
+
 // Feature names a feature that can be implemented by the crypto provider components.
 type Feature = features.Feature[SubtleCrypto]
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"slices"
+	"strings"
+
+	internals "github.com/dapr/kit/crypto"
+)
+
+// Key types of the Transit secrets engine.
+// ref: https://developer.hashicorp.com/vault/api-docs/secret/transit#type
+const (
+	keyTypeAES128GCM96      = "aes128-gcm96"
+	keyTypeAES256GCM96      = "aes256-gcm96"
+	keyTypeChaCha20Poly1305 = "chacha20-poly1305"
+	keyTypeECDSAP256        = "ecdsa-p256"
+	keyTypeECDSAP384        = "ecdsa-p384"
+	keyTypeECDSAP521        = "ecdsa-p521"
+	keyTypeED25519          = "ed25519"
+	keyTypeRSAPrefix        = "rsa-"
+)
+
+// Transit picks the cipher from the type of the key, so each algorithm is only accepted with the matching key types.
+var encryptionAlgs = map[string]func(keyType string) bool{
+	internals.Algorithm_A128GCM:      isKeyType(keyTypeAES128GCM96),
+	internals.Algorithm_A256GCM:      isKeyType(keyTypeAES256GCM96),
+	internals.Algorithm_C20P:         isKeyType(keyTypeChaCha20Poly1305),
+	internals.Algorithm_RSA_OAEP_256: isRSAKeyType,
+}
+
+// signatureAlg contains the parameters of a Transit sign/verify request for a signature algorithm.
+type signatureAlg struct {
+	keyTypeFn          func(keyType string) bool
+	hashAlgorithm      string
+	signatureAlgorithm string
+	prehashed          bool
+}
+
+var signatureAlgs = map[string]signatureAlg{
+	internals.Algorithm_RS256: {keyTypeFn: isRSAKeyType, hashAlgorithm: "sha2-256", signatureAlgorithm: "pkcs1v15", prehashed: true},
+	internals.Algorithm_RS384: {keyTypeFn: isRSAKeyType, hashAlgorithm: "sha2-384", signatureAlgorithm: "pkcs1v15", prehashed: true},
+	internals.Algorithm_RS512: {keyTypeFn: isRSAKeyType, hashAlgorithm: "sha2-512", signatureAlgorithm: "pkcs1v15", prehashed: true},
+	internals.Algorithm_PS256: {keyTypeFn: isRSAKeyType, hashAlgorithm: "sha2-256", signatureAlgorithm: "pss", prehashed: true},
+	internals.Algorithm_PS384: {keyTypeFn: isRSAKeyType, hashAlgorithm: "sha2-384", signatureAlgorithm: "pss", prehashed: true},
+	internals.Algorithm_PS512: {keyTypeFn: isRSAKeyType, hashAlgorithm: "sha2-512", signatureAlgorithm: "pss", prehashed: true},
+	internals.Algorithm_ES256: {keyTypeFn: isKeyType(keyTypeECDSAP256), hashAlgorithm: "sha2-256", prehashed: true},
+	internals.Algorithm_ES384: {keyTypeFn: isKeyType(keyTypeECDSAP384), hashAlgorithm: "sha2-384", prehashed: true},
+	internals.Algorithm_ES512: {keyTypeFn: isKeyType(keyTypeECDSAP521), hashAlgorithm: "sha2-512", prehashed: true},
+	// Ed25519 signs the message itself, which is passed as the "digest"
+	internals.Algorithm_EdDSA: {keyTypeFn: isKeyType(keyTypeED25519)},
+}
+
+var (
+	encryptionAlgsList = sortedKeys(encryptionAlgs)
+	signatureAlgsList  = sortedKeys(signatureAlgs)
+)
+
+func isKeyType(expect string) func(keyType string) bool {
+	return func(keyType string) bool {
+		return keyType == expect
+	}
+}
+
+func isRSAKeyType(keyType string) bool {
+	return strings.HasPrefix(keyType, keyTypeRSAPrefix)
+}
+
+// isSymmetricKeyType returns true for key types that have no public part.
+func isSymmetricKeyType(keyType string) bool {
+	switch keyType {
+	case keyTypeAES128GCM96, keyTypeAES256GCM96, keyTypeChaCha20Poly1305:
+		return true
+	default:
+		// Includes HMAC and managed keys
+		return !isRSAKeyType(keyType) && keyType != keyTypeED25519 &&
+			keyType != keyTypeECDSAP256 && keyType != keyTypeECDSAP384 && keyType != keyTypeECDSAP521
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	slices.Sort(res)
+	return res
+}
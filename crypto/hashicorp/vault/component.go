@@ -0,0 +1,559 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	vaultclient "github.com/dapr/components-contrib/common/component/hashicorp/vault"
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	internals "github.com/dapr/kit/crypto"
+	"github.com/dapr/kit/logger"
+)
+
+var _ contribCrypto.DataKeyGenerator = (*vaultTransitCrypto)(nil)
+
+type vaultTransitCrypto struct {
+	keyCache *contribCrypto.PubKeyCache
+	md       vaultTransitMetadata
+	client   *vaultclient.Client
+	logger   logger.Logger
+
+	// Type of each key, which never changes once a key is created
+	keyTypes     map[string]string
+	keyTypesLock sync.RWMutex
+}
+
+// NewVaultTransitCrypto returns a new crypto provider that uses the Transit secrets engine of HashiCorp Vault.
+func NewVaultTransitCrypto(logger logger.Logger) contribCrypto.SubtleCrypto {
+	return &vaultTransitCrypto{
+		logger:   logger,
+		keyTypes: map[string]string{},
+	}
+}
+
+// Init creates a HashiCorp Vault client.
+func (k *vaultTransitCrypto) Init(_ context.Context, metadata contribCrypto.Metadata) error {
+	// Init the metadata
+	err := k.md.InitWithMetadata(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to load metadata: %w", err)
+	}
+
+	// Create a cache for keys
+	k.keyCache = contribCrypto.NewPubKeyCache(k.getKeyCacheFn)
+
+	k.client, err = vaultclient.NewClient(k.md.ClientMetadata, k.logger)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Features returns the features available in this crypto provider.
+func (k *vaultTransitCrypto) Features() []contribCrypto.Feature {
+	return []contribCrypto.Feature{contribCrypto.FeatureDataKey}
+}
+
+// transitKey is the response data for a key read from Transit.
+type transitKey struct {
+	Type          string                     `json:"type"`
+	LatestVersion int                        `json:"latest_version"`
+	Keys          map[string]json.RawMessage `json:"keys"`
+}
+
+// GetKey returns the public part of a key stored in the vault.
+// This method returns an error if the key is symmetric.
+// The key argument can be in the format "name" or "name/version".
+func (k *vaultTransitCrypto) GetKey(parentCtx context.Context, key string) (pubKey jwk.Key, err error) {
+	kid := newKeyID(key)
+
+	// If the key is cacheable, get it from the cache
+	if kid.Cacheable() {
+		return k.keyCache.GetKey(parentCtx, key)
+	}
+
+	return k.getKeyFromVault(parentCtx, kid)
+}
+
+func (k *vaultTransitCrypto) getKeyFromVault(parentCtx context.Context, kid keyID) (pubKey jwk.Key, err error) {
+	tk, err := k.readKey(parentCtx, kid.Name)
+	if err != nil {
+		return nil, err
+	}
+	if isSymmetricKeyType(tk.Type) {
+		return nil, fmt.Errorf("key '%s' of type '%s' does not have a public part", kid.Name, tk.Type)
+	}
+
+	version := kid.Version
+	if !kid.Cacheable() {
+		version = strconv.Itoa(tk.LatestVersion)
+	}
+	rawVersion, ok := tk.Keys[version]
+	if !ok {
+		return nil, contribCrypto.ErrKeyNotFound
+	}
+	var keyVersion struct {
+		PublicKey string `json:"public_key"`
+	}
+	err = json.Unmarshal(rawVersion, &keyVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key from Vault: %w", err)
+	}
+
+	if tk.Type == keyTypeED25519 {
+		// Ed25519 public keys are returned as base64-encoded bytes rather than PEM
+		var raw []byte
+		raw, err = base64.StdEncoding.DecodeString(keyVersion.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key: %w", err)
+		}
+		pubKey, err = jwk.FromRaw(ed25519.PublicKey(raw))
+	} else {
+		pubKey, err = jwk.ParseKey([]byte(keyVersion.PublicKey), jwk.WithPEM(true))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	err = pubKey.Set(jwk.KeyIDKey, kid.Name+"/"+version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set key ID: %w", err)
+	}
+	return pubKey, nil
+}
+
+// Handler for the getKeyCacheFn method
+func (k *vaultTransitCrypto) getKeyCacheFn(ctx context.Context, key string) func(resolve func(jwk.Key), reject func(error)) {
+	kid := newKeyID(key)
+	return func(resolve func(jwk.Key), reject func(error)) {
+		pk, err := k.getKeyFromVault(ctx, kid)
+		if err != nil {
+			reject(err)
+			return
+		}
+		resolve(pk)
+	}
+}
+
+func (k *vaultTransitCrypto) readKey(parentCtx context.Context, name string) (*transitKey, error) {
+	var res struct {
+		Data transitKey `json:"data"`
+	}
+	err := k.do(parentCtx, http.MethodGet, "keys", name, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	k.keyTypesLock.Lock()
+	k.keyTypes[name] = res.Data.Type
+	k.keyTypesLock.Unlock()
+
+	return &res.Data, nil
+}
+
+// getKeyType returns the type of a key, reading it from Vault the first time.
+func (k *vaultTransitCrypto) getKeyType(parentCtx context.Context, name string) (string, error) {
+	k.keyTypesLock.RLock()
+	keyType, ok := k.keyTypes[name]
+	k.keyTypesLock.RUnlock()
+	if ok {
+		return keyType, nil
+	}
+
+	tk, err := k.readKey(parentCtx, name)
+	if err != nil {
+		return "", err
+	}
+	return tk.Type, nil
+}
+
+// checkEncryptionAlgorithm returns an error if the key can't be used with the encryption algorithm.
+func (k *vaultTransitCrypto) checkEncryptionAlgorithm(parentCtx context.Context, algorithm string, kid keyID) error {
+	keyTypeFn, ok := encryptionAlgs[algorithm]
+	if !ok {
+		return fmt.Errorf("invalid algorithm: %s", algorithm)
+	}
+	keyType, err := k.getKeyType(parentCtx, kid.Name)
+	if err != nil {
+		return err
+	}
+	if !keyTypeFn(keyType) {
+		return fmt.Errorf("key cannot be used with algorithm '%s'", algorithm)
+	}
+	return nil
+}
+
+// Encrypt a small message and returns the ciphertext.
+// The key argument can be in the format "name" or "name/version".
+// Transit generates the nonce and includes it in the ciphertext, which is returned in Vault's "vault:v<version>:<data>" format.
+func (k *vaultTransitCrypto) Encrypt(parentCtx context.Context, plaintext []byte, algorithm string, key string, nonce []byte, associatedData []byte) (ciphertext []byte, tag []byte, err error) {
+	kid := newKeyID(key)
+
+	err = k.checkEncryptionAlgorithm(parentCtx, algorithm, kid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return k.encryptInVault(parentCtx, plaintext, kid, associatedData)
+}
+
+func (k *vaultTransitCrypto) encryptInVault(parentCtx context.Context, plaintext []byte, kid keyID, associatedData []byte) (ciphertext []byte, tag []byte, err error) {
+	keyVersion, err := kid.VersionNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := map[string]any{
+		"plaintext": base64.StdEncoding.EncodeToString(plaintext),
+	}
+	if len(associatedData) > 0 {
+		req["associated_data"] = base64.StdEncoding.EncodeToString(associatedData)
+	}
+	if keyVersion > 0 {
+		req["key_version"] = keyVersion
+	}
+
+	var res struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	err = k.do(parentCtx, http.MethodPost, "encrypt", kid.Name, req, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.Data.Ciphertext == "" {
+		return nil, nil, errors.New("response from Vault does not contain a valid ciphertext")
+	}
+
+	return []byte(res.Data.Ciphertext), nil, nil
+}
+
+// Decrypt a small message and returns the plaintext.
+// The key argument can be in the format "name" or "name/version"; the version used is the one encoded in the ciphertext.
+func (k *vaultTransitCrypto) Decrypt(parentCtx context.Context, ciphertext []byte, algorithm string, key string, nonce []byte, tag []byte, associatedData []byte) (plaintext []byte, err error) {
+	kid := newKeyID(key)
+
+	err = k.checkEncryptionAlgorithm(parentCtx, algorithm, kid)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.decryptInVault(parentCtx, ciphertext, kid, associatedData)
+}
+
+func (k *vaultTransitCrypto) decryptInVault(parentCtx context.Context, ciphertext []byte, kid keyID, associatedData []byte) (plaintext []byte, err error) {
+	req := map[string]any{
+		"ciphertext": string(ciphertext),
+	}
+	if len(associatedData) > 0 {
+		req["associated_data"] = base64.StdEncoding.EncodeToString(associatedData)
+	}
+
+	var res struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	err = k.do(parentCtx, http.MethodPost, "decrypt", kid.Name, req, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err = base64.StdEncoding.DecodeString(res.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("response from Vault does not contain a valid plaintext: %w", err)
+	}
+	return plaintext, nil
+}
+
+// WrapKey wraps a symmetric key, such as a data encryption key.
+// The key argument can be in the format "name" or "name/version".
+// Keys are wrapped with Transit's encrypt endpoint; to have Vault generate the data key too, use GenerateDataKey.
+func (k *vaultTransitCrypto) WrapKey(parentCtx context.Context, plaintextKey jwk.Key, algorithm string, key string, nonce []byte, associatedData []byte) (wrappedKey []byte, tag []byte, err error) {
+	// Only symmetric keys can be wrapped, so unwrapped keys can be parsed from raw bytes
+	if plaintextKey.KeyType() != jwa.OctetSeq {
+		return nil, nil, errors.New("cannot wrap asymmetric keys")
+	}
+	plaintext, err := internals.SerializeKey(plaintextKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot serialize key: %w", err)
+	}
+
+	return k.Encrypt(parentCtx, plaintext, algorithm, key, nonce, associatedData)
+}
+
+// UnwrapKey unwraps a key.
+// The key argument can be in the format "name" or "name/version".
+func (k *vaultTransitCrypto) UnwrapKey(parentCtx context.Context, wrappedKey []byte, algorithm string, key string, nonce []byte, tag []byte, associatedData []byte) (plaintextKey jwk.Key, err error) {
+	plaintext, err := k.Decrypt(parentCtx, wrappedKey, algorithm, key, nonce, tag, associatedData)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintextKey, err = jwk.FromRaw(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWK from raw key: %w", err)
+	}
+
+	return plaintextKey, nil
+}
+
+// GenerateDataKey generates a data key with Transit's datakey endpoint, which returns it both in plaintext and encrypted with the key.
+// The key argument must be in the format "name": Transit always wraps data keys with the latest version of the key.
+// The wrapped key is returned in Vault's "vault:v<version>:<data>" format, and can be unwrapped with UnwrapKey.
+func (k *vaultTransitCrypto) GenerateDataKey(parentCtx context.Context, algorithm string, key string, bits int) (plaintextKey jwk.Key, wrappedKey []byte, err error) {
+	kid := newKeyID(key)
+	if kid.Version != "" {
+		return nil, nil, errors.New("data keys are always wrapped with the latest version of the key, which can't be specified")
+	}
+	if bits == 0 {
+		bits = contribCrypto.DefaultDataKeySize
+	}
+	switch bits {
+	case 128, 256, 512:
+		// Nop
+	default:
+		return nil, nil, fmt.Errorf("invalid data key size %d: must be 128, 256 or 512 bits", bits)
+	}
+
+	err = k.checkEncryptionAlgorithm(parentCtx, algorithm, kid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var res struct {
+		Data struct {
+			Plaintext  string `json:"plaintext"`
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	err = k.doPath(parentCtx, http.MethodPost, k.md.TransitPath+"/datakey/plaintext/"+url.PathEscape(kid.Name), map[string]any{"bits": bits}, &res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(res.Data.Plaintext)
+	if err != nil || len(raw) != bits/8 {
+		return nil, nil, errors.New("response from Vault does not contain a valid plaintext key")
+	}
+	if res.Data.Ciphertext == "" {
+		return nil, nil, errors.New("response from Vault does not contain a valid ciphertext")
+	}
+
+	plaintextKey, err = jwk.FromRaw(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create JWK from raw key: %w", err)
+	}
+	return plaintextKey, []byte(res.Data.Ciphertext), nil
+}
+
+// checkSignatureAlgorithm returns the parameters for the signature algorithm, or an error if the key can't be used with it.
+func (k *vaultTransitCrypto) checkSignatureAlgorithm(parentCtx context.Context, algorithm string, kid keyID) (signatureAlg, error) {
+	alg, ok := signatureAlgs[algorithm]
+	if !ok {
+		return signatureAlg{}, fmt.Errorf("invalid algorithm: %s", algorithm)
+	}
+	keyType, err := k.getKeyType(parentCtx, kid.Name)
+	if err != nil {
+		return signatureAlg{}, err
+	}
+	if !alg.keyTypeFn(keyType) {
+		return signatureAlg{}, fmt.Errorf("key cannot be used with algorithm '%s'", algorithm)
+	}
+	return alg, nil
+}
+
+func (alg signatureAlg) request(digest []byte) map[string]any {
+	req := map[string]any{
+		"input": base64.StdEncoding.EncodeToString(digest),
+	}
+	if alg.prehashed {
+		req["prehashed"] = true
+	}
+	if alg.signatureAlgorithm != "" {
+		req["signature_algorithm"] = alg.signatureAlgorithm
+	}
+	return req
+}
+
+func (alg signatureAlg) path(op string) string {
+	if alg.hashAlgorithm == "" {
+		return op
+	}
+	return op + "/" + alg.hashAlgorithm
+}
+
+// Sign a digest.
+// The key argument can be in the format "name" or "name/version".
+// The signature is returned in Vault's "vault:v<version>:<signature>" format.
+func (k *vaultTransitCrypto) Sign(parentCtx context.Context, digest []byte, algorithm string, key string) (signature []byte, err error) {
+	kid := newKeyID(key)
+
+	alg, err := k.checkSignatureAlgorithm(parentCtx, algorithm, kid)
+	if err != nil {
+		return nil, err
+	}
+	keyVersion, err := kid.VersionNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	req := alg.request(digest)
+	if keyVersion > 0 {
+		req["key_version"] = keyVersion
+	}
+
+	var res struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	err = k.do(parentCtx, http.MethodPost, alg.path("sign"), kid.Name, req, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Data.Signature == "" {
+		return nil, errors.New("response from Vault does not contain a valid signature")
+	}
+
+	return []byte(res.Data.Signature), nil
+}
+
+// Verify a signature.
+// The key argument can be in the format "name" or "name/version"; the version used is the one encoded in the signature.
+func (k *vaultTransitCrypto) Verify(parentCtx context.Context, digest []byte, signature []byte, algorithm string, key string) (valid bool, err error) {
+	kid := newKeyID(key)
+
+	alg, err := k.checkSignatureAlgorithm(parentCtx, algorithm, kid)
+	if err != nil {
+		return false, err
+	}
+
+	req := alg.request(digest)
+	req["signature"] = string(signature)
+
+	var res struct {
+		Data struct {
+			Valid bool `json:"valid"`
+		} `json:"data"`
+	}
+	err = k.do(parentCtx, http.MethodPost, alg.path("verify"), kid.Name, req, &res)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Data.Valid, nil
+}
+
+// do sends a request to the Transit endpoint "<transitPath>/<op>/<keyName>".
+// If op contains a "/", such as "sign/sha2-256", the part after it is appended to the path after the key name.
+func (k *vaultTransitCrypto) do(parentCtx context.Context, method string, op string, keyName string, body any, out any) error {
+	op, suffix, _ := strings.Cut(op, "/")
+	path := k.md.TransitPath + "/" + op + "/" + url.PathEscape(keyName)
+	if suffix != "" {
+		path += "/" + suffix
+	}
+	return k.doPath(parentCtx, method, path, body, out)
+}
+
+// doPath sends a request to a path of the Vault API.
+func (k *vaultTransitCrypto) doPath(parentCtx context.Context, method string, path string, body any, out any) error {
+	ctx, cancel := context.WithTimeout(parentCtx, k.md.RequestTimeout)
+	err := k.client.Do(ctx, method, path, body, out)
+	cancel()
+	if vaultclient.IsNotFound(err) {
+		return contribCrypto.ErrKeyNotFound
+	} else if err != nil {
+		return fmt.Errorf("error from Vault: %w", err)
+	}
+	return nil
+}
+
+func (k *vaultTransitCrypto) Close() error {
+	return nil
+}
+
+func (*vaultTransitCrypto) SupportedEncryptionAlgorithms() []string {
+	return encryptionAlgsList
+}
+
+func (*vaultTransitCrypto) SupportedSignatureAlgorithms() []string {
+	return signatureAlgsList
+}
+
+func (*vaultTransitCrypto) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
+	metadataStruct := vaultTransitMetadata{}
+	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.CryptoType)
+	return
+}
+
+type keyID struct {
+	Version string
+	Name    string
+}
+
+func newKeyID(val string) keyID {
+	obj := keyID{}
+	idx := strings.IndexRune(val, '/')
+	// Can't be on position 0, because the key name must be at least 1 character
+	if idx > 0 {
+		obj.Version = val[idx+1:]
+		obj.Name = val[:idx]
+	} else {
+		obj.Name = val
+	}
+	return obj
+}
+
+// Cacheable returns true if the key can be cached locally.
+func (id keyID) Cacheable() bool {
+	switch strings.ToLower(id.Version) {
+	case "", "latest":
+		return false
+	default:
+		return true
+	}
+}
+
+// VersionNumber returns the version of the key as a number, or 0 for the latest version.
+func (id keyID) VersionNumber() (int, error) {
+	if !id.Cacheable() {
+		return 0, nil
+	}
+	v, err := strconv.Atoi(id.Version)
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("invalid key version '%s'", id.Version)
+	}
+	return v, nil
+}
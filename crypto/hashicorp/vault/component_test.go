@@ -0,0 +1,310 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+const testToken = "test-token"
+
+// newFakeTransit returns a server that implements the subset of the Transit API used by the component.
+// Ciphertexts are not encrypted: they contain the associated data and plaintext, to check they are passed correctly.
+func newFakeTransit(t *testing.T, ecKey *ecdsa.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	pubDer, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+	pubPem := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDer}))
+
+	keys := map[string]any{
+		"aeskey": map[string]any{
+			"type":           "aes256-gcm96",
+			"latest_version": 2,
+			"keys":           map[string]any{"1": 1700000000, "2": 1700000001},
+		},
+		"eckey": map[string]any{
+			"type":           "ecdsa-p256",
+			"latest_version": 1,
+			"keys":           map[string]any{"1": map[string]any{"public_key": pubPem}},
+		},
+	}
+
+	respond := func(w http.ResponseWriter, data map[string]any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != testToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/transit/"), "/")
+		if len(parts) == 3 && parts[0] == "datakey" {
+			// Path is "datakey/<type>/<name>"
+			parts[1], parts[2] = parts[2], parts[1]
+		}
+		if len(parts) < 2 || keys[parts[1]] == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+
+		var req struct {
+			Plaintext      string `json:"plaintext"`
+			Ciphertext     string `json:"ciphertext"`
+			AssociatedData string `json:"associated_data"`
+			KeyVersion     int    `json:"key_version"`
+			Input          string `json:"input"`
+			Prehashed      bool   `json:"prehashed"`
+			Signature      string `json:"signature"`
+			Bits           int    `json:"bits"`
+		}
+		if r.Method == http.MethodPost {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		}
+
+		switch parts[0] {
+		case "keys":
+			respond(w, keys[parts[1]].(map[string]any))
+		case "encrypt":
+			version := req.KeyVersion
+			if version == 0 {
+				version = 2
+			}
+			respond(w, map[string]any{
+				"ciphertext": "vault:v" + string(rune('0'+version)) + ":" + req.AssociatedData + "." + req.Plaintext,
+			})
+		case "decrypt":
+			_, data, _ := strings.Cut(strings.TrimPrefix(req.Ciphertext, "vault:"), ":")
+			aad, plaintext, _ := strings.Cut(data, ".")
+			if aad != req.AssociatedData {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errors":["cipher: message authentication failed"]}`))
+				return
+			}
+			respond(w, map[string]any{"plaintext": plaintext})
+		case "datakey":
+			assert.Equal(t, "plaintext", parts[2])
+			dk := make([]byte, req.Bits/8)
+			_, err := rand.Read(dk)
+			require.NoError(t, err)
+			plaintext := base64.StdEncoding.EncodeToString(dk)
+			respond(w, map[string]any{
+				"plaintext":  plaintext,
+				"ciphertext": "vault:v2:." + plaintext,
+			})
+		case "sign":
+			assert.Equal(t, "sha2-256", parts[2])
+			assert.True(t, req.Prehashed)
+			digest, _ := base64.StdEncoding.DecodeString(req.Input)
+			sig, err := ecdsa.SignASN1(rand.Reader, ecKey, digest)
+			require.NoError(t, err)
+			respond(w, map[string]any{"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(sig)})
+		case "verify":
+			assert.Equal(t, "sha2-256", parts[2])
+			digest, _ := base64.StdEncoding.DecodeString(req.Input)
+			sig, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(req.Signature, "vault:v1:"))
+			respond(w, map[string]any{"valid": ecdsa.VerifyASN1(&ecKey.PublicKey, digest, sig)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestVaultTransitCrypto(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server := newFakeTransit(t, ecKey)
+	defer server.Close()
+
+	component := NewVaultTransitCrypto(logger.NewLogger("test"))
+	err = component.Init(t.Context(), contribCrypto.Metadata{Base: metadata.Base{
+		Properties: map[string]string{
+			"vaultAddr":  server.URL,
+			"vaultToken": testToken,
+		},
+	}})
+	require.NoError(t, err)
+	defer component.Close()
+
+	t.Run("get public key", func(t *testing.T) {
+		for _, name := range []string{"eckey", "eckey/1"} {
+			key, err := component.GetKey(t.Context(), name)
+			require.NoError(t, err)
+
+			expect, err := jwk.FromRaw(&ecKey.PublicKey)
+			require.NoError(t, err)
+			expectThumbprint, _ := expect.Thumbprint(crypto.SHA256)
+			thumbprint, _ := key.Thumbprint(crypto.SHA256)
+			assert.Equal(t, expectThumbprint, thumbprint)
+			assert.Equal(t, "eckey/1", key.KeyID())
+		}
+	})
+
+	t.Run("get symmetric key fails", func(t *testing.T) {
+		_, err := component.GetKey(t.Context(), "aeskey")
+		require.Error(t, err)
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, err := component.GetKey(t.Context(), "notfound")
+		require.ErrorIs(t, err, contribCrypto.ErrKeyNotFound)
+	})
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		ciphertext, tag, err := component.Encrypt(t.Context(), []byte("hello"), "A256GCM", "aeskey", nil, []byte("aad"))
+		require.NoError(t, err)
+		assert.Nil(t, tag)
+		assert.True(t, strings.HasPrefix(string(ciphertext), "vault:v2:"))
+
+		plaintext, err := component.Decrypt(t.Context(), ciphertext, "A256GCM", "aeskey", nil, nil, []byte("aad"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(plaintext))
+
+		_, err = component.Decrypt(t.Context(), ciphertext, "A256GCM", "aeskey", nil, nil, []byte("other"))
+		require.ErrorContains(t, err, "message authentication failed")
+	})
+
+	t.Run("encrypt with key version", func(t *testing.T) {
+		ciphertext, _, err := component.Encrypt(t.Context(), []byte("hello"), "A256GCM", "aeskey/1", nil, nil)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(ciphertext), "vault:v1:"))
+
+		_, _, err = component.Encrypt(t.Context(), []byte("hello"), "A256GCM", "aeskey/abc", nil, nil)
+		require.ErrorContains(t, err, "invalid key version")
+	})
+
+	t.Run("algorithm must match key type", func(t *testing.T) {
+		_, _, err := component.Encrypt(t.Context(), []byte("hello"), "A128GCM", "aeskey", nil, nil)
+		require.ErrorContains(t, err, "key cannot be used with algorithm")
+
+		_, _, err = component.Encrypt(t.Context(), []byte("hello"), "A256CBC", "aeskey", nil, nil)
+		require.ErrorContains(t, err, "invalid algorithm")
+
+		_, err = component.Sign(t.Context(), []byte("hello"), "ES256", "aeskey")
+		require.ErrorContains(t, err, "key cannot be used with algorithm")
+	})
+
+	t.Run("wrap and unwrap key", func(t *testing.T) {
+		dek, err := jwk.FromRaw([]byte("0123456789abcdef0123456789abcdef"))
+		require.NoError(t, err)
+
+		wrapped, _, err := component.WrapKey(t.Context(), dek, "A256GCM", "aeskey", nil, nil)
+		require.NoError(t, err)
+
+		unwrapped, err := component.UnwrapKey(t.Context(), wrapped, "A256GCM", "aeskey", nil, nil, nil)
+		require.NoError(t, err)
+		var raw []byte
+		require.NoError(t, unwrapped.Raw(&raw))
+		assert.Equal(t, "0123456789abcdef0123456789abcdef", string(raw))
+
+		pub, err := jwk.FromRaw(&ecKey.PublicKey)
+		require.NoError(t, err)
+		_, _, err = component.WrapKey(t.Context(), pub, "A256GCM", "aeskey", nil, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("generate data key", func(t *testing.T) {
+		generator, ok := component.(contribCrypto.DataKeyGenerator)
+		require.True(t, ok)
+		assert.True(t, contribCrypto.FeatureDataKey.IsPresent(component.(*vaultTransitCrypto).Features()))
+
+		dek, wrapped, err := generator.GenerateDataKey(t.Context(), "A256GCM", "aeskey", 0)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(wrapped), "vault:v2:"))
+		var raw []byte
+		require.NoError(t, dek.Raw(&raw))
+		assert.Len(t, raw, 32)
+
+		unwrapped, err := component.UnwrapKey(t.Context(), wrapped, "A256GCM", "aeskey", nil, nil, nil)
+		require.NoError(t, err)
+		var unwrappedRaw []byte
+		require.NoError(t, unwrapped.Raw(&unwrappedRaw))
+		assert.Equal(t, raw, unwrappedRaw)
+
+		dek, _, err = generator.GenerateDataKey(t.Context(), "A256GCM", "aeskey", 512)
+		require.NoError(t, err)
+		require.NoError(t, dek.Raw(&raw))
+		assert.Len(t, raw, 64)
+
+		_, _, err = generator.GenerateDataKey(t.Context(), "A256GCM", "aeskey", 100)
+		require.Error(t, err)
+		_, _, err = generator.GenerateDataKey(t.Context(), "A256GCM", "aeskey/1", 0)
+		require.Error(t, err)
+		_, _, err = generator.GenerateDataKey(t.Context(), "ES256", "eckey", 0)
+		require.Error(t, err)
+		_, _, err = generator.GenerateDataKey(t.Context(), "A256GCM", "missing", 0)
+		require.ErrorIs(t, err, contribCrypto.ErrKeyNotFound)
+	})
+
+	t.Run("sign and verify", func(t *testing.T) {
+		digest := sha256.Sum256([]byte("hello"))
+		signature, err := component.Sign(t.Context(), digest[:], "ES256", "eckey")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(signature), "vault:v1:"))
+
+		valid, err := component.Verify(t.Context(), digest[:], signature, "ES256", "eckey")
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		other := sha256.Sum256([]byte("world"))
+		valid, err = component.Verify(t.Context(), other[:], signature, "ES256", "eckey")
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+}
+
+func TestVaultTransitMetadata(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		md := vaultTransitMetadata{}
+		err := md.InitWithMetadata(contribCrypto.Metadata{Base: metadata.Base{
+			Properties: map[string]string{"vaultToken": testToken},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, defaultTransitPath, md.TransitPath)
+		assert.Equal(t, defaultRequestTimeout, md.RequestTimeout)
+	})
+
+	t.Run("token is required", func(t *testing.T) {
+		component := NewVaultTransitCrypto(logger.NewLogger("test"))
+		err := component.Init(t.Context(), contribCrypto.Metadata{Base: metadata.Base{
+			Properties: map[string]string{"transitPath": "/my-transit/"},
+		}})
+		require.ErrorContains(t, err, "token mount path and token not set")
+	})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"strings"
+	"time"
+
+	vaultclient "github.com/dapr/components-contrib/common/component/hashicorp/vault"
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultRequestTimeout = 30 * time.Second
+	defaultTransitPath    = "transit"
+)
+
+type vaultTransitMetadata struct {
+	vaultclient.ClientMetadata `mapstructure:",squash"`
+
+	// Path where the Transit secrets engine is mounted.
+	// Defaults to "transit".
+	TransitPath string `json:"transitPath" mapstructure:"transitPath"`
+
+	// Timeout for network requests, as a Go duration string (e.g. "30s")
+	// Defaults to "30s".
+	RequestTimeout time.Duration `json:"requestTimeout" mapstructure:"requestTimeout"`
+}
+
+func (m *vaultTransitMetadata) InitWithMetadata(meta contribCrypto.Metadata) error {
+	m.reset()
+
+	// Decode the metadata
+	err := metadata.DecodeMetadata(meta.Properties, &m)
+	if err != nil {
+		return err
+	}
+
+	m.TransitPath = strings.Trim(m.TransitPath, "/")
+	if m.TransitPath == "" {
+		m.TransitPath = defaultTransitPath
+	}
+
+	// Set default requestTimeout if empty
+	if m.RequestTimeout < time.Second {
+		m.RequestTimeout = defaultRequestTimeout
+	}
+
+	return nil
+}
+
+// Reset the object
+func (m *vaultTransitMetadata) reset() {
+	m.ClientMetadata = vaultclient.ClientMetadata{}
+	m.TransitPath = defaultTransitPath
+	m.RequestTimeout = defaultRequestTimeout
+}
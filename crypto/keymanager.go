@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	internals "github.com/dapr/kit/crypto"
+)
+
+// DefaultRSAKeySize is the size, in bits, of RSA keys generated when CreateKeyRequest.KeySize is not set.
+const DefaultRSAKeySize = 3072
+
+var (
+	// ErrKeyExists is returned when creating a key that already exists.
+	ErrKeyExists = errors.New("key already exists")
+	// ErrKeyDisabled is returned when using a key that has been disabled.
+	ErrKeyDisabled = errors.New("key is disabled")
+)
+
+// KeyManager is an optional interface for crypto providers that can create and manage the lifecycle of keys.
+// Components that implement it advertise FeatureKeyManagement.
+type KeyManager interface {
+	// CreateKey generates a new key with the given algorithm and stores it as its first version.
+	CreateKey(ctx context.Context, req CreateKeyRequest) (*KeyInfo, error)
+
+	// RotateKey generates a new version of an existing key, which becomes the current one.
+	// Older versions are kept and can still be used by name/version to decrypt, unwrap and verify.
+	RotateKey(ctx context.Context, keyName string) (*KeyInfo, error)
+
+	// ListKeys returns the keys managed by the provider.
+	ListKeys(ctx context.Context) ([]KeyInfo, error)
+
+	// DisableKey disables a key, including all its versions, so it can't be used for any operation.
+	DisableKey(ctx context.Context, keyName string) error
+}
+
+// CreateKeyRequest is the request object for KeyManager.CreateKey.
+type CreateKeyRequest struct {
+	// Name of the key.
+	Name string
+	// Algorithm the key is generated for, such as "A256GCM", "RSA-OAEP-256" or "ES256".
+	// The key can only be used with this algorithm.
+	Algorithm string
+	// KeySize is the size in bits of RSA keys.
+	// Optional, defaults to DefaultRSAKeySize; ignored for other algorithms.
+	KeySize int
+}
+
+// KeyInfo contains the properties of a managed key.
+type KeyInfo struct {
+	Name           string           `json:"name"`
+	Algorithm      string           `json:"algorithm"`
+	CurrentVersion string           `json:"currentVersion"`
+	Enabled        bool             `json:"enabled"`
+	Versions       []KeyVersionInfo `json:"versions"`
+}
+
+// KeyVersionInfo contains the properties of a version of a managed key.
+type KeyVersionInfo struct {
+	Version   string    `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GenerateKey generates a new random key that can be used with the given algorithm.
+// The key's "alg" property is set to algorithm and its "kid" property to kid, if not empty.
+func GenerateKey(algorithm string, rsaKeySize int, kid string) (jwk.Key, error) {
+	if rsaKeySize <= 0 {
+		rsaKeySize = DefaultRSAKeySize
+	}
+
+	var (
+		raw any
+		err error
+	)
+	switch algorithm {
+	case internals.Algorithm_A128CBC, internals.Algorithm_A128CBC_NOPAD, internals.Algorithm_A128GCM,
+		internals.Algorithm_A128KW, internals.Algorithm_A128GCMKW:
+		raw, err = randomBytes(16)
+	case internals.Algorithm_A192CBC, internals.Algorithm_A192CBC_NOPAD, internals.Algorithm_A192GCM,
+		internals.Algorithm_A192KW, internals.Algorithm_A192GCMKW:
+		raw, err = randomBytes(24)
+	case internals.Algorithm_A256CBC, internals.Algorithm_A256CBC_NOPAD, internals.Algorithm_A256GCM,
+		internals.Algorithm_A256KW, internals.Algorithm_A256GCMKW, internals.Algorithm_A128CBC_HS256,
+		internals.Algorithm_C20P, internals.Algorithm_XC20P, internals.Algorithm_C20PKW, internals.Algorithm_XC20PKW,
+		internals.Algorithm_HS256:
+		raw, err = randomBytes(32)
+	case internals.Algorithm_A192CBC_HS384, internals.Algorithm_HS384:
+		raw, err = randomBytes(48)
+	case internals.Algorithm_A256CBC_HS512, internals.Algorithm_HS512:
+		raw, err = randomBytes(64)
+	case internals.Algorithm_RSA1_5, internals.Algorithm_RSA_OAEP, internals.Algorithm_RSA_OAEP_256,
+		internals.Algorithm_RSA_OAEP_384, internals.Algorithm_RSA_OAEP_512,
+		internals.Algorithm_PS256, internals.Algorithm_PS384, internals.Algorithm_PS512,
+		internals.Algorithm_RS256, internals.Algorithm_RS384, internals.Algorithm_RS512:
+		raw, err = rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case internals.Algorithm_ES256, internals.Algorithm_ECDH_ES, internals.Algorithm_ECDH_ES_A128KW,
+		internals.Algorithm_ECDH_ES_A192KW, internals.Algorithm_ECDH_ES_A256KW:
+		raw, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case internals.Algorithm_ES384:
+		raw, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case internals.Algorithm_ES512:
+		raw, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case internals.Algorithm_EdDSA:
+		_, raw, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: %s", internals.ErrUnsupportedAlgorithm, algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	key, err := jwk.FromRaw(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JWK from generated key: %w", err)
+	}
+	err = key.Set(jwk.AlgorithmKey, algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set key algorithm: %w", err)
+	}
+	if kid != "" {
+		err = key.Set(jwk.KeyIDKey, kid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set key ID: %w", err)
+		}
+	}
+	return key, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	return b, err
+}
+
+// RestrictToPreviousVersionOps limits a key to the operations allowed on versions that are not the current one:
+// decrypt, unwrapKey and verify. This way, data protected with older versions can still be read after a rotation.
+func RestrictToPreviousVersionOps(key jwk.Key) error {
+	allowed := make(jwk.KeyOperationList, 0, 3)
+	for _, op := range []jwk.KeyOperation{jwk.KeyOpDecrypt, jwk.KeyOpUnwrapKey, jwk.KeyOpVerify} {
+		// Keep restrictions that were already set on the key
+		if KeyCanPerformOperation(key, op) {
+			allowed = append(allowed, op)
+		}
+	}
+	return key.Set(jwk.KeyOpsKey, allowed)
+}
+
+// ValidateKeyName returns an error if a managed key's name is empty or contains characters used as separators.
+func ValidateKeyName(name string) error {
+	if name == "" {
+		return errors.New("key name is required")
+	}
+	if strings.Contains(name, "..") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid key name '%s': cannot contain '..', '/' or '\\'", name)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internals "github.com/dapr/kit/crypto"
+)
+
+func TestGenerateKey(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		keyType   jwa.KeyType
+		size      int
+	}{
+		{algorithm: internals.Algorithm_A128GCM, keyType: jwa.OctetSeq, size: 16},
+		{algorithm: internals.Algorithm_A256KW, keyType: jwa.OctetSeq, size: 32},
+		{algorithm: internals.Algorithm_A256CBC_HS512, keyType: jwa.OctetSeq, size: 64},
+		{algorithm: internals.Algorithm_C20P, keyType: jwa.OctetSeq, size: 32},
+		{algorithm: internals.Algorithm_RSA_OAEP_256, keyType: jwa.RSA},
+		{algorithm: internals.Algorithm_ES384, keyType: jwa.EC},
+		{algorithm: internals.Algorithm_EdDSA, keyType: jwa.OKP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			key, err := GenerateKey(tt.algorithm, 2048, "mykey/1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.keyType, key.KeyType())
+			assert.Equal(t, tt.algorithm, key.Algorithm().String())
+			assert.Equal(t, "mykey/1", key.KeyID())
+			assert.True(t, KeyCanPerformAlgorithm(key, tt.algorithm))
+
+			if tt.size > 0 {
+				var raw []byte
+				require.NoError(t, key.Raw(&raw))
+				assert.Len(t, raw, tt.size)
+			}
+		})
+	}
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		_, err := GenerateKey("foo", 0, "")
+		require.ErrorIs(t, err, internals.ErrUnsupportedAlgorithm)
+	})
+}
+
+func TestRestrictToPreviousVersionOps(t *testing.T) {
+	key, err := GenerateKey(internals.Algorithm_A256GCM, 0, "")
+	require.NoError(t, err)
+	require.NoError(t, RestrictToPreviousVersionOps(key))
+
+	assert.True(t, KeyCanPerformOperation(key, jwk.KeyOpDecrypt))
+	assert.True(t, KeyCanPerformOperation(key, jwk.KeyOpUnwrapKey))
+	assert.False(t, KeyCanPerformOperation(key, jwk.KeyOpEncrypt))
+	assert.False(t, KeyCanPerformOperation(key, jwk.KeyOpWrapKey))
+	assert.False(t, KeyCanPerformOperation(key, jwk.KeyOpSign))
+}
+
+func TestValidateKeyName(t *testing.T) {
+	require.NoError(t, ValidateKeyName("mykey"))
+	require.Error(t, ValidateKeyName(""))
+	require.Error(t, ValidateKeyName("a/b"))
+	require.Error(t, ValidateKeyName(".."))
+}
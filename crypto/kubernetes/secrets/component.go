@@ -49,6 +49,7 @@ type kubeSecretsCrypto struct {
 
 // NewKubeSecretsCrypto returns a new Kubernetes secrets crypto provider.
 // The key arguments in methods can be in the format "namespace/secretName/key" or "secretName/key" if using the default namespace passed as component metadata.
+// The provider also implements contribCrypto.KeyManager; for managed keys, "key" is the version number or "latest".
 func NewKubeSecretsCrypto(log logger.Logger) contribCrypto.SubtleCrypto {
 	k := &kubeSecretsCrypto{
 		logger: log,
@@ -80,7 +81,10 @@ func (k *kubeSecretsCrypto) Init(_ context.Context, metadata contribCrypto.Metad
 
 // Features returns the features available in this crypto provider.
 func (k *kubeSecretsCrypto) Features() []contribCrypto.Feature {
-	return []contribCrypto.Feature{} // No Feature supported.
+	return []contribCrypto.Feature{
+		contribCrypto.FeatureKeyManagement,
+		contribCrypto.FeatureKeyRotation,
+	}
 }
 
 // Retrieves a key (public or private or symmetric) from a Kubernetes secret.
@@ -99,6 +103,16 @@ func (k *kubeSecretsCrypto) retrieveKeyFromSecret(parentCtx context.Context, key
 	if err != nil {
 		return nil, err
 	}
+
+	// For managed keys, resolve the version to use
+	var previousVersion bool
+	if isManagedKeySecret(res) {
+		keyName, previousVersion, err = resolveManagedKeyVersion(res, keyName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil || len(res.Data) == 0 || len(res.Data[keyName]) == 0 {
 		return nil, contribCrypto.ErrKeyNotFound
 	}
@@ -117,6 +131,11 @@ func (k *kubeSecretsCrypto) retrieveKeyFromSecret(parentCtx context.Context, key
 		return nil, fmt.Errorf("failed to parse key from secret: %w", err)
 	}
 
+	err = restrictIfPreviousVersion(jwkObj, previousVersion)
+	if err != nil {
+		return nil, err
+	}
+
 	return jwkObj, nil
 }
 
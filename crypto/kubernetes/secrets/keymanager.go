@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	coreV1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+)
+
+// Managed keys are stored in a secret named after the key, with one entry per version ("1", "2", ...).
+// The key is referenced as "[namespace/]secretName/latest" for its current version, or "[namespace/]secretName/<version>".
+const (
+	managedKeyLabel          = "crypto.dapr.io/managed-key"
+	managedKeyLabelSelector  = managedKeyLabel + "=true"
+	annotationAlgorithm      = "crypto.dapr.io/algorithm"
+	annotationKeySize        = "crypto.dapr.io/key-size"
+	annotationCurrentVersion = "crypto.dapr.io/current-version"
+	annotationEnabled        = "crypto.dapr.io/enabled"
+	annotationVersions       = "crypto.dapr.io/versions"
+	latestVersionAlias       = "latest"
+)
+
+func (k *kubeSecretsCrypto) CreateKey(parentCtx context.Context, req contribCrypto.CreateKeyRequest) (*contribCrypto.KeyInfo, error) {
+	namespace, name, err := k.parseManagedKeyName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &coreV1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				managedKeyLabel: "true",
+			},
+			Annotations: map[string]string{
+				annotationAlgorithm: req.Algorithm,
+				annotationEnabled:   "true",
+			},
+		},
+		Type: coreV1.SecretTypeOpaque,
+		Data: map[string][]byte{},
+	}
+	if req.KeySize > 0 {
+		secret.Annotations[annotationKeySize] = strconv.Itoa(req.KeySize)
+	}
+	err = addSecretKeyVersion(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, requestTimeout)
+	defer cancel()
+	_, err = k.kubeClient.CoreV1().Secrets(namespace).Create(ctx, secret, metaV1.CreateOptions{})
+	if apiErrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("%w: %s", contribCrypto.ErrKeyExists, req.Name)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	return secretKeyInfo(secret)
+}
+
+func (k *kubeSecretsCrypto) RotateKey(parentCtx context.Context, keyName string) (*contribCrypto.KeyInfo, error) {
+	secret, err := k.getManagedKeySecret(parentCtx, keyName)
+	if err != nil {
+		return nil, err
+	}
+	if secret.Annotations[annotationEnabled] != "true" {
+		return nil, fmt.Errorf("%w: %s", contribCrypto.ErrKeyDisabled, keyName)
+	}
+
+	err = addSecretKeyVersion(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// The update fails with a conflict if the secret was modified since it was read
+	ctx, cancel := context.WithTimeout(parentCtx, requestTimeout)
+	defer cancel()
+	_, err = k.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metaV1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update secret: %w", err)
+	}
+
+	return secretKeyInfo(secret)
+}
+
+func (k *kubeSecretsCrypto) ListKeys(parentCtx context.Context) ([]contribCrypto.KeyInfo, error) {
+	// If there's no default namespace, list keys in all namespaces
+	ctx, cancel := context.WithTimeout(parentCtx, requestTimeout)
+	defer cancel()
+	list, err := k.kubeClient.CoreV1().Secrets(k.md.DefaultNamespace).List(ctx, metaV1.ListOptions{
+		LabelSelector: managedKeyLabelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	res := make([]contribCrypto.KeyInfo, 0, len(list.Items))
+	for i := range list.Items {
+		info, err := secretKeyInfo(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *info)
+	}
+	return res, nil
+}
+
+func (k *kubeSecretsCrypto) DisableKey(parentCtx context.Context, keyName string) error {
+	secret, err := k.getManagedKeySecret(parentCtx, keyName)
+	if err != nil {
+		return err
+	}
+
+	secret.Annotations[annotationEnabled] = "false"
+
+	ctx, cancel := context.WithTimeout(parentCtx, requestTimeout)
+	defer cancel()
+	_, err = k.kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metaV1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update secret: %w", err)
+	}
+	return nil
+}
+
+// getManagedKeySecret returns the secret for a managed key, in the format "[namespace/]secretName".
+func (k *kubeSecretsCrypto) getManagedKeySecret(parentCtx context.Context, keyName string) (*coreV1.Secret, error) {
+	namespace, name, err := k.parseManagedKeyName(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, requestTimeout)
+	defer cancel()
+	secret, err := k.kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metaV1.GetOptions{})
+	if apiErrors.IsNotFound(err) {
+		return nil, contribCrypto.ErrKeyNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+	if !isManagedKeySecret(secret) {
+		return nil, fmt.Errorf("secret '%s/%s' is not a managed key", namespace, name)
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	return secret, nil
+}
+
+// parseManagedKeyName returns the namespace and secret name for a managed key, in the format "[namespace/]secretName".
+func (k *kubeSecretsCrypto) parseManagedKeyName(keyName string) (namespace string, name string, err error) {
+	namespace, name, found := strings.Cut(keyName, "/")
+	if !found {
+		namespace = k.md.DefaultNamespace
+		name = keyName
+	}
+	if namespace == "" {
+		return "", "", errors.New("key doesn't have a namespace and the default namespace isn't set")
+	}
+	err = contribCrypto.ValidateKeyName(name)
+	if err != nil {
+		return "", "", err
+	}
+	return namespace, name, nil
+}
+
+// resolveManagedKeyVersion returns the data entry to use for a managed key, resolving the "latest" alias.
+// It also returns true if the version is not the current one.
+func resolveManagedKeyVersion(secret *coreV1.Secret, version string) (string, bool, error) {
+	if secret.Annotations[annotationEnabled] != "true" {
+		return "", false, fmt.Errorf("%w: %s/%s", contribCrypto.ErrKeyDisabled, secret.Namespace, secret.Name)
+	}
+
+	current := secret.Annotations[annotationCurrentVersion]
+	if version == latestVersionAlias {
+		version = current
+	}
+	return version, version != current, nil
+}
+
+func isManagedKeySecret(secret *coreV1.Secret) bool {
+	return secret != nil && secret.Labels[managedKeyLabel] == "true"
+}
+
+// addSecretKeyVersion generates a new version of the key, adds it to the secret and makes it the current one.
+func addSecretKeyVersion(secret *coreV1.Secret) error {
+	current, _ := strconv.Atoi(secret.Annotations[annotationCurrentVersion])
+	keySize, _ := strconv.Atoi(secret.Annotations[annotationKeySize])
+	version := strconv.Itoa(current + 1)
+
+	key, err := contribCrypto.GenerateKey(secret.Annotations[annotationAlgorithm], keySize, secret.Name+"/"+version)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to serialize key: %w", err)
+	}
+
+	var versions []contribCrypto.KeyVersionInfo
+	if v := secret.Annotations[annotationVersions]; v != "" {
+		err = json.Unmarshal([]byte(v), &versions)
+		if err != nil {
+			return fmt.Errorf("failed to parse key versions: %w", err)
+		}
+	}
+	versions = append(versions, contribCrypto.KeyVersionInfo{
+		Version:   version,
+		CreatedAt: time.Now().UTC(),
+	})
+	versionsJSON, err := json.Marshal(versions)
+	if err != nil {
+		return fmt.Errorf("failed to serialize key versions: %w", err)
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[version] = data
+	secret.Annotations[annotationCurrentVersion] = version
+	secret.Annotations[annotationVersions] = string(versionsJSON)
+	return nil
+}
+
+func secretKeyInfo(secret *coreV1.Secret) (*contribCrypto.KeyInfo, error) {
+	info := &contribCrypto.KeyInfo{
+		Name:           secret.Namespace + "/" + secret.Name,
+		Algorithm:      secret.Annotations[annotationAlgorithm],
+		CurrentVersion: secret.Annotations[annotationCurrentVersion],
+		Enabled:        secret.Annotations[annotationEnabled] == "true",
+	}
+	if v := secret.Annotations[annotationVersions]; v != "" {
+		err := json.Unmarshal([]byte(v), &info.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse versions of key '%s': %w", info.Name, err)
+		}
+	}
+	return info, nil
+}
+
+// restrictIfPreviousVersion limits keys that are not the current version to decrypt, unwrapKey and verify operations.
+func restrictIfPreviousVersion(key jwk.Key, previous bool) error {
+	if !previous {
+		return nil
+	}
+	return contribCrypto.RestrictToPreviousVersionOps(key)
+}
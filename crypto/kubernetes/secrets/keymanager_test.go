@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+	internals "github.com/dapr/kit/crypto"
+	"github.com/dapr/kit/logger"
+)
+
+func TestKeyManager(t *testing.T) {
+	k := NewKubeSecretsCrypto(logger.NewLogger("test")).(*kubeSecretsCrypto)
+	k.md.DefaultNamespace = "default"
+	k.kubeClient = fake.NewClientset(&coreV1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "unmanaged", Namespace: "default"},
+	})
+
+	ctx := t.Context()
+	digest := make([]byte, 32)
+
+	info, err := k.CreateKey(ctx, contribCrypto.CreateKeyRequest{Name: "signing", Algorithm: internals.Algorithm_ES256})
+	require.NoError(t, err)
+	assert.Equal(t, "default/signing", info.Name)
+	assert.Equal(t, "1", info.CurrentVersion)
+
+	_, err = k.CreateKey(ctx, contribCrypto.CreateKeyRequest{Name: "signing", Algorithm: internals.Algorithm_ES256})
+	require.ErrorIs(t, err, contribCrypto.ErrKeyExists)
+
+	signature, err := k.Sign(ctx, digest, internals.Algorithm_ES256, "signing/latest")
+	require.NoError(t, err)
+
+	info, err = k.RotateKey(ctx, "default/signing")
+	require.NoError(t, err)
+	assert.Equal(t, "2", info.CurrentVersion)
+	require.Len(t, info.Versions, 2)
+
+	// Previous versions can verify, but not sign
+	valid, err := k.Verify(ctx, digest, signature, internals.Algorithm_ES256, "signing/1")
+	require.NoError(t, err)
+	assert.True(t, valid)
+	_, err = k.Sign(ctx, digest, internals.Algorithm_ES256, "signing/1")
+	require.Error(t, err)
+	_, err = k.Sign(ctx, digest, internals.Algorithm_ES256, "default/signing/latest")
+	require.NoError(t, err)
+
+	pub, err := k.GetKey(ctx, "signing/latest")
+	require.NoError(t, err)
+	assert.Equal(t, "signing/2", pub.KeyID())
+
+	keys, err := k.ListKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].Enabled)
+
+	_, err = k.RotateKey(ctx, "unmanaged")
+	require.ErrorContains(t, err, "not a managed key")
+
+	require.NoError(t, k.DisableKey(ctx, "signing"))
+	_, err = k.Verify(ctx, digest, signature, internals.Algorithm_ES256, "signing/1")
+	require.ErrorIs(t, err, contribCrypto.ErrKeyDisabled)
+}
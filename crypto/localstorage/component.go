@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
@@ -36,10 +37,12 @@ type localStorageCrypto struct {
 
 	md     localStorageMetadata
 	logger logger.Logger
+	lock   sync.RWMutex
 }
 
 // NewLocalStorageCrypto returns a new local storage crypto provider.
 // Keys are loaded from PEM or JSON (each containing an individual JWK) files from a local folder on disk.
+// The provider also implements contribCrypto.KeyManager, storing managed keys as versioned files.
 func NewLocalStorageCrypto(logger logger.Logger) contribCrypto.SubtleCrypto {
 	k := &localStorageCrypto{
 		logger: logger,
@@ -61,7 +64,10 @@ func (l *localStorageCrypto) Init(_ context.Context, metadata contribCrypto.Meta
 
 // Features returns the features available in this crypto provider.
 func (l *localStorageCrypto) Features() []contribCrypto.Feature {
-	return []contribCrypto.Feature{} // No Feature supported.
+	return []contribCrypto.Feature{
+		contribCrypto.FeatureKeyManagement,
+		contribCrypto.FeatureKeyRotation,
+	}
 }
 
 func (l *localStorageCrypto) Close() error {
@@ -76,6 +82,12 @@ func (l *localStorageCrypto) retrieveKey(parentCtx context.Context, key string)
 		return nil, errors.New("invalid key path: cannot contain '..'")
 	}
 
+	// Check if this is a managed key first
+	jwkObj, managed, err := l.retrieveManagedKey(key)
+	if managed {
+		return jwkObj, err
+	}
+
 	// Load the file
 	path := filepath.Join(l.md.Path, key)
 	data, err := os.ReadFile(path)
@@ -93,7 +105,7 @@ func (l *localStorageCrypto) retrieveKey(parentCtx context.Context, key string)
 	}
 
 	// Parse the key
-	jwkObj, err := internals.ParseKey(data, contentType)
+	jwkObj, err = internals.ParseKey(data, contentType)
 	if err == nil {
 		switch jwkObj.KeyType() {
 		case jwa.EC, jwa.RSA, jwa.OKP, jwa.OctetSeq:
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	commonutils "github.com/dapr/components-contrib/common/utils"
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+)
+
+// Managed keys are stored in a folder named after the key, containing a manifest and one JWK file per version:
+//
+//	<path>/<name>/manifest.json
+//	<path>/<name>/1.json
+//	<path>/<name>/2.json
+//
+// The key is referenced as "<name>" for its current version, or "<name>/<version>" for a specific version.
+const manifestFileName = "manifest.json"
+
+type keyManifest struct {
+	Name           string                         `json:"name"`
+	Algorithm      string                         `json:"algorithm"`
+	KeySize        int                            `json:"keySize,omitempty"`
+	CurrentVersion int                            `json:"currentVersion"`
+	Enabled        bool                           `json:"enabled"`
+	Versions       []contribCrypto.KeyVersionInfo `json:"versions"`
+}
+
+func (m keyManifest) keyInfo() contribCrypto.KeyInfo {
+	return contribCrypto.KeyInfo{
+		Name:           m.Name,
+		Algorithm:      m.Algorithm,
+		CurrentVersion: strconv.Itoa(m.CurrentVersion),
+		Enabled:        m.Enabled,
+		Versions:       m.Versions,
+	}
+}
+
+func (l *localStorageCrypto) CreateKey(_ context.Context, req contribCrypto.CreateKeyRequest) (*contribCrypto.KeyInfo, error) {
+	err := contribCrypto.ValidateKeyName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	dir := filepath.Join(l.md.Path, req.Name)
+	_, err = os.Stat(dir)
+	if err == nil {
+		return nil, fmt.Errorf("%w: %s", contribCrypto.ErrKeyExists, req.Name)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to check if key '%s' exists: %w", req.Name, err)
+	}
+
+	m := keyManifest{
+		Name:      req.Name,
+		Algorithm: req.Algorithm,
+		KeySize:   req.KeySize,
+		Enabled:   true,
+	}
+	err = l.addKeyVersion(dir, &m)
+	if err != nil {
+		// Do not leave a partially-created key behind
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	info := m.keyInfo()
+	return &info, nil
+}
+
+func (l *localStorageCrypto) RotateKey(_ context.Context, keyName string) (*contribCrypto.KeyInfo, error) {
+	err := contribCrypto.ValidateKeyName(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	dir := filepath.Join(l.md.Path, keyName)
+	m, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !m.Enabled {
+		return nil, fmt.Errorf("%w: %s", contribCrypto.ErrKeyDisabled, keyName)
+	}
+
+	err = l.addKeyVersion(dir, m)
+	if err != nil {
+		return nil, err
+	}
+
+	info := m.keyInfo()
+	return &info, nil
+}
+
+func (l *localStorageCrypto) ListKeys(_ context.Context) ([]contribCrypto.KeyInfo, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	entries, err := os.ReadDir(l.md.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	res := make([]contribCrypto.KeyInfo, 0)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		m, err := readManifest(filepath.Join(l.md.Path, e.Name()))
+		if errors.Is(err, contribCrypto.ErrKeyNotFound) {
+			// Not a managed key
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		res = append(res, m.keyInfo())
+	}
+	return res, nil
+}
+
+func (l *localStorageCrypto) DisableKey(_ context.Context, keyName string) error {
+	err := contribCrypto.ValidateKeyName(keyName)
+	if err != nil {
+		return err
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	dir := filepath.Join(l.md.Path, keyName)
+	m, err := readManifest(dir)
+	if err != nil {
+		return err
+	}
+	m.Enabled = false
+	return writeManifest(dir, m)
+}
+
+// addKeyVersion generates a new version of the key, writes it to disk, and then makes it the current one in the manifest.
+func (l *localStorageCrypto) addKeyVersion(dir string, m *keyManifest) error {
+	version := m.CurrentVersion + 1
+	key, err := contribCrypto.GenerateKey(m.Algorithm, m.KeySize, m.Name+"/"+strconv.Itoa(version))
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to serialize key: %w", err)
+	}
+
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return fmt.Errorf("failed to create key folder: %w", err)
+	}
+	err = commonutils.WriteFileAtomic(filepath.Join(dir, strconv.Itoa(version)+".json"), data)
+	if err != nil {
+		return fmt.Errorf("failed to write key: %w", err)
+	}
+
+	m.CurrentVersion = version
+	m.Versions = append(m.Versions, contribCrypto.KeyVersionInfo{
+		Version:   strconv.Itoa(version),
+		CreatedAt: time.Now().UTC(),
+	})
+	return writeManifest(dir, m)
+}
+
+// retrieveManagedKey loads a managed key if the key name refers to one.
+// The returned bool is false if the key is not a managed key.
+func (l *localStorageCrypto) retrieveManagedKey(key string) (jwk.Key, bool, error) {
+	path := filepath.Join(l.md.Path, key)
+
+	var (
+		dir     string
+		version string
+	)
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		// Name of a managed key: use the current version
+		dir = path
+	case errors.Is(err, fs.ErrNotExist):
+		// Could be "name/version"
+		dir, version = filepath.Split(path)
+		dir = filepath.Clean(dir)
+	default:
+		return nil, false, nil
+	}
+
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	m, err := readManifest(dir)
+	if errors.Is(err, contribCrypto.ErrKeyNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, true, err
+	}
+	if !m.Enabled {
+		return nil, true, fmt.Errorf("%w: %s", contribCrypto.ErrKeyDisabled, m.Name)
+	}
+
+	current := strconv.Itoa(m.CurrentVersion)
+	if version == "" {
+		version = current
+	}
+	data, err := os.ReadFile(filepath.Join(dir, version+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, true, contribCrypto.ErrKeyNotFound
+	} else if err != nil {
+		return nil, true, fmt.Errorf("failed to load key '%s': %w", key, err)
+	}
+	jwkObj, err := jwk.ParseKey(data)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to parse key '%s': %w", key, err)
+	}
+
+	if version != current {
+		err = contribCrypto.RestrictToPreviousVersionOps(jwkObj)
+		if err != nil {
+			return nil, true, err
+		}
+	}
+	return jwkObj, true, nil
+}
+
+func readManifest(dir string) (*keyManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, contribCrypto.ErrKeyNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read key manifest: %w", err)
+	}
+
+	m := &keyManifest{}
+	err = json.Unmarshal(data, m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key manifest: %w", err)
+	}
+	return m, nil
+}
+
+func writeManifest(dir string, m *keyManifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to serialize key manifest: %w", err)
+	}
+	err = commonutils.WriteFileAtomic(filepath.Join(dir, manifestFileName), data)
+	if err != nil {
+		return fmt.Errorf("failed to write key manifest: %w", err)
+	}
+	return nil
+}
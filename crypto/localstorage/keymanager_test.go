@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstorage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	contribCrypto "github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/components-contrib/metadata"
+	internals "github.com/dapr/kit/crypto"
+	"github.com/dapr/kit/logger"
+)
+
+func TestKeyManager(t *testing.T) {
+	dir := t.TempDir()
+	component := NewLocalStorageCrypto(logger.NewLogger("test"))
+	require.NoError(t, component.Init(t.Context(), contribCrypto.Metadata{Base: metadata.Base{
+		Properties: map[string]string{"path": dir},
+	}}))
+	km, ok := component.(contribCrypto.KeyManager)
+	require.True(t, ok)
+
+	ctx := t.Context()
+	nonce := []byte("123456789012")
+
+	info, err := km.CreateKey(ctx, contribCrypto.CreateKeyRequest{Name: "mykey", Algorithm: internals.Algorithm_A256GCM})
+	require.NoError(t, err)
+	assert.Equal(t, "1", info.CurrentVersion)
+	assert.True(t, info.Enabled)
+	assert.FileExists(t, filepath.Join(dir, "mykey", "1.json"))
+
+	_, err = km.CreateKey(ctx, contribCrypto.CreateKeyRequest{Name: "mykey", Algorithm: internals.Algorithm_A256GCM})
+	require.ErrorIs(t, err, contribCrypto.ErrKeyExists)
+	_, err = km.CreateKey(ctx, contribCrypto.CreateKeyRequest{Name: "bad", Algorithm: "nope"})
+	require.ErrorIs(t, err, internals.ErrUnsupportedAlgorithm)
+	assert.NoDirExists(t, filepath.Join(dir, "bad"))
+
+	ciphertext, tag, err := component.Encrypt(ctx, []byte("hello"), internals.Algorithm_A256GCM, "mykey", nonce, nil)
+	require.NoError(t, err)
+
+	info, err = km.RotateKey(ctx, "mykey")
+	require.NoError(t, err)
+	assert.Equal(t, "2", info.CurrentVersion)
+	assert.Len(t, info.Versions, 2)
+
+	t.Run("old version can only decrypt", func(t *testing.T) {
+		_, err = component.Decrypt(ctx, ciphertext, internals.Algorithm_A256GCM, "mykey", nonce, tag, nil)
+		require.Error(t, err)
+
+		plaintext, err := component.Decrypt(ctx, ciphertext, internals.Algorithm_A256GCM, "mykey/1", nonce, tag, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(plaintext))
+
+		_, _, err = component.Encrypt(ctx, []byte("hello"), internals.Algorithm_A256GCM, "mykey/1", nonce, nil)
+		require.ErrorContains(t, err, "cannot perform the 'encrypt' operation")
+
+		_, _, err = component.Encrypt(ctx, []byte("hello"), internals.Algorithm_A256GCM, "mykey/2", nonce, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("key can only be used with its algorithm", func(t *testing.T) {
+		_, _, err = component.Encrypt(ctx, []byte("hello"), internals.Algorithm_A128GCM, "mykey", nonce, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("list keys ignores unmanaged keys", func(t *testing.T) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "other"), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "plain.json"), []byte("{}"), 0o600))
+
+		keys, err := km.ListKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "mykey", keys[0].Name)
+	})
+
+	t.Run("disabled keys cannot be used", func(t *testing.T) {
+		require.NoError(t, km.DisableKey(ctx, "mykey"))
+
+		_, err = component.Decrypt(ctx, ciphertext, internals.Algorithm_A256GCM, "mykey/1", nonce, tag, nil)
+		require.ErrorIs(t, err, contribCrypto.ErrKeyDisabled)
+		_, err = km.RotateKey(ctx, "mykey")
+		require.ErrorIs(t, err, contribCrypto.ErrKeyDisabled)
+
+		keys, err := km.ListKeys(ctx)
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.False(t, keys[0].Enabled)
+	})
+}
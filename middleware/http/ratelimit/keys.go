@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	libstring "github.com/didip/tollbooth/v7/libstring"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+const (
+	keyByIP       = "ip"
+	keyByHeader   = "header:"
+	keyByJWTClaim = "jwtClaim:"
+	keyByPath     = "path:"
+
+	// Used when the request has no remote IP
+	unknownIP = "0.0.0.0"
+)
+
+// Same lookups as the default tollbooth limiter used by previous versions of this middleware.
+var ipLookups = []string{"RemoteAddr", "X-Forwarded-For", "X-Real-IP"}
+
+// keyFunc returns the key requests are limited by.
+// It returns false if the key can't be extracted from the request, in which case the remote IP is used.
+type keyFunc func(r *http.Request) (string, bool)
+
+// parseKeyBy returns the keyFunc for a "keyBy" option, which can be:
+//
+//   - "ip" (the default): the remote IP of the client
+//   - "header:<name>": the value of a request header, such as "header:X-API-Key"
+//   - "jwtClaim:<claim>": a claim of the bearer token in the Authorization header, such as "jwtClaim:sub"
+//   - "path:<template>": the segments captured by a path template, such as "path:/v1.0/invoke/{appId}/**"
+func parseKeyBy(keyBy string) (keyFunc, error) {
+	switch {
+	case keyBy == "" || strings.EqualFold(keyBy, keyByIP):
+		return func(r *http.Request) (string, bool) {
+			return "", false
+		}, nil
+	case strings.HasPrefix(keyBy, keyByHeader):
+		name := http.CanonicalHeaderKey(strings.TrimSpace(strings.TrimPrefix(keyBy, keyByHeader)))
+		if name == "" {
+			return nil, fmt.Errorf("invalid keyBy '%s': header name is empty", keyBy)
+		}
+		return func(r *http.Request) (string, bool) {
+			v := r.Header.Get(name)
+			return v, v != ""
+		}, nil
+	case strings.HasPrefix(keyBy, keyByJWTClaim):
+		claim := strings.TrimSpace(strings.TrimPrefix(keyBy, keyByJWTClaim))
+		if claim == "" {
+			return nil, fmt.Errorf("invalid keyBy '%s': claim name is empty", keyBy)
+		}
+		return func(r *http.Request) (string, bool) {
+			return jwtClaim(r, claim)
+		}, nil
+	case strings.HasPrefix(keyBy, keyByPath):
+		tpl, err := parsePathTemplate(strings.TrimPrefix(keyBy, keyByPath))
+		if err != nil {
+			return nil, fmt.Errorf("invalid keyBy '%s': %w", keyBy, err)
+		}
+		if len(tpl.params) == 0 {
+			return nil, fmt.Errorf("invalid keyBy '%s': path template does not contain any {parameter}", keyBy)
+		}
+		return func(r *http.Request) (string, bool) {
+			values, ok := tpl.match(r.URL.Path)
+			if !ok {
+				return "", false
+			}
+			return strings.Join(values, "/"), true
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid keyBy '%s': must be 'ip', 'header:<name>', 'jwtClaim:<claim>' or 'path:<template>'", keyBy)
+	}
+}
+
+// remoteIP returns the IP of the client that sent the request.
+func remoteIP(r *http.Request) string {
+	ip := libstring.CanonicalizeIP(libstring.RemoteIP(ipLookups, 0, r))
+	if ip == "" {
+		return unknownIP
+	}
+	return ip
+}
+
+// jwtClaim returns a claim from the bearer token in the Authorization header.
+// The token's signature is not validated: this middleware must come after one that authenticates the request, such as the bearer or OAuth2 middlewares.
+func jwtClaim(r *http.Request, claim string) (string, bool) {
+	scheme, rawToken, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+
+	token, err := jwt.ParseString(strings.TrimSpace(rawToken), jwt.WithVerify(false), jwt.WithValidate(false))
+	if err != nil {
+		return "", false
+	}
+	v, ok := token.Get(claim)
+	if !ok {
+		return "", false
+	}
+
+	var res string
+	switch v := v.(type) {
+	case string:
+		res = v
+	case []string:
+		res = strings.Join(v, ",")
+	default:
+		res = fmt.Sprint(v)
+	}
+	return res, res != ""
+}
+
+// pathTemplate matches URL paths. Each segment of the template can be:
+//
+//   - a literal value
+//   - "*" to match any single segment
+//   - "{name}" to match any single segment and capture its value
+//   - "**" as the last segment, to match any remaining segments (including none)
+type pathTemplate struct {
+	segments []string
+	params   []int
+	rest     bool
+}
+
+func parsePathTemplate(tpl string) (*pathTemplate, error) {
+	tpl = strings.TrimSpace(tpl)
+	if !strings.HasPrefix(tpl, "/") {
+		return nil, fmt.Errorf("path template '%s' must start with '/'", tpl)
+	}
+
+	res := &pathTemplate{
+		segments: strings.Split(strings.Trim(tpl, "/"), "/"),
+	}
+	for i, s := range res.segments {
+		switch {
+		case s == "**":
+			if i != len(res.segments)-1 {
+				return nil, fmt.Errorf("path template '%s' can only contain '**' as last segment", tpl)
+			}
+			res.rest = true
+			res.segments = res.segments[:i]
+		case strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}"):
+			res.params = append(res.params, i)
+		}
+	}
+	return res, nil
+}
+
+// match returns the values of the captured segments if the path matches the template.
+func (t *pathTemplate) match(path string) ([]string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < len(t.segments) || (!t.rest && len(parts) != len(t.segments)) {
+		return nil, false
+	}
+
+	for i, s := range t.segments {
+		if s == "*" || (strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}")) {
+			if parts[i] == "" {
+				return nil, false
+			}
+			continue
+		}
+		if s != parts[i] {
+			return nil, false
+		}
+	}
+
+	values := make([]string, len(t.params))
+	for i, p := range t.params {
+		values[i] = parts[p]
+	}
+	return values, true
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyBy(t *testing.T) {
+	t.Run("ip", func(t *testing.T) {
+		fn, err := parseKeyBy("ip")
+		require.NoError(t, err)
+		_, ok := fn(httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.False(t, ok)
+	})
+
+	t.Run("header", func(t *testing.T) {
+		fn, err := parseKeyBy("header:x-api-key")
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		_, ok := fn(r)
+		assert.False(t, ok)
+
+		r.Header.Set("X-API-Key", "mykey")
+		key, ok := fn(r)
+		assert.True(t, ok)
+		assert.Equal(t, "mykey", key)
+	})
+
+	t.Run("jwt claim", func(t *testing.T) {
+		fn, err := parseKeyBy("jwtClaim:sub")
+		require.NoError(t, err)
+
+		token, err := jwt.NewBuilder().Subject("alice").Build()
+		require.NoError(t, err)
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.HS256, []byte("secret")))
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Authorization", "Bearer "+string(signed))
+		key, ok := fn(r)
+		assert.True(t, ok)
+		assert.Equal(t, "alice", key)
+
+		r.Header.Set("Authorization", "Bearer not-a-token")
+		_, ok = fn(r)
+		assert.False(t, ok)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		fn, err := parseKeyBy("path:/v1.0/invoke/{appId}/method/**")
+		require.NoError(t, err)
+
+		key, ok := fn(httptest.NewRequest(http.MethodGet, "/v1.0/invoke/orders/method/new/item", nil))
+		assert.True(t, ok)
+		assert.Equal(t, "orders", key)
+
+		_, ok = fn(httptest.NewRequest(http.MethodGet, "/v1.0/state/store", nil))
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, keyBy := range []string{"cookie:foo", "header:", "jwtClaim:", "path:/v1.0/*", "path:foo/{bar}"} {
+			_, err := parseKeyBy(keyBy)
+			require.Error(t, err, keyBy)
+		}
+	})
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		path     string
+		values   []string
+		match    bool
+	}{
+		{"/v1.0/state/*", "/v1.0/state/store", []string{}, true},
+		{"/v1.0/state/*", "/v1.0/state/store/key", nil, false},
+		{"/v1.0/state/*", "/v1.0/state/", nil, false},
+		{"/v1.0/state/**", "/v1.0/state/store/key", []string{}, true},
+		{"/v1.0/state/**", "/v1.0/state", []string{}, true},
+		{"/v1.0/{api}/{name}", "/v1.0/state/store", []string{"state", "store"}, true},
+		{"/v1.0/{api}/{name}", "/v1.0/state", nil, false},
+		{"/healthz", "/healthz/", []string{}, true},
+		{"/healthz", "/readyz", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template+" "+tt.path, func(t *testing.T) {
+			tpl, err := parsePathTemplate(tt.template)
+			require.NoError(t, err)
+			values, ok := tpl.match(tt.path)
+			assert.Equal(t, tt.match, ok)
+			assert.Equal(t, tt.values, values)
+		})
+	}
+
+	_, err := parsePathTemplate("/a/**/b")
+	require.Error(t, err)
+}
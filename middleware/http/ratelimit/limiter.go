@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// How often expired keys are removed from the in-memory limiter.
+const memoryCleanupInterval = time.Minute
+
+// limit is the rate a key is allowed, as GCRA parameters.
+type limit struct {
+	// Time between requests at the sustained rate
+	emissionInterval time.Duration
+	// Number of requests that can be made at once
+	burst int
+}
+
+func newLimit(requestsPerSecond float64, burst int) limit {
+	return limit{
+		emissionInterval: time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:            burst,
+	}
+}
+
+// limitResult is the outcome of a limiter.Allow call.
+type limitResult struct {
+	Allowed bool
+	// Number of requests that can still be made right away
+	Remaining int
+	// Time until the next request is allowed; zero if Allowed is true
+	RetryAfter time.Duration
+	// Time until the key is back to its full burst
+	ResetAfter time.Duration
+}
+
+// limiter tracks the requests made by each key, using the generic cell rate algorithm (GCRA).
+// GCRA stores a single timestamp per key, the "theoretical arrival time" (TAT) of the next request at the sustained rate:
+// a request is allowed if it doesn't arrive earlier than TAT minus the burst tolerance.
+type limiter interface {
+	Allow(ctx context.Context, key string, l limit) (limitResult, error)
+	io.Closer
+}
+
+// gcra applies the algorithm to the TAT of a key, returning the result and the new TAT to store if the request is allowed.
+func gcra(now time.Time, tat time.Time, l limit) (limitResult, time.Time) {
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(l.emissionInterval)
+	allowAt := newTat.Add(-time.Duration(l.burst) * l.emissionInterval)
+
+	if now.Before(allowAt) {
+		return limitResult{
+			Allowed:    false,
+			Remaining:  0,
+			RetryAfter: allowAt.Sub(now),
+			ResetAfter: tat.Sub(now),
+		}, tat
+	}
+
+	return limitResult{
+		Allowed:    true,
+		Remaining:  int(now.Sub(allowAt) / l.emissionInterval),
+		ResetAfter: newTat.Sub(now),
+	}, newTat
+}
+
+// memoryLimiter keeps state in memory, so limits apply to each instance separately.
+type memoryLimiter struct {
+	clock       clock.Clock
+	lock        sync.Mutex
+	tats        map[string]time.Time
+	lastCleanup time.Time
+}
+
+func newMemoryLimiter(clk clock.Clock) *memoryLimiter {
+	return &memoryLimiter{
+		clock:       clk,
+		tats:        map[string]time.Time{},
+		lastCleanup: clk.Now(),
+	}
+}
+
+func (m *memoryLimiter) Allow(_ context.Context, key string, l limit) (limitResult, error) {
+	now := m.clock.Now()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	res, tat := gcra(now, m.tats[key], l)
+	if res.Allowed {
+		m.tats[key] = tat
+	}
+
+	// Keys whose TAT is in the past are back to their full burst, so they don't need to be stored
+	if now.Sub(m.lastCleanup) > memoryCleanupInterval {
+		for k, t := range m.tats {
+			if t.Before(now) {
+				delete(m.tats, k)
+			}
+		}
+		m.lastCleanup = now
+	}
+
+	return res, nil
+}
+
+func (m *memoryLimiter) Close() error {
+	return nil
+}
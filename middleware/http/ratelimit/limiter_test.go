@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestGCRA(t *testing.T) {
+	now := time.Now()
+	l := newLimit(10, 3)
+
+	// A new key has its full burst
+	res, tat := gcra(now, time.Time{}, l)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 2, res.Remaining)
+	assert.Equal(t, 100*time.Millisecond, res.ResetAfter)
+
+	res, tat = gcra(now, tat, l)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+
+	res, tat = gcra(now, tat, l)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 300*time.Millisecond, res.ResetAfter)
+
+	// The burst is exhausted
+	res, denied := gcra(now, tat, l)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, tat, denied)
+	assert.Equal(t, 100*time.Millisecond, res.RetryAfter)
+
+	// Requests are allowed again at the sustained rate
+	res, _ = gcra(now.Add(100*time.Millisecond), tat, l)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	m := newMemoryLimiter(clock)
+	l := newLimit(1, 1)
+
+	res, err := m.Allow(t.Context(), "a", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = m.Allow(t.Context(), "a", l)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+
+	res, err = m.Allow(t.Context(), "b", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	// Expired keys are removed
+	clock.Step(memoryCleanupInterval + time.Second)
+	res, err = m.Allow(t.Context(), "c", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Len(t, m.tats, 1)
+	assert.Contains(t, m.tats, "c")
+}
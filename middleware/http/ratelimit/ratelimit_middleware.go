@@ -15,12 +15,18 @@ package ratelimit
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
-	tollbooth "github.com/didip/tollbooth/v7"
-	libstring "github.com/didip/tollbooth/v7/libstring"
+	"k8s.io/utils/clock"
 
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/middleware"
@@ -30,53 +36,167 @@ import (
 
 // Metadata is the ratelimit middleware config.
 type rateLimitMiddlewareMetadata struct {
+	// Maximum number of requests allowed per second, for each key.
 	MaxRequestsPerSecond float64 `json:"maxRequestsPerSecond"`
+	// Number of requests that can be made at once, before being limited to maxRequestsPerSecond.
+	// Defaults to maxRequestsPerSecond, rounded down, with a minimum of 1.
+	Burst int `json:"burst"`
+	// What requests are limited by: "ip", "header:<name>", "jwtClaim:<claim>" or "path:<template>".
+	// Defaults to "ip".
+	KeyBy string `json:"keyBy"`
+	// JSON array of rules with a different limit for some routes.
+	Rules string `json:"rules"`
+	// Where the state of the limiter is kept: "memory" (for each instance) or "redis" (shared by all instances).
+	// Defaults to "memory".
+	Backend string `json:"backend"`
+	// Prefix for keys stored in Redis.
+	KeyPrefix string `json:"keyPrefix"`
+	// If true, requests are allowed when the backend can't be reached; otherwise, they fail with status 503.
+	// Defaults to true.
+	FailOpen bool `json:"failOpen"`
+}
+
+// rateLimitRule is a rule in the "rules" metadata property.
+// Properties that are not set default to the ones of the middleware.
+type rateLimitRule struct {
+	// Name of the rule, used in the keys of the backend. Defaults to the index of the rule.
+	Name string `json:"name"`
+	// Path template the request must match; see parsePathTemplate. If empty, all paths match.
+	Path string `json:"path"`
+	// HTTP methods the request must use. If empty, all methods match.
+	Methods              []string `json:"methods"`
+	MaxRequestsPerSecond float64  `json:"maxRequestsPerSecond"`
+	Burst                int      `json:"burst"`
+	KeyBy                string   `json:"keyBy"`
 }
 
 const (
 	maxRequestsPerSecondKey = "maxRequestsPerSecond"
 
+	backendMemory = "memory"
+	backendRedis  = "redis"
+
+	defaultRuleName = "default"
+
 	// Defaults.
 	defaultMaxRequestsPerSecond = 100
+	defaultKeyPrefix            = "dapr-ratelimit"
+
+	// Same response as the tollbooth limiter used by previous versions of this middleware.
+	limitReachedMessage     = "You have reached maximum request limit."
+	limitReachedContentType = "text/plain; charset=utf-8"
+
+	// Response headers, from the IETF draft "RateLimit header fields for HTTP".
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
 )
 
 // NewRateLimitMiddleware returns a new ratelimit middleware.
-func NewRateLimitMiddleware(_ logger.Logger) middleware.Middleware {
-	return &Middleware{}
+func NewRateLimitMiddleware(logger logger.Logger) middleware.Middleware {
+	return &Middleware{
+		logger: logger,
+		clock:  clock.RealClock{},
+	}
 }
 
 // Middleware is an ratelimit middleware.
-type Middleware struct{}
+type Middleware struct {
+	logger logger.Logger
+	clock  clock.Clock
+
+	lock     sync.Mutex
+	limiters []limiter
+}
+
+// compiledRule is a rule with the defaults applied, ready to be matched against requests.
+type compiledRule struct {
+	name    string
+	path    *pathTemplate
+	methods map[string]struct{}
+	limit   limit
+	keyFn   keyFunc
+}
+
+func (r *compiledRule) matches(req *http.Request) bool {
+	if len(r.methods) > 0 {
+		if _, ok := r.methods[req.Method]; !ok {
+			return false
+		}
+	}
+	if r.path != nil {
+		if _, ok := r.path.match(req.URL.Path); !ok {
+			return false
+		}
+	}
+	return true
+}
 
 // GetHandler returns the HTTP handler provided by the middleware.
-func (m *Middleware) GetHandler(_ context.Context, metadata middleware.Metadata) (func(next http.Handler) http.Handler, error) {
+func (m *Middleware) GetHandler(ctx context.Context, metadata middleware.Metadata) (func(next http.Handler) http.Handler, error) {
 	meta, err := m.getNativeMetadata(metadata)
 	if err != nil {
 		return nil, err
 	}
 
-	limiter := tollbooth.NewLimiter(meta.MaxRequestsPerSecond, nil)
+	rules, err := meta.compileRules()
+	if err != nil {
+		return nil, err
+	}
+
+	var l limiter
+	switch meta.Backend {
+	case backendRedis:
+		l, err = newRedisLimiter(ctx, metadata.Properties, m.logger)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		l = newMemoryLimiter(m.clock)
+	}
+
+	m.lock.Lock()
+	m.limiters = append(m.limiters, l)
+	m.lock.Unlock()
 
 	return func(next http.Handler) http.Handler {
-		// Adapted from toolbooth.LimitHandler
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// The tollbooth library requires a remote IP. If this isn't present in the request's headers, then we need to set a value for X-Forwarded-For or the rate limiter won't work
-			remoteIP := libstring.RemoteIP(limiter.GetIPLookups(), limiter.GetForwardedForIndexFromBehind(), r)
-			remoteIP = libstring.CanonicalizeIP(remoteIP)
-			if remoteIP == "" {
-				// Forcefully set a remote IP
-				r.Header.Set("X-Forwarded-For", "0.0.0.0")
+			// The first matching rule applies; the last rule is the default one, which matches all requests
+			var rule *compiledRule
+			for _, rule = range rules {
+				if rule.matches(r) {
+					break
+				}
 			}
 
-			httpError := tollbooth.LimitByRequest(limiter, w, r)
-			if httpError != nil {
-				limiter.ExecOnLimitReached(w, r)
-				if limiter.GetOverrideDefaultResponseWriter() {
+			key, ok := rule.keyFn(r)
+			if !ok {
+				key = remoteIP(r)
+			}
+
+			res, err := l.Allow(r.Context(), meta.KeyPrefix+"|"+rule.name+"|"+key, rule.limit)
+			if err != nil {
+				if meta.FailOpen {
+					m.logger.Warnf("Rate limiter is not available, allowing request: %v", err)
+					next.ServeHTTP(w, r)
 					return
 				}
-				w.Header().Add("Content-Type", limiter.GetMessageContentType())
-				w.WriteHeader(httpError.StatusCode)
-				w.Write([]byte(httpError.Message))
+				m.logger.Errorf("Rate limiter is not available: %v", err)
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+
+			h := w.Header()
+			h.Set(headerRateLimitLimit, strconv.Itoa(rule.limit.burst))
+			h.Set(headerRateLimitRemaining, strconv.Itoa(res.Remaining))
+			h.Set(headerRateLimitReset, strconv.FormatInt(ceilSeconds(res.ResetAfter), 10))
+
+			if !res.Allowed {
+				h.Set(headerRetryAfter, strconv.FormatInt(ceilSeconds(res.RetryAfter), 10))
+				h.Set("Content-Type", limitReachedContentType)
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(limitReachedMessage))
 				return
 			}
 
@@ -89,6 +209,9 @@ func (m *Middleware) GetHandler(_ context.Context, metadata middleware.Metadata)
 func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*rateLimitMiddlewareMetadata, error) {
 	middlewareMetadata := rateLimitMiddlewareMetadata{
 		MaxRequestsPerSecond: defaultMaxRequestsPerSecond,
+		Backend:              backendMemory,
+		KeyPrefix:            defaultKeyPrefix,
+		FailOpen:             true,
 	}
 	err := kitmd.DecodeMetadata(metadata.Properties, &middlewareMetadata)
 	if err != nil {
@@ -98,12 +221,121 @@ func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*rateLimit
 	if middlewareMetadata.MaxRequestsPerSecond <= 0 {
 		return nil, fmt.Errorf("metadata property %s must be a positive value", maxRequestsPerSecondKey)
 	}
+	if middlewareMetadata.Burst < 0 {
+		return nil, errors.New("metadata property burst must not be negative")
+	}
+
+	middlewareMetadata.Backend = strings.ToLower(middlewareMetadata.Backend)
+	switch middlewareMetadata.Backend {
+	case backendMemory, backendRedis:
+		// ok
+	case "":
+		middlewareMetadata.Backend = backendMemory
+	default:
+		return nil, fmt.Errorf("metadata property backend must be '%s' or '%s'", backendMemory, backendRedis)
+	}
 
 	return &middlewareMetadata, nil
 }
 
+// compileRules returns the rules in the metadata followed by the default rule.
+func (m *rateLimitMiddlewareMetadata) compileRules() ([]*compiledRule, error) {
+	var rules []rateLimitRule
+	if m.Rules != "" {
+		err := json.Unmarshal([]byte(m.Rules), &rules)
+		if err != nil {
+			return nil, fmt.Errorf("metadata property rules is not a valid JSON array: %w", err)
+		}
+	}
+	rules = append(rules, rateLimitRule{Name: defaultRuleName})
+
+	res := make([]*compiledRule, len(rules))
+	names := make(map[string]struct{}, len(rules))
+	for i, r := range rules {
+		if r.Name == "" {
+			r.Name = strconv.Itoa(i)
+		}
+		if _, ok := names[r.Name]; ok {
+			return nil, fmt.Errorf("rate limit rule name '%s' is used more than once", r.Name)
+		}
+		names[r.Name] = struct{}{}
+
+		c, err := m.compileRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit rule '%s': %w", r.Name, err)
+		}
+		res[i] = c
+	}
+
+	return res, nil
+}
+
+func (m *rateLimitMiddlewareMetadata) compileRule(r rateLimitRule) (*compiledRule, error) {
+	if r.MaxRequestsPerSecond < 0 || r.Burst < 0 {
+		return nil, errors.New("maxRequestsPerSecond and burst must not be negative")
+	}
+
+	rps := r.MaxRequestsPerSecond
+	burst := r.Burst
+	if rps == 0 {
+		rps = m.MaxRequestsPerSecond
+		if burst == 0 {
+			burst = m.Burst
+		}
+	}
+	if burst == 0 {
+		burst = int(math.Max(1, rps))
+	}
+
+	keyBy := r.KeyBy
+	if keyBy == "" {
+		keyBy = m.KeyBy
+	}
+	keyFn, err := parseKeyBy(keyBy)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &compiledRule{
+		name:  r.Name,
+		limit: newLimit(rps, burst),
+		keyFn: keyFn,
+	}
+	if r.Path != "" {
+		c.path, err = parsePathTemplate(r.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(r.Methods) > 0 {
+		c.methods = make(map[string]struct{}, len(r.Methods))
+		for _, method := range r.Methods {
+			c.methods[strings.ToUpper(method)] = struct{}{}
+		}
+	}
+	return c, nil
+}
+
 func (m *Middleware) GetComponentMetadata() (metadataInfo contribMetadata.MetadataMap) {
 	metadataStruct := rateLimitMiddlewareMetadata{}
 	contribMetadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, contribMetadata.MiddlewareType)
 	return
 }
+
+// Close releases the connections to the backends.
+func (m *Middleware) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	errs := make([]error, len(m.limiters))
+	for i, l := range m.limiters {
+		errs[i] = l.Close()
+	}
+	m.limiters = nil
+	return errors.Join(errs...)
+}
+
+// ceilSeconds returns a duration in seconds, rounded up, as used in the response headers.
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
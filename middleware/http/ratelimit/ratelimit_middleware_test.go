@@ -14,13 +14,19 @@ limitations under the License.
 package ratelimit
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	miniredis "github.com/alicebob/miniredis/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/kit/logger"
 )
 
 func TestMiddlewareGetNativeMetadata(t *testing.T) {
@@ -71,3 +77,183 @@ func TestMiddlewareGetNativeMetadata(t *testing.T) {
 		assert.EqualValues(t, float64(42.42), res.MaxRequestsPerSecond)
 	})
 }
+
+func TestMiddlewareGetNativeMetadataOptions(t *testing.T) {
+	m := &Middleware{}
+
+	t.Run("defaults", func(t *testing.T) {
+		res, err := m.getNativeMetadata(middleware.Metadata{})
+		require.NoError(t, err)
+		assert.EqualValues(t, defaultMaxRequestsPerSecond, res.MaxRequestsPerSecond)
+		assert.Equal(t, backendMemory, res.Backend)
+		assert.Equal(t, defaultKeyPrefix, res.KeyPrefix)
+		assert.True(t, res.FailOpen)
+	})
+
+	t.Run("invalid backend", func(t *testing.T) {
+		_, err := m.getNativeMetadata(middleware.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"backend": "memcached",
+		}}})
+		require.ErrorContains(t, err, "metadata property backend must be")
+	})
+
+	t.Run("negative burst", func(t *testing.T) {
+		_, err := m.getNativeMetadata(middleware.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"burst": "-1",
+		}}})
+		require.ErrorContains(t, err, "metadata property burst must not be negative")
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, rules := range []string{
+			`{"path": "/foo"}`,
+			`[{"path": "foo"}]`,
+			`[{"keyBy": "cookie:foo"}]`,
+			`[{"name": "a"}, {"name": "a"}]`,
+			`[{"maxRequestsPerSecond": -1}]`,
+		} {
+			meta, err := m.getNativeMetadata(middleware.Metadata{Base: metadata.Base{Properties: map[string]string{
+				"rules": rules,
+			}}})
+			require.NoError(t, err)
+			_, err = meta.compileRules()
+			require.Error(t, err, rules)
+		}
+	})
+
+	t.Run("rules inherit defaults", func(t *testing.T) {
+		meta, err := m.getNativeMetadata(middleware.Metadata{Base: metadata.Base{Properties: map[string]string{
+			maxRequestsPerSecondKey: "10",
+			"burst":                 "20",
+			"rules":                 `[{"path": "/a"}, {"path": "/b", "maxRequestsPerSecond": 0.5}, {"path": "/c", "burst": 5}]`,
+		}}})
+		require.NoError(t, err)
+		rules, err := meta.compileRules()
+		require.NoError(t, err)
+		require.Len(t, rules, 4)
+
+		assert.Equal(t, "0", rules[0].name)
+		assert.Equal(t, newLimit(10, 20), rules[0].limit)
+		assert.Equal(t, newLimit(0.5, 1), rules[1].limit)
+		assert.Equal(t, newLimit(10, 5), rules[2].limit)
+		assert.Equal(t, defaultRuleName, rules[3].name)
+		assert.Equal(t, newLimit(10, 20), rules[3].limit)
+	})
+}
+
+func TestMiddlewareHandler(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	m := &Middleware{
+		logger: logger.NewLogger("test"),
+		clock:  clock,
+	}
+	t.Cleanup(func() {
+		require.NoError(t, m.Close())
+	})
+
+	handler, err := m.GetHandler(t.Context(), middleware.Metadata{Base: metadata.Base{Properties: map[string]string{
+		maxRequestsPerSecondKey: "1",
+		"burst":                 "2",
+		"keyBy":                 "header:X-API-Key",
+		"rules":                 `[{"name": "orders", "path": "/v1.0/invoke/orders/**", "methods": ["POST"], "maxRequestsPerSecond": 1}]`,
+	}}})
+	require.NoError(t, err)
+	h := handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(method, path, apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		if apiKey != "" {
+			r.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	// Burst of 2 requests
+	w := do(http.MethodGet, "/v1.0/state/store", "a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get(headerRateLimitLimit))
+	assert.Equal(t, "1", w.Header().Get(headerRateLimitRemaining))
+	assert.Equal(t, "1", w.Header().Get(headerRateLimitReset))
+
+	w = do(http.MethodGet, "/v1.0/state/store", "a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get(headerRateLimitRemaining))
+	assert.Equal(t, "2", w.Header().Get(headerRateLimitReset))
+
+	w = do(http.MethodGet, "/v1.0/state/store", "a")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get(headerRetryAfter))
+	assert.Equal(t, limitReachedMessage, w.Body.String())
+
+	// Other keys have their own limit
+	w = do(http.MethodGet, "/v1.0/state/store", "b")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Requests without the header are limited by IP
+	w = do(http.MethodGet, "/v1.0/state/store", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// The rule has its own limit, with a burst of 1
+	w = do(http.MethodPost, "/v1.0/invoke/orders/method/new", "a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get(headerRateLimitLimit))
+	w = do(http.MethodPost, "/v1.0/invoke/orders/method/new", "a")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// Other methods use the default rule
+	w = do(http.MethodGet, "/v1.0/invoke/orders/method/list", "c")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get(headerRateLimitLimit))
+
+	// After a second, one more request is allowed
+	clock.Step(time.Second)
+	w = do(http.MethodGet, "/v1.0/state/store", "a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = do(http.MethodGet, "/v1.0/state/store", "a")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestMiddlewareHandlerRedis(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	newHandler := func(failOpen string) http.Handler {
+		m := NewRateLimitMiddleware(logger.NewLogger("test")).(*Middleware)
+		t.Cleanup(func() {
+			m.Close()
+		})
+		handler, err := m.GetHandler(t.Context(), middleware.Metadata{Base: metadata.Base{Properties: map[string]string{
+			maxRequestsPerSecondKey: "1",
+			"backend":               "redis",
+			"redisHost":             s.Addr(),
+			"failOpen":              failOpen,
+		}}})
+		require.NoError(t, err)
+		return handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	}
+
+	// Both handlers share the same limit, as if they were in different instances
+	h1 := newHandler("true")
+	h2 := newHandler("false")
+	do := func(h http.Handler) int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do(h1))
+	assert.Equal(t, http.StatusTooManyRequests, do(h2))
+	assert.Equal(t, http.StatusTooManyRequests, do(h1))
+
+	// When Redis is not available, failOpen decides whether requests are allowed
+	s.Close()
+	assert.Equal(t, http.StatusOK, do(h1))
+	assert.Equal(t, http.StatusServiceUnavailable, do(h2))
+}
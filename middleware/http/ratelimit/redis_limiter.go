@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/kit/logger"
+)
+
+// gcraScript implements the same algorithm as the gcra function atomically in Redis.
+// It uses the clock of the Redis server, so all instances share the same time source.
+// Times are in microseconds.
+//
+// KEYS[1]: key
+// ARGV[1]: emission interval
+// ARGV[2]: burst
+//
+// Returns: allowed (0 or 1), remaining, retry after, reset after
+const gcraScript = `
+local emission = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call("TIME")
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+local tat = tonumber(redis.call("GET", KEYS[1]))
+if not tat or tat < now then
+  tat = now
+end
+local newTat = tat + emission
+local allowAt = newTat - burst * emission
+
+if now < allowAt then
+  return {0, 0, allowAt - now, tat - now}
+end
+
+redis.call("SET", KEYS[1], newTat, "PX", math.ceil((newTat - now) / 1000))
+return {1, math.floor((now - allowAt) / emission), 0, newTat - now}
+`
+
+// redisLimiter keeps state in Redis, so limits are shared by all instances using the same Redis server and key prefix.
+type redisLimiter struct {
+	client rediscomponent.RedisClient
+}
+
+func newRedisLimiter(ctx context.Context, properties map[string]string, log logger.Logger) (*redisLimiter, error) {
+	client, _, err := rediscomponent.ParseClientFromProperties(properties, contribMetadata.MiddlewareType, ctx, &log)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.PingResult(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &redisLimiter{
+		client: client,
+	}, nil
+}
+
+func (r *redisLimiter) Allow(ctx context.Context, key string, l limit) (limitResult, error) {
+	res, err := r.client.DoRead(ctx, "EVAL", gcraScript, 1, key, l.emissionInterval.Microseconds(), l.burst)
+	if err != nil {
+		return limitResult{}, fmt.Errorf("failed to execute rate limit script: %w", err)
+	}
+
+	values, ok := res.([]any)
+	if !ok || len(values) != 4 {
+		return limitResult{}, fmt.Errorf("unexpected response from rate limit script: %v", res)
+	}
+	ints := make([]int64, len(values))
+	for i, v := range values {
+		ints[i], ok = v.(int64)
+		if !ok {
+			return limitResult{}, fmt.Errorf("unexpected response from rate limit script: %v", res)
+		}
+	}
+
+	return limitResult{
+		Allowed:    ints[0] == 1,
+		Remaining:  int(ints[1]),
+		RetryAfter: time.Duration(ints[2]) * time.Microsecond,
+		ResetAfter: time.Duration(ints[3]) * time.Microsecond,
+	}, nil
+}
+
+func (r *redisLimiter) Close() error {
+	return r.client.Close()
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/http-wasm/http-wasm-host-go/api"
 	"github.com/http-wasm/http-wasm-host-go/handler"
 	wasmnethttp "github.com/http-wasm/http-wasm-host-go/handler/nethttp"
+	"github.com/tetratelabs/wazero"
 
 	"github.com/dapr/components-contrib/common/wasm"
 	mdutils "github.com/dapr/components-contrib/metadata"
@@ -69,8 +70,16 @@ func (m *middleware) getHandler(ctx context.Context, metadata dapr.Metadata) (*r
 		return nil, fmt.Errorf("wasm: failed to parse wasm middleware metadata: %w", err)
 	}
 
+	runtimeConfig, err := wasm.NewRuntimeConfig(meta)
+	if err != nil {
+		return nil, fmt.Errorf("wasm: %w", err)
+	}
+
 	var stdout, stderr bytes.Buffer
 	mw, err := wasmnethttp.NewMiddleware(ctx, meta.Guest,
+		handler.Runtime(func(ctx context.Context) (wazero.Runtime, error) {
+			return wazero.NewRuntimeWithConfig(ctx, runtimeConfig), nil
+		}),
 		handler.Logger(m),
 		handler.ModuleConfig(wasm.NewModuleConfig(meta).
 			WithName(meta.GuestName).
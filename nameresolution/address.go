@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nameresolution
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoHealthyAddress is returned when all the resolved addresses are unhealthy.
+var ErrNoHealthyAddress = errors.New("no healthy address found")
+
+// HealthStatus is the health status of an address.
+type HealthStatus string
+
+const (
+	// The resolver doesn't know the health of the address.
+	HealthUnknown HealthStatus = ""
+	HealthPassing HealthStatus = "passing"
+	HealthWarning HealthStatus = "warning"
+	// Addresses in critical health are never picked.
+	HealthCritical HealthStatus = "critical"
+)
+
+// Address is an address resolved by a name resolver, with its metadata.
+type Address struct {
+	// Address, in the format returned by ResolveID.
+	Address string
+	// Relative weight of the address, used by the weighted picking strategies.
+	// Addresses with a weight of 0 or less have a weight of 1.
+	Weight int
+	// Zone of the address, such as an availability zone; can be empty.
+	Zone string
+	// Health status of the address.
+	Health HealthStatus
+	// Additional metadata of the address.
+	Metadata map[string]string
+}
+
+// Healthy returns true if the address can be picked.
+func (a Address) Healthy() bool {
+	return a.Health != HealthCritical
+}
+
+func (a Address) weight() int {
+	if a.Weight <= 0 {
+		return 1
+	}
+	return a.Weight
+}
+
+// ResolverAddresses is an optional interface for name resolvers that can return multiple addresses with their metadata.
+type ResolverAddresses interface {
+	ResolveIDAddresses(ctx context.Context, req ResolveRequest) ([]Address, error)
+}
+
+// ResolverFeedback is an optional interface for name resolvers that use the result of the requests sent to the resolved addresses,
+// for example to eject addresses that keep failing.
+type ResolverFeedback interface {
+	// ReportResult reports the result of a request sent to an address resolved for req; err is nil if the request succeeded.
+	ReportResult(req ResolveRequest, address string, err error)
+}
+
+// ToAddressList returns the addresses of a list of addresses with metadata.
+func ToAddressList(addresses []Address) AddressList {
+	res := make(AddressList, len(addresses))
+	for i, a := range addresses {
+		res[i] = a.Address
+	}
+	return res
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cenkalti/backoff/v4"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	commonetcd "github.com/dapr/components-contrib/common/component/etcd"
+	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/kit/logger"
+)
+
+// ErrNoHost is returned by ResolveID when no host can be found.
+var ErrNoHost = errors.New("no host found with the given ID")
+
+// Compile-time interface assertions
+var (
+	_ nameresolution.Resolver          = (*resolver)(nil)
+	_ nameresolution.ResolverMulti     = (*resolver)(nil)
+	_ nameresolution.ResolverAddresses = (*resolver)(nil)
+	_ nameresolution.ResolverFeedback  = (*resolver)(nil)
+)
+
+// registration is the value of the key of a host in etcd.
+type registration struct {
+	Address   string `json:"address"`
+	AppID     string `json:"appId"`
+	Namespace string `json:"namespace"`
+	Zone      string `json:"zone,omitempty"`
+	Weight    int    `json:"weight,omitempty"`
+}
+
+type resolver struct {
+	logger    logger.Logger
+	metadata  etcdMetadata
+	picker    nameresolution.Picker
+	client    *clientv3.Client
+	leaseID   atomic.Int64
+	closed    atomic.Bool
+	runCtx    context.Context
+	runCancel context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewResolver creates a name resolver that is based on etcd.
+// Hosts register themselves with a key attached to a lease, which is kept alive while they are running, so apps on different machines can resolve each other.
+func NewResolver(logger logger.Logger) nameresolution.Resolver {
+	runCtx, runCancel := context.WithCancel(context.Background())
+	return &resolver{
+		logger:    logger,
+		runCtx:    runCtx,
+		runCancel: runCancel,
+	}
+}
+
+// Init initializes the name resolver.
+func (s *resolver) Init(ctx context.Context, md nameresolution.Metadata) error {
+	if s.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	err := s.metadata.InitWithMetadata(md)
+	if err != nil {
+		return err
+	}
+
+	s.picker, err = nameresolution.NewPicker(s.metadata.pickerConfig, nameresolution.StrategyRandom)
+	if err != nil {
+		return err
+	}
+
+	s.client, err = commonetcd.NewClient(s.metadata.ClientMetadata)
+	if err != nil {
+		return fmt.Errorf("initializing etcd client: %w", err)
+	}
+
+	return s.start(ctx)
+}
+
+// start registers the host and keeps the registration alive in background, once the client is ready.
+func (s *resolver) start(ctx context.Context) error {
+	leaseID, err := s.registerHost(ctx)
+	if err != nil {
+		return err
+	}
+	s.leaseID.Store(int64(leaseID))
+
+	s.wg.Add(1)
+	go s.keepRegistration()
+
+	return nil
+}
+
+// Registers the host with a key attached to a new lease, returning the ID of the lease
+func (s *resolver) registerHost(ctx context.Context) (clientv3.LeaseID, error) {
+	queryCtx, queryCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer queryCancel()
+
+	lease, err := s.client.Grant(queryCtx, int64(s.metadata.TTL.Seconds()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to grant lease: %w", err)
+	}
+
+	addr := s.metadata.GetAddress()
+	value, err := json.Marshal(registration{
+		Address:   addr,
+		AppID:     s.metadata.appID,
+		Namespace: s.metadata.namespace,
+		Zone:      s.metadata.Zone,
+		Weight:    s.metadata.Weight,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to register host: %w", err)
+	}
+
+	_, err = s.client.Put(queryCtx, s.metadata.appPrefix(s.metadata.appID)+addr, string(value), clientv3.WithLease(lease.ID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to register host: %w", err)
+	}
+
+	return lease.ID, nil
+}
+
+// In background, keeps the lease of the host's registration alive, registering the host again if the lease is lost
+// Should be invoked in a background goroutine
+func (s *resolver) keepRegistration() {
+	defer s.wg.Done()
+
+	s.logger.Debugf("Started keeping host registration alive in background with TTL %v", s.metadata.TTL)
+	for {
+		ch, err := s.client.KeepAlive(s.runCtx, clientv3.LeaseID(s.leaseID.Load()))
+		if err == nil {
+			// The channel is closed when the lease expires or the context is canceled
+			for range ch {
+			}
+		}
+
+		if s.runCtx.Err() != nil {
+			// Component is closing
+			s.logger.Debug("Stopped keeping host registration alive: component is closing")
+			return
+		}
+
+		// This can happen if etcd was unreachable for longer than the TTL
+		s.logger.Warnf("Host registration lost, registering again: %v", err)
+		b := backoff.NewExponentialBackOff()
+		b.MaxElapsedTime = 0
+		err = backoff.Retry(func() error {
+			leaseID, rErr := s.registerHost(s.runCtx)
+			if rErr != nil {
+				s.logger.Errorf("Failed to register host: %v", rErr)
+				return rErr
+			}
+			s.leaseID.Store(int64(leaseID))
+			return nil
+		}, backoff.WithContext(b, s.runCtx))
+		if err != nil {
+			// The context was canceled
+			s.logger.Debug("Stopped keeping host registration alive: component is closing")
+			return
+		}
+	}
+}
+
+// ResolveID resolves name to address, picking one of the registered hosts.
+func (s *resolver) ResolveID(ctx context.Context, req nameresolution.ResolveRequest) (string, error) {
+	addresses, err := s.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return nameresolution.PickAddress(s.picker, req, addresses)
+}
+
+// ResolveIDMulti resolves name to the addresses of all registered hosts.
+func (s *resolver) ResolveIDMulti(ctx context.Context, req nameresolution.ResolveRequest) (nameresolution.AddressList, error) {
+	addresses, err := s.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return nameresolution.ToAddressList(addresses), nil
+}
+
+// ResolveIDAddresses resolves name to the addresses of all hosts registered in the namespace of the request, with their zone and weight.
+// Hosts whose lease is alive are healthy.
+func (s *resolver) ResolveIDAddresses(ctx context.Context, req nameresolution.ResolveRequest) ([]nameresolution.Address, error) {
+	queryCtx, queryCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer queryCancel()
+
+	resp, err := s.client.Get(queryCtx, s.metadata.appPrefix(req.ID), clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up addresses: %w", err)
+	}
+
+	addresses := make([]nameresolution.Address, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var reg registration
+		err = json.Unmarshal(kv.Value, &reg)
+		if err != nil {
+			s.logger.Warnf("Ignoring invalid registration in key %s: %v", string(kv.Key), err)
+			continue
+		}
+		if reg.AppID != req.ID || reg.Namespace != req.Namespace {
+			continue
+		}
+		addresses = append(addresses, nameresolution.Address{
+			Address: reg.Address,
+			Zone:    reg.Zone,
+			Weight:  reg.Weight,
+			Health:  nameresolution.HealthPassing,
+		})
+	}
+
+	if len(addresses) == 0 {
+		return nil, ErrNoHost
+	}
+	return addresses, nil
+}
+
+// ReportResult implements nameresolution.ResolverFeedback.
+func (s *resolver) ReportResult(_ nameresolution.ResolveRequest, address string, err error) {
+	nameresolution.ReportPickerResult(s.picker, address, err)
+}
+
+// Removes the registration for the host, by revoking its lease
+func (s *resolver) deregisterHost(ctx context.Context) error {
+	leaseID := clientv3.LeaseID(s.leaseID.Load())
+	if leaseID == clientv3.NoLease {
+		// We never registered
+		return nil
+	}
+
+	queryCtx, queryCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer queryCancel()
+
+	_, err := s.client.Revoke(queryCtx, leaseID)
+	if err != nil {
+		return fmt.Errorf("failed to unregister host: %w", err)
+	}
+
+	return nil
+}
+
+// Close implements io.Closer.
+func (s *resolver) Close() (err error) {
+	if !s.closed.CompareAndSwap(false, true) {
+		s.wg.Wait()
+		return nil
+	}
+
+	s.runCancel()
+	s.wg.Wait()
+
+	errs := make([]error, 0)
+
+	if s.client != nil {
+		err = s.deregisterHost(context.Background())
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		err = s.client.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
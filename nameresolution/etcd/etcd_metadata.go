@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	commonetcd "github.com/dapr/components-contrib/common/component/etcd"
+	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/kit/config"
+	"github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultKeyPrefixPath = "dapr/nameresolution"
+	defaultTTL           = 10 * time.Second
+
+	// For a nameresolver, we want a fairly low timeout
+	defaultTimeout = 2 * time.Second
+)
+
+type etcdMetadata struct {
+	// Config options - passed by the user via the Configuration resource
+	commonetcd.ClientMetadata `mapstructure:",squash"`
+
+	// Prefix of the keys of the registrations in etcd
+	KeyPrefixPath string `mapstructure:"keyPrefixPath"`
+	// TTL of the lease of the registration, which is kept alive while the instance is running
+	// Units smaller than seconds are not accepted
+	TTL     time.Duration `mapstructure:"ttl"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Zone and weight of the instance, used by the picking strategies of the instances resolving this one
+	Zone   string `mapstructure:"zone"`
+	Weight int    `mapstructure:"weight"`
+
+	// Configuration of the picking strategy, which is an object so it can't be decoded with DecodeMetadata
+	pickerConfig any
+
+	// Instance properties - these are passed by the runtime
+	appID       string
+	namespace   string
+	hostAddress string
+	port        int
+}
+
+func (m *etcdMetadata) InitWithMetadata(meta nameresolution.Metadata) error {
+	// Reset the object
+	m.reset()
+
+	// Set and validate the instance properties
+	m.appID = meta.Instance.AppID
+	if m.appID == "" {
+		return errors.New("name is missing")
+	}
+	m.hostAddress = meta.Instance.Address
+	if m.hostAddress == "" {
+		return errors.New("address is missing")
+	}
+	m.port = meta.Instance.DaprInternalPort
+	if m.port == 0 {
+		return errors.New("port is missing or invalid")
+	}
+	m.namespace = meta.Instance.Namespace // Can be empty
+
+	// Decode the configuration using DecodeMetadata
+	err := metadata.DecodeMetadata(meta.Configuration, &m)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Normalize(meta.Configuration)
+	if err != nil {
+		return err
+	}
+	if cfgMap, ok := cfg.(map[string]any); ok {
+		m.pickerConfig = cfgMap["picker"]
+	}
+
+	// Validate and sanitize configuration
+	m.KeyPrefixPath = strings.Trim(m.KeyPrefixPath, "/")
+	if m.KeyPrefixPath == "" {
+		return errors.New("key prefix path must not be empty")
+	}
+	// Leases have a TTL in seconds
+	if m.TTL < time.Second || m.TTL != m.TTL.Truncate(time.Second) {
+		return errors.New("invalid value for 'ttl': must be a whole number of seconds, and at least 1s")
+	}
+	if m.Timeout <= 0 {
+		return errors.New("invalid value for 'timeout': must be greater than 0")
+	}
+	if m.Weight < 0 {
+		return errors.New("weight must not be negative")
+	}
+
+	return nil
+}
+
+func (m *etcdMetadata) GetAddress() string {
+	return net.JoinHostPort(m.hostAddress, strconv.Itoa(m.port))
+}
+
+// appPrefix returns the prefix of the keys of the registrations of an app.
+func (m *etcdMetadata) appPrefix(appID string) string {
+	return m.KeyPrefixPath + "/" + appID + "/"
+}
+
+// Reset the object
+func (m *etcdMetadata) reset() {
+	m.ClientMetadata = commonetcd.ClientMetadata{}
+
+	m.KeyPrefixPath = defaultKeyPrefixPath
+	m.TTL = defaultTTL
+	m.Timeout = defaultTimeout
+	m.Zone = ""
+	m.Weight = 1
+	m.pickerConfig = nil
+
+	m.appID = ""
+	m.namespace = ""
+	m.hostAddress = ""
+	m.port = 0
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/kit/logger"
+)
+
+// fakeEtcd implements Get and Put of clientv3.KV and the leases of clientv3.Lease in memory.
+type fakeEtcd struct {
+	clientv3.KV
+	clientv3.Lease
+
+	lock       sync.Mutex
+	kvs        map[string]*mvccpb.KeyValue
+	lastLease  clientv3.LeaseID
+	keepAlives map[clientv3.LeaseID]chan *clientv3.LeaseKeepAliveResponse
+	expired    map[clientv3.LeaseID]bool
+	revoked    []clientv3.LeaseID
+}
+
+func newFakeEtcd() *fakeEtcd {
+	return &fakeEtcd{
+		kvs:        map[string]*mvccpb.KeyValue{},
+		keepAlives: map[clientv3.LeaseID]chan *clientv3.LeaseKeepAliveResponse{},
+		expired:    map[clientv3.LeaseID]bool{},
+	}
+}
+
+func (f *fakeEtcd) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	resp := &clientv3.GetResponse{}
+	for k, kv := range f.kvs {
+		if strings.HasPrefix(k, key) {
+			resp.Kvs = append(resp.Kvs, kv)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeEtcd) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// The only option used by the resolver is WithLease, with the last granted lease
+	kv := &mvccpb.KeyValue{Key: []byte(key), Value: []byte(val)}
+	if len(opts) > 0 {
+		kv.Lease = int64(f.lastLease)
+	}
+	f.kvs[key] = kv
+	return &clientv3.PutResponse{}, nil
+}
+
+func (f *fakeEtcd) Grant(ctx context.Context, ttl int64) (*clientv3.LeaseGrantResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.lastLease++
+	return &clientv3.LeaseGrantResponse{ID: f.lastLease, TTL: ttl}, nil
+}
+
+func (f *fakeEtcd) KeepAlive(ctx context.Context, id clientv3.LeaseID) (<-chan *clientv3.LeaseKeepAliveResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	ch := make(chan *clientv3.LeaseKeepAliveResponse)
+	if f.expired[id] {
+		close(ch)
+		return ch, nil
+	}
+	f.keepAlives[id] = ch
+	go func() {
+		<-ctx.Done()
+		f.expire(id)
+	}()
+	return ch, nil
+}
+
+func (f *fakeEtcd) Revoke(ctx context.Context, id clientv3.LeaseID) (*clientv3.LeaseRevokeResponse, error) {
+	f.expire(id)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.revoked = append(f.revoked, id)
+	return &clientv3.LeaseRevokeResponse{}, nil
+}
+
+func (f *fakeEtcd) Close() error {
+	return nil
+}
+
+// expire deletes the keys attached to a lease and stops keeping it alive.
+func (f *fakeEtcd) expire(id clientv3.LeaseID) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.expired[id] = true
+	for k, kv := range f.kvs {
+		if kv.Lease == int64(id) {
+			delete(f.kvs, k)
+		}
+	}
+	if ch, ok := f.keepAlives[id]; ok {
+		close(ch)
+		delete(f.keepAlives, id)
+	}
+}
+
+func (f *fakeEtcd) leaseOf(key string) clientv3.LeaseID {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if kv, ok := f.kvs[key]; ok {
+		return clientv3.LeaseID(kv.Lease)
+	}
+	return clientv3.NoLease
+}
+
+var testInstance = nameresolution.Instance{
+	Address:          "127.0.0.1",
+	DaprInternalPort: 1234,
+	AppID:            "myapp",
+	Namespace:        "default",
+}
+
+func newTestResolver(t *testing.T) (*resolver, *fakeEtcd) {
+	t.Helper()
+
+	r := NewResolver(logger.NewLogger("test")).(*resolver)
+	err := r.metadata.InitWithMetadata(nameresolution.Metadata{
+		Instance: testInstance,
+		Configuration: map[string]any{
+			"endpoints": "localhost:2379",
+			"zone":      "eu-1",
+			"weight":    "3",
+			"picker": map[string]any{
+				"strategy": "zoneAffinity",
+				"zone":     "eu-2",
+			},
+		},
+	})
+	require.NoError(t, err)
+	r.picker, err = nameresolution.NewPicker(r.metadata.pickerConfig, nameresolution.StrategyRandom)
+	require.NoError(t, err)
+
+	fake := newFakeEtcd()
+	r.client = clientv3.NewCtxClient(t.Context())
+	r.client.KV = fake
+	r.client.Lease = fake
+	require.NoError(t, r.start(t.Context()))
+
+	return r, fake
+}
+
+func TestMetadata(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		var m etcdMetadata
+		err := m.InitWithMetadata(nameresolution.Metadata{
+			Instance:      testInstance,
+			Configuration: map[string]string{"endpoints": "localhost:2379"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, defaultKeyPrefixPath, m.KeyPrefixPath)
+		assert.Equal(t, defaultTTL, m.TTL)
+		assert.Equal(t, 1, m.Weight)
+		assert.Equal(t, "dapr/nameresolution/myapp/", m.appPrefix("myapp"))
+		assert.Nil(t, m.pickerConfig)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := map[string]struct {
+			config map[string]string
+			err    string
+		}{
+			"empty prefix":    {config: map[string]string{"keyPrefixPath": "/"}, err: "key prefix path must not be empty"},
+			"ttl too short":   {config: map[string]string{"ttl": "500ms"}, err: "invalid value for 'ttl'"},
+			"ttl fraction":    {config: map[string]string{"ttl": "1500ms"}, err: "invalid value for 'ttl'"},
+			"negative weight": {config: map[string]string{"weight": "-1"}, err: "weight must not be negative"},
+		}
+		for name, tc := range tests {
+			t.Run(name, func(t *testing.T) {
+				var m etcdMetadata
+				err := m.InitWithMetadata(nameresolution.Metadata{Instance: testInstance, Configuration: tc.config})
+				require.ErrorContains(t, err, tc.err)
+			})
+		}
+	})
+}
+
+func TestRegistration(t *testing.T) {
+	const key = "dapr/nameresolution/myapp/127.0.0.1:1234"
+	r, fake := newTestResolver(t)
+
+	// The host is registered with a lease
+	require.Equal(t, clientv3.LeaseID(1), fake.leaseOf(key))
+	addresses, err := r.ResolveIDAddresses(t.Context(), nameresolution.ResolveRequest{ID: "myapp", Namespace: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, []nameresolution.Address{
+		{Address: "127.0.0.1:1234", Zone: "eu-1", Weight: 3, Health: nameresolution.HealthPassing},
+	}, addresses)
+
+	// The host is registered again if the lease expires
+	fake.expire(1)
+	assert.Eventually(t, func() bool {
+		return fake.leaseOf(key) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The lease is revoked on close
+	// The client created by NewCtxClient returns the error of its canceled context when it's closed
+	_ = r.Close()
+	assert.Equal(t, clientv3.NoLease, fake.leaseOf(key))
+	assert.Equal(t, []clientv3.LeaseID{2}, fake.revoked)
+}
+
+func TestResolve(t *testing.T) {
+	r, fake := newTestResolver(t)
+	t.Cleanup(func() { r.Close() })
+
+	for key, value := range map[string]string{
+		"dapr/nameresolution/otherapp/1.1.1.1:1":  `{"address": "1.1.1.1:1", "appId": "otherapp", "namespace": "default", "zone": "eu-1"}`,
+		"dapr/nameresolution/otherapp/2.2.2.2:1":  `{"address": "2.2.2.2:1", "appId": "otherapp", "namespace": "default", "zone": "eu-2", "weight": 2}`,
+		"dapr/nameresolution/otherapp/3.3.3.3:1":  `{"address": "3.3.3.3:1", "appId": "otherapp", "namespace": "other", "zone": "eu-2"}`,
+		"dapr/nameresolution/otherapp/4.4.4.4:1":  `invalid`,
+		"dapr/nameresolution/otherapp2/5.5.5.5:1": `{"address": "5.5.5.5:1", "appId": "otherapp2", "namespace": "default", "zone": "eu-2"}`,
+	} {
+		_, err := fake.Put(t.Context(), key, value)
+		require.NoError(t, err)
+	}
+	req := nameresolution.ResolveRequest{ID: "otherapp", Namespace: "default"}
+
+	addresses, err := r.ResolveIDAddresses(t.Context(), req)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []nameresolution.Address{
+		{Address: "1.1.1.1:1", Zone: "eu-1", Health: nameresolution.HealthPassing},
+		{Address: "2.2.2.2:1", Zone: "eu-2", Weight: 2, Health: nameresolution.HealthPassing},
+	}, addresses)
+
+	// The host in the zone of the picker is preferred
+	for range 10 {
+		addr, err := r.ResolveID(t.Context(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "2.2.2.2:1", addr)
+	}
+
+	list, err := r.ResolveIDMulti(t.Context(), req)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, nameresolution.AddressList{"1.1.1.1:1", "2.2.2.2:1"}, list)
+
+	_, err = r.ResolveID(t.Context(), nameresolution.ResolveRequest{ID: "notfound", Namespace: "default"})
+	require.ErrorIs(t, err, ErrNoHost)
+}
@@ -23,7 +23,10 @@ import (
 	"github.com/dapr/kit/config"
 )
 
-const defaultDaprPortMetaKey string = "DAPR_PORT" // default key for DaprPort in meta
+const (
+	defaultDaprPortMetaKey string = "DAPR_PORT" // default key for DaprPort in meta
+	defaultZoneMetaKey     string = "DAPR_ZONE" // default key for the zone of the instance in meta
+)
 
 // The intermediateConfig is based off of the consul api types. User configurations are
 // deserialized into this type before being converted to the equivalent consul types
@@ -36,9 +39,11 @@ type intermediateConfig struct {
 	QueryOptions         *QueryOptions
 	AdvancedRegistration *AgentServiceRegistration // advanced use-case
 	DaprPortMetaKey      string
+	ZoneMetaKey          string
 	SelfRegister         bool
 	SelfDeregister       bool
 	UseCache             bool
+	Picker               map[string]any // picking strategy, see nameresolution.PickerConfig
 }
 
 type configSpec struct {
@@ -49,14 +54,17 @@ type configSpec struct {
 	QueryOptions         *consul.QueryOptions
 	AdvancedRegistration *consul.AgentServiceRegistration // advanced use-case
 	DaprPortMetaKey      string
+	ZoneMetaKey          string
 	SelfRegister         bool
 	SelfDeregister       bool
 	UseCache             bool
+	Picker               map[string]any
 }
 
 func newIntermediateConfig() intermediateConfig {
 	return intermediateConfig{
 		DaprPortMetaKey: defaultDaprPortMetaKey,
+		ZoneMetaKey:     defaultZoneMetaKey,
 	}
 }
 
@@ -94,7 +102,9 @@ func mapConfig(config intermediateConfig) configSpec {
 		SelfRegister:         config.SelfRegister,
 		SelfDeregister:       config.SelfDeregister,
 		DaprPortMetaKey:      config.DaprPortMetaKey,
+		ZoneMetaKey:          config.ZoneMetaKey,
 		UseCache:             config.UseCache,
+		Picker:               config.Picker,
 	}
 }
 
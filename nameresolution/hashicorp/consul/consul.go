@@ -16,7 +16,6 @@ package consul
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"net"
 	"strconv"
 	"sync"
@@ -68,8 +67,17 @@ type healthInterface interface {
 	State(state string, q *consul.QueryOptions) (consul.HealthChecks, *consul.QueryMeta, error)
 }
 
+// Compile-time interface assertions
+var (
+	_ nr.Resolver          = (*resolver)(nil)
+	_ nr.ResolverMulti     = (*resolver)(nil)
+	_ nr.ResolverAddresses = (*resolver)(nil)
+	_ nr.ResolverFeedback  = (*resolver)(nil)
+)
+
 type resolver struct {
 	config             resolverConfig
+	picker             nr.Picker
 	logger             logger.Logger
 	client             clientInterface
 	registry           registryInterface
@@ -116,30 +124,22 @@ func (r *registry) get(service string) *registryEntry {
 	return nil
 }
 
-func (e *registryEntry) next() *consul.ServiceEntry {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+func (e *registryEntry) list() []*consul.ServiceEntry {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 
-	if len(e.services) == 0 {
-		return nil
-	}
-
-	// gosec is complaining that we are using a non-crypto-safe PRNG. This is fine in this scenario since we are using it only for selecting a random address for load-balancing.
-	//nolint:gosec
-	return e.services[rand.Int()%len(e.services)]
+	return e.services
 }
 
-func (r *resolver) getService(service string) (*consul.ServiceEntry, error) {
-	var services []*consul.ServiceEntry
-
+func (r *resolver) getServices(service string) ([]*consul.ServiceEntry, error) {
 	if r.config.UseCache {
 		r.startWatcher()
 
 		entry := r.registry.get(service)
 		if entry != nil {
-			result := entry.next()
+			result := entry.list()
 
-			if result != nil {
+			if len(result) > 0 {
 				return result, nil
 			}
 		} else {
@@ -158,8 +158,7 @@ func (r *resolver) getService(service string) (*consul.ServiceEntry, error) {
 		return nil, fmt.Errorf("no healthy services found with AppID '%s'", service)
 	}
 
-	//nolint:gosec
-	return services[rand.Int()%len(services)], nil
+	return services, nil
 }
 
 func (r *registry) addOrUpdate(service string, services []*consul.ServiceEntry) {
@@ -220,7 +219,9 @@ type resolverConfig struct {
 	Registration      *consul.AgentServiceRegistration
 	DeregisterOnClose bool
 	DaprPortMetaKey   string
+	ZoneMetaKey       string
 	UseCache          bool
+	Picker            map[string]any
 }
 
 // NewResolver creates Consul name resolver.
@@ -232,6 +233,7 @@ func newResolver(logger logger.Logger, resolverConfig resolverConfig, client cli
 	return &resolver{
 		logger:             logger,
 		config:             resolverConfig,
+		picker:             nr.NewRandomPicker(),
 		client:             client,
 		registry:           registry,
 		watcherStopChannel: watcherStopChannel,
@@ -245,6 +247,13 @@ func (r *resolver) Init(ctx context.Context, metadata nr.Metadata) (err error) {
 		return err
 	}
 
+	if r.config.Picker != nil {
+		r.picker, err = nr.NewPicker(r.config.Picker, nr.StrategyRandom)
+		if err != nil {
+			return err
+		}
+	}
+
 	if r.config.Client.TLSConfig.InsecureSkipVerify {
 		r.logger.Infof("hashicorp consul: you are using 'insecureSkipVerify' to skip server config verify which is unsafe!")
 	}
@@ -274,28 +283,103 @@ func (r *resolver) Init(ctx context.Context, metadata nr.Metadata) (err error) {
 	return nil
 }
 
-// ResolveID resolves name to address via consul.
+// ResolveID resolves name to address via consul, picking one of the healthy instances.
 func (r *resolver) ResolveID(ctx context.Context, req nr.ResolveRequest) (addr string, err error) {
-	cfg := r.config
-	svc, err := r.getService(req.ID)
+	addresses, err := r.ResolveIDAddresses(ctx, req)
 	if err != nil {
 		return "", err
 	}
 
+	return nr.PickAddress(r.picker, req, addresses)
+}
+
+// ResolveIDMulti resolves name to the addresses of all the healthy instances via consul.
+func (r *resolver) ResolveIDMulti(ctx context.Context, req nr.ResolveRequest) (nr.AddressList, error) {
+	addresses, err := r.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return nr.ToAddressList(addresses), nil
+}
+
+// ResolveIDAddresses resolves name to the addresses of all the healthy instances via consul,
+// with their weight, zone and health status.
+// Instances that can't be resolved to an address are skipped.
+func (r *resolver) ResolveIDAddresses(ctx context.Context, req nr.ResolveRequest) ([]nr.Address, error) {
+	services, err := r.getServices(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]nr.Address, 0, len(services))
+	for _, svc := range services {
+		var addr nr.Address
+		addr, err = r.toAddress(req, svc)
+		if err != nil {
+			r.logger.Debugf("skipping instance of AppID '%s': %v", req.ID, err)
+			continue
+		}
+		addresses = append(addresses, addr)
+	}
+
+	if len(addresses) == 0 {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+// toAddress returns the address of a service instance, with its metadata.
+func (r *resolver) toAddress(req nr.ResolveRequest, svc *consul.ServiceEntry) (nr.Address, error) {
+	cfg := r.config
+	if svc.Service == nil {
+		return nr.Address{}, fmt.Errorf("no healthy services found with AppID '%s'", req.ID)
+	}
+
 	port := svc.Service.Meta[cfg.DaprPortMetaKey]
 	if port == "" {
-		return "", fmt.Errorf("target service AppID '%s' found but %s missing from meta", req.ID, cfg.DaprPortMetaKey)
+		return nr.Address{}, fmt.Errorf("target service AppID '%s' found but %s missing from meta", req.ID, cfg.DaprPortMetaKey)
 	}
 
+	var host string
 	if svc.Service.Address != "" {
-		addr = svc.Service.Address
-	} else if svc.Node.Address != "" {
-		addr = svc.Node.Address
+		host = svc.Service.Address
+	} else if svc.Node != nil && svc.Node.Address != "" {
+		host = svc.Node.Address
 	} else {
-		return "", fmt.Errorf("no healthy services found with AppID '%s'", req.ID)
+		return nr.Address{}, fmt.Errorf("no healthy services found with AppID '%s'", req.ID)
+	}
+
+	addr, err := formatAddress(host, port)
+	if err != nil {
+		return nr.Address{}, err
+	}
+
+	res := nr.Address{
+		Address:  addr,
+		Weight:   svc.Service.Weights.Passing,
+		Metadata: svc.Service.Meta,
+	}
+	if cfg.ZoneMetaKey != "" {
+		res.Zone = svc.Service.Meta[cfg.ZoneMetaKey]
+	}
+	switch svc.Checks.AggregatedStatus() {
+	case consul.HealthPassing:
+		res.Health = nr.HealthPassing
+	case consul.HealthWarning:
+		res.Health = nr.HealthWarning
+		res.Weight = svc.Service.Weights.Warning
+	case consul.HealthCritical, consul.HealthMaint:
+		res.Health = nr.HealthCritical
 	}
 
-	return formatAddress(addr, port)
+	return res, nil
+}
+
+// ReportResult implements nr.ResolverFeedback.
+func (r *resolver) ReportResult(_ nr.ResolveRequest, address string, err error) {
+	nr.ReportPickerResult(r.picker, address, err)
 }
 
 // Close will stop the watcher and deregister app from consul
@@ -345,6 +429,8 @@ func getConfig(metadata nr.Metadata) (resolverCfg resolverConfig, err error) {
 	}
 
 	resolverCfg.DaprPortMetaKey = cfg.DaprPortMetaKey
+	resolverCfg.ZoneMetaKey = cfg.ZoneMetaKey
+	resolverCfg.Picker = cfg.Picker
 	resolverCfg.DeregisterOnClose = cfg.SelfDeregister
 	resolverCfg.UseCache = cfg.UseCache
 
@@ -1031,6 +1031,70 @@ func TestResolveID(t *testing.T) {
 	}
 }
 
+func TestResolveIDAddresses(t *testing.T) {
+	mock := mockClient{
+		mockHealth: mockHealth{
+			serviceResult: []*consul.ServiceEntry{
+				{
+					Service: &consul.AgentService{
+						Address: "10.0.0.1",
+						Meta:    map[string]string{"DAPR_PORT": "50005", "DAPR_ZONE": "eu-1"},
+						Weights: consul.AgentWeights{Passing: 3, Warning: 1},
+					},
+				},
+				{
+					Service: &consul.AgentService{
+						Address: "10.0.0.2",
+						Meta:    map[string]string{"DAPR_PORT": "50005", "DAPR_ZONE": "eu-2"},
+						Weights: consul.AgentWeights{Passing: 3, Warning: 1},
+					},
+					Checks: consul.HealthChecks{
+						&consul.HealthCheck{Status: consul.HealthWarning},
+					},
+				},
+				{
+					Service: &consul.AgentService{
+						Address: "10.0.0.3",
+						Meta:    map[string]string{"DAPR_ZONE": "eu-1"},
+					},
+				},
+			},
+		},
+	}
+	cfg := resolverConfig{
+		DaprPortMetaKey: "DAPR_PORT",
+		ZoneMetaKey:     "DAPR_ZONE",
+		QueryOptions:    &consul.QueryOptions{},
+	}
+	resolver := newResolver(logger.NewLogger("test"), cfg, &mock, &registry{}, make(chan struct{})).(*resolver)
+	req := nr.ResolveRequest{ID: "test-app"}
+
+	// Instances without the dapr port are skipped
+	addresses, err := resolver.ResolveIDAddresses(t.Context(), req)
+	require.NoError(t, err)
+	require.Len(t, addresses, 2)
+	assert.Equal(t, "10.0.0.1:50005", addresses[0].Address)
+	assert.Equal(t, "eu-1", addresses[0].Zone)
+	assert.Equal(t, 3, addresses[0].Weight)
+	assert.Equal(t, nr.HealthPassing, addresses[0].Health)
+	assert.Equal(t, "10.0.0.2:50005", addresses[1].Address)
+	assert.Equal(t, "eu-2", addresses[1].Zone)
+	assert.Equal(t, 1, addresses[1].Weight)
+	assert.Equal(t, nr.HealthWarning, addresses[1].Health)
+
+	list, err := resolver.ResolveIDMulti(t.Context(), req)
+	require.NoError(t, err)
+	assert.Equal(t, nr.AddressList{"10.0.0.1:50005", "10.0.0.2:50005"}, list)
+
+	// The picker is used to pick an address
+	resolver.picker = nr.NewZoneAffinityPicker("eu-2", nr.NewRandomPicker())
+	for range 10 {
+		addr, err := resolver.ResolveID(t.Context(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.2:50005", addr)
+	}
+}
+
 func TestClose(t *testing.T) {
 	tests := []struct {
 		testName string
@@ -1303,6 +1367,10 @@ func TestParseConfig(t *testing.T) {
 				},
 				"DaprPortMetaKey": "DAPR_PORT",
 				"UseCache":        false,
+				"Picker": map[any]any{
+					"strategy": "zoneAffinity",
+					"zone":     "eu-1",
+				},
 			},
 			configSpec{
 				Checks: []*consul.AgentServiceCheck{
@@ -1327,7 +1395,12 @@ func TestParseConfig(t *testing.T) {
 					Filter:   "Checks.ServiceTags contains dapr",
 				},
 				DaprPortMetaKey: "DAPR_PORT",
+				ZoneMetaKey:     defaultZoneMetaKey,
 				UseCache:        false,
+				Picker: map[string]any{
+					"strategy": "zoneAffinity",
+					"zone":     "eu-1",
+				},
 			},
 		},
 		{
@@ -1336,6 +1409,7 @@ func TestParseConfig(t *testing.T) {
 			nil,
 			configSpec{
 				DaprPortMetaKey: defaultDaprPortMetaKey,
+				ZoneMetaKey:     defaultZoneMetaKey,
 			},
 		},
 		{
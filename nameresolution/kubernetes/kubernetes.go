@@ -31,12 +31,15 @@ const (
 	DefaultClusterDomain = "cluster.local"
 	ClusterDomainKey     = "clusterDomain"
 	TemplateKey          = "template"
+	PickerKey            = "picker"
 )
 
 // Compile-time interface assertions
 var (
-	_ nameresolution.Resolver      = (*resolver)(nil)
-	_ nameresolution.ResolverMulti = (*resolver)(nil)
+	_ nameresolution.Resolver          = (*resolver)(nil)
+	_ nameresolution.ResolverMulti     = (*resolver)(nil)
+	_ nameresolution.ResolverAddresses = (*resolver)(nil)
+	_ nameresolution.ResolverFeedback  = (*resolver)(nil)
 )
 
 func executeTemplateWithResolveRequest(tmpl *template.Template, req nameresolution.ResolveRequest) (string, error) {
@@ -51,6 +54,8 @@ type resolver struct {
 	logger        logger.Logger
 	clusterDomain string
 	tmpl          *template.Template
+	// If set, ResolveID resolves the IP addresses of the service and picks one, instead of returning the DNS name of the service
+	picker nameresolution.Picker
 }
 
 // NewResolver creates Kubernetes name resolver.
@@ -87,13 +92,34 @@ func (k *resolver) Init(ctx context.Context, metadata nameresolution.Metadata) e
 				k.logger.Debugf("using custom template %s", tmplStr)
 			}
 		}
+
+		if pickerCfg := cfg[PickerKey]; pickerCfg != nil {
+			k.picker, err = nameresolution.NewPicker(pickerCfg, nameresolution.StrategyRandom)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
 // ResolveID resolves name to address in Kubernetes.
+// If a picker is configured, it resolves the IP addresses of the service and picks one.
 func (k *resolver) ResolveID(ctx context.Context, req nameresolution.ResolveRequest) (string, error) {
+	if k.picker != nil {
+		addresses, err := k.ResolveIDAddresses(ctx, req)
+		if err != nil {
+			return "", err
+		}
+		return nameresolution.PickAddress(k.picker, req, addresses)
+	}
+
+	return k.resolveName(req)
+}
+
+// resolveName returns the DNS name of the service of an app-id.
+func (k *resolver) resolveName(req nameresolution.ResolveRequest) (string, error) {
 	if k.tmpl != nil {
 		return executeTemplateWithResolveRequest(k.tmpl, req)
 	}
@@ -103,8 +129,18 @@ func (k *resolver) ResolveID(ctx context.Context, req nameresolution.ResolveRequ
 
 // ResolveIDMulti resolves an app-id to a set of IP addresses in Kubernetes
 func (k *resolver) ResolveIDMulti(ctx context.Context, req nameresolution.ResolveRequest) (nameresolution.AddressList, error) {
-	// First, get the address from ResolveID, which is usually a DNS name
-	addr, err := k.ResolveID(ctx, req)
+	addresses, err := k.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return nameresolution.ToAddressList(addresses), nil
+}
+
+// ResolveIDAddresses resolves an app-id to a set of IP addresses in Kubernetes.
+// The health of the addresses is unknown, as DNS only returns the addresses of ready pods.
+func (k *resolver) ResolveIDAddresses(ctx context.Context, req nameresolution.ResolveRequest) ([]nameresolution.Address, error) {
+	// First, get the address of the service, which is usually a DNS name
+	addr, err := k.resolveName(req)
 	if err != nil {
 		return nil, err
 	}
@@ -124,13 +160,22 @@ func (k *resolver) ResolveIDMulti(ctx context.Context, req nameresolution.Resolv
 	}
 
 	// Return a list of IPs + port
-	res := make(nameresolution.AddressList, len(ips))
+	res := make([]nameresolution.Address, len(ips))
 	for i, ip := range ips {
-		res[i] = net.JoinHostPort(ip.String(), port)
+		res[i] = nameresolution.Address{
+			Address: net.JoinHostPort(ip.String(), port),
+		}
 	}
 	return res, nil
 }
 
+// ReportResult implements nameresolution.ResolverFeedback.
+func (k *resolver) ReportResult(_ nameresolution.ResolveRequest, address string, err error) {
+	if k.picker != nil {
+		nameresolution.ReportPickerResult(k.picker, address, err)
+	}
+}
+
 func (k *resolver) Close() error {
 	return nil
 }
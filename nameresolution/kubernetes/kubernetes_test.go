@@ -88,3 +88,35 @@ func TestResolveWithTemplateAndData(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, target)
 }
+
+func TestResolveWithPicker(t *testing.T) {
+	resolver := NewResolver(logger.NewLogger("test"))
+	err := resolver.Init(t.Context(), nameresolution.Metadata{
+		Configuration: map[string]interface{}{
+			"template": "{{.ID}}:{{.Port}}",
+			"picker": map[string]interface{}{
+				"strategy": "roundRobin",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// The IP addresses of the name are resolved, and one is picked
+	request := nameresolution.ResolveRequest{ID: "127.0.0.1", Namespace: "abc", Port: 1234}
+	target, err := resolver.ResolveID(t.Context(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:1234", target)
+
+	addresses, err := resolver.(nameresolution.ResolverAddresses).ResolveIDAddresses(t.Context(), request)
+	require.NoError(t, err)
+	assert.Equal(t, []nameresolution.Address{{Address: "127.0.0.1:1234"}}, addresses)
+
+	err = resolver.Init(t.Context(), nameresolution.Metadata{
+		Configuration: map[string]interface{}{
+			"picker": map[string]interface{}{
+				"strategy": "invalid",
+			},
+		},
+	})
+	require.Error(t, err)
+}
@@ -20,6 +20,7 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -28,9 +29,29 @@ import (
 	"github.com/grandcat/zeroconf"
 
 	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/kit/config"
 	"github.com/dapr/kit/logger"
 )
 
+// Keys of the configuration of the resolver.
+const (
+	// ZoneKey is the zone of the instance, published with its address.
+	ZoneKey = "zone"
+	// WeightKey is the weight of the instance, published with its address.
+	WeightKey = "weight"
+	// PickerKey is the configuration of the picking strategy.
+	// If it's not set, the addresses are picked in turn.
+	PickerKey = "picker"
+)
+
+// Compile-time interface assertions
+var (
+	_ nameresolution.Resolver          = (*Resolver)(nil)
+	_ nameresolution.ResolverMulti     = (*Resolver)(nil)
+	_ nameresolution.ResolverAddresses = (*Resolver)(nil)
+	_ nameresolution.ResolverFeedback  = (*Resolver)(nil)
+)
+
 const (
 	// browseOneTimeout is the timeout used when
 	// browsing for the first response to a single app id.
@@ -55,10 +76,12 @@ const (
 
 // address is used to store an ip address along with
 // an expiry time at which point the address is considered
-// too stale to trust.
+// too stale to trust, and the metadata published by the instance.
 type address struct {
 	ip        string
 	expiresAt time.Time
+	zone      string
+	weight    int
 }
 
 // addressList represents a set of addresses along with
@@ -87,24 +110,44 @@ func (a *addressList) expire() {
 
 // add adds a new address to the address list with a
 // maximum expiry time. For existing addresses, the
-// expiry time is updated to the maximum.
+// expiry time and metadata are updated.
 // TODO: Consider enforcing a maximum address list size.
-func (a *addressList) add(ip string) {
+func (a *addressList) add(ip string, meta instanceMetadata) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	for i := range a.addresses {
 		if a.addresses[i].ip == ip {
 			a.addresses[i].expiresAt = time.Now().Add(addressTTL)
+			a.addresses[i].zone = meta.zone
+			a.addresses[i].weight = meta.weight
 			return
 		}
 	}
 	a.addresses = append(a.addresses, address{
 		ip:        ip,
 		expiresAt: time.Now().Add(addressTTL),
+		zone:      meta.zone,
+		weight:    meta.weight,
 	})
 }
 
+// list returns the addresses in the list with their metadata.
+func (a *addressList) list() []nameresolution.Address {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	res := make([]nameresolution.Address, len(a.addresses))
+	for i, addr := range a.addresses {
+		res[i] = nameresolution.Address{
+			Address: addr.ip,
+			Zone:    addr.zone,
+			Weight:  addr.weight,
+		}
+	}
+	return res
+}
+
 // next gets the next address from the list given
 // the current round robin implementation.
 // There are no guarantees on the selection
@@ -129,6 +172,43 @@ func (a *addressList) next() *string {
 	return &addr.ip
 }
 
+// instanceMetadata is the metadata an instance publishes
+// in the TXT record of its mDNS service entry, after its app id.
+type instanceMetadata struct {
+	zone   string
+	weight int
+}
+
+// txt returns the TXT record values of the metadata.
+func (i instanceMetadata) txt() []string {
+	res := make([]string, 0, 2)
+	if i.zone != "" {
+		res = append(res, ZoneKey+"="+i.zone)
+	}
+	if i.weight > 0 {
+		res = append(res, WeightKey+"="+strconv.Itoa(i.weight))
+	}
+	return res
+}
+
+// parseInstanceMetadata parses the TXT record values of the metadata,
+// ignoring unknown and invalid values.
+func parseInstanceMetadata(txt []string) instanceMetadata {
+	var res instanceMetadata
+	for _, t := range txt {
+		key, value, _ := strings.Cut(t, "=")
+		switch key {
+		case ZoneKey:
+			res.zone = value
+		case WeightKey:
+			if w, err := strconv.Atoi(value); err == nil && w > 0 {
+				res.weight = w
+			}
+		}
+	}
+	return res
+}
+
 // SubscriberPool is used to manage
 // a pool of subscribers for a given app id.
 // 'Once' belongs to the first subscriber as
@@ -229,6 +309,11 @@ type Resolver struct {
 	serversRunning sync.WaitGroup
 	refreshRunning atomic.Bool
 	logger         logger.Logger
+	// metadata published by the instances registered with this resolver.
+	instance instanceMetadata
+	// picker picks the cached addresses if configured,
+	// otherwise the addresses are picked in turn.
+	picker nameresolution.Picker
 }
 
 func (m *Resolver) startRefreshers() {
@@ -281,7 +366,12 @@ func (m *Resolver) Init(ctx context.Context, metadata nameresolution.Metadata) e
 		return errors.New("port is missing or invalid")
 	}
 
-	err := m.registerMDNS("", metadata.Instance.AppID, []string{metadata.Instance.Address}, metadata.Instance.DaprInternalPort)
+	err := m.initConfiguration(metadata.Configuration)
+	if err != nil {
+		return err
+	}
+
+	err = m.registerMDNS("", metadata.Instance.AppID, []string{metadata.Instance.Address}, metadata.Instance.DaprInternalPort)
 	if err != nil {
 		return err
 	}
@@ -293,6 +383,36 @@ func (m *Resolver) Init(ctx context.Context, metadata nameresolution.Metadata) e
 	return nil
 }
 
+// initConfiguration reads the optional configuration of the resolver.
+func (m *Resolver) initConfiguration(configuration any) error {
+	cfg, err := config.Normalize(configuration)
+	if err != nil {
+		return err
+	}
+	cfgMap, _ := cfg.(map[string]any)
+	if len(cfgMap) == 0 {
+		return nil
+	}
+
+	if zone, ok := cfgMap[ZoneKey]; ok {
+		m.instance.zone = fmt.Sprint(zone)
+	}
+	if weight, ok := cfgMap[WeightKey]; ok {
+		m.instance.weight, err = strconv.Atoi(fmt.Sprint(weight))
+		if err != nil || m.instance.weight < 0 {
+			return fmt.Errorf("invalid value for '%s': must be a non-negative integer", WeightKey)
+		}
+	}
+	if pickerCfg := cfgMap[PickerKey]; pickerCfg != nil {
+		m.picker, err = nameresolution.NewPicker(pickerCfg, nameresolution.StrategyRoundRobin)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Resolver) getZeroconfResolver() (resolver *zeroconf.Resolver, err error) {
 	// Try with IPv4 + IPv6 first, then IPv4-only, then IPv6-only
 	opts := []zeroconf.ClientOption{
@@ -364,7 +484,8 @@ func (m *Resolver) registerMDNS(instanceID string, appID string, ips []string, p
 		defer m.serversRunning.Done()
 
 		host, _ := os.Hostname()
-		info := []string{appID}
+		// the app id is always the first value, followed by the metadata of the instance.
+		info := append([]string{appID}, m.instance.txt()...)
 
 		// default instance id is unique to the process.
 		if instanceID == "" {
@@ -403,13 +524,8 @@ func (m *Resolver) registerMDNS(instanceID string, appID string, ips []string, p
 
 // ResolveID resolves name to address via mDNS.
 func (m *Resolver) ResolveID(parentCtx context.Context, req nameresolution.ResolveRequest) (string, error) {
-	// check for cached IPv4 addresses for this app id first.
-	if addr := m.nextIPv4Address(req.ID); addr != nil {
-		return *addr, nil
-	}
-
-	// check for cached IPv6 addresses for this app id second.
-	if addr := m.nextIPv6Address(req.ID); addr != nil {
+	// check for cached addresses for this app id first.
+	if addr := m.nextAddress(req); addr != nil {
 		return *addr, nil
 	}
 
@@ -454,10 +570,7 @@ func (m *Resolver) ResolveID(parentCtx context.Context, req nameresolution.Resol
 	// browser as they must wait on the published channel and perform
 	// the cleanup before returning.
 	if once == nil {
-		if addr := m.nextIPv4Address(req.ID); addr != nil {
-			return *addr, nil
-		}
-		if addr := m.nextIPv6Address(req.ID); addr != nil {
+		if addr := m.nextAddress(req); addr != nil {
 			return *addr, nil
 		}
 	}
@@ -515,16 +628,49 @@ func (m *Resolver) ResolveID(parentCtx context.Context, req nameresolution.Resol
 		// If no address or error has been received
 		// within the timeout, we will check the cache again and
 		// if no address is present we will return an error.
-		if addr := m.nextIPv4Address(req.ID); addr != nil {
-			return *addr, nil
-		}
-		if addr := m.nextIPv6Address(req.ID); addr != nil {
+		if addr := m.nextAddress(req); addr != nil {
 			return *addr, nil
 		}
 		return "", fmt.Errorf("timeout waiting for address for app id %s", req.ID)
 	}
 }
 
+// ResolveIDMulti resolves an app id to the set of addresses found via mDNS.
+func (m *Resolver) ResolveIDMulti(ctx context.Context, req nameresolution.ResolveRequest) (nameresolution.AddressList, error) {
+	addresses, err := m.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return nameresolution.ToAddressList(addresses), nil
+}
+
+// ResolveIDAddresses resolves an app id to the set of addresses found via mDNS, with the zone and weight published by the instances.
+// IPv4 addresses are preferred over IPv6 addresses. The health of the addresses is unknown.
+func (m *Resolver) ResolveIDAddresses(ctx context.Context, req nameresolution.ResolveRequest) ([]nameresolution.Address, error) {
+	addresses := m.cachedAddresses(req.ID)
+	if len(addresses) > 0 {
+		return addresses, nil
+	}
+
+	// cache miss, browse the network which populates the cache.
+	addr, err := m.ResolveID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	addresses = m.cachedAddresses(req.ID)
+	if len(addresses) == 0 {
+		addresses = []nameresolution.Address{{Address: addr}}
+	}
+	return addresses, nil
+}
+
+// ReportResult implements nameresolution.ResolverFeedback.
+func (m *Resolver) ReportResult(_ nameresolution.ResolveRequest, address string, err error) {
+	if m.picker != nil {
+		nameresolution.ReportPickerResult(m.picker, address, err)
+	}
+}
+
 // browseOne will perform a mDNS network browse for an address
 // matching the provided app id. It will return the first address it
 // receives and stop browsing for any more.
@@ -691,40 +837,40 @@ func (m *Resolver) browse(ctx context.Context, appID string, onEach func(ip stri
 	entries := make(chan *zeroconf.ServiceEntry)
 
 	handleEntry := func(entry *zeroconf.ServiceEntry) {
-		for _, text := range entry.Text {
-			if text != appID {
-				m.logger.Debugf("mDNS response doesn't match app id %s, skipping.", appID)
-				break
-			}
+		// the first TXT value is the app id, followed by the metadata of the instance.
+		if len(entry.Text) == 0 || entry.Text[0] != appID {
+			m.logger.Debugf("mDNS response doesn't match app id %s, skipping.", appID)
+			return
+		}
 
-			m.logger.Debugf("mDNS response for app id %s received.", appID)
+		m.logger.Debugf("mDNS response for app id %s received.", appID)
 
-			hasIPv4Address := len(entry.AddrIPv4) > 0
-			hasIPv6Address := len(entry.AddrIPv6) > 0
+		hasIPv4Address := len(entry.AddrIPv4) > 0
+		hasIPv6Address := len(entry.AddrIPv6) > 0
 
-			if !hasIPv4Address && !hasIPv6Address {
-				m.logger.Debugf("mDNS response for app id %s doesn't contain any IPv4 or IPv6 addresses, skipping.", appID)
-				break
-			}
-
-			var addr string
-			port := entry.Port
+		if !hasIPv4Address && !hasIPv6Address {
+			m.logger.Debugf("mDNS response for app id %s doesn't contain any IPv4 or IPv6 addresses, skipping.", appID)
+			return
+		}
 
-			// TODO: we currently only use the first IPv4 and IPv6 address.
-			// We should understand the cases in which additional addresses
-			// are returned and whether we need to support them.
-			if hasIPv4Address {
-				addr = entry.AddrIPv4[0].String() + ":" + strconv.Itoa(port)
-				m.addAppAddressIPv4(appID, addr)
-			}
-			if hasIPv6Address {
-				addr = entry.AddrIPv6[0].String() + ":" + strconv.Itoa(port)
-				m.addAppAddressIPv6(appID, addr)
-			}
+		var addr string
+		port := entry.Port
+		meta := parseInstanceMetadata(entry.Text[1:])
+
+		// TODO: we currently only use the first IPv4 and IPv6 address.
+		// We should understand the cases in which additional addresses
+		// are returned and whether we need to support them.
+		if hasIPv4Address {
+			addr = entry.AddrIPv4[0].String() + ":" + strconv.Itoa(port)
+			m.addAppAddressIPv4(appID, addr, meta)
+		}
+		if hasIPv6Address {
+			addr = entry.AddrIPv6[0].String() + ":" + strconv.Itoa(port)
+			m.addAppAddressIPv6(appID, addr, meta)
+		}
 
-			if onEach != nil {
-				onEach(addr) // invoke callback.
-			}
+		if onEach != nil {
+			onEach(addr) // invoke callback.
 		}
 	}
 
@@ -763,7 +909,7 @@ func (m *Resolver) browse(ctx context.Context, appID string, onEach func(ip stri
 
 // addAppAddressIPv4 adds an IPv4 address to the
 // cache for the provided app id.
-func (m *Resolver) addAppAddressIPv4(appID string, addr string) {
+func (m *Resolver) addAppAddressIPv4(appID string, addr string, meta instanceMetadata) {
 	m.ipv4Mu.Lock()
 	defer m.ipv4Mu.Unlock()
 
@@ -772,12 +918,12 @@ func (m *Resolver) addAppAddressIPv4(appID string, addr string) {
 		var addrList addressList
 		m.appAddressesIPv4[appID] = &addrList
 	}
-	m.appAddressesIPv4[appID].add(addr)
+	m.appAddressesIPv4[appID].add(addr, meta)
 }
 
 // addAppIPv4Address adds an IPv6 address to the
 // cache for the provided app id.
-func (m *Resolver) addAppAddressIPv6(appID string, addr string) {
+func (m *Resolver) addAppAddressIPv6(appID string, addr string, meta instanceMetadata) {
 	m.ipv6Mu.Lock()
 	defer m.ipv6Mu.Unlock()
 
@@ -786,7 +932,7 @@ func (m *Resolver) addAppAddressIPv6(appID string, addr string) {
 		var addrList addressList
 		m.appAddressesIPv6[appID] = &addrList
 	}
-	m.appAddressesIPv6[appID].add(addr)
+	m.appAddressesIPv6[appID].add(addr, meta)
 }
 
 // getAppIDsIPv4 returns a list of the current IPv4 app IDs.
@@ -829,6 +975,50 @@ func (m *Resolver) getAppIDs() []string {
 	return union(m.getAppIDsIPv4(), m.getAppIDsIPv6())
 }
 
+// nextAddress returns the next address for the provided
+// app id from the cache, preferring IPv4 addresses.
+// If a picker is configured, it picks the address.
+func (m *Resolver) nextAddress(req nameresolution.ResolveRequest) *string {
+	if m.picker == nil {
+		if addr := m.nextIPv4Address(req.ID); addr != nil {
+			return addr
+		}
+		return m.nextIPv6Address(req.ID)
+	}
+
+	addresses := m.cachedAddresses(req.ID)
+	if len(addresses) == 0 {
+		return nil
+	}
+	addr, ok := m.picker.Pick(req, addresses)
+	if !ok {
+		return nil
+	}
+	m.logger.Debugf("picked mDNS address from cache: %s", addr.Address)
+	return &addr.Address
+}
+
+// cachedAddresses returns the cached IPv4 addresses for
+// the provided app id, or the IPv6 addresses if there's none.
+func (m *Resolver) cachedAddresses(appID string) []nameresolution.Address {
+	m.ipv4Mu.RLock()
+	addrList, exists := m.appAddressesIPv4[appID]
+	m.ipv4Mu.RUnlock()
+	if exists {
+		if addresses := addrList.list(); len(addresses) > 0 {
+			return addresses
+		}
+	}
+
+	m.ipv6Mu.RLock()
+	addrList, exists = m.appAddressesIPv6[appID]
+	m.ipv6Mu.RUnlock()
+	if exists {
+		return addrList.list()
+	}
+	return nil
+}
+
 // nextIPv4Address returns the next IPv4 address for
 // the provided app id from the cache.
 func (m *Resolver) nextIPv4Address(appID string) *string {
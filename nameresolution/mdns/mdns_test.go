@@ -479,7 +479,7 @@ func TestAddressListAddNewAddress(t *testing.T) {
 	}
 
 	// act
-	addressList.add("addr2")
+	addressList.add("addr2", instanceMetadata{})
 
 	// assert
 	require.Len(t, addressList.addresses, 3)
@@ -503,7 +503,7 @@ func TestAddressListAddExisitingAddress(t *testing.T) {
 	}
 
 	// act
-	addressList.add("addr1")
+	addressList.add("addr1", instanceMetadata{})
 	deltaSec := int(addressList.addresses[1].expiresAt.Sub(expiry).Seconds())
 
 	// assert
@@ -644,7 +644,7 @@ func TestAddressListNextWithAdd(t *testing.T) {
 	require.Equal(t, "addr1", *addressList.next())
 	require.Equal(t, "addr2", *addressList.next())
 	require.Equal(t, "addr3", *addressList.next())
-	addressList.add("addr6")
+	addressList.add("addr6", instanceMetadata{})
 	require.Equal(t, "addr4", *addressList.next())
 	require.Equal(t, "addr5", *addressList.next())
 	require.Equal(t, "addr6", *addressList.next())
@@ -697,6 +697,56 @@ func TestAddressListNextWithExpiration(t *testing.T) {
 	require.Equal(t, "addr3", *addressList.next())
 }
 
+func TestInstanceMetadata(t *testing.T) {
+	meta := instanceMetadata{zone: "eu-1", weight: 3}
+	require.Equal(t, []string{"zone=eu-1", "weight=3"}, meta.txt())
+	require.Equal(t, meta, parseInstanceMetadata(meta.txt()))
+
+	require.Empty(t, instanceMetadata{}.txt())
+	require.Equal(t, instanceMetadata{zone: "eu-1"}, parseInstanceMetadata([]string{"zone=eu-1", "weight=-1", "other"}))
+}
+
+func TestResolverPicker(t *testing.T) {
+	// arrange
+	resolver := NewResolver(logger.NewLogger("test")).(*Resolver)
+	defer resolver.Close()
+	require.NoError(t, resolver.initConfiguration(map[string]any{
+		PickerKey: map[string]any{
+			"strategy": nr.StrategyZoneAffinity,
+			"zone":     "eu-1",
+		},
+	}))
+
+	resolver.addAppAddressIPv4("testAppID", "10.0.0.1:1234", instanceMetadata{zone: "eu-1", weight: 2})
+	resolver.addAppAddressIPv4("testAppID", "10.0.0.2:1234", instanceMetadata{zone: "eu-2"})
+	resolver.addAppAddressIPv6("testAppID", "[::1]:1234", instanceMetadata{zone: "eu-1"})
+
+	// act & assert
+	request := nr.ResolveRequest{ID: "testAppID"}
+	addresses, err := resolver.ResolveIDAddresses(t.Context(), request)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []nr.Address{
+		{Address: "10.0.0.1:1234", Zone: "eu-1", Weight: 2},
+		{Address: "10.0.0.2:1234", Zone: "eu-2"},
+	}, addresses)
+
+	for range 10 {
+		addr, err := resolver.ResolveID(t.Context(), request)
+		require.NoError(t, err)
+		require.Equal(t, "10.0.0.1:1234", addr)
+	}
+}
+
+func TestResolverInvalidConfiguration(t *testing.T) {
+	resolver := NewResolver(logger.NewLogger("test")).(*Resolver)
+	defer resolver.Close()
+
+	require.Error(t, resolver.initConfiguration(map[string]any{WeightKey: "heavy"}))
+	require.Error(t, resolver.initConfiguration(map[string]any{PickerKey: map[string]any{"strategy": "fastest"}}))
+	require.NoError(t, resolver.initConfiguration(map[string]any{ZoneKey: "eu-1", WeightKey: "3"}))
+	require.Equal(t, instanceMetadata{zone: "eu-1", weight: 3}, resolver.instance)
+}
+
 func TestUnion(t *testing.T) {
 	// arrange
 	testCases := []struct {
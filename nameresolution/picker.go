@@ -0,0 +1,347 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nameresolution
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dapr/kit/config"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+// Picking strategies.
+const (
+	// Picks a random address.
+	StrategyRandom = "random"
+	// Picks the addresses in turn.
+	StrategyRoundRobin = "roundRobin"
+	// Picks a random address, with a probability proportional to its weight.
+	StrategyWeighted = "weighted"
+	// Picks a weighted random address in the zone of the instance, or in any zone if there's no address in that zone.
+	StrategyZoneAffinity = "zoneAffinity"
+	// Picks the address that failed least recently, ejecting the addresses that keep failing for some time.
+	StrategyLeastFailed = "leastFailed"
+)
+
+const (
+	defaultConsecutiveFailures = 5
+	defaultEjectionDuration    = 30 * time.Second
+	// Maximum multiplier of the ejection duration, for addresses ejected repeatedly
+	maxEjectionMultiplier = 10
+)
+
+// Picker is a strategy to pick an address among the addresses resolved for a request.
+// Pickers never pick addresses in critical health.
+type Picker interface {
+	// Pick returns an address of the list, or false if there's no healthy address.
+	Pick(req ResolveRequest, addresses []Address) (Address, bool)
+}
+
+// PickerFeedback is an optional interface for pickers that use the result of the requests sent to the picked addresses.
+type PickerFeedback interface {
+	ReportResult(address string, err error)
+}
+
+// PickerConfig is the configuration of the picking strategy of a name resolver.
+type PickerConfig struct {
+	// Picking strategy.
+	Strategy string `mapstructure:"strategy"`
+	// Zone of the instance, for the zoneAffinity strategy.
+	Zone string `mapstructure:"zone"`
+	// Number of consecutive failures after which an address is ejected, for the leastFailed strategy.
+	ConsecutiveFailures int `mapstructure:"consecutiveFailures"`
+	// Duration of the first ejection of an address, for the leastFailed strategy.
+	// Addresses that are ejected again are ejected for longer.
+	EjectionDuration time.Duration `mapstructure:"ejectionDuration"`
+}
+
+// NewPicker returns the picker of a configuration, which is usually the "picker" property of the configuration of a name resolver.
+// The default strategy is used if the configuration doesn't set a strategy.
+func NewPicker(rawConfig any, defaultStrategy string) (Picker, error) {
+	cfg := PickerConfig{
+		Strategy:            defaultStrategy,
+		ConsecutiveFailures: defaultConsecutiveFailures,
+		EjectionDuration:    defaultEjectionDuration,
+	}
+	if rawConfig != nil {
+		normalized, err := config.Normalize(rawConfig)
+		if err != nil {
+			return nil, err
+		}
+		err = kitmd.DecodeMetadata(normalized, &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid picker configuration: %w", err)
+		}
+	}
+
+	switch strings.ToLower(cfg.Strategy) {
+	case "", strings.ToLower(StrategyRandom):
+		return NewRandomPicker(), nil
+	case strings.ToLower(StrategyRoundRobin):
+		return NewRoundRobinPicker(), nil
+	case strings.ToLower(StrategyWeighted):
+		return NewWeightedPicker(), nil
+	case strings.ToLower(StrategyZoneAffinity):
+		if cfg.Zone == "" {
+			return nil, fmt.Errorf("picking strategy %s requires a zone", StrategyZoneAffinity)
+		}
+		return NewZoneAffinityPicker(cfg.Zone, NewWeightedPicker()), nil
+	case strings.ToLower(StrategyLeastFailed):
+		if cfg.ConsecutiveFailures <= 0 {
+			return nil, errors.New("invalid value for 'consecutiveFailures': must be greater than 0")
+		}
+		if cfg.EjectionDuration <= 0 {
+			return nil, errors.New("invalid value for 'ejectionDuration': must be greater than 0")
+		}
+		return NewLeastFailedPicker(cfg.ConsecutiveFailures, cfg.EjectionDuration), nil
+	default:
+		return nil, fmt.Errorf("invalid picking strategy: %s", cfg.Strategy)
+	}
+}
+
+// PickAddress picks an address with a picker, returning ErrNoHealthyAddress if there's no healthy address.
+func PickAddress(picker Picker, req ResolveRequest, addresses []Address) (string, error) {
+	addr, ok := picker.Pick(req, addresses)
+	if !ok {
+		return "", ErrNoHealthyAddress
+	}
+	return addr.Address, nil
+}
+
+// ReportPickerResult reports the result of a request to a picker, if it implements PickerFeedback.
+func ReportPickerResult(picker Picker, address string, err error) {
+	if f, ok := picker.(PickerFeedback); ok {
+		f.ReportResult(address, err)
+	}
+}
+
+// healthyAddresses returns the addresses that can be picked.
+func healthyAddresses(addresses []Address) []Address {
+	res := make([]Address, 0, len(addresses))
+	for _, a := range addresses {
+		if a.Healthy() {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+type randomPicker struct{}
+
+// NewRandomPicker returns a picker that picks a random address.
+func NewRandomPicker() Picker {
+	return randomPicker{}
+}
+
+func (randomPicker) Pick(_ ResolveRequest, addresses []Address) (Address, bool) {
+	addresses = healthyAddresses(addresses)
+	if len(addresses) == 0 {
+		return Address{}, false
+	}
+	//nolint:gosec
+	return addresses[rand.Intn(len(addresses))], true
+}
+
+type roundRobinPicker struct {
+	// Counters by request cache key
+	counters sync.Map
+}
+
+// NewRoundRobinPicker returns a picker that picks the addresses of each app in turn.
+// Addresses are sorted, so the order doesn't depend on the order in which they're resolved.
+func NewRoundRobinPicker() Picker {
+	return &roundRobinPicker{}
+}
+
+func (p *roundRobinPicker) Pick(req ResolveRequest, addresses []Address) (Address, bool) {
+	addresses = healthyAddresses(addresses)
+	if len(addresses) == 0 {
+		return Address{}, false
+	}
+	slices.SortFunc(addresses, func(a, b Address) int {
+		return strings.Compare(a.Address, b.Address)
+	})
+
+	counter, _ := p.counters.LoadOrStore(req.CacheKey(), &atomic.Uint64{})
+	n := counter.(*atomic.Uint64).Add(1) - 1
+	return addresses[n%uint64(len(addresses))], true
+}
+
+type weightedPicker struct{}
+
+// NewWeightedPicker returns a picker that picks a random address, with a probability proportional to its weight.
+func NewWeightedPicker() Picker {
+	return weightedPicker{}
+}
+
+func (weightedPicker) Pick(_ ResolveRequest, addresses []Address) (Address, bool) {
+	addresses = healthyAddresses(addresses)
+	if len(addresses) == 0 {
+		return Address{}, false
+	}
+	return pickWeighted(addresses), true
+}
+
+func pickWeighted(addresses []Address) Address {
+	total := 0
+	for _, a := range addresses {
+		total += a.weight()
+	}
+	//nolint:gosec
+	n := rand.Intn(total)
+	for _, a := range addresses {
+		n -= a.weight()
+		if n < 0 {
+			return a
+		}
+	}
+	return addresses[len(addresses)-1]
+}
+
+type zoneAffinityPicker struct {
+	zone   string
+	picker Picker
+}
+
+// NewZoneAffinityPicker returns a picker that picks an address in the given zone with another picker,
+// or in any zone if there's no healthy address in that zone.
+func NewZoneAffinityPicker(zone string, picker Picker) Picker {
+	return &zoneAffinityPicker{
+		zone:   zone,
+		picker: picker,
+	}
+}
+
+func (p *zoneAffinityPicker) Pick(req ResolveRequest, addresses []Address) (Address, bool) {
+	addresses = healthyAddresses(addresses)
+	inZone := make([]Address, 0, len(addresses))
+	for _, a := range addresses {
+		if a.Zone == p.zone {
+			inZone = append(inZone, a)
+		}
+	}
+	if len(inZone) > 0 {
+		addresses = inZone
+	}
+	return p.picker.Pick(req, addresses)
+}
+
+// ReportResult implements PickerFeedback.
+func (p *zoneAffinityPicker) ReportResult(address string, err error) {
+	ReportPickerResult(p.picker, address, err)
+}
+
+type leastFailedPicker struct {
+	consecutiveFailures int
+	ejectionDuration    time.Duration
+	now                 func() time.Time
+
+	lock sync.Mutex
+	// Failures by address; addresses are removed when a request succeeds
+	failures map[string]*addressFailures
+}
+
+type addressFailures struct {
+	consecutive  int
+	last         time.Time
+	ejections    int
+	ejectedUntil time.Time
+}
+
+// NewLeastFailedPicker returns a picker that picks the address that failed least recently, with outlier ejection:
+// addresses that fail consecutiveFailures times in a row aren't picked for ejectionDuration,
+// multiplied by the number of times they were ejected since their last success.
+// If all addresses are ejected, they can all be picked.
+// The results of the requests must be reported with ReportResult.
+func NewLeastFailedPicker(consecutiveFailures int, ejectionDuration time.Duration) Picker {
+	return &leastFailedPicker{
+		consecutiveFailures: consecutiveFailures,
+		ejectionDuration:    ejectionDuration,
+		now:                 time.Now,
+		failures:            map[string]*addressFailures{},
+	}
+}
+
+func (p *leastFailedPicker) Pick(_ ResolveRequest, addresses []Address) (Address, bool) {
+	addresses = healthyAddresses(addresses)
+	if len(addresses) == 0 {
+		return Address{}, false
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	available := make([]Address, 0, len(addresses))
+	for _, a := range addresses {
+		if f := p.failures[a.Address]; f == nil || !now.Before(f.ejectedUntil) {
+			available = append(available, a)
+		}
+	}
+	if len(available) == 0 {
+		available = addresses
+	}
+
+	// Pick among the addresses whose last failure is the oldest, addresses that never failed first
+	var (
+		oldest     time.Time
+		candidates []Address
+	)
+	for _, a := range available {
+		var last time.Time
+		if f := p.failures[a.Address]; f != nil {
+			last = f.last
+		}
+		switch {
+		case candidates == nil || last.Before(oldest):
+			oldest = last
+			candidates = []Address{a}
+		case last.Equal(oldest):
+			candidates = append(candidates, a)
+		}
+	}
+	return pickWeighted(candidates), true
+}
+
+// ReportResult implements PickerFeedback.
+func (p *leastFailedPicker) ReportResult(address string, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err == nil {
+		delete(p.failures, address)
+		return
+	}
+
+	f := p.failures[address]
+	if f == nil {
+		f = &addressFailures{}
+		p.failures[address] = f
+	}
+	now := p.now()
+	f.consecutive++
+	f.last = now
+	if f.consecutive >= p.consecutiveFailures {
+		f.ejections = min(f.ejections+1, maxEjectionMultiplier)
+		f.ejectedUntil = now.Add(time.Duration(f.ejections) * p.ejectionDuration)
+		f.consecutive = 0
+	}
+}
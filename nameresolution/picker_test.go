@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nameresolution
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testReq = ResolveRequest{ID: "app", Namespace: "default", Port: 50001}
+
+func pickMany(t *testing.T, p Picker, addresses []Address, n int) map[string]int {
+	t.Helper()
+
+	counts := map[string]int{}
+	for range n {
+		addr, ok := p.Pick(testReq, addresses)
+		require.True(t, ok)
+		counts[addr.Address]++
+	}
+	return counts
+}
+
+func TestNewPicker(t *testing.T) {
+	p, err := NewPicker(nil, StrategyRoundRobin)
+	require.NoError(t, err)
+	assert.IsType(t, &roundRobinPicker{}, p)
+
+	p, err = NewPicker(map[string]any{"strategy": "weighted"}, StrategyRoundRobin)
+	require.NoError(t, err)
+	assert.IsType(t, weightedPicker{}, p)
+
+	p, err = NewPicker(map[string]any{"strategy": "leastFailed", "consecutiveFailures": "3", "ejectionDuration": "1m"}, "")
+	require.NoError(t, err)
+	require.IsType(t, &leastFailedPicker{}, p)
+	assert.Equal(t, 3, p.(*leastFailedPicker).consecutiveFailures)
+	assert.Equal(t, time.Minute, p.(*leastFailedPicker).ejectionDuration)
+
+	p, err = NewPicker(map[string]any{"strategy": "zoneAffinity", "zone": "eu-1"}, "")
+	require.NoError(t, err)
+	require.IsType(t, &zoneAffinityPicker{}, p)
+
+	_, err = NewPicker(map[string]any{"strategy": "zoneAffinity"}, "")
+	require.ErrorContains(t, err, "requires a zone")
+
+	_, err = NewPicker(map[string]any{"strategy": "fastest"}, "")
+	require.ErrorContains(t, err, "invalid picking strategy")
+}
+
+func TestHealth(t *testing.T) {
+	addresses := []Address{
+		{Address: "a", Health: HealthCritical},
+		{Address: "b", Health: HealthWarning},
+		{Address: "c"},
+	}
+	pickers := map[string]Picker{
+		"random":       NewRandomPicker(),
+		"roundRobin":   NewRoundRobinPicker(),
+		"weighted":     NewWeightedPicker(),
+		"zoneAffinity": NewZoneAffinityPicker("z", NewWeightedPicker()),
+		"leastFailed":  NewLeastFailedPicker(1, time.Minute),
+	}
+	for name, p := range pickers {
+		t.Run(name, func(t *testing.T) {
+			counts := pickMany(t, p, addresses, 100)
+			assert.Zero(t, counts["a"])
+
+			_, err := PickAddress(p, testReq, addresses[:1])
+			require.ErrorIs(t, err, ErrNoHealthyAddress)
+		})
+	}
+}
+
+func TestRoundRobinPicker(t *testing.T) {
+	p := NewRoundRobinPicker()
+
+	// The order of the addresses doesn't matter
+	picked := make([]string, 0, 4)
+	for _, addresses := range [][]Address{{{Address: "a"}, {Address: "b"}}, {{Address: "b"}, {Address: "a"}}} {
+		for range 2 {
+			addr, ok := p.Pick(testReq, addresses)
+			require.True(t, ok)
+			picked = append(picked, addr.Address)
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "a", "b"}, picked)
+
+	// Apps have separate counters
+	addr, _ := p.Pick(ResolveRequest{ID: "other"}, []Address{{Address: "a"}, {Address: "b"}})
+	assert.Equal(t, "a", addr.Address)
+}
+
+func TestWeightedPicker(t *testing.T) {
+	counts := pickMany(t, NewWeightedPicker(), []Address{{Address: "a", Weight: 3}, {Address: "b"}}, 10000)
+	assert.InDelta(t, 7500, counts["a"], 500)
+	assert.InDelta(t, 2500, counts["b"], 500)
+}
+
+func TestZoneAffinityPicker(t *testing.T) {
+	p := NewZoneAffinityPicker("eu-1", NewRoundRobinPicker())
+
+	counts := pickMany(t, p, []Address{{Address: "a", Zone: "eu-1"}, {Address: "b", Zone: "eu-2"}, {Address: "c", Zone: "eu-1"}}, 10)
+	assert.Equal(t, map[string]int{"a": 5, "c": 5}, counts)
+
+	// Falls back to other zones if there's no healthy address in the zone
+	counts = pickMany(t, p, []Address{{Address: "a", Zone: "eu-1", Health: HealthCritical}, {Address: "b", Zone: "eu-2"}}, 10)
+	assert.Equal(t, map[string]int{"b": 10}, counts)
+}
+
+func TestLeastFailedPicker(t *testing.T) {
+	p := NewLeastFailedPicker(2, time.Minute).(*leastFailedPicker)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	addresses := []Address{{Address: "a"}, {Address: "b"}, {Address: "c"}}
+	failure := errors.New("failure")
+
+	// Addresses that never failed are picked first
+	ReportPickerResult(p, "a", failure)
+	now = now.Add(time.Second)
+	ReportPickerResult(p, "b", failure)
+	assert.Equal(t, map[string]int{"c": 10}, pickMany(t, p, addresses, 10))
+
+	// Then the address that failed least recently
+	now = now.Add(time.Second)
+	ReportPickerResult(p, "c", failure)
+	assert.Equal(t, map[string]int{"a": 10}, pickMany(t, p, addresses, 10))
+
+	// Addresses failing consecutively are ejected
+	ReportPickerResult(p, "a", failure)
+	assert.Equal(t, map[string]int{"b": 10}, pickMany(t, p, addresses, 10))
+	ReportPickerResult(p, "b", failure)
+	ReportPickerResult(p, "c", failure)
+
+	// If all addresses are ejected, any can be picked
+	assert.Len(t, pickMany(t, p, addresses, 100), 3)
+
+	// The ejection ends after the ejection duration
+	now = now.Add(time.Minute)
+	ReportPickerResult(p, "b", failure)
+	assert.Len(t, pickMany(t, p, addresses, 100), 2)
+
+	// A success resets the failures of an address
+	ReportPickerResult(p, "c", nil)
+	assert.Equal(t, map[string]int{"c": 10}, pickMany(t, p, addresses, 10))
+	assert.NotContains(t, p.failures, "c")
+
+	// Addresses ejected again are ejected for longer
+	ReportPickerResult(p, "a", failure)
+	ReportPickerResult(p, "a", failure)
+	assert.Equal(t, 2*time.Minute, p.failures["a"].ejectedUntil.Sub(now))
+}
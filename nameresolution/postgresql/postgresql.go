@@ -0,0 +1,388 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
+	commonsql "github.com/dapr/components-contrib/common/component/sql"
+	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/kit/logger"
+)
+
+// ErrNoHost is returned by ResolveID when no host can be found.
+var ErrNoHost = errors.New("no host found with the given ID")
+
+// Internally-used error to indicate the registration was lost
+var errRegistrationLost = errors.New("host registration lost")
+
+// Compile-time interface assertions
+var (
+	_ nameresolution.Resolver          = (*resolver)(nil)
+	_ nameresolution.ResolverMulti     = (*resolver)(nil)
+	_ nameresolution.ResolverAddresses = (*resolver)(nil)
+	_ nameresolution.ResolverFeedback  = (*resolver)(nil)
+)
+
+type resolver struct {
+	logger         logger.Logger
+	metadata       postgresMetadata
+	picker         nameresolution.Picker
+	db             pginterfaces.PGXPoolConn
+	gc             commonsql.GarbageCollector
+	registrationID string
+	closed         atomic.Bool
+	closeCh        chan struct{}
+	wg             sync.WaitGroup
+}
+
+// NewResolver creates a name resolver that is based on a PostgreSQL DB.
+// Hosts register themselves in a table and renew their registration periodically, so apps on different machines can resolve each other.
+func NewResolver(logger logger.Logger) nameresolution.Resolver {
+	return &resolver{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// Init initializes the name resolver.
+func (s *resolver) Init(ctx context.Context, md nameresolution.Metadata) error {
+	if s.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	err := s.metadata.InitWithMetadata(md)
+	if err != nil {
+		return err
+	}
+
+	s.picker, err = nameresolution.NewPicker(s.metadata.pickerConfig, nameresolution.StrategyRandom)
+	if err != nil {
+		return err
+	}
+
+	config, err := s.metadata.GetPgxPoolConfig()
+	if err != nil {
+		return err
+	}
+
+	connCtx, connCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer connCancel()
+	pool, err := pgxpool.NewWithConfig(connCtx, config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the database: %w", err)
+	}
+	s.db = pool
+
+	pingCtx, pingCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer pingCancel()
+	err = s.db.Ping(pingCtx)
+	if err != nil {
+		return fmt.Errorf("failed to ping the database: %w", err)
+	}
+
+	// Performs migrations
+	err = performMigrations(ctx, s.db, s.logger, migrationOptions{
+		HostsTableName:    s.metadata.TableName,
+		MetadataTableName: s.metadata.MetadataTableName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to perform migrations: %w", err)
+	}
+
+	return s.start(ctx)
+}
+
+// start schedules the background GC and registers the host, once the database is ready.
+func (s *resolver) start(ctx context.Context) error {
+	// Init the background GC
+	err := s.initGC()
+	if err != nil {
+		return err
+	}
+
+	// Register the host and update in background
+	err = s.registerHost(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.wg.Add(1)
+	go s.renewRegistration()
+
+	return nil
+}
+
+func (s *resolver) initGC() (err error) {
+	s.gc, err = commonsql.ScheduleGarbageCollector(commonsql.GCOptions{
+		Logger: s.logger,
+		UpdateLastCleanupQuery: func(arg any) (string, any) {
+			return fmt.Sprintf(
+				`INSERT INTO %[1]s (key, value)
+				VALUES ('nr-last-cleanup-%[2]s', now()::text)
+				ON CONFLICT (key)
+				DO UPDATE SET value = now()::text
+					WHERE (EXTRACT('epoch' FROM now() - %[1]s.value::timestamp with time zone) * 1000)::bigint > $1`,
+				s.metadata.MetadataTableName,
+				s.metadata.TableName,
+			), arg
+		},
+		DeleteExpiredValuesQuery: fmt.Sprintf(
+			`DELETE FROM %s WHERE last_update < now() - interval '%d milliseconds'`,
+			s.metadata.TableName,
+			s.metadata.UpdateInterval.Milliseconds(),
+		),
+		CleanupInterval: s.metadata.CleanupInterval,
+		DB:              commonsql.AdaptPgxConn(s.db),
+	})
+	return err
+}
+
+// Registers the host
+func (s *resolver) registerHost(ctx context.Context) error {
+	// Get the registration ID
+	u, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("failed to generate registration ID: %w", err)
+	}
+	s.registrationID = u.String()
+
+	queryCtx, queryCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer queryCancel()
+
+	// There's a unique constraint on address
+	// We take over any previous registration for that address
+	_, err = s.db.Exec(queryCtx,
+		fmt.Sprintf(`INSERT INTO %s (registration_id, address, app_id, namespace, zone, weight, last_update)
+			VALUES ($1, $2, $3, $4, $5, $6, now())
+			ON CONFLICT (address) DO UPDATE SET
+				registration_id = EXCLUDED.registration_id,
+				app_id = EXCLUDED.app_id,
+				namespace = EXCLUDED.namespace,
+				zone = EXCLUDED.zone,
+				weight = EXCLUDED.weight,
+				last_update = EXCLUDED.last_update`, s.metadata.TableName),
+		s.registrationID, s.metadata.GetAddress(), s.metadata.appID, s.metadata.namespace, s.metadata.Zone, s.metadata.Weight,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register host: %w", err)
+	}
+
+	return nil
+}
+
+// In backgrounds, periodically renews the host's registration
+// Should be invoked in a background goroutine
+func (s *resolver) renewRegistration() {
+	defer s.wg.Done()
+
+	addr := s.metadata.GetAddress()
+
+	// Update every UpdateInterval - Timeout
+	// This is because the record has to be updated every UpdateInterval, but we allow up to "timeout" for it to be performed
+	d := s.metadata.UpdateInterval - s.metadata.Timeout
+	s.logger.Debugf("Started renewing host registration in background with interval %v", s.metadata.UpdateInterval)
+	t := time.NewTicker(d)
+	defer t.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for {
+		select {
+		case <-s.closeCh:
+			// Component is closing
+			s.logger.Debug("Stopped renewing host registration: component is closing")
+			return
+
+		case <-t.C:
+			// Renew on the ticker
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				err := s.doRenewRegistration(ctx, addr)
+				if err != nil {
+					// Log errors
+					s.logger.Errorf("Failed to update host registration: %v", err)
+
+					if errors.Is(err, errRegistrationLost) {
+						// This means that our registration has been taken over by another host
+						// It should never happen unless there's something really bad going on
+						// Panicking here to force a restart of Dapr
+						s.logger.Fatalf("Host registration lost")
+					}
+				}
+			}()
+		}
+	}
+}
+
+func (s *resolver) doRenewRegistration(ctx context.Context, addr string) error {
+	// We retry this query in case of database error, up to the timeout
+	queryCtx, queryCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer queryCancel()
+
+	// We use string formatting here for the table name only
+	//nolint:gosec
+	query := fmt.Sprintf("UPDATE %s SET last_update = now() WHERE registration_id = $1 AND address = $2", s.metadata.TableName)
+
+	b := backoff.WithContext(backoff.NewConstantBackOff(50*time.Millisecond), queryCtx)
+	return backoff.Retry(func() error {
+		res, err := s.db.Exec(queryCtx, query, s.registrationID, addr)
+		if err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+
+		if res.RowsAffected() == 0 {
+			// This is a permanent error
+			return backoff.Permanent(errRegistrationLost)
+		}
+
+		return nil
+	}, b)
+}
+
+// ResolveID resolves name to address, picking one of the registered hosts.
+func (s *resolver) ResolveID(ctx context.Context, req nameresolution.ResolveRequest) (string, error) {
+	addresses, err := s.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return nameresolution.PickAddress(s.picker, req, addresses)
+}
+
+// ResolveIDMulti resolves name to the addresses of all registered hosts.
+func (s *resolver) ResolveIDMulti(ctx context.Context, req nameresolution.ResolveRequest) (nameresolution.AddressList, error) {
+	addresses, err := s.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return nameresolution.ToAddressList(addresses), nil
+}
+
+// ResolveIDAddresses resolves name to the addresses of all hosts registered in the namespace of the request, with their zone and weight.
+// Hosts whose registration is current are healthy.
+func (s *resolver) ResolveIDAddresses(ctx context.Context, req nameresolution.ResolveRequest) ([]nameresolution.Address, error) {
+	queryCtx, queryCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer queryCancel()
+
+	//nolint:gosec
+	q := fmt.Sprintf(
+		`SELECT address, zone, weight
+		FROM %s
+		WHERE
+			app_id = $1
+			AND namespace = $2
+			AND last_update >= now() - interval '%d milliseconds'`,
+		s.metadata.TableName,
+		s.metadata.UpdateInterval.Milliseconds(),
+	)
+
+	rows, err := s.db.Query(queryCtx, q, req.ID, req.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up addresses: %w", err)
+	}
+	defer rows.Close()
+
+	var addresses []nameresolution.Address
+	for rows.Next() {
+		addr := nameresolution.Address{
+			Health: nameresolution.HealthPassing,
+		}
+		err = rows.Scan(&addr.Address, &addr.Zone, &addr.Weight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up addresses: %w", err)
+		}
+		addresses = append(addresses, addr)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up addresses: %w", err)
+	}
+
+	if len(addresses) == 0 {
+		return nil, ErrNoHost
+	}
+	return addresses, nil
+}
+
+// ReportResult implements nameresolution.ResolverFeedback.
+func (s *resolver) ReportResult(_ nameresolution.ResolveRequest, address string, err error) {
+	nameresolution.ReportPickerResult(s.picker, address, err)
+}
+
+// Removes the registration for the host
+func (s *resolver) deregisterHost(ctx context.Context) error {
+	if s.registrationID == "" {
+		// We never registered
+		return nil
+	}
+
+	queryCtx, queryCancel := context.WithTimeout(ctx, s.metadata.Timeout)
+	defer queryCancel()
+
+	res, err := s.db.Exec(queryCtx,
+		fmt.Sprintf("DELETE FROM %s WHERE registration_id = $1 AND address = $2", s.metadata.TableName),
+		s.registrationID, s.metadata.GetAddress(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unregister host: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return errors.New("failed to unregister host: no row deleted")
+	}
+
+	return nil
+}
+
+// Close implements io.Closer.
+func (s *resolver) Close() (err error) {
+	if !s.closed.CompareAndSwap(false, true) {
+		s.wg.Wait()
+		return nil
+	}
+
+	close(s.closeCh)
+	s.wg.Wait()
+
+	errs := make([]error, 0)
+
+	if s.gc != nil {
+		err = s.gc.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if s.db != nil {
+		err := s.deregisterHost(context.Background())
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		s.db.Close()
+	}
+
+	return errors.Join(errs...)
+}
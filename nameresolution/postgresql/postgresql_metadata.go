@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	pgauth "github.com/dapr/components-contrib/common/authentication/postgresql"
+	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/kit/config"
+	"github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultTableName         = "dapr_nr_hosts"
+	defaultMetadataTableName = "dapr_metadata"
+	defaultUpdateInterval    = 5 * time.Second
+	defaultCleanupInternal   = time.Hour
+
+	// For a nameresolver, we want a fairly low timeout
+	defaultTimeout = 2 * time.Second
+)
+
+// Table names can be in the format "schema.table" or just "table"
+var validTableName = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*\.)?[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+type postgresMetadata struct {
+	// Config options - passed by the user via the Configuration resource
+	pgauth.PostgresAuthMetadata `mapstructure:",squash"`
+
+	TableName         string        `mapstructure:"tableName"`
+	MetadataTableName string        `mapstructure:"metadataTableName"`
+	Timeout           time.Duration `mapstructure:"timeout" mapstructurealiases:"timeoutInSeconds"`
+	UpdateInterval    time.Duration `mapstructure:"updateInterval"`
+	CleanupInterval   time.Duration `mapstructure:"cleanupInterval" mapstructurealiases:"cleanupIntervalInSeconds"`
+	// Zone and weight of the instance, used by the picking strategies of the instances resolving this one
+	Zone   string `mapstructure:"zone"`
+	Weight int    `mapstructure:"weight"`
+
+	// Configuration of the picking strategy, which is an object so it can't be decoded with DecodeMetadata
+	pickerConfig any
+
+	// Instance properties - these are passed by the runtime
+	appID       string
+	namespace   string
+	hostAddress string
+	port        int
+}
+
+func (m *postgresMetadata) InitWithMetadata(meta nameresolution.Metadata) error {
+	// Reset the object
+	m.reset()
+
+	// Set and validate the instance properties
+	m.appID = meta.Instance.AppID
+	if m.appID == "" {
+		return errors.New("name is missing")
+	}
+	m.hostAddress = meta.Instance.Address
+	if m.hostAddress == "" {
+		return errors.New("address is missing")
+	}
+	m.port = meta.Instance.DaprInternalPort
+	if m.port == 0 {
+		return errors.New("port is missing or invalid")
+	}
+	m.namespace = meta.Instance.Namespace // Can be empty
+
+	// Decode the configuration using DecodeMetadata
+	err := metadata.DecodeMetadata(meta.Configuration, &m)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Normalize(meta.Configuration)
+	if err != nil {
+		return err
+	}
+	if cfgMap, ok := cfg.(map[string]any); ok {
+		m.pickerConfig = cfgMap["picker"]
+	}
+
+	// Validate and sanitize configuration
+	// Azure AD and AWS IAM authentication are not supported by the name resolver
+	err = m.PostgresAuthMetadata.InitWithMetadata(nil, pgauth.InitWithMetadataOpts{})
+	if err != nil {
+		return err
+	}
+	if !validTableName.MatchString(m.TableName) {
+		return fmt.Errorf("invalid identifier for table name: %s", m.TableName)
+	}
+	if !validTableName.MatchString(m.MetadataTableName) {
+		return fmt.Errorf("invalid identifier for metadata table name: %s", m.MetadataTableName)
+	}
+
+	if m.Timeout < 100*time.Millisecond {
+		return errors.New("invalid value for 'timeout': must be at least 100ms")
+	}
+	// UpdateInterval must be greater than Timeout
+	if (m.UpdateInterval - m.Timeout) < time.Second {
+		return errors.New("update interval must be at least 1s greater than timeout")
+	}
+	if m.Weight < 0 {
+		return errors.New("weight must not be negative")
+	}
+
+	return nil
+}
+
+func (m *postgresMetadata) GetAddress() string {
+	return net.JoinHostPort(m.hostAddress, strconv.Itoa(m.port))
+}
+
+// Reset the object
+func (m *postgresMetadata) reset() {
+	m.PostgresAuthMetadata.Reset()
+
+	m.TableName = defaultTableName
+	m.MetadataTableName = defaultMetadataTableName
+	m.Timeout = defaultTimeout
+	m.UpdateInterval = defaultUpdateInterval
+	m.CleanupInterval = defaultCleanupInternal
+	m.Zone = ""
+	m.Weight = 1
+	m.pickerConfig = nil
+
+	m.appID = ""
+	m.namespace = ""
+	m.hostAddress = ""
+	m.port = 0
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	pginterfaces "github.com/dapr/components-contrib/common/component/postgresql/interfaces"
+	commonsql "github.com/dapr/components-contrib/common/component/sql"
+	pgmigrations "github.com/dapr/components-contrib/common/component/sql/migrations/postgres"
+	"github.com/dapr/kit/logger"
+)
+
+type migrationOptions struct {
+	HostsTableName    string
+	MetadataTableName string
+}
+
+// Perform the required migrations
+func performMigrations(ctx context.Context, db pginterfaces.PGXPoolConn, logger logger.Logger, opts migrationOptions) error {
+	m := pgmigrations.Migrations{
+		DB:                db,
+		Logger:            logger,
+		MetadataTableName: opts.MetadataTableName,
+		MetadataKey:       "nr-migrations-" + opts.HostsTableName,
+	}
+
+	return m.Perform(ctx, []commonsql.MigrationFn{
+		// Migration 1: create the hosts table
+		func(ctx context.Context) error {
+			logger.Infof("Creating hosts table '%s'", opts.HostsTableName)
+			_, err := db.Exec(
+				ctx,
+				fmt.Sprintf(
+					`CREATE TABLE IF NOT EXISTS %[1]s (
+						registration_id text NOT NULL PRIMARY KEY,
+						address text NOT NULL UNIQUE,
+						app_id text NOT NULL,
+						namespace text NOT NULL,
+						zone text NOT NULL DEFAULT '',
+						weight integer NOT NULL DEFAULT 1,
+						last_update timestamp with time zone NOT NULL
+					);
+					CREATE INDEX ON %[1]s (app_id, namespace);
+					CREATE INDEX ON %[1]s (last_update);`,
+					opts.HostsTableName,
+				),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create hosts table: %w", err)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresql
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/kit/logger"
+)
+
+var testInstance = nameresolution.Instance{
+	Address:          "127.0.0.1",
+	DaprInternalPort: 1234,
+	AppID:            "myapp",
+	Namespace:        "default",
+}
+
+func newTestResolver(t *testing.T) (*resolver, pgxmock.PgxPoolIface) {
+	t.Helper()
+
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+
+	r := NewResolver(logger.NewLogger("test")).(*resolver)
+	err = r.metadata.InitWithMetadata(nameresolution.Metadata{
+		Instance: testInstance,
+		Configuration: map[string]any{
+			"connectionString": "host=localhost",
+			"zone":             "eu-1",
+			"weight":           "3",
+			"picker": map[string]any{
+				"strategy": "zoneAffinity",
+				"zone":     "eu-2",
+			},
+		},
+	})
+	require.NoError(t, err)
+	r.picker, err = nameresolution.NewPicker(r.metadata.pickerConfig, nameresolution.StrategyRandom)
+	require.NoError(t, err)
+	r.db = mock
+	return r, mock
+}
+
+func TestMetadata(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		var m postgresMetadata
+		err := m.InitWithMetadata(nameresolution.Metadata{
+			Instance:      testInstance,
+			Configuration: map[string]string{"connectionString": "host=localhost"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, defaultTableName, m.TableName)
+		assert.Equal(t, defaultMetadataTableName, m.MetadataTableName)
+		assert.Equal(t, defaultUpdateInterval, m.UpdateInterval)
+		assert.Equal(t, 1, m.Weight)
+		assert.Equal(t, "127.0.0.1:1234", m.GetAddress())
+		assert.Nil(t, m.pickerConfig)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := map[string]struct {
+			instance nameresolution.Instance
+			config   map[string]string
+			err      string
+		}{
+			"missing app id":    {instance: nameresolution.Instance{Address: "127.0.0.1", DaprInternalPort: 1234}, err: "name is missing"},
+			"invalid table":     {config: map[string]string{"tableName": "hosts; DROP TABLE x"}, err: "invalid identifier for table name"},
+			"interval too low":  {config: map[string]string{"updateInterval": "2s", "timeout": "1500ms"}, err: "update interval must be at least 1s greater than timeout"},
+			"negative weight":   {config: map[string]string{"weight": "-1"}, err: "weight must not be negative"},
+			"timeout too short": {config: map[string]string{"timeout": "10ms"}, err: "invalid value for 'timeout'"},
+		}
+		for name, tc := range tests {
+			t.Run(name, func(t *testing.T) {
+				if tc.instance.AppID == "" && tc.instance.Address == "" {
+					tc.instance = testInstance
+				}
+				cfg := map[string]string{"connectionString": "host=localhost"}
+				for k, v := range tc.config {
+					cfg[k] = v
+				}
+				var m postgresMetadata
+				err := m.InitWithMetadata(nameresolution.Metadata{Instance: tc.instance, Configuration: cfg})
+				require.ErrorContains(t, err, tc.err)
+			})
+		}
+	})
+}
+
+func TestRegistration(t *testing.T) {
+	r, mock := newTestResolver(t)
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO dapr_nr_hosts (registration_id, address, app_id, namespace, zone, weight, last_update)")).
+		WithArgs(pgxmock.AnyArg(), "127.0.0.1:1234", "myapp", "default", "eu-1", 3).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, r.registerHost(t.Context()))
+	require.NotEmpty(t, r.registrationID)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE dapr_nr_hosts SET last_update = now() WHERE registration_id = $1 AND address = $2")).
+		WithArgs(r.registrationID, "127.0.0.1:1234").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	require.NoError(t, r.doRenewRegistration(t.Context(), "127.0.0.1:1234"))
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE dapr_nr_hosts SET last_update = now()")).
+		WithArgs(r.registrationID, "127.0.0.1:1234").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	require.ErrorIs(t, r.doRenewRegistration(t.Context(), "127.0.0.1:1234"), errRegistrationLost)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM dapr_nr_hosts WHERE registration_id = $1 AND address = $2")).
+		WithArgs(r.registrationID, "127.0.0.1:1234").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	require.NoError(t, r.deregisterHost(t.Context()))
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolve(t *testing.T) {
+	const query = "SELECT address, zone, weight FROM dapr_nr_hosts WHERE app_id = $1 AND namespace = $2 AND last_update >= now() - interval '5000 milliseconds'"
+	req := nameresolution.ResolveRequest{ID: "otherapp", Namespace: "default"}
+
+	t.Run("found", func(t *testing.T) {
+		r, mock := newTestResolver(t)
+		for range 11 {
+			mock.ExpectQuery(regexp.QuoteMeta(query)).
+				WithArgs("otherapp", "default").
+				WillReturnRows(pgxmock.NewRows([]string{"address", "zone", "weight"}).
+					AddRow("1.1.1.1:1", "eu-1", 1).
+					AddRow("2.2.2.2:1", "eu-2", 2))
+		}
+
+		addresses, err := r.ResolveIDAddresses(t.Context(), req)
+		require.NoError(t, err)
+		assert.Equal(t, []nameresolution.Address{
+			{Address: "1.1.1.1:1", Zone: "eu-1", Weight: 1, Health: nameresolution.HealthPassing},
+			{Address: "2.2.2.2:1", Zone: "eu-2", Weight: 2, Health: nameresolution.HealthPassing},
+		}, addresses)
+
+		// The host in the zone of the picker is preferred
+		for range 10 {
+			addr, err := r.ResolveID(t.Context(), req)
+			require.NoError(t, err)
+			assert.Equal(t, "2.2.2.2:1", addr)
+		}
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r, mock := newTestResolver(t)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("otherapp", "default").
+			WillReturnRows(pgxmock.NewRows([]string{"address", "zone", "weight"}))
+
+		_, err := r.ResolveID(t.Context(), req)
+		require.ErrorIs(t, err, ErrNoHost)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
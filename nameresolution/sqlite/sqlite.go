@@ -38,9 +38,18 @@ var ErrNoHost = errors.New("no host found with the given ID")
 // Internally-used error to indicate the registration was lost
 var errRegistrationLost = errors.New("host registration lost")
 
+// Compile-time interface assertions
+var (
+	_ nameresolution.Resolver          = (*resolver)(nil)
+	_ nameresolution.ResolverMulti     = (*resolver)(nil)
+	_ nameresolution.ResolverAddresses = (*resolver)(nil)
+	_ nameresolution.ResolverFeedback  = (*resolver)(nil)
+)
+
 type resolver struct {
 	logger         logger.Logger
 	metadata       sqliteMetadata
+	picker         nameresolution.Picker
 	db             *sql.DB
 	gc             commonsql.GarbageCollector
 	registrationID string
@@ -68,6 +77,11 @@ func (s *resolver) Init(ctx context.Context, md nameresolution.Metadata) error {
 		return err
 	}
 
+	s.picker, err = nameresolution.NewPicker(s.metadata.pickerConfig, nameresolution.StrategyRandom)
+	if err != nil {
+		return err
+	}
+
 	connString, err := s.metadata.GetConnectionString(s.logger, sqlite.GetConnectionStringOpts{})
 	if err != nil {
 		// Already logged
@@ -152,8 +166,8 @@ func (s *resolver) registerHost(ctx context.Context) error {
 	// We use REPLACE to take over any previous registration for that address
 	// TODO: Add support for namespacing. See https://github.com/dapr/components-contrib/issues/3179
 	_, err = s.db.ExecContext(queryCtx,
-		fmt.Sprintf("REPLACE INTO %s (registration_id, address, app_id, namespace, last_update) VALUES (?, ?, ?, ?, unixepoch(CURRENT_TIMESTAMP))", s.metadata.TableName),
-		s.registrationID, s.metadata.GetAddress(), s.metadata.appID, "",
+		fmt.Sprintf("REPLACE INTO %s (registration_id, address, app_id, namespace, zone, weight, last_update) VALUES (?, ?, ?, ?, ?, ?, unixepoch(CURRENT_TIMESTAMP))", s.metadata.TableName),
+		s.registrationID, s.metadata.GetAddress(), s.metadata.appID, "", s.metadata.Zone, s.metadata.Weight,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to register host: %w", err)
@@ -234,39 +248,72 @@ func (s *resolver) doRenewRegistration(ctx context.Context, addr string) error {
 	}, b)
 }
 
-// ResolveID resolves name to address.
-func (s *resolver) ResolveID(ctx context.Context, req nameresolution.ResolveRequest) (addr string, err error) {
+// ResolveID resolves name to address, picking one of the registered hosts.
+func (s *resolver) ResolveID(ctx context.Context, req nameresolution.ResolveRequest) (string, error) {
+	addresses, err := s.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return nameresolution.PickAddress(s.picker, req, addresses)
+}
+
+// ResolveIDMulti resolves name to the addresses of all registered hosts.
+func (s *resolver) ResolveIDMulti(ctx context.Context, req nameresolution.ResolveRequest) (nameresolution.AddressList, error) {
+	addresses, err := s.ResolveIDAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return nameresolution.ToAddressList(addresses), nil
+}
+
+// ResolveIDAddresses resolves name to the addresses of all registered hosts, with their zone and weight.
+// Hosts whose registration is current are healthy.
+func (s *resolver) ResolveIDAddresses(ctx context.Context, req nameresolution.ResolveRequest) ([]nameresolution.Address, error) {
 	queryCtx, queryCancel := context.WithTimeout(ctx, s.metadata.Timeout)
 	defer queryCancel()
 
 	//nolint:gosec
 	q := fmt.Sprintf(
-		// See: https://stackoverflow.com/a/24591696
-		`SELECT address
-		FROM %[1]s
+		`SELECT address, zone, weight
+		FROM %s
 		WHERE
-			ROWID = (
-				SELECT ROWID
-				FROM %[1]s
-				WHERE
-					app_id = ?
-					AND unixepoch(CURRENT_TIMESTAMP) - last_update < %[2]d
-				ORDER BY RANDOM()
-				LIMIT 1
-			)`,
+			app_id = ?
+			AND unixepoch(CURRENT_TIMESTAMP) - last_update < %d`,
 		s.metadata.TableName,
 		int(s.metadata.UpdateInterval.Seconds()),
 	)
 
-	err = s.db.QueryRowContext(queryCtx, q, req.ID).Scan(&addr)
+	rows, err := s.db.QueryContext(queryCtx, q, req.ID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return "", ErrNoHost
+		return nil, fmt.Errorf("failed to look up addresses: %w", err)
+	}
+	defer rows.Close()
+
+	var addresses []nameresolution.Address
+	for rows.Next() {
+		addr := nameresolution.Address{
+			Health: nameresolution.HealthPassing,
+		}
+		err = rows.Scan(&addr.Address, &addr.Zone, &addr.Weight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up addresses: %w", err)
 		}
-		return "", fmt.Errorf("failed to look up address: %w", err)
+		addresses = append(addresses, addr)
 	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up addresses: %w", err)
+	}
+
+	if len(addresses) == 0 {
+		return nil, ErrNoHost
+	}
+	return addresses, nil
+}
 
-	return addr, nil
+// ReportResult implements nameresolution.ResolverFeedback.
+func (s *resolver) ReportResult(_ nameresolution.ResolveRequest, address string, err error) {
+	nameresolution.ReportPickerResult(s.picker, address, err)
 }
 
 // Removes the registration for the host
@@ -22,6 +22,7 @@ import (
 
 	authSqlite "github.com/dapr/components-contrib/common/authentication/sqlite"
 	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/kit/config"
 	"github.com/dapr/kit/metadata"
 )
 
@@ -44,6 +45,12 @@ type sqliteMetadata struct {
 	MetadataTableName string        `mapstructure:"metadataTableName"`
 	UpdateInterval    time.Duration `mapstructure:"updateInterval"` // Units smaller than seconds are not accepted
 	CleanupInterval   time.Duration `mapstructure:"cleanupInterval" mapstructurealiases:"cleanupIntervalInSeconds"`
+	// Zone and weight of the instance, used by the picking strategies of the instances resolving this one
+	Zone   string `mapstructure:"zone"`
+	Weight int    `mapstructure:"weight"`
+
+	// Configuration of the picking strategy, which is an object so it can't be decoded with DecodeMetadata
+	pickerConfig any
 
 	// Instance properties - these are passed by the runtime
 	appID       string
@@ -76,6 +83,13 @@ func (m *sqliteMetadata) InitWithMetadata(meta nameresolution.Metadata) error {
 	if err != nil {
 		return err
 	}
+	cfg, err := config.Normalize(meta.Configuration)
+	if err != nil {
+		return err
+	}
+	if cfgMap, ok := cfg.(map[string]any); ok {
+		m.pickerConfig = cfgMap["picker"]
+	}
 
 	// Validate and sanitize configuration
 	err = m.SqliteAuthMetadata.Validate()
@@ -97,6 +111,9 @@ func (m *sqliteMetadata) InitWithMetadata(meta nameresolution.Metadata) error {
 	if (m.UpdateInterval - m.Timeout) < time.Second {
 		return errors.New("update interval must be at least 1s greater than timeout")
 	}
+	if m.Weight < 0 {
+		return errors.New("weight must not be negative")
+	}
 
 	return nil
 }
@@ -117,6 +134,9 @@ func (m *sqliteMetadata) reset() {
 	m.MetadataTableName = defaultMetadataTableName
 	m.UpdateInterval = defaultUpdateInterval
 	m.CleanupInterval = defaultCleanupInternal
+	m.Zone = ""
+	m.Weight = 1
+	m.pickerConfig = nil
 
 	m.appID = ""
 	m.namespace = ""
@@ -62,7 +62,7 @@ func TestSqliteNameResolver(t *testing.T) {
 			{"f77ed318", "8.8.8.8:1", "app-8", "", now - 100},
 		}
 		for i, r := range rows {
-			_, err := nr.db.Exec("INSERT INTO hosts VALUES (?, ?, ?, ?, ?)", r...)
+			_, err := nr.db.Exec("INSERT INTO hosts (registration_id, address, app_id, namespace, last_update) VALUES (?, ?, ?, ?, ?)", r...)
 			require.NoErrorf(t, err, "Failed to insert row %d", i)
 		}
 	})
@@ -150,3 +150,54 @@ func TestSqliteNameResolver(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestSqliteNameResolverPicker(t *testing.T) {
+	nr := NewResolver(logger.NewLogger("test")).(*resolver)
+	err := nr.Init(t.Context(), nameresolution.Metadata{
+		Instance: nameresolution.Instance{
+			Address:          "127.0.0.1",
+			DaprInternalPort: 1234,
+			AppID:            "myapp",
+		},
+		Configuration: map[string]any{
+			"connectionString": ":memory:",
+			"cleanupInterval":  "0",
+			"zone":             "eu-1",
+			"weight":           "3",
+			"picker": map[string]any{
+				"strategy": "zoneAffinity",
+				"zone":     "eu-2",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer nr.Close()
+
+	now := time.Now().Unix()
+	rows := [][]any{
+		{"2cb5f837", "1.1.1.1:1", "myapp", "", "eu-2", 1, now},
+		{"4d1e7b11", "1.1.1.1:2", "myapp", "", "eu-2", 1, now - 200},
+	}
+	for i, r := range rows {
+		_, err = nr.db.Exec("INSERT INTO hosts (registration_id, address, app_id, namespace, zone, weight, last_update) VALUES (?, ?, ?, ?, ?, ?, ?)", r...)
+		require.NoErrorf(t, err, "Failed to insert row %d", i)
+	}
+
+	addresses, err := nr.ResolveIDAddresses(t.Context(), nameresolution.ResolveRequest{ID: "myapp"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []nameresolution.Address{
+		{Address: "127.0.0.1:1234", Zone: "eu-1", Weight: 3, Health: nameresolution.HealthPassing},
+		{Address: "1.1.1.1:1", Zone: "eu-2", Weight: 1, Health: nameresolution.HealthPassing},
+	}, addresses)
+
+	// The host in the zone of the picker is preferred
+	for i := range 20 {
+		res, err := nr.ResolveID(t.Context(), nameresolution.ResolveRequest{ID: "myapp"})
+		require.NoErrorf(t, err, "Error on iteration %d", i)
+		require.Equal(t, "1.1.1.1:1", res)
+	}
+
+	list, err := nr.ResolveIDMulti(t.Context(), nameresolution.ResolveRequest{ID: "myapp"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, nameresolution.AddressList{"127.0.0.1:1234", "1.1.1.1:1"}, list)
+}
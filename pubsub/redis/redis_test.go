@@ -327,6 +327,10 @@ func (s *stubRedisClient) TxPipeline() commonredis.RedisPipeliner {
 	return &stubRedisPipeliner{}
 }
 
+func (s *stubRedisClient) Pipeline() commonredis.RedisPipeliner {
+	return &stubRedisPipeliner{}
+}
+
 func (s *stubRedisClient) TTLResult(context.Context, string) (time.Duration, error) {
 	return 0, nil
 }
@@ -342,3 +346,7 @@ func (p *stubRedisPipeliner) Exec(context.Context) error {
 }
 
 func (p *stubRedisPipeliner) Do(context.Context, ...interface{}) {}
+
+func (p *stubRedisPipeliner) DoResult(context.Context, ...interface{}) commonredis.RedisCmdResult {
+	return nil
+}
@@ -16,9 +16,14 @@ package secretmanager
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 
 	awsAuth "github.com/dapr/components-contrib/common/authentication/aws"
@@ -31,9 +36,19 @@ import (
 const (
 	VersionID    = "version_id"
 	VersionStage = "version_stage"
+
+	// ForceDeleteWithoutRecovery is the metadata property of delete requests to delete the secret immediately,
+	// instead of scheduling its deletion after the recovery window.
+	ForceDeleteWithoutRecovery = "force_delete_without_recovery"
+
+	versionStageCurrent = "AWSCURRENT"
 )
 
-var _ secretstores.SecretStore = (*smSecretStore)(nil)
+var (
+	_ secretstores.SecretStore   = (*smSecretStore)(nil)
+	_ secretstores.SecretWriter  = (*smSecretStore)(nil)
+	_ secretstores.SecretWatcher = (*smSecretStore)(nil)
+)
 
 // NewSecretManager returns a new secret manager store.
 func NewSecretManager(logger logger.Logger) secretstores.SecretStore {
@@ -47,12 +62,16 @@ type SecretManagerMetaData struct {
 	SessionToken               string `json:"sessionToken" mapstructure:"sessionToken" mdignore:"true"`
 	Endpoint                   string `json:"endpoint" mapstructure:"endpoint"`
 	MultipleKeyValuesPerSecret bool   `json:"multipleKeyValuesPerSecret" mapstructure:"multipleKeyValuesPerSecret"`
+	// Interval between polls for changes to secrets, when subscribed to.
+	PollInterval time.Duration `json:"pollInterval" mapstructure:"pollInterval"`
 }
 
 type smSecretStore struct {
 	authProvider               awsAuth.Provider
 	logger                     logger.Logger
 	multipleKeyValuesPerSecret bool
+	pollInterval               time.Duration
+	watcher                    *secretstores.PollingWatcher
 }
 
 // Init creates an AWS secret manager client.
@@ -77,6 +96,8 @@ func (s *smSecretStore) Init(ctx context.Context, metadata secretstores.Metadata
 		return err
 	}
 	s.authProvider = provider
+	s.pollInterval = meta.PollInterval
+	s.watcher = s.newWatcher()
 	return nil
 }
 
@@ -177,8 +198,125 @@ func (s *smSecretStore) BulkGetSecret(ctx context.Context, req secretstores.Bulk
 	return resp, nil
 }
 
+// SetSecret creates a secret or stores a new value for it, which becomes its current version.
+// The version of a secret is the ID of its current version.
+// Secrets Manager doesn't support conditional writes: when req.Version is set to a version ID,
+// the current version is checked before the new value is stored, but the two operations are not atomic.
+func (s *smSecretStore) SetSecret(ctx context.Context, req secretstores.SetSecretRequest) (secretstores.SetSecretResponse, error) {
+	if req.Name == "" {
+		return secretstores.SetSecretResponse{}, errors.New("secret name is required")
+	}
+	secretString, err := s.formatSecretString(req.Name, req.Data)
+	if err != nil {
+		return secretstores.SetSecretResponse{}, err
+	}
+
+	manager := s.authProvider.SecretManager().Manager
+
+	// If no version is expected, the secret is created only if it doesn't exist
+	if req.Version != nil && *req.Version == "" {
+		output, err := manager.CreateSecretWithContext(ctx, &secretsmanager.CreateSecretInput{
+			Name:         &req.Name,
+			SecretString: &secretString,
+		})
+		if err != nil {
+			if isAWSErrorCode(err, secretsmanager.ErrCodeResourceExistsException) {
+				return secretstores.SetSecretResponse{}, secretstores.ErrVersionMismatch
+			}
+			return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't create secret: %w", err)
+		}
+		return secretstores.SetSecretResponse{Version: aws.StringValue(output.VersionId)}, nil
+	}
+
+	if req.Version != nil {
+		current, err := manager.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId:     &req.Name,
+			VersionStage: aws.String(versionStageCurrent),
+		})
+		switch {
+		case isAWSErrorCode(err, secretsmanager.ErrCodeResourceNotFoundException):
+			return secretstores.SetSecretResponse{}, secretstores.ErrVersionMismatch
+		case err != nil:
+			return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't get current version of secret: %w", err)
+		case aws.StringValue(current.VersionId) != *req.Version:
+			return secretstores.SetSecretResponse{}, secretstores.ErrVersionMismatch
+		}
+	}
+
+	output, err := manager.PutSecretValueWithContext(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     &req.Name,
+		SecretString: &secretString,
+	})
+	if err == nil {
+		return secretstores.SetSecretResponse{Version: aws.StringValue(output.VersionId)}, nil
+	}
+	if req.Version != nil || !isAWSErrorCode(err, secretsmanager.ErrCodeResourceNotFoundException) {
+		return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't set secret: %w", err)
+	}
+
+	// The secret doesn't exist yet
+	created, err := manager.CreateSecretWithContext(ctx, &secretsmanager.CreateSecretInput{
+		Name:         &req.Name,
+		SecretString: &secretString,
+	})
+	if err != nil {
+		return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't create secret: %w", err)
+	}
+	return secretstores.SetSecretResponse{Version: aws.StringValue(created.VersionId)}, nil
+}
+
+// DeleteSecret deletes a secret.
+// Unless the "force_delete_without_recovery" metadata property is true, the secret is scheduled for deletion after
+// the default recovery window, during which it can't be read nor created again.
+func (s *smSecretStore) DeleteSecret(ctx context.Context, req secretstores.DeleteSecretRequest) error {
+	if req.Name == "" {
+		return errors.New("secret name is required")
+	}
+
+	input := &secretsmanager.DeleteSecretInput{
+		SecretId: &req.Name,
+	}
+	if value, ok := req.Metadata[ForceDeleteWithoutRecovery]; ok {
+		force, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for metadata property %s: %w", ForceDeleteWithoutRecovery, err)
+		}
+		input.ForceDeleteWithoutRecovery = &force
+	}
+
+	_, err := s.authProvider.SecretManager().Manager.DeleteSecretWithContext(ctx, input)
+	if err != nil && !isAWSErrorCode(err, secretsmanager.ErrCodeResourceNotFoundException) {
+		return fmt.Errorf("couldn't delete secret: %w", err)
+	}
+	return nil
+}
+
+// formatSecretString returns the value to store for a secret; it's the inverse of formatSecret.
+func (s *smSecretStore) formatSecretString(name string, data map[string]string) (string, error) {
+	if s.multipleKeyValuesPerSecret {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return "", fmt.Errorf("couldn't encode secret: %w", err)
+		}
+		return string(b), nil
+	}
+
+	value, ok := data[name]
+	if !ok || len(data) != 1 {
+		return "", errors.New("secret must contain a single value, with the secret name as key, unless multipleKeyValuesPerSecret is enabled")
+	}
+	return value, nil
+}
+
+func isAWSErrorCode(err error, code string) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == code
+}
+
 func (s *smSecretStore) getSecretManagerMetadata(spec secretstores.Metadata) (*SecretManagerMetaData, error) {
-	var meta SecretManagerMetaData
+	meta := SecretManagerMetaData{
+		PollInterval: secretstores.DefaultPollInterval,
+	}
 	err := kitmd.DecodeMetadata(spec.Properties, &meta)
 	if err != nil {
 		return nil, err
@@ -189,10 +327,17 @@ func (s *smSecretStore) getSecretManagerMetadata(spec secretstores.Metadata) (*S
 // Features returns the features available in this secret store.
 func (s *smSecretStore) Features() []secretstores.Feature {
 	if s.multipleKeyValuesPerSecret {
-		return []secretstores.Feature{secretstores.FeatureMultipleKeyValuesPerSecret}
+		return []secretstores.Feature{
+			secretstores.FeatureMultipleKeyValuesPerSecret,
+			secretstores.FeatureSecretWriter,
+			secretstores.FeatureSecretWatcher,
+		}
 	}
 
-	return []secretstores.Feature{}
+	return []secretstores.Feature{
+		secretstores.FeatureSecretWriter,
+		secretstores.FeatureSecretWatcher,
+	}
 }
 
 func (s *smSecretStore) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
@@ -202,6 +347,9 @@ func (s *smSecretStore) GetComponentMetadata() (metadataInfo metadata.MetadataMa
 }
 
 func (s *smSecretStore) Close() error {
+	if s.watcher != nil {
+		s.watcher.Close()
+	}
 	if s.authProvider != nil {
 		return s.authProvider.Close()
 	}
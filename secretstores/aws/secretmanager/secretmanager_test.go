@@ -17,8 +17,13 @@ package secretmanager
 import (
 	"context"
 	"errors"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/stretchr/testify/assert"
@@ -488,18 +493,262 @@ func TestGetFeatures(t *testing.T) {
 		assert.True(t, secretstores.FeatureMultipleKeyValuesPerSecret.IsPresent(f))
 	})
 
-	t.Run("when multipleKeyValuesPerSecret = false, no feature advertised", func(t *testing.T) {
+	t.Run("when multipleKeyValuesPerSecret = false, only the secret writer and watcher features are advertised", func(t *testing.T) {
 		s.multipleKeyValuesPerSecret = false
 		f := s.Features()
-		assert.Empty(t, f)
+		assert.Equal(t, []secretstores.Feature{secretstores.FeatureSecretWriter, secretstores.FeatureSecretWatcher}, f)
 	})
 
-	t.Run("by default, no feature advertised", func(t *testing.T) {
+	t.Run("by default, only the secret writer and watcher features are advertised", func(t *testing.T) {
 		f := s.Features()
-		assert.Empty(t, f)
+		assert.Equal(t, []secretstores.Feature{secretstores.FeatureSecretWriter, secretstores.FeatureSecretWatcher}, f)
 	})
 }
 
+func TestSetSecret(t *testing.T) {
+	// newStore returns a store backed by a fake Secrets Manager, where each write creates a new version ID.
+	newStore := func(multipleKeyValuesPerSecret bool) (*smSecretStore, map[string]*secretsmanager.GetSecretValueOutput) {
+		secrets := map[string]*secretsmanager.GetSecretValueOutput{}
+		versions := 0
+		store := func(name, value string) *string {
+			versions++
+			version := "v" + strconv.Itoa(versions)
+			secrets[name] = &secretsmanager.GetSecretValueOutput{
+				Name:         aws.String(name),
+				SecretString: aws.String(value),
+				VersionId:    aws.String(version),
+			}
+			return aws.String(version)
+		}
+		mockSSM := &awsAuth.MockSecretManager{
+			GetSecretValueFn: func(ctx context.Context, input *secretsmanager.GetSecretValueInput, option ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+				secret, ok := secrets[*input.SecretId]
+				if !ok {
+					return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+				}
+				return secret, nil
+			},
+			CreateSecretFn: func(ctx context.Context, input *secretsmanager.CreateSecretInput, option ...request.Option) (*secretsmanager.CreateSecretOutput, error) {
+				if _, ok := secrets[*input.Name]; ok {
+					return nil, awserr.New(secretsmanager.ErrCodeResourceExistsException, "exists", nil)
+				}
+				return &secretsmanager.CreateSecretOutput{VersionId: store(*input.Name, *input.SecretString)}, nil
+			},
+			PutSecretValueFn: func(ctx context.Context, input *secretsmanager.PutSecretValueInput, option ...request.Option) (*secretsmanager.PutSecretValueOutput, error) {
+				if _, ok := secrets[*input.SecretId]; !ok {
+					return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+				}
+				return &secretsmanager.PutSecretValueOutput{VersionId: store(*input.SecretId, *input.SecretString)}, nil
+			},
+		}
+
+		mockAuthProvider := &awsAuth.StaticAuth{}
+		mockAuthProvider.WithMockClients(&awsAuth.Clients{
+			Secret: &awsAuth.SecretManagerClients{Manager: mockSSM},
+		})
+		return &smSecretStore{
+			authProvider:               mockAuthProvider,
+			multipleKeyValuesPerSecret: multipleKeyValuesPerSecret,
+		}, secrets
+	}
+	ptr := func(s string) *string {
+		return &s
+	}
+
+	t.Run("single value", func(t *testing.T) {
+		s, secrets := newStore(false)
+
+		res, err := s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "/aws/secret/testing",
+			Data: map[string]string{"/aws/secret/testing": secretValue},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "v1", res.Version)
+		assert.Equal(t, secretValue, *secrets["/aws/secret/testing"].SecretString)
+
+		res, err = s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "/aws/secret/testing",
+			Data: map[string]string{"/aws/secret/testing": "new"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "v2", res.Version)
+
+		_, err = s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "/aws/secret/testing",
+			Data: map[string]string{"other": "new"},
+		})
+		require.ErrorContains(t, err, "secret must contain a single value")
+	})
+
+	t.Run("multiple key values", func(t *testing.T) {
+		s, secrets := newStore(true)
+
+		_, err := s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "db",
+			Data: map[string]string{"username": "admin", "password": "secret"},
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"username": "admin", "password": "secret"}`, *secrets["db"].SecretString)
+
+		get, err := s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"username": "admin", "password": "secret"}, get.Data)
+	})
+
+	t.Run("versioned updates", func(t *testing.T) {
+		s, _ := newStore(false)
+		set := func(version *string) (secretstores.SetSecretResponse, error) {
+			return s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+				Name:    "key",
+				Data:    map[string]string{"key": "value"},
+				Version: version,
+			})
+		}
+
+		_, err := set(ptr("v1"))
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		res, err := set(ptr(""))
+		require.NoError(t, err)
+		assert.Equal(t, "v1", res.Version)
+
+		_, err = set(ptr(""))
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		res, err = set(ptr("v1"))
+		require.NoError(t, err)
+		assert.Equal(t, "v2", res.Version)
+
+		_, err = set(ptr("v1"))
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+	})
+}
+
+func TestDeleteSecret(t *testing.T) {
+	var lastInput *secretsmanager.DeleteSecretInput
+	mockSSM := &awsAuth.MockSecretManager{
+		DeleteSecretFn: func(ctx context.Context, input *secretsmanager.DeleteSecretInput, option ...request.Option) (*secretsmanager.DeleteSecretOutput, error) {
+			lastInput = input
+			if *input.SecretId != "key" {
+				return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+			}
+			return &secretsmanager.DeleteSecretOutput{}, nil
+		},
+	}
+	mockAuthProvider := &awsAuth.StaticAuth{}
+	mockAuthProvider.WithMockClients(&awsAuth.Clients{
+		Secret: &awsAuth.SecretManagerClients{Manager: mockSSM},
+	})
+	s := smSecretStore{authProvider: mockAuthProvider}
+
+	require.NoError(t, s.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "key"}))
+	assert.Nil(t, lastInput.ForceDeleteWithoutRecovery)
+
+	require.NoError(t, s.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{
+		Name:     "key",
+		Metadata: map[string]string{ForceDeleteWithoutRecovery: "true"},
+	}))
+	assert.True(t, *lastInput.ForceDeleteWithoutRecovery)
+
+	// Deleting a secret that doesn't exist is not an error
+	require.NoError(t, s.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "notfound"}))
+}
+
+func TestSubscribeSecrets(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		versions = map[string]string{"a": "v1", "b": "v1"}
+	)
+	setVersion := func(name, version string) {
+		lock.Lock()
+		defer lock.Unlock()
+		if version == "" {
+			delete(versions, name)
+		} else {
+			versions[name] = version
+		}
+	}
+	mockSSM := &awsAuth.MockSecretManager{
+		DescribeSecretFn: func(ctx context.Context, input *secretsmanager.DescribeSecretInput, option ...request.Option) (*secretsmanager.DescribeSecretOutput, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			version, ok := versions[*input.SecretId]
+			if !ok {
+				return nil, awserr.New(secretsmanager.ErrCodeResourceNotFoundException, "not found", nil)
+			}
+			return &secretsmanager.DescribeSecretOutput{
+				Name: input.SecretId,
+				VersionIdsToStages: map[string][]*string{
+					"old":   {aws.String("AWSPREVIOUS")},
+					version: {aws.String(versionStageCurrent)},
+				},
+			}, nil
+		},
+		GetSecretValueFn: func(ctx context.Context, input *secretsmanager.GetSecretValueInput, option ...request.Option) (*secretsmanager.GetSecretValueOutput, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			return &secretsmanager.GetSecretValueOutput{
+				Name:         input.SecretId,
+				SecretString: aws.String(versions[*input.SecretId]),
+			}, nil
+		},
+	}
+	mockAuthProvider := &awsAuth.StaticAuth{}
+	mockAuthProvider.WithMockClients(&awsAuth.Clients{
+		Secret: &awsAuth.SecretManagerClients{Manager: mockSSM},
+	})
+	s := &smSecretStore{
+		authProvider: mockAuthProvider,
+		logger:       logger.NewLogger("test"),
+		pollInterval: 50 * time.Millisecond,
+	}
+	s.watcher = s.newWatcher()
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+
+	events := make(chan *secretstores.SecretUpdateEvent, 10)
+	id, err := s.SubscribeSecrets(t.Context(), secretstores.SubscribeSecretsRequest{
+		Names: []string{"a", "c"},
+	}, func(ctx context.Context, e *secretstores.SecretUpdateEvent) error {
+		events <- e
+		return nil
+	})
+	require.NoError(t, err)
+
+	receive := func() map[string]*secretstores.SecretUpdate {
+		t.Helper()
+		select {
+		case e := <-events:
+			assert.Equal(t, id, e.ID)
+			return e.Secrets
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for update")
+			return nil
+		}
+	}
+
+	// Secrets that are not watched are ignored
+	setVersion("b", "v2")
+	setVersion("a", "v2")
+	assert.Equal(t, map[string]*secretstores.SecretUpdate{
+		"a": {Data: map[string]string{"a": "v2"}, Version: "v2"},
+	}, receive())
+
+	setVersion("c", "v1")
+	assert.Equal(t, map[string]*secretstores.SecretUpdate{
+		"c": {Data: map[string]string{"c": "v1"}, Version: "v1"},
+	}, receive())
+
+	setVersion("a", "")
+	assert.Equal(t, map[string]*secretstores.SecretUpdate{
+		"a": {Deleted: true},
+	}, receive())
+
+	require.NoError(t, s.UnsubscribeSecrets(t.Context(), secretstores.UnsubscribeSecretsRequest{ID: id}))
+	require.Error(t, s.UnsubscribeSecrets(t.Context(), secretstores.UnsubscribeSecretsRequest{ID: id}))
+}
+
 func TestGetSecretManagerMetadata(t *testing.T) {
 	s := &smSecretStore{
 		logger: logger.NewLogger("test"),
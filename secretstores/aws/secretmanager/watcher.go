@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	"github.com/dapr/components-contrib/secretstores"
+)
+
+// SubscribeSecrets polls Secrets Manager for changes to the secrets.
+// The version of a secret is the ID of its current version, so a change is notified when a new value is stored.
+func (s *smSecretStore) SubscribeSecrets(ctx context.Context, req secretstores.SubscribeSecretsRequest, handler secretstores.SecretUpdateHandler) (string, error) {
+	if s.watcher == nil {
+		return "", errors.New("secret store is not initialized")
+	}
+	return s.watcher.SubscribeSecrets(ctx, req, handler)
+}
+
+// UnsubscribeSecrets implements secretstores.SecretWatcher.
+func (s *smSecretStore) UnsubscribeSecrets(ctx context.Context, req secretstores.UnsubscribeSecretsRequest) error {
+	if s.watcher == nil {
+		return errors.New("secret store is not initialized")
+	}
+	return s.watcher.UnsubscribeSecrets(ctx, req)
+}
+
+func (s *smSecretStore) newWatcher() *secretstores.PollingWatcher {
+	return secretstores.NewPollingWatcher(secretstores.PollingWatcherOptions{
+		Interval:    s.pollInterval,
+		GetVersions: s.getSecretVersions,
+		GetSecret: func(ctx context.Context, name string, _ map[string]string) (map[string]string, error) {
+			res, err := s.GetSecret(ctx, secretstores.GetSecretRequest{Name: name})
+			if err != nil {
+				return nil, err
+			}
+			return res.Data, nil
+		},
+		Logger: s.logger,
+	})
+}
+
+// getSecretVersions returns the IDs of the current versions of the secrets with the given names, or of all secrets.
+func (s *smSecretStore) getSecretVersions(ctx context.Context, names []string, _ map[string]string) (map[string]string, error) {
+	client := s.authProvider.SecretManager().Manager
+	versions := make(map[string]string, len(names))
+
+	if len(names) == 0 {
+		var nextToken *string
+		for {
+			output, err := client.ListSecretsWithContext(ctx, &secretsmanager.ListSecretsInput{
+				NextToken: nextToken,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("couldn't list secrets: %w", err)
+			}
+			for _, entry := range output.SecretList {
+				if entry.Name == nil {
+					continue
+				}
+				if version := currentVersionID(entry.SecretVersionsToStages); version != "" {
+					versions[*entry.Name] = version
+				}
+			}
+			if output.NextToken == nil {
+				return versions, nil
+			}
+			nextToken = output.NextToken
+		}
+	}
+
+	for _, name := range names {
+		output, err := client.DescribeSecretWithContext(ctx, &secretsmanager.DescribeSecretInput{
+			SecretId: &name,
+		})
+		if isAWSErrorCode(err, secretsmanager.ErrCodeResourceNotFoundException) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("couldn't describe secret %s: %w", name, err)
+		}
+		// Secrets scheduled for deletion can't be read
+		if output.DeletedDate != nil {
+			continue
+		}
+		if version := currentVersionID(output.VersionIdsToStages); version != "" {
+			versions[name] = version
+		}
+	}
+	return versions, nil
+}
+
+// currentVersionID returns the ID of the version with the AWSCURRENT stage.
+func currentVersionID(versionsToStages map[string][]*string) string {
+	for id, stages := range versionsToStages {
+		for _, stage := range stages {
+			if stage != nil && *stage == versionStageCurrent {
+				return id
+			}
+		}
+	}
+	return ""
+}
@@ -20,6 +20,10 @@ import (
 const (
 	// FeatureMultipleKeyValuesPerSecret advertises that this SecretStore supports multiple keys-values under a single secret.
 	FeatureMultipleKeyValuesPerSecret Feature = "MULTIPLE_KEY_VALUES_PER_SECRET"
+	// FeatureSecretWriter advertises that this SecretStore implements the SecretWriter interface.
+	FeatureSecretWriter Feature = "SECRET_WRITER"
+	// FeatureSecretWatcher advertises that this SecretStore implements the SecretWatcher interface.
+	FeatureSecretWatcher Feature = "SECRET_WATCHER"
 )
 
 type Feature = features.Feature[SecretStore]
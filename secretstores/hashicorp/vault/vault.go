@@ -14,23 +14,19 @@ limitations under the License.
 package vault
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"os"
-	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
-	"golang.org/x/net/http2"
 
+	vaultclient "github.com/dapr/components-contrib/common/component/hashicorp/vault"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/kit/logger"
@@ -51,11 +47,10 @@ const (
 	componentVaultKVPrefix       string = "vaultKVPrefix"
 	componentVaultKVUsePrefix    string = "vaultKVUsePrefix"
 	defaultVaultKVPrefix         string = "dapr"
-	vaultHTTPHeader              string = "X-Vault-Token"
-	vaultHTTPRequestHeader       string = "X-Vault-Request"
 	vaultEnginePath              string = "enginePath"
 	vaultValueType               string = "vaultValueType"
 	versionID                    string = "version_id"
+	defaultVaultKVVersion        int    = 2
 
 	DataStr string = "data"
 )
@@ -67,7 +62,11 @@ const (
 	valueTypeText valueType = "text"
 )
 
-var _ secretstores.SecretStore = (*vaultSecretStore)(nil)
+var (
+	_ secretstores.SecretStore   = (*vaultSecretStore)(nil)
+	_ secretstores.SecretWriter  = (*vaultSecretStore)(nil)
+	_ secretstores.SecretWatcher = (*vaultSecretStore)(nil)
+)
 
 func (v valueType) isMapType() bool {
 	return v == valueTypeMap
@@ -77,13 +76,16 @@ var ErrNotFound = errors.New("secret key or version not exist")
 
 // vaultSecretStore is a secret store implementation for HashiCorp Vault.
 type vaultSecretStore struct {
-	client              *http.Client
+	client              *vaultclient.Client
 	vaultAddress        string
 	vaultToken          string
 	vaultTokenMountPath string
 	vaultKVPrefix       string
 	vaultEnginePath     string
+	vaultKVVersion      int
 	vaultValueType      valueType
+	pollInterval        time.Duration
+	watcher             *secretstores.PollingWatcher
 
 	json jsoniter.API
 
@@ -91,6 +93,8 @@ type vaultSecretStore struct {
 }
 
 type VaultMetadata struct {
+	vaultclient.AuthMetadata `mapstructure:",squash"`
+
 	CaCert              string
 	CaPath              string
 	CaPem               string
@@ -102,7 +106,10 @@ type VaultMetadata struct {
 	VaultToken          string
 	VaultTokenMountPath string
 	EnginePath          string
+	VaultKVVersion      int
 	VaultValueType      string
+	// Interval between polls for changes to secrets, when subscribed to.
+	PollInterval time.Duration
 }
 
 // tlsConfig is TLS configuration to interact with HashiCorp Vault.
@@ -121,6 +128,23 @@ type vaultKVResponse struct {
 	} `json:"data"`
 }
 
+// vaultKVv1Response is the response data from Vault KV version 1.
+type vaultKVv1Response struct {
+	Data map[string]string `json:"data"`
+}
+
+// vaultKVWriteResponse is the response data from Vault KV version 2 when writing a secret.
+type vaultKVWriteResponse struct {
+	Data struct {
+		Version int `json:"version"`
+	} `json:"data"`
+}
+
+// vaultErrorResponse is the response data from Vault when a request fails.
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
 // vaultListKVResponse is the response data from Vault KV.
 type vaultListKVResponse struct {
 	Data struct {
@@ -131,7 +155,6 @@ type vaultListKVResponse struct {
 // NewHashiCorpVaultSecretStore returns a new HashiCorp Vault secret store.
 func NewHashiCorpVaultSecretStore(logger logger.Logger) secretstores.SecretStore {
 	return &vaultSecretStore{
-		client: &http.Client{},
 		logger: logger,
 		json:   jsoniter.ConfigFastest,
 	}
@@ -141,6 +164,8 @@ func NewHashiCorpVaultSecretStore(logger logger.Logger) secretstores.SecretStore
 func (v *vaultSecretStore) Init(_ context.Context, meta secretstores.Metadata) error {
 	m := VaultMetadata{
 		VaultKVUsePrefix: true,
+		VaultKVVersion:   defaultVaultKVVersion,
+		PollInterval:     secretstores.DefaultPollInterval,
 	}
 	err := kitmd.DecodeMetadata(meta.Properties, &m)
 	if err != nil {
@@ -160,6 +185,13 @@ func (v *vaultSecretStore) Init(_ context.Context, meta secretstores.Metadata) e
 		v.vaultEnginePath = m.EnginePath
 	}
 
+	switch m.VaultKVVersion {
+	case 1, 2:
+		v.vaultKVVersion = m.VaultKVVersion
+	default:
+		return fmt.Errorf("vault init error, invalid KV version %d, accepted values are 1 or 2", m.VaultKVVersion)
+	}
+
 	v.vaultValueType = valueTypeMap
 	if m.VaultValueType != "" {
 		switch valueType(m.VaultValueType) {
@@ -173,9 +205,12 @@ func (v *vaultSecretStore) Init(_ context.Context, meta secretstores.Metadata) e
 
 	v.vaultToken = m.VaultToken
 	v.vaultTokenMountPath = m.VaultTokenMountPath
-	initErr := v.initVaultToken()
-	if initErr != nil {
-		return initErr
+	// With other auth methods, the client logs in to get a token
+	if m.VaultAuthMethod == "" || m.VaultAuthMethod == vaultclient.AuthMethodToken {
+		initErr := v.initVaultToken()
+		if initErr != nil {
+			return initErr
+		}
 	}
 
 	vaultKVPrefix := m.VaultKVPrefix
@@ -189,13 +224,26 @@ func (v *vaultSecretStore) Init(_ context.Context, meta secretstores.Metadata) e
 	// Generate TLS config
 	tlsConf := metadataToTLSConfig(&m)
 
-	client, err := v.createHTTPClient(tlsConf)
+	client, err := vaultclient.NewClient(vaultclient.ClientMetadata{
+		AuthMetadata:        m.AuthMetadata,
+		VaultAddr:           v.vaultAddress,
+		VaultToken:          m.VaultToken,
+		VaultTokenMountPath: m.VaultTokenMountPath,
+		CaCert:              tlsConf.vaultCACert,
+		CaPath:              tlsConf.vaultCAPath,
+		CaPem:               tlsConf.vaultCAPem,
+		SkipVerify:          tlsConf.vaultSkipVerify,
+		TLSServerName:       tlsConf.vaultServerName,
+	}, v.logger)
 	if err != nil {
-		return fmt.Errorf("couldn't create client using config: %w", err)
+		return err
 	}
 
 	v.client = client
 
+	v.pollInterval = m.PollInterval
+	v.watcher = v.newWatcher()
+
 	return nil
 }
 
@@ -219,57 +267,42 @@ func metadataToTLSConfig(meta *VaultMetadata) *tlsConfig {
 
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values.
 func (v *vaultSecretStore) getSecret(ctx context.Context, secret, version string) (*vaultKVResponse, error) {
-	// Create get secret url
-	var vaultSecretPathAddr string
-	if v.vaultKVPrefix == "" {
-		vaultSecretPathAddr = v.vaultAddress + "/v1/" + v.vaultEnginePath + "/data/" + secret + "?version=" + version
-	} else {
-		vaultSecretPathAddr = v.vaultAddress + "/v1/" + v.vaultEnginePath + "/data/" + v.vaultKVPrefix + "/" + secret + "?version=" + version
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, vaultSecretPathAddr, nil)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate request: %w", err)
+	// Create get secret path
+	vaultSecretPath := v.secretPath("data", secret)
+	if !v.isKVv1() {
+		vaultSecretPath += "?version=" + version
 	}
-	// Set vault token.
-	httpReq.Header.Set(vaultHTTPHeader, v.vaultToken)
-	// Set X-Vault-Request header
-	httpReq.Header.Set(vaultHTTPRequestHeader, "true")
 
-	httpresp, err := v.client.Do(httpReq)
+	status, body, err := v.doRequest(ctx, http.MethodGet, vaultSecretPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get secret: %w", err)
 	}
 
-	defer httpresp.Body.Close()
-
-	if httpresp.StatusCode != http.StatusOK {
-		var b bytes.Buffer
-		io.Copy(&b, httpresp.Body)
-		v.logger.Debugf("getSecret %s couldn't get successful response: %#v, %s", secret, httpresp, b.String())
-		if httpresp.StatusCode == http.StatusNotFound {
+	if status != http.StatusOK {
+		v.logger.Debugf("getSecret %s couldn't get successful response: status code %d, %s", secret, status, string(body))
+		if status == http.StatusNotFound {
 			// handle not found error
 			return nil, fmt.Errorf("getSecret %s failed %w", secret, ErrNotFound)
 		}
 
 		return nil, fmt.Errorf("couldn't get successful response, status code %d, body %s",
-			httpresp.StatusCode, b.String())
+			status, string(body))
 	}
 
 	var d vaultKVResponse
 
+	if v.isKVv1() {
+		return v.decodeKVv1Response(body, secret)
+	}
+
 	if v.vaultValueType.isMapType() {
 		// parse the secret value to map[string]string
-		if err := json.NewDecoder(httpresp.Body).Decode(&d); err != nil {
+		if err := json.Unmarshal(body, &d); err != nil {
 			return nil, fmt.Errorf("couldn't decode response body: %s", err)
 		}
 	} else {
 		// treat the secret as string
-		b, err := io.ReadAll(httpresp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("couldn't read response: %s", err)
-		}
-		res := v.json.Get(b, DataStr, DataStr).ToString()
+		res := v.json.Get(body, DataStr, DataStr).ToString()
 		d.Data.Data = map[string]string{
 			secret: res,
 		}
@@ -278,6 +311,25 @@ func (v *vaultSecretStore) getSecret(ctx context.Context, secret, version string
 	return &d, nil
 }
 
+// decodeKVv1Response parses the response of a read from Vault KV version 1, where the secret's values are not nested in a "data" object.
+func (v *vaultSecretStore) decodeKVv1Response(body []byte, secret string) (*vaultKVResponse, error) {
+	var d vaultKVResponse
+
+	if v.vaultValueType.isMapType() {
+		var v1 vaultKVv1Response
+		if err := json.Unmarshal(body, &v1); err != nil {
+			return nil, fmt.Errorf("couldn't decode response body: %s", err)
+		}
+		d.Data.Data = v1.Data
+	} else {
+		d.Data.Data = map[string]string{
+			secret: v.json.Get(body, DataStr).ToString(),
+		}
+	}
+
+	return &d, nil
+}
+
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values.
 func (v *vaultSecretStore) GetSecret(ctx context.Context, req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
 	// version 0 represent for latest version
@@ -337,42 +389,21 @@ func (v *vaultSecretStore) BulkGetSecret(ctx context.Context, req secretstores.B
 // listKeysUnderPath get all the keys recursively under a given path.(returned keys including path as prefix)
 // path should not has `/` prefix.
 func (v *vaultSecretStore) listKeysUnderPath(ctx context.Context, path string) ([]string, error) {
-	var vaultSecretsPathAddr string
-
-	// Create list secrets url
-	if v.vaultKVPrefix == "" {
-		vaultSecretsPathAddr = fmt.Sprintf("%s/v1/%s/metadata/%s", v.vaultAddress, v.vaultEnginePath, path)
-	} else {
-		vaultSecretsPathAddr = fmt.Sprintf("%s/v1/%s/metadata/%s/%s", v.vaultAddress, v.vaultEnginePath, v.vaultKVPrefix, path)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, "LIST", vaultSecretsPathAddr, nil)
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate request: %s", err)
-	}
-	// Set vault token.
-	httpReq.Header.Set(vaultHTTPHeader, v.vaultToken)
-	// Set X-Vault-Request header
-	httpReq.Header.Set(vaultHTTPRequestHeader, "true")
-	httpresp, err := v.client.Do(httpReq)
+	status, body, err := v.doRequest(ctx, "LIST", v.secretPath("metadata", path), nil)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get secret: %s", err)
 	}
 
-	defer httpresp.Body.Close()
-
-	if httpresp.StatusCode != http.StatusOK {
-		var b bytes.Buffer
-		io.Copy(&b, httpresp.Body)
-		v.logger.Debugf("list keys couldn't get successful response: %#v, %s", httpresp, b.String())
+	if status != http.StatusOK {
+		v.logger.Debugf("list keys couldn't get successful response: status code %d, %s", status, string(body))
 
 		return nil, fmt.Errorf("list keys couldn't get successful response, status code: %d, status: %s, response %s",
-			httpresp.StatusCode, httpresp.Status, b.String())
+			status, http.StatusText(status), string(body))
 	}
 
 	var d vaultListKVResponse
 
-	if err := json.NewDecoder(httpresp.Body).Decode(&d); err != nil {
+	if err := json.Unmarshal(body, &d); err != nil {
 		return nil, fmt.Errorf("couldn't decode response body: %s", err)
 	}
 	res := make([]string, 0, len(d.Data.Keys))
@@ -391,138 +422,139 @@ func (v *vaultSecretStore) listKeysUnderPath(ctx context.Context, path string) (
 	return res, nil
 }
 
-// isSecretPath checks if the key is a valid secret path or it is part of the secret path.
-func (v *vaultSecretStore) isSecretPath(key string) bool {
-	return !strings.HasSuffix(key, "/")
-}
-
-// initVaultToken reads the vault token from the file if token is defined by mount path.
-func (v *vaultSecretStore) initVaultToken() error {
-	// Test that at least one of them are set if not return error
-	if v.vaultToken == "" && v.vaultTokenMountPath == "" {
-		return errors.New("token mount path and token not set")
+// SetSecret creates or updates a secret in the KV engine.
+// With KV version 2, req.Version is the version number of the secret, and conditional updates use the check-and-set option.
+func (v *vaultSecretStore) SetSecret(ctx context.Context, req secretstores.SetSecretRequest) (secretstores.SetSecretResponse, error) {
+	if !v.vaultValueType.isMapType() {
+		return secretstores.SetSecretResponse{}, errors.New("writing secrets is not supported when vaultValueType is text")
 	}
-
-	// Test that both are not set. If so return error
-	if v.vaultToken != "" && v.vaultTokenMountPath != "" {
-		return errors.New("token mount path and token both set")
+	if req.Name == "" {
+		return secretstores.SetSecretResponse{}, errors.New("secret name is required")
 	}
 
-	if v.vaultToken != "" {
-		return nil
+	var body any
+	if v.isKVv1() {
+		if req.Version != nil {
+			return secretstores.SetSecretResponse{}, fmt.Errorf("%w: Vault KV version 1 does not keep versions of secrets", secretstores.ErrVersionNotSupported)
+		}
+		body = req.Data
+	} else {
+		kvBody := map[string]any{
+			DataStr: req.Data,
+		}
+		if req.Version != nil {
+			// A check-and-set value of 0 means the secret must not exist
+			cas := 0
+			if *req.Version != "" {
+				var err error
+				cas, err = strconv.Atoi(*req.Version)
+				if err != nil || cas <= 0 {
+					return secretstores.SetSecretResponse{}, fmt.Errorf("invalid version '%s': must be a positive integer", *req.Version)
+				}
+			}
+			kvBody["options"] = map[string]any{
+				"cas": cas,
+			}
+		}
+		body = kvBody
 	}
 
-	data, err := os.ReadFile(v.vaultTokenMountPath)
+	reqBody, err := json.Marshal(body)
 	if err != nil {
-		return fmt.Errorf("couldn't read vault token from mount path %s err: %s", v.vaultTokenMountPath, err)
+		return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't encode request body: %w", err)
 	}
-	v.vaultToken = string(bytes.TrimSpace(data))
-
-	return nil
-}
-
-func (v *vaultSecretStore) createHTTPClient(config *tlsConfig) (*http.Client, error) {
-	tlsClientConfig := &tls.Config{MinVersion: tls.VersionTLS12}
 
-	if config != nil && config.vaultSkipVerify {
-		v.logger.Infof("hashicorp vault: you are using 'skipVerify' to skip server config verify which is unsafe!")
+	status, respBody, err := v.doRequest(ctx, http.MethodPost, v.secretPath("data", req.Name), reqBody)
+	if err != nil {
+		return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't set secret: %w", err)
 	}
-
-	tlsClientConfig.InsecureSkipVerify = config.vaultSkipVerify
-	if !config.vaultSkipVerify {
-		rootCAPools, err := v.getRootCAsPools(config.vaultCAPem, config.vaultCAPath, config.vaultCACert)
-		if err != nil {
-			return nil, err
-		}
-
-		tlsClientConfig.RootCAs = rootCAPools
-
-		if config.vaultServerName != "" {
-			tlsClientConfig.ServerName = config.vaultServerName
-		}
+	switch {
+	case status == http.StatusBadRequest && req.Version != nil && isCheckAndSetError(respBody):
+		return secretstores.SetSecretResponse{}, secretstores.ErrVersionMismatch
+	case status != http.StatusOK && status != http.StatusNoContent:
+		return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't set secret %s, status code %d, body %s", req.Name, status, string(respBody))
 	}
 
-	// Setup http transport
-	transport := &http.Transport{
-		TLSClientConfig: tlsClientConfig,
+	// Vault KV version 1 returns no content
+	if v.isKVv1() {
+		return secretstores.SetSecretResponse{}, nil
 	}
 
-	// Configure http2 client
-	err := http2.ConfigureTransport(transport)
+	var d vaultKVWriteResponse
+	err = json.Unmarshal(respBody, &d)
 	if err != nil {
-		return nil, errors.New("failed to configure http2")
+		return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't decode response body: %w", err)
 	}
-
-	return &http.Client{
-		Transport: transport,
+	return secretstores.SetSecretResponse{
+		Version: strconv.Itoa(d.Data.Version),
 	}, nil
 }
 
-// getRootCAsPools returns root CAs when you give it CA Pem file, CA path, and CA Certificate. Default is system certificates.
-func (v *vaultSecretStore) getRootCAsPools(vaultCAPem string, vaultCAPath string, vaultCACert string) (*x509.CertPool, error) {
-	if vaultCAPem != "" {
-		certPool := x509.NewCertPool()
-		cert := []byte(vaultCAPem)
-		if ok := certPool.AppendCertsFromPEM(cert); !ok {
-			return nil, errors.New("couldn't read PEM")
-		}
-
-		return certPool, nil
+// DeleteSecret deletes a secret from the KV engine.
+// With KV version 2, all versions of the secret and its metadata are deleted permanently.
+func (v *vaultSecretStore) DeleteSecret(ctx context.Context, req secretstores.DeleteSecretRequest) error {
+	if req.Name == "" {
+		return errors.New("secret name is required")
 	}
 
-	if vaultCAPath != "" {
-		certPool := x509.NewCertPool()
-		if err := readCertificateFolder(certPool, vaultCAPath); err != nil {
-			return nil, err
-		}
-
-		return certPool, nil
+	status, respBody, err := v.doRequest(ctx, http.MethodDelete, v.secretPath("metadata", req.Name), nil)
+	if err != nil {
+		return fmt.Errorf("couldn't delete secret: %w", err)
 	}
+	if status != http.StatusNoContent && status != http.StatusOK && status != http.StatusNotFound {
+		return fmt.Errorf("couldn't delete secret %s, status code %d, body %s", req.Name, status, string(respBody))
+	}
+	return nil
+}
 
-	if vaultCACert != "" {
-		certPool := x509.NewCertPool()
-		if err := readCertificateFile(certPool, vaultCACert); err != nil {
-			return nil, err
-		}
+// doRequest sends a request to Vault at path, relative to "/v1/", and returns the status code and body of the response.
+func (v *vaultSecretStore) doRequest(ctx context.Context, method, path string, body []byte) (int, []byte, error) {
+	return v.client.Request(ctx, method, path, body)
+}
 
-		return certPool, nil
+// isCheckAndSetError returns true if the body of an error response from Vault KV version 2 reports a check-and-set failure.
+func isCheckAndSetError(body []byte) bool {
+	var errResp vaultErrorResponse
+	if json.Unmarshal(body, &errResp) != nil {
+		return false
 	}
-
-	certPool, err := x509.SystemCertPool()
-	if err != nil {
-		return nil, fmt.Errorf("couldn't read system certs: %s", err)
+	for _, e := range errResp.Errors {
+		if strings.Contains(e, "check-and-set") {
+			return true
+		}
 	}
-
-	return certPool, nil
+	return false
 }
 
-// readCertificateFile reads the certificate at given path.
-func readCertificateFile(certPool *x509.CertPool, path string) error {
-	// Read certificate file
-	pemFile, err := os.ReadFile(path)
-	if err != nil {
-		return fmt.Errorf("couldn't read CA file from disk: %s", err)
+// secretPath returns the path of a secret, or of a path to list secrets under, in the KV engine.
+// api is "data" or "metadata", which are the APIs of KV version 2; it's ignored with KV version 1.
+func (v *vaultSecretStore) secretPath(api, secret string) string {
+	path := secret
+	if v.vaultKVPrefix != "" {
+		path = v.vaultKVPrefix + "/" + secret
 	}
-
-	if ok := certPool.AppendCertsFromPEM(pemFile); !ok {
-		return errors.New("couldn't read PEM")
+	if v.isKVv1() {
+		return v.vaultEnginePath + "/" + path
 	}
+	return v.vaultEnginePath + "/" + api + "/" + path
+}
 
-	return nil
+func (v *vaultSecretStore) isKVv1() bool {
+	return v.vaultKVVersion == 1
 }
 
-// readCertificateFolder scans a folder for certificates.
-func readCertificateFolder(certPool *x509.CertPool, path string) error {
-	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
-		if info.IsDir() {
-			return nil
-		}
+// isSecretPath checks if the key is a valid secret path or it is part of the secret path.
+func (v *vaultSecretStore) isSecretPath(key string) bool {
+	return !strings.HasSuffix(key, "/")
+}
 
-		return readCertificateFile(certPool, p)
-	})
+// initVaultToken reads the vault token from the file if token is defined by mount path.
+func (v *vaultSecretStore) initVaultToken() error {
+	token, err := vaultclient.ReadToken(v.vaultToken, v.vaultTokenMountPath)
 	if err != nil {
-		return fmt.Errorf("couldn't read certificates at %s: %s", path, err)
+		return err
 	}
+	v.vaultToken = token
 
 	return nil
 }
@@ -530,10 +562,16 @@ func readCertificateFolder(certPool *x509.CertPool, path string) error {
 // Features returns the features available in this secret store.
 func (v *vaultSecretStore) Features() []secretstores.Feature {
 	if v.vaultValueType == valueTypeText {
-		return []secretstores.Feature{}
+		return []secretstores.Feature{
+			secretstores.FeatureSecretWatcher,
+		}
 	}
 
-	return []secretstores.Feature{secretstores.FeatureMultipleKeyValuesPerSecret}
+	return []secretstores.Feature{
+		secretstores.FeatureMultipleKeyValuesPerSecret,
+		secretstores.FeatureSecretWriter,
+		secretstores.FeatureSecretWatcher,
+	}
 }
 
 func (v *vaultSecretStore) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
@@ -543,5 +581,8 @@ func (v *vaultSecretStore) GetComponentMetadata() (metadataInfo metadata.Metadat
 }
 
 func (v *vaultSecretStore) Close() error {
+	if v.watcher != nil {
+		return v.watcher.Close()
+	}
 	return nil
 }
@@ -14,10 +14,17 @@ limitations under the License.
 package vault
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -444,6 +451,8 @@ func TestGetFeatures(t *testing.T) {
 		s := NewHashiCorpVaultSecretStore(logger.NewLogger("test"))
 		f := s.Features()
 		assert.True(t, secretstores.FeatureMultipleKeyValuesPerSecret.IsPresent(f))
+		assert.True(t, secretstores.FeatureSecretWriter.IsPresent(f))
+		assert.True(t, secretstores.FeatureSecretWatcher.IsPresent(f))
 	})
 
 	t.Run("Vault supports MULTIPLE_KEY_VALUES_PER_SECRET if configured with vaultValueType=map", func(t *testing.T) {
@@ -458,5 +467,320 @@ func TestGetFeatures(t *testing.T) {
 		s := initVaultWithVaultValueType("text")
 		f := s.Features()
 		assert.False(t, secretstores.FeatureMultipleKeyValuesPerSecret.IsPresent(f))
+		assert.False(t, secretstores.FeatureSecretWriter.IsPresent(f))
+	})
+}
+
+// fakeVaultKV is a minimal in-memory implementation of the Vault KV secrets engine, versions 1 and 2.
+type fakeVaultKV struct {
+	lock     sync.Mutex
+	version  int
+	secrets  map[string]map[string]string
+	versions map[string]int
+}
+
+func (f *fakeVaultKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.URL.Path == "/v1/auth/approle/login" {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": expectedTok, "lease_duration": 3600, "renewable": true}})
+		return
+	}
+
+	if r.Header.Get("X-Vault-Token") != expectedTok {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/secret/")
+	var api string
+	if f.version == 2 {
+		api, path, _ = strings.Cut(path, "/")
+		if (api == "data" && r.Method == http.MethodDelete) || (api == "data" && r.Method == "LIST") || (api == "metadata" && r.Method == http.MethodPost) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+	}
+
+	switch r.Method {
+	case "LIST":
+		keys := make([]string, 0, len(f.secrets))
+		for key := range f.secrets {
+			if strings.HasPrefix(key, path) {
+				keys = append(keys, strings.TrimPrefix(key, path))
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"keys": keys}})
+	case http.MethodGet:
+		data, ok := f.secrets[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if api == "metadata" {
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"current_version": f.versions[path]}})
+		} else if f.version == 2 {
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+		} else {
+			json.NewEncoder(w).Encode(map[string]any{"data": data})
+		}
+	case http.MethodPost:
+		var body struct {
+			Data    map[string]string `json:"data"`
+			Options struct {
+				Cas *int `json:"cas"`
+			} `json:"options"`
+		}
+		if f.version == 2 {
+			json.NewDecoder(r.Body).Decode(&body)
+		} else {
+			json.NewDecoder(r.Body).Decode(&body.Data)
+		}
+		if body.Options.Cas != nil && *body.Options.Cas != f.versions[path] {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":["check-and-set parameter did not match the current version"]}`))
+			return
+		}
+		f.secrets[path] = body.Data
+		f.versions[path]++
+		if f.version == 2 {
+			json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"version": f.versions[path]}})
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case http.MethodDelete:
+		delete(f.secrets, path)
+		delete(f.versions, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newFakeVaultStore(t *testing.T, kvVersion int) *vaultSecretStore {
+	t.Helper()
+
+	server := httptest.NewServer(&fakeVaultKV{
+		version:  kvVersion,
+		secrets:  map[string]map[string]string{},
+		versions: map[string]int{},
+	})
+	t.Cleanup(server.Close)
+
+	store := NewHashiCorpVaultSecretStore(logger.NewLogger("test")).(*vaultSecretStore)
+	err := store.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"vaultAddr":      server.URL,
+		"vaultToken":     expectedTok,
+		"vaultKVVersion": strconv.Itoa(kvVersion),
+		"pollInterval":   "50ms",
+	}}})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	return store
+}
+
+func TestSecretWriter(t *testing.T) {
+	newStore := newFakeVaultStore
+	ptr := func(s string) *string {
+		return &s
+	}
+
+	t.Run("KV version 2", func(t *testing.T) {
+		store := newStore(t, 2)
+
+		res, err := store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "db",
+			Data:    map[string]string{"username": "admin", "password": "secret1"},
+			Version: ptr(""),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1", res.Version)
+
+		// The secret already exists
+		_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "db",
+			Data:    map[string]string{"username": "admin", "password": "secret2"},
+			Version: ptr(""),
+		})
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		res, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "db",
+			Data:    map[string]string{"username": "admin", "password": "secret2"},
+			Version: ptr("1"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "2", res.Version)
+
+		_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "db",
+			Data:    map[string]string{"username": "admin", "password": "secret3"},
+			Version: ptr("1"),
+		})
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		get, err := store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"username": "admin", "password": "secret2"}, get.Data)
+
+		require.NoError(t, store.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "db"}))
+		_, err = store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.ErrorIs(t, err, ErrNotFound)
+
+		// Deleting a secret that doesn't exist is not an error
+		require.NoError(t, store.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "db"}))
+	})
+
+	t.Run("KV version 1", func(t *testing.T) {
+		store := newStore(t, 1)
+
+		res, err := store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "db",
+			Data: map[string]string{"password": "secret1"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res.Version)
+
+		_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "db",
+			Data:    map[string]string{"password": "secret2"},
+			Version: ptr(""),
+		})
+		require.ErrorIs(t, err, secretstores.ErrVersionNotSupported)
+
+		get, err := store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"password": "secret1"}, get.Data)
+
+		require.NoError(t, store.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "db"}))
+		_, err = store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("invalid KV version", func(t *testing.T) {
+		store := NewHashiCorpVaultSecretStore(logger.NewLogger("test"))
+		err := store.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"vaultToken":     expectedTok,
+			"vaultKVVersion": "3",
+		}}})
+		require.ErrorContains(t, err, "invalid KV version 3")
+	})
+}
+
+func TestSubscribeSecrets(t *testing.T) {
+	for _, kvVersion := range []int{1, 2} {
+		t.Run("KV version "+strconv.Itoa(kvVersion), func(t *testing.T) {
+			store := newFakeVaultStore(t, kvVersion)
+			set := func(name, value string) {
+				t.Helper()
+				_, err := store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+					Name: name,
+					Data: map[string]string{"value": value},
+				})
+				require.NoError(t, err)
+			}
+			set("a", "1")
+
+			events := make(chan *secretstores.SecretUpdateEvent, 10)
+			id, err := store.SubscribeSecrets(t.Context(), secretstores.SubscribeSecretsRequest{}, func(ctx context.Context, e *secretstores.SecretUpdateEvent) error {
+				events <- e
+				return nil
+			})
+			require.NoError(t, err)
+
+			receive := func() map[string]*secretstores.SecretUpdate {
+				t.Helper()
+				select {
+				case e := <-events:
+					assert.Equal(t, id, e.ID)
+					return e.Secrets
+				case <-time.After(5 * time.Second):
+					require.FailNow(t, "timed out waiting for update")
+					return nil
+				}
+			}
+
+			set("a", "2")
+			set("b", "1")
+			updates := receive()
+			require.Len(t, updates, 2)
+			assert.Equal(t, map[string]string{"value": "2"}, updates["a"].Data)
+			assert.Equal(t, map[string]string{"value": "1"}, updates["b"].Data)
+			if kvVersion == 2 {
+				assert.Equal(t, "2", updates["a"].Version)
+			} else {
+				assert.NotEmpty(t, updates["a"].Version)
+			}
+
+			require.NoError(t, store.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "a"}))
+			updates = receive()
+			require.Len(t, updates, 1)
+			assert.True(t, updates["a"].Deleted)
+
+			require.NoError(t, store.UnsubscribeSecrets(t.Context(), secretstores.UnsubscribeSecretsRequest{ID: id}))
+			set("b", "2")
+			select {
+			case e := <-events:
+				assert.Failf(t, "unexpected update", "%v", e)
+			case <-time.After(200 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestVaultAuthMethods(t *testing.T) {
+	server := httptest.NewServer(&fakeVaultKV{
+		version:  2,
+		secrets:  map[string]map[string]string{"dapr/db": {"password": "secret"}},
+		versions: map[string]int{"dapr/db": 1},
+	})
+	t.Cleanup(server.Close)
+
+	t.Run("approle", func(t *testing.T) {
+		store := NewHashiCorpVaultSecretStore(logger.NewLogger("test"))
+		err := store.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"vaultAddr":            server.URL,
+			"vaultAuthMethod":      "approle",
+			"vaultAppRoleID":       "role",
+			"vaultAppRoleSecretID": "secret",
+		}}})
+		require.NoError(t, err)
+
+		res, err := store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"password": "secret"}, res.Data)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		store := NewHashiCorpVaultSecretStore(logger.NewLogger("test"))
+		err := store.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"vaultAddr":            server.URL,
+			"vaultAuthMethod":      "approle",
+			"vaultAppRoleID":       "role",
+			"vaultAppRoleSecretID": "wrong",
+		}}})
+		require.NoError(t, err)
+
+		_, err = store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.ErrorContains(t, err, "approle")
+	})
+
+	t.Run("token is not allowed with other auth methods", func(t *testing.T) {
+		store := NewHashiCorpVaultSecretStore(logger.NewLogger("test"))
+		err := store.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"vaultAuthMethod": "kubernetes",
+			"vaultRole":       "app",
+			"vaultToken":      expectedTok,
+		}}})
+		require.Error(t, err)
 	})
 }
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dapr/components-contrib/secretstores"
+)
+
+// vaultKVMetadataResponse is the response data from Vault KV version 2 when reading the metadata of a secret.
+type vaultKVMetadataResponse struct {
+	Data struct {
+		CurrentVersion int `json:"current_version"`
+		Versions       map[string]struct {
+			DeletionTime string `json:"deletion_time"`
+			Destroyed    bool   `json:"destroyed"`
+		} `json:"versions"`
+	} `json:"data"`
+}
+
+// SubscribeSecrets polls Vault for changes to the secrets.
+// With KV version 2, the version of a secret is its current version number; with KV version 1, it's a hash of its values.
+func (v *vaultSecretStore) SubscribeSecrets(ctx context.Context, req secretstores.SubscribeSecretsRequest, handler secretstores.SecretUpdateHandler) (string, error) {
+	if v.watcher == nil {
+		return "", errors.New("secret store is not initialized")
+	}
+	return v.watcher.SubscribeSecrets(ctx, req, handler)
+}
+
+// UnsubscribeSecrets implements secretstores.SecretWatcher.
+func (v *vaultSecretStore) UnsubscribeSecrets(ctx context.Context, req secretstores.UnsubscribeSecretsRequest) error {
+	if v.watcher == nil {
+		return errors.New("secret store is not initialized")
+	}
+	return v.watcher.UnsubscribeSecrets(ctx, req)
+}
+
+func (v *vaultSecretStore) newWatcher() *secretstores.PollingWatcher {
+	return secretstores.NewPollingWatcher(secretstores.PollingWatcherOptions{
+		Interval:    v.pollInterval,
+		GetVersions: v.getSecretVersions,
+		GetSecret: func(ctx context.Context, name string, _ map[string]string) (map[string]string, error) {
+			d, err := v.getSecret(ctx, name, "0")
+			if err != nil {
+				return nil, err
+			}
+			return d.Data.Data, nil
+		},
+		Logger: v.logger,
+	})
+}
+
+// getSecretVersions returns the current versions of the secrets with the given names, or of all secrets.
+func (v *vaultSecretStore) getSecretVersions(ctx context.Context, names []string, _ map[string]string) (map[string]string, error) {
+	if len(names) == 0 {
+		var err error
+		names, err = v.listKeysUnderPath(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	versions := make(map[string]string, len(names))
+	for _, name := range names {
+		version, err := v.getSecretVersion(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		versions[name] = version
+	}
+	return versions, nil
+}
+
+// getSecretVersion returns the current version of a secret, or ErrNotFound if it doesn't exist or its current version is deleted.
+func (v *vaultSecretStore) getSecretVersion(ctx context.Context, name string) (string, error) {
+	if v.isKVv1() {
+		d, err := v.getSecret(ctx, name, "")
+		if err != nil {
+			return "", err
+		}
+		// Keys of maps are sorted when encoded, so the hash doesn't depend on their order
+		b, err := json.Marshal(d.Data.Data)
+		if err != nil {
+			return "", err
+		}
+		h := sha256.Sum256(b)
+		return hex.EncodeToString(h[:16]), nil
+	}
+
+	status, respBody, err := v.doRequest(ctx, http.MethodGet, v.secretPath("metadata", name), nil)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get metadata of secret: %w", err)
+	}
+	switch status {
+	case http.StatusOK:
+		// Ok
+	case http.StatusNotFound:
+		return "", ErrNotFound
+	default:
+		return "", fmt.Errorf("couldn't get metadata of secret %s, status code %d, body %s", name, status, string(respBody))
+	}
+
+	var d vaultKVMetadataResponse
+	err = json.Unmarshal(respBody, &d)
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode response body: %w", err)
+	}
+	version := strconv.Itoa(d.Data.CurrentVersion)
+	if current, ok := d.Data.Versions[version]; ok && (current.DeletionTime != "" || current.Destroyed) {
+		return "", ErrNotFound
+	}
+	return version, nil
+}
@@ -18,9 +18,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 
 	kubeclient "github.com/dapr/components-contrib/common/authentication/kubernetes"
 	"github.com/dapr/components-contrib/metadata"
@@ -28,17 +32,29 @@ import (
 	"github.com/dapr/kit/logger"
 )
 
-var _ secretstores.SecretStore = (*kubernetesSecretStore)(nil)
+var (
+	_ secretstores.SecretStore   = (*kubernetesSecretStore)(nil)
+	_ secretstores.SecretWriter  = (*kubernetesSecretStore)(nil)
+	_ secretstores.SecretWatcher = (*kubernetesSecretStore)(nil)
+)
 
 type kubernetesSecretStore struct {
 	kubeClient kubernetes.Interface
 	md         kubernetesMetadata
 	logger     logger.Logger
+
+	subs    *secretstores.Subscriptions
+	lock    sync.Mutex
+	watches map[string]func()
 }
 
 // NewKubernetesSecretStore returns a new Kubernetes secret store.
 func NewKubernetesSecretStore(logger logger.Logger) secretstores.SecretStore {
-	return &kubernetesSecretStore{logger: logger}
+	return &kubernetesSecretStore{
+		logger:  logger,
+		subs:    secretstores.NewSubscriptions(logger),
+		watches: map[string]func(){},
+	}
 }
 
 // Init creates a Kubernetes client.
@@ -109,6 +125,92 @@ func (k *kubernetesSecretStore) BulkGetSecret(ctx context.Context, req secretsto
 	return resp, nil
 }
 
+// SetSecret creates a secret or replaces its data.
+// The version of a secret is its resource version, which the API server uses to reject conflicting updates.
+func (k *kubernetesSecretStore) SetSecret(ctx context.Context, req secretstores.SetSecretRequest) (secretstores.SetSecretResponse, error) {
+	namespace, err := k.getNamespaceFromMetadata(req.Metadata)
+	if err != nil {
+		return secretstores.SetSecretResponse{}, err
+	}
+
+	data := make(map[string][]byte, len(req.Data))
+	for key, value := range req.Data {
+		data[key] = []byte(value)
+	}
+	client := k.kubeClient.CoreV1().Secrets(namespace)
+
+	create := func() (secretstores.SetSecretResponse, error) {
+		secret, err := client.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      req.Name,
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return secretstores.SetSecretResponse{}, err
+		}
+		return secretstores.SetSecretResponse{Version: secret.ResourceVersion}, nil
+	}
+
+	// The secret must not exist
+	if req.Version != nil && *req.Version == "" {
+		res, err := create()
+		if apierrors.IsAlreadyExists(err) {
+			return res, secretstores.ErrVersionMismatch
+		}
+		return res, err
+	}
+
+	var res secretstores.SetSecretResponse
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := client.Get(ctx, req.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err) && req.Version == nil:
+			res, err = create()
+			return err
+		case apierrors.IsNotFound(err):
+			return secretstores.ErrVersionMismatch
+		case err != nil:
+			return err
+		case req.Version != nil && secret.ResourceVersion != *req.Version:
+			return secretstores.ErrVersionMismatch
+		}
+
+		secret.Data = data
+		secret.StringData = nil
+		updated, err := client.Update(ctx, secret, metav1.UpdateOptions{})
+		if err != nil {
+			// With an expected version, a conflict means the secret was changed in the meantime, so it must not be retried
+			if req.Version != nil && apierrors.IsConflict(err) {
+				return secretstores.ErrVersionMismatch
+			}
+			return err
+		}
+		res.Version = updated.ResourceVersion
+		return nil
+	})
+	if err != nil {
+		return secretstores.SetSecretResponse{}, err
+	}
+	return res, nil
+}
+
+// DeleteSecret deletes a secret.
+func (k *kubernetesSecretStore) DeleteSecret(ctx context.Context, req secretstores.DeleteSecretRequest) error {
+	namespace, err := k.getNamespaceFromMetadata(req.Metadata)
+	if err != nil {
+		return err
+	}
+
+	err = k.kubeClient.CoreV1().Secrets(namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func (k *kubernetesSecretStore) getNamespaceFromMetadata(metadata map[string]string) (string, error) {
 	if val, ok := metadata["namespace"]; ok && val != "" {
 		return val, nil
@@ -127,7 +229,10 @@ func (k *kubernetesSecretStore) getNamespaceFromMetadata(metadata map[string]str
 
 // Features returns the features available in this secret store.
 func (k *kubernetesSecretStore) Features() []secretstores.Feature {
-	return []secretstores.Feature{}
+	return []secretstores.Feature{
+		secretstores.FeatureSecretWriter,
+		secretstores.FeatureSecretWatcher,
+	}
 }
 
 func (k *kubernetesSecretStore) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
@@ -136,5 +241,6 @@ func (k *kubernetesSecretStore) GetComponentMetadata() (metadataInfo metadata.Me
 }
 
 func (k *kubernetesSecretStore) Close() error {
+	k.stopWatches()
 	return nil
 }
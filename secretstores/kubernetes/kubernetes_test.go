@@ -14,11 +14,17 @@ limitations under the License.
 package kubernetes
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/kit/logger"
 )
 
@@ -67,8 +73,156 @@ func TestGetNamespace(t *testing.T) {
 func TestGetFeatures(t *testing.T) {
 	s := kubernetesSecretStore{logger: logger.NewLogger("test")}
 	// Yes, we are skipping initialization as feature retrieval doesn't depend on it.
-	t.Run("no features are advertised", func(t *testing.T) {
+	t.Run("the secret writer and watcher features are advertised", func(t *testing.T) {
 		f := s.Features()
-		assert.Empty(t, f)
+		assert.Equal(t, []secretstores.Feature{secretstores.FeatureSecretWriter, secretstores.FeatureSecretWatcher}, f)
 	})
 }
+
+func TestSecretWriter(t *testing.T) {
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "existing",
+			Namespace:       "default",
+			ResourceVersion: "42",
+		},
+		Data: map[string][]byte{"password": []byte("old")},
+	}
+	store := kubernetesSecretStore{
+		kubeClient: fake.NewClientset(existing),
+		md: kubernetesMetadata{
+			DefaultNamespace: "default",
+		},
+		logger: logger.NewLogger("test"),
+	}
+	ptr := func(s string) *string {
+		return &s
+	}
+
+	t.Run("create and update", func(t *testing.T) {
+		_, err := store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "new",
+			Data: map[string]string{"username": "admin", "password": "secret"},
+		})
+		require.NoError(t, err)
+
+		_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "new",
+			Data: map[string]string{"password": "other"},
+		})
+		require.NoError(t, err)
+
+		res, err := store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "new"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"password": "other"}, res.Data)
+	})
+
+	t.Run("versioned updates", func(t *testing.T) {
+		_, err := store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "existing",
+			Data:    map[string]string{"password": "new"},
+			Version: ptr(""),
+		})
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "existing",
+			Data:    map[string]string{"password": "new"},
+			Version: ptr("41"),
+		})
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "missing",
+			Data:    map[string]string{"password": "new"},
+			Version: ptr("1"),
+		})
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "existing",
+			Data:    map[string]string{"password": "new"},
+			Version: ptr("42"),
+		})
+		require.NoError(t, err)
+
+		res, err := store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "existing"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"password": "new"}, res.Data)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "new"}))
+		_, err := store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "new"})
+		require.Error(t, err)
+
+		// Deleting a secret that doesn't exist is not an error
+		require.NoError(t, store.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "new"}))
+	})
+}
+
+func TestSubscribeSecrets(t *testing.T) {
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{"password": []byte("old")},
+	}
+	store := NewKubernetesSecretStore(logger.NewLogger("test")).(*kubernetesSecretStore)
+	store.kubeClient = fake.NewClientset(existing)
+	store.md.DefaultNamespace = "default"
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+
+	events := make(chan *secretstores.SecretUpdateEvent, 10)
+	id, err := store.SubscribeSecrets(t.Context(), secretstores.SubscribeSecretsRequest{
+		Names: []string{"existing", "new"},
+	}, func(ctx context.Context, e *secretstores.SecretUpdateEvent) error {
+		events <- e
+		return nil
+	})
+	require.NoError(t, err)
+
+	receive := func(name string) *secretstores.SecretUpdate {
+		t.Helper()
+		select {
+		case e := <-events:
+			assert.Equal(t, id, e.ID)
+			require.Contains(t, e.Secrets, name)
+			return e.Secrets[name]
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for update")
+			return nil
+		}
+	}
+
+	// Secrets that are not watched are ignored
+	_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+		Name: "other",
+		Data: map[string]string{"key": "value"},
+	})
+	require.NoError(t, err)
+
+	_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+		Name: "existing",
+		Data: map[string]string{"password": "new"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"password": "new"}, receive("existing").Data)
+
+	_, err = store.SetSecret(t.Context(), secretstores.SetSecretRequest{
+		Name: "new",
+		Data: map[string]string{"key": "value"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value"}, receive("new").Data)
+
+	require.NoError(t, store.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "existing"}))
+	assert.True(t, receive("existing").Deleted)
+
+	require.NoError(t, store.UnsubscribeSecrets(t.Context(), secretstores.UnsubscribeSecretsRequest{ID: id}))
+	require.Error(t, store.UnsubscribeSecrets(t.Context(), secretstores.UnsubscribeSecretsRequest{ID: id}))
+	assert.Empty(t, events)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/dapr/components-contrib/secretstores"
+)
+
+// SubscribeSecrets watches the secrets in the namespace with an informer.
+// The version of a secret is its resource version.
+func (k *kubernetesSecretStore) SubscribeSecrets(ctx context.Context, req secretstores.SubscribeSecretsRequest, handler secretstores.SecretUpdateHandler) (string, error) {
+	namespace, err := k.getNamespaceFromMetadata(req.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	sub, err := k.subs.Add(req, handler)
+	if err != nil {
+		return "", err
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	factory := informers.NewSharedInformerFactoryWithOptions(k.kubeClient, 0, informers.WithNamespace(namespace))
+	informer := factory.Core().V1().Secrets().Informer()
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			// Secrets that exist when subscribing are not changes
+			if isInInitialList {
+				return
+			}
+			if secret, ok := obj.(*corev1.Secret); ok {
+				notifySecret(watchCtx, sub, secret, false)
+			}
+		},
+		// There's no resync period, so updates are changes
+		UpdateFunc: func(_, newObj any) {
+			if secret, ok := newObj.(*corev1.Secret); ok {
+				notifySecret(watchCtx, sub, secret, false)
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if secret, ok := obj.(*corev1.Secret); ok {
+				notifySecret(watchCtx, sub, secret, true)
+			}
+		},
+	})
+	if err != nil {
+		cancel()
+		k.subs.Remove(sub.ID)
+		return "", fmt.Errorf("failed to watch secrets: %w", err)
+	}
+
+	factory.Start(watchCtx.Done())
+	// Wait for the initial list, so changes made after returning are notified
+	for _, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			cancel()
+			factory.Shutdown()
+			k.subs.Remove(sub.ID)
+			return "", errors.New("failed to list secrets")
+		}
+	}
+
+	k.lock.Lock()
+	k.watches[sub.ID] = func() {
+		cancel()
+		factory.Shutdown()
+	}
+	k.lock.Unlock()
+
+	return sub.ID, nil
+}
+
+// UnsubscribeSecrets implements secretstores.SecretWatcher.
+func (k *kubernetesSecretStore) UnsubscribeSecrets(_ context.Context, req secretstores.UnsubscribeSecretsRequest) error {
+	_, err := k.subs.Remove(req.ID)
+	if err != nil {
+		return err
+	}
+
+	k.lock.Lock()
+	stop := k.watches[req.ID]
+	delete(k.watches, req.ID)
+	k.lock.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+	return nil
+}
+
+// stopWatches stops the informers of all subscriptions.
+func (k *kubernetesSecretStore) stopWatches() {
+	k.lock.Lock()
+	watches := k.watches
+	k.watches = map[string]func(){}
+	k.lock.Unlock()
+
+	for _, stop := range watches {
+		stop()
+	}
+}
+
+func notifySecret(ctx context.Context, sub *secretstores.Subscription, secret *corev1.Secret, deleted bool) {
+	update := &secretstores.SecretUpdate{
+		Version: secret.ResourceVersion,
+		Deleted: deleted,
+	}
+	if deleted {
+		update.Version = ""
+	} else {
+		update.Data = make(map[string]string, len(secret.Data))
+		for key, value := range secret.Data {
+			update.Data[key] = string(value)
+		}
+	}
+
+	sub.Notify(ctx, map[string]*secretstores.SecretUpdate{
+		secret.Name: update,
+	})
+}
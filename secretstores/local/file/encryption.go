@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/getsops/sops/v3"
+	sopsaes "github.com/getsops/sops/v3/aes"
+	sopsage "github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/shamir"
+	sopsjson "github.com/getsops/sops/v3/stores/json"
+	sopsyaml "github.com/getsops/sops/v3/stores/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Environment variables used by the SOPS CLI for age keys, used when the component doesn't set them.
+	sopsAgeKeyEnvVar     = "SOPS_AGE_KEY"
+	sopsAgeKeyFileEnvVar = "SOPS_AGE_KEY_FILE"
+
+	// sopsMetadataKey is the top-level key containing the metadata of SOPS files.
+	sopsMetadataKey = "sops"
+
+	ageFileHeader = "age-encryption.org/v1\n"
+)
+
+// decodeSecretsFile decodes the content of a secrets file, decrypting it if it's encrypted with age or SOPS.
+// Files are JSON unless their extension, without a trailing ".age", is ".yaml" or ".yml".
+// It returns true if the file can be written, which is the case of JSON files that are not encrypted.
+func (j *localSecretStore) decodeSecretsFile(secretsFile string, data []byte) (map[string]interface{}, bool, error) {
+	encrypted := false
+	if isAgeEncrypted(data) {
+		identities, err := j.ageIdentities()
+		if err != nil {
+			return nil, false, err
+		}
+		data, err = decryptAge(data, identities)
+		if err != nil {
+			return nil, false, fmt.Errorf("couldn't decrypt secrets file: %w", err)
+		}
+		encrypted = true
+	}
+
+	yamlFile := isYAMLFile(secretsFile)
+	decode := decodeJSON
+	if yamlFile {
+		decode = decodeYAML
+	}
+	secrets, err := decode(data)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, ok := secrets[sopsMetadataKey]; !ok {
+		return secrets, !encrypted && !yamlFile, nil
+	}
+
+	identities, err := j.ageIdentities()
+	if err != nil {
+		return nil, false, err
+	}
+	data, err = decryptSOPS(data, yamlFile, identities)
+	if err != nil {
+		return nil, false, fmt.Errorf("couldn't decrypt SOPS secrets file: %w", err)
+	}
+	secrets, err = decode(data)
+	return secrets, false, err
+}
+
+// ageIdentities returns the age keys used to decrypt files.
+// They are read each time, so rotated keys are used when the file is reloaded.
+func (j *localSecretStore) ageIdentities() ([]age.Identity, error) {
+	var identities []age.Identity
+
+	envVar := j.ageKeyEnvVar
+	if envVar == "" {
+		envVar = sopsAgeKeyEnvVar
+	}
+	if keys := os.Getenv(envVar); keys != "" {
+		ids, err := age.ParseIdentities(strings.NewReader(keys))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse age keys in environment variable %s: %w", envVar, err)
+		}
+		identities = append(identities, ids...)
+	}
+
+	keyFile := j.ageKeyFile
+	if keyFile == "" {
+		keyFile = os.Getenv(sopsAgeKeyFileEnvVar)
+	}
+	if keyFile != "" {
+		f, err := os.Open(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read age key file: %w", err)
+		}
+		defer f.Close()
+		ids, err := age.ParseIdentities(f)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse age key file %s: %w", keyFile, err)
+		}
+		identities = append(identities, ids...)
+	}
+
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("secrets file is encrypted, but no age key is configured: set ageKeyFile or the %s environment variable", envVar)
+	}
+	return identities, nil
+}
+
+func isAgeEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(ageFileHeader)) ||
+		bytes.HasPrefix(bytes.TrimSpace(data), []byte(armor.Header))
+}
+
+func isYAMLFile(name string) bool {
+	switch filepath.Ext(strings.TrimSuffix(name, ".age")) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// decryptAge decrypts a binary or armored age file.
+func decryptAge(data []byte, identities []age.Identity) ([]byte, error) {
+	var src io.Reader = bytes.NewReader(data)
+	if !bytes.HasPrefix(data, []byte(ageFileHeader)) {
+		src = armor.NewReader(bytes.NewReader(bytes.TrimSpace(data)))
+	}
+	r, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func decodeJSON(data []byte) (map[string]interface{}, error) {
+	// Numbers are kept as written in the file, so they are returned as-is and preserved when the file is rewritten
+	var jsonConfig map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	err := dec.Decode(&jsonConfig)
+	if err != nil {
+		return nil, err
+	}
+	return jsonConfig, nil
+}
+
+func decodeYAML(data []byte) (map[string]interface{}, error) {
+	var yamlConfig map[string]interface{}
+	err := yaml.Unmarshal(data, &yamlConfig)
+	if err != nil {
+		return nil, err
+	}
+	return toJSONValue(yamlConfig).(map[string]interface{}), nil
+}
+
+// toJSONValue converts a value decoded from a YAML file to the values returned when decoding a JSON file.
+func toJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			res[key] = toJSONValue(item)
+		}
+		return res
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			res[fmt.Sprint(key)] = toJSONValue(item)
+		}
+		return res
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = toJSONValue(item)
+		}
+		return list
+	case string, bool, nil:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		// Integers
+		return json.Number(fmt.Sprint(v))
+	}
+}
+
+// decryptSOPS decrypts a SOPS file with the SOPS library and verifies its MAC.
+// It returns the decrypted file, in the same format and without the SOPS metadata.
+func decryptSOPS(data []byte, yamlFile bool, identities []age.Identity) ([]byte, error) {
+	var store sops.Store = &sopsjson.Store{}
+	if yamlFile {
+		store = &sopsyaml.Store{}
+	}
+	tree, err := store.LoadEncryptedFile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := sopsDataKey(tree.Metadata, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	cipher := sopsaes.NewCipher()
+	mac, err := tree.Decrypt(dataKey, cipher)
+	if err != nil {
+		return nil, err
+	}
+	fileMAC, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decrypt the MAC: %w", err)
+	}
+	if fileMAC != mac {
+		return nil, errors.New("MAC mismatch: the file was modified after it was encrypted")
+	}
+
+	return store.EmitPlainFile(tree.Branches)
+}
+
+// sopsDataKey decrypts the data key of a SOPS file with age identities.
+// Only age keys are supported: the other keys of the file are ignored.
+// Files with several key groups need a part of the data key from at least as many groups as their Shamir threshold.
+func sopsDataKey(md sops.Metadata, identities []age.Identity) ([]byte, error) {
+	var (
+		parts [][]byte
+		errs  []error
+	)
+	for i, group := range md.KeyGroups {
+		part, err := sopsGroupDataKey(group, identities)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("key group %d: %w", i, err))
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	switch {
+	case len(md.KeyGroups) > 1 && len(parts) >= md.ShamirThreshold:
+		return shamir.Combine(parts)
+	case len(md.KeyGroups) == 1 && len(parts) == 1:
+		return parts[0], nil
+	default:
+		return nil, fmt.Errorf("couldn't decrypt the data key: %w", errors.Join(errs...))
+	}
+}
+
+// sopsGroupDataKey decrypts the data key, or the part of the data key, of a SOPS key group with age identities.
+func sopsGroupDataKey(group sops.KeyGroup, identities []age.Identity) ([]byte, error) {
+	var errs []error
+	for _, key := range group {
+		ageKey, ok := key.(*sopsage.MasterKey)
+		if !ok {
+			continue
+		}
+		sopsage.ParsedIdentities(identities).ApplyToMasterKey(ageKey)
+		dataKey, err := ageKey.Decrypt()
+		if err == nil {
+			return dataKey, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no age key")
+	}
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/kit/logger"
+)
+
+// The files in testdata were encrypted with the SOPS 3.8.1 and age CLIs, with the key in testdata/age-key.txt.
+// secrets.partial.sops.json was encrypted with --encrypted-regex '^(password|apiKey)$', and the other SOPS files keep note_unencrypted in clear with the default unencrypted suffix.
+const testAgeKeyFile = "testdata/age-key.txt"
+
+func initEncryptedStore(t *testing.T, secretsFile string, props map[string]string) (*localSecretStore, error) {
+	t.Helper()
+
+	s := NewLocalSecretStore(logger.NewLogger("test")).(*localSecretStore)
+	m := secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"secretsFile": secretsFile,
+	}}}
+	for k, v := range props {
+		m.Properties[k] = v
+	}
+	return s, s.Init(t.Context(), m)
+}
+
+func TestEncryptedSecretsFile(t *testing.T) {
+	expected := map[string]map[string]string{
+		"db:user":          {"db:user": "admin"},
+		"db:password":      {"db:password": "s3cr3t"},
+		"db:port":          {"db:port": "5432"},
+		"apiKey":           {"apiKey": "abc123"},
+		"enabled":          {"enabled": "true"},
+		"ratio":            {"ratio": "1.5"},
+		"hosts:0":          {"hosts:0": "a.example.com"},
+		"hosts:1":          {"hosts:1": "b.example.com"},
+		"note_unencrypted": {"note_unencrypted": "visible"},
+	}
+
+	for _, file := range []string{
+		"secrets.sops.json",
+		"secrets.sops.yaml",
+		"secrets.partial.sops.json",
+		"secrets.json.age",
+	} {
+		t.Run(file, func(t *testing.T) {
+			s, err := initEncryptedStore(t, filepath.Join("testdata", file), map[string]string{
+				"ageKeyFile": testAgeKeyFile,
+			})
+			require.NoError(t, err)
+
+			res, err := s.BulkGetSecret(t.Context(), secretstores.BulkGetSecretRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, expected, res.Data)
+
+			// Encrypted files are read-only
+			assert.False(t, secretstores.FeatureSecretWriter.IsPresent(s.Features()))
+			_, err = s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+				Name: "apiKey",
+				Data: map[string]string{"apiKey": "new"},
+			})
+			require.ErrorIs(t, err, errFileNotWritable)
+		})
+	}
+
+	t.Run("multiValued", func(t *testing.T) {
+		s, err := initEncryptedStore(t, "testdata/secrets.sops.yaml", map[string]string{
+			"ageKeyFile":  testAgeKeyFile,
+			"multiValued": "true",
+		})
+		require.NoError(t, err)
+
+		res, err := s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"user": "admin", "password": "s3cr3t", "port": "5432"}, res.Data)
+	})
+}
+
+func TestAgeKeys(t *testing.T) {
+	key, err := os.ReadFile(testAgeKeyFile)
+	require.NoError(t, err)
+
+	t.Run("default environment variable", func(t *testing.T) {
+		t.Setenv("SOPS_AGE_KEY", string(key))
+		_, err := initEncryptedStore(t, "testdata/secrets.sops.json", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("custom environment variable", func(t *testing.T) {
+		t.Setenv("MY_AGE_KEY", string(key))
+		_, err := initEncryptedStore(t, "testdata/secrets.sops.json", map[string]string{
+			"ageKeyEnvVar": "MY_AGE_KEY",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("key file from environment variable", func(t *testing.T) {
+		t.Setenv("SOPS_AGE_KEY_FILE", testAgeKeyFile)
+		_, err := initEncryptedStore(t, "testdata/secrets.json.age", nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		t.Setenv("SOPS_AGE_KEY", "")
+		t.Setenv("SOPS_AGE_KEY_FILE", "")
+		_, err := initEncryptedStore(t, "testdata/secrets.sops.json", nil)
+		require.ErrorContains(t, err, "no age key is configured")
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		t.Setenv("SOPS_AGE_KEY", "AGE-SECRET-KEY-1GFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPYYSJZGFPQ4EGAEX")
+		_, err := initEncryptedStore(t, "testdata/secrets.sops.json", nil)
+		require.ErrorContains(t, err, "couldn't decrypt the data key")
+	})
+}
+
+func TestTamperedSOPSFile(t *testing.T) {
+	content, err := os.ReadFile("testdata/secrets.sops.json")
+	require.NoError(t, err)
+
+	tamper := func(t *testing.T, old, new string) string {
+		t.Helper()
+		require.Contains(t, string(content), old)
+		path := filepath.Join(t.TempDir(), "secrets.json")
+		require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(content), old, new, 1)), 0o600))
+		return path
+	}
+
+	t.Run("unencrypted value changed", func(t *testing.T) {
+		path := tamper(t, `"visible"`, `"changed"`)
+		_, err := initEncryptedStore(t, path, map[string]string{"ageKeyFile": testAgeKeyFile})
+		require.ErrorContains(t, err, "MAC mismatch")
+	})
+
+	t.Run("encrypted value moved to another key", func(t *testing.T) {
+		path := tamper(t, `"apiKey"`, `"apiKey2"`)
+		_, err := initEncryptedStore(t, path, map[string]string{"ageKeyFile": testAgeKeyFile})
+		require.ErrorContains(t, err, "Could not decrypt value")
+	})
+}
@@ -18,11 +18,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/secretstores"
@@ -34,25 +34,54 @@ type localSecretStoreMetaData struct {
 	SecretsFile     string `json:"secretsFile"`
 	NestedSeparator string `json:"nestedSeparator"`
 	MultiValued     bool   `json:"multiValued"`
+	// If true, the secrets are reloaded when the file changes.
+	// The file is also watched while there are subscriptions to secret changes.
+	Watch bool `json:"watch"`
+	// Path to a file containing the age keys used to decrypt the secrets file, if it's encrypted with age or SOPS.
+	// Defaults to the value of the SOPS_AGE_KEY_FILE environment variable.
+	AgeKeyFile string `json:"ageKeyFile"`
+	// Name of an environment variable containing age keys, used in addition to ageKeyFile.
+	// Defaults to SOPS_AGE_KEY.
+	AgeKeyEnvVar string `json:"ageKeyEnvVar"`
 }
 
-var _ secretstores.SecretStore = (*localSecretStore)(nil)
+var (
+	_ secretstores.SecretStore   = (*localSecretStore)(nil)
+	_ secretstores.SecretWriter  = (*localSecretStore)(nil)
+	_ secretstores.SecretWatcher = (*localSecretStore)(nil)
+)
 
 type localSecretStore struct {
 	secretsFile     string
 	nestedSeparator string
+	multiValued     bool
+	ageKeyFile      string
+	ageKeyEnvVar    string
+	// False if the secrets file is encrypted or is not JSON, in which case it can't be written
+	writable        bool
+	lock            sync.RWMutex
 	currenContext   []string
 	currentPath     string
 	secrets         map[string]interface{}
 	readLocalFileFn func(secretsFile string) (map[string]interface{}, error)
 	features        []secretstores.Feature
 	logger          logger.Logger
+
+	// Versions of the secrets, and the updates not yet sent to subscribers
+	versions    map[string]string
+	pending     map[string]*secretstores.SecretUpdate
+	subs        *secretstores.Subscriptions
+	notifyLock  sync.Mutex
+	watchLock   sync.Mutex
+	watchCancel context.CancelFunc
+	watchDone   chan struct{}
 }
 
 // NewLocalSecretStore returns a new Local secret store.
 func NewLocalSecretStore(logger logger.Logger) secretstores.SecretStore {
 	return &localSecretStore{
 		logger: logger,
+		subs:   secretstores.NewSubscriptions(logger),
 	}
 }
 
@@ -73,12 +102,34 @@ func (j *localSecretStore) Init(_ context.Context, metadata secretstores.Metadat
 		j.readLocalFileFn = j.readLocalFile
 	}
 
+	j.secretsFile = meta.SecretsFile
+	j.multiValued = meta.MultiValued
+	j.ageKeyFile = meta.AgeKeyFile
+	j.ageKeyEnvVar = meta.AgeKeyEnvVar
+	j.writable = true
+
 	jsonConfig, err := j.readLocalFileFn(meta.SecretsFile)
 	if err != nil {
 		return err
 	}
 
-	if meta.MultiValued {
+	j.lock.Lock()
+	j.versions = nil
+	j.loadSecrets(jsonConfig)
+	j.pending = nil
+	j.lock.Unlock()
+
+	if meta.Watch {
+		return j.startWatcher()
+	}
+
+	return nil
+}
+
+// loadSecrets sets the secrets of the store from the content of the secrets file.
+// Secrets whose version changed are added to the updates to send to subscribers.
+func (j *localSecretStore) loadSecrets(jsonConfig map[string]interface{}) {
+	if j.multiValued {
 		allSecrets := map[string]interface{}{}
 		for k, v := range jsonConfig {
 			switch v := v.(type) {
@@ -95,40 +146,37 @@ func (j *localSecretStore) Init(_ context.Context, metadata secretstores.Metadat
 		// key-valyes per secret.
 		j.features = []secretstores.Feature{
 			secretstores.FeatureMultipleKeyValuesPerSecret,
+			secretstores.FeatureSecretWatcher,
 		}
 	} else {
 		j.secrets = map[string]interface{}{}
 		j.visitJSONObject(jsonConfig)
 		// MultiValued is not set: reset to its default single-value per
-		// secret (no extra feature) behavior.
-		j.features = []secretstores.Feature{}
+		// secret behavior.
+		j.features = []secretstores.Feature{
+			secretstores.FeatureSecretWatcher,
+		}
+	}
+	if j.writable {
+		j.features = append(j.features, secretstores.FeatureSecretWriter)
 	}
 
-	return nil
+	j.updateVersions()
 }
 
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values.
 func (j *localSecretStore) GetSecret(ctx context.Context, req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+
 	secretValue, exists := j.secrets[req.Name]
 	if !exists {
 		return secretstores.GetSecretResponse{}, fmt.Errorf("secret %s not found", req.Name)
 	}
 
-	var data map[string]string
-	switch v := secretValue.(type) {
-	case string:
-		data = map[string]string{
-			req.Name: v,
-		}
-	case map[string]interface{}:
-		data = make(map[string]string, len(v))
-		for key, value := range v {
-			data[key] = fmt.Sprint(value)
-		}
-	case map[string]string:
-		data = v
-	default:
-		return secretstores.GetSecretResponse{}, fmt.Errorf("unexpected type %q for secret value", reflect.TypeOf(v))
+	data, err := secretData(req.Name, secretValue)
+	if err != nil {
+		return secretstores.GetSecretResponse{}, err
 	}
 
 	return secretstores.GetSecretResponse{
@@ -138,25 +186,17 @@ func (j *localSecretStore) GetSecret(ctx context.Context, req secretstores.GetSe
 
 // BulkGetSecret retrieves all secrets in the store and returns a map of decrypted string/string values.
 func (j *localSecretStore) BulkGetSecret(ctx context.Context, req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+
 	r := map[string]map[string]string{}
 
 	for k, v := range j.secrets {
-		switch v := v.(type) {
-		case string:
-			r[k] = map[string]string{
-				k: v,
-			}
-		case map[string]interface{}:
-			data := make(map[string]string, len(v))
-			for key, value := range v {
-				data[key] = fmt.Sprint(value)
-			}
-			r[k] = data
-		case map[string]string:
-			r[k] = v
-		default:
-			return secretstores.BulkGetSecretResponse{}, fmt.Errorf("unexpected type %q for secret value", reflect.TypeOf(v))
+		data, err := secretData(k, v)
+		if err != nil {
+			return secretstores.BulkGetSecretResponse{}, err
 		}
+		r[k] = data
 	}
 
 	return secretstores.BulkGetSecretResponse{
@@ -164,6 +204,26 @@ func (j *localSecretStore) BulkGetSecret(ctx context.Context, req secretstores.B
 	}, nil
 }
 
+// secretData returns the values of a secret as returned by GetSecret.
+func secretData(name string, secretValue interface{}) (map[string]string, error) {
+	switch v := secretValue.(type) {
+	case string:
+		return map[string]string{
+			name: v,
+		}, nil
+	case map[string]interface{}:
+		data := make(map[string]string, len(v))
+		for key, value := range v {
+			data[key] = fmt.Sprint(value)
+		}
+		return data, nil
+	case map[string]string:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unexpected type %q for secret value", reflect.TypeOf(v))
+	}
+}
+
 func (j *localSecretStore) visitJSONObject(jsonConfig map[string]interface{}) error {
 	for key, element := range jsonConfig {
 		j.enterContext(key)
@@ -214,7 +274,11 @@ func (j *localSecretStore) visitProperty(property interface{}) error {
 		return j.visitJSONObject(v)
 	case []interface{}:
 		return j.visitArray(v)
-	case bool, string, int, float32, float64, byte, nil:
+	case json.Number:
+		return j.visitPrimitive(v.String())
+	case bool:
+		return j.visitPrimitive(strconv.FormatBool(v))
+	case string, int, float32, float64, byte, nil:
 		return j.visitPrimitive(fmt.Sprintf("%s", v))
 	default:
 		return errors.New("couldn't parse property")
@@ -250,31 +314,27 @@ func (j *localSecretStore) getLocalSecretStoreMetadata(spec secretstores.Metadat
 	return &meta, nil
 }
 
+// readLocalFile reads and decodes the secrets file, decrypting it if it's encrypted with age or SOPS.
 func (j *localSecretStore) readLocalFile(secretsFile string) (map[string]interface{}, error) {
-	j.secretsFile = secretsFile
-	jsonFile, err := os.Open(secretsFile)
+	byteValue, err := os.ReadFile(secretsFile)
 	if err != nil {
 		return nil, err
 	}
 
-	defer jsonFile.Close()
-
-	byteValue, err := io.ReadAll(jsonFile)
-	if err != nil {
-		return nil, err
-	}
-
-	var jsonConfig map[string]interface{}
-	err = json.Unmarshal(byteValue, &jsonConfig)
+	jsonConfig, writable, err := j.decodeSecretsFile(secretsFile, byteValue)
 	if err != nil {
 		return nil, err
 	}
+	j.writable = writable
 
 	return jsonConfig, nil
 }
 
 // Features returns the features available in this secret store.
 func (j *localSecretStore) Features() []secretstores.Feature {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+
 	return j.features
 }
 
@@ -285,5 +345,6 @@ func (j *localSecretStore) GetComponentMetadata() (metadataInfo metadata.Metadat
 }
 
 func (j *localSecretStore) Close() error {
+	j.stopWatcher()
 	return nil
 }
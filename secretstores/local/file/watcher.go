@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/kit/fswatcher"
+)
+
+// SubscribeSecrets notifies changes to the secrets, made with SetSecret or DeleteSecret or by editing the file.
+// The file is watched while there are subscriptions, or always if the watch metadata property is set.
+func (j *localSecretStore) SubscribeSecrets(ctx context.Context, req secretstores.SubscribeSecretsRequest, handler secretstores.SecretUpdateHandler) (string, error) {
+	sub, err := j.subs.Add(req, handler)
+	if err != nil {
+		return "", err
+	}
+
+	err = j.startWatcher()
+	if err != nil {
+		j.subs.Remove(sub.ID)
+		return "", err
+	}
+
+	return sub.ID, nil
+}
+
+// UnsubscribeSecrets implements secretstores.SecretWatcher.
+func (j *localSecretStore) UnsubscribeSecrets(ctx context.Context, req secretstores.UnsubscribeSecretsRequest) error {
+	_, err := j.subs.Remove(req.ID)
+	return err
+}
+
+// startWatcher starts watching the secrets file, if not already watched.
+func (j *localSecretStore) startWatcher() error {
+	j.watchLock.Lock()
+	defer j.watchLock.Unlock()
+
+	if j.watchCancel != nil {
+		return nil
+	}
+
+	// The folder is watched, as the file may be replaced rather than written to
+	watcher, err := fswatcher.New(fswatcher.Options{
+		Targets: []string{filepath.Dir(j.secretsFile)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch secrets file: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventCh := make(chan struct{})
+	done := make(chan struct{})
+	j.watchCancel = cancel
+	j.watchDone = done
+
+	go func() {
+		err := watcher.Run(ctx, eventCh)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			j.logger.Errorf("Error watching secrets file %s: %v", j.secretsFile, err)
+		}
+	}()
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-eventCh:
+				j.reloadChangedSecrets(ctx)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// stopWatcher stops watching the secrets file.
+func (j *localSecretStore) stopWatcher() {
+	j.watchLock.Lock()
+	defer j.watchLock.Unlock()
+
+	if j.watchCancel == nil {
+		return
+	}
+	j.watchCancel()
+	<-j.watchDone
+	j.watchCancel = nil
+	j.watchDone = nil
+}
+
+// reloadChangedSecrets reads the secrets file after it changed, and notifies the subscribers.
+// If the file can't be read, for example while it's being written, the current secrets are kept.
+func (j *localSecretStore) reloadChangedSecrets(ctx context.Context) {
+	j.lock.Lock()
+	jsonConfig, err := j.readLocalFileFn(j.secretsFile)
+	if err != nil {
+		j.lock.Unlock()
+		j.logger.Warnf("Failed to reload secrets file %s, keeping the current secrets: %v", j.secretsFile, err)
+		return
+	}
+	if jsonConfig == nil {
+		jsonConfig = map[string]interface{}{}
+	}
+	j.loadSecrets(jsonConfig)
+	j.lock.Unlock()
+
+	j.flushUpdates(ctx)
+}
+
+// updateVersions computes the versions of the secrets, adding the changed secrets to the pending updates.
+// It must be called while holding the write lock.
+func (j *localSecretStore) updateVersions() {
+	versions := make(map[string]string, len(j.secrets))
+	for name, secretValue := range j.secrets {
+		data, err := secretData(name, secretValue)
+		if err != nil {
+			continue
+		}
+		versions[name] = hashSecretData(data)
+
+		if j.versions != nil && j.versions[name] != versions[name] {
+			j.addPendingUpdate(name, &secretstores.SecretUpdate{
+				Data:    data,
+				Version: versions[name],
+			})
+		}
+	}
+	for name := range j.versions {
+		if _, ok := versions[name]; !ok {
+			j.addPendingUpdate(name, &secretstores.SecretUpdate{
+				Deleted: true,
+			})
+		}
+	}
+	j.versions = versions
+}
+
+func (j *localSecretStore) addPendingUpdate(name string, update *secretstores.SecretUpdate) {
+	if j.pending == nil {
+		j.pending = map[string]*secretstores.SecretUpdate{}
+	}
+	j.pending[name] = update
+}
+
+// flushUpdates sends the pending updates to the subscribers.
+// It must be called without holding the lock, so handlers can read secrets.
+func (j *localSecretStore) flushUpdates(ctx context.Context) {
+	// Updates are sent in order
+	j.notifyLock.Lock()
+	defer j.notifyLock.Unlock()
+
+	j.lock.Lock()
+	pending := j.pending
+	j.pending = nil
+	j.lock.Unlock()
+
+	j.subs.Notify(ctx, pending)
+}
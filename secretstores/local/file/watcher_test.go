@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/secretstores"
+)
+
+func TestSubscribeSecrets(t *testing.T) {
+	s, secretsFile := newTestStore(t, `{"a": "1", "b": "2"}`, false)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+	assert.True(t, secretstores.FeatureSecretWatcher.IsPresent(s.Features()))
+
+	events := make(chan *secretstores.SecretUpdateEvent, 10)
+	id, err := s.SubscribeSecrets(t.Context(), secretstores.SubscribeSecretsRequest{
+		Names: []string{"a", "c"},
+	}, func(ctx context.Context, e *secretstores.SecretUpdateEvent) error {
+		// Handlers can read secrets
+		_, err := s.GetSecret(ctx, secretstores.GetSecretRequest{Name: "b"})
+		require.NoError(t, err)
+		events <- e
+		return nil
+	})
+	require.NoError(t, err)
+
+	receive := func() *secretstores.SecretUpdateEvent {
+		t.Helper()
+		select {
+		case e := <-events:
+			assert.Equal(t, id, e.ID)
+			return e
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for update")
+			return nil
+		}
+	}
+
+	t.Run("changes made with SetSecret", func(t *testing.T) {
+		res, err := s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "c",
+			Data: map[string]string{"c": "3"},
+		})
+		require.NoError(t, err)
+
+		e := receive()
+		assert.Equal(t, map[string]*secretstores.SecretUpdate{
+			"c": {Data: map[string]string{"c": "3"}, Version: res.Version},
+		}, e.Secrets)
+	})
+
+	t.Run("changes made to the file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(secretsFile, []byte(`{"a": "10", "b": "20"}`), 0o600))
+
+		e := receive()
+		require.Len(t, e.Secrets, 2)
+		assert.Equal(t, map[string]string{"a": "10"}, e.Secrets["a"].Data)
+		assert.True(t, e.Secrets["c"].Deleted)
+
+		get, err := s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "b"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"b": "20"}, get.Data)
+	})
+
+	t.Run("invalid file keeps the current secrets", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(secretsFile, []byte(`{"a": `), 0o600))
+		time.Sleep(time.Second)
+
+		get, err := s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "a"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "10"}, get.Data)
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		require.NoError(t, s.UnsubscribeSecrets(t.Context(), secretstores.UnsubscribeSecretsRequest{ID: id}))
+		require.Error(t, s.UnsubscribeSecrets(t.Context(), secretstores.UnsubscribeSecretsRequest{ID: id}))
+
+		require.NoError(t, os.WriteFile(secretsFile, []byte(`{"a": "100"}`), 0o600))
+		select {
+		case e := <-events:
+			assert.Failf(t, "unexpected update", "%v", e)
+		case <-time.After(2 * time.Second):
+		}
+	})
+}
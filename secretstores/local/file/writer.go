@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	commonutils "github.com/dapr/components-contrib/common/utils"
+	"github.com/dapr/components-contrib/secretstores"
+)
+
+// errFileNotWritable is returned when writing secrets to an encrypted or YAML file.
+var errFileNotWritable = errors.New("secrets file is encrypted or is not a JSON file, so secrets can't be written")
+
+// SetSecret creates or updates a secret, rewriting the secrets file.
+// The version of a secret is a hash of its values, so conditional updates fail if the secret was changed, including by editing the file.
+//
+// If multiValued is false, the secret must contain a single value, with the secret name as key.
+// Names containing the nested separator are stored as nested objects.
+// If multiValued is true, the secret is stored as a top-level object, with keys containing the nested separator stored as nested objects.
+func (j *localSecretStore) SetSecret(ctx context.Context, req secretstores.SetSecretRequest) (secretstores.SetSecretResponse, error) {
+	if req.Name == "" {
+		return secretstores.SetSecretResponse{}, errors.New("secret name is required")
+	}
+	if len(req.Data) == 0 {
+		return secretstores.SetSecretResponse{}, errors.New("secret must contain at least one value")
+	}
+
+	// Subscribers are notified after the lock is released
+	defer j.flushUpdates(ctx)
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	// Read the file again, so changes made by other processes are not lost
+	jsonConfig, err := j.reloadSecrets()
+	if err != nil {
+		return secretstores.SetSecretResponse{}, err
+	}
+	if !j.writable {
+		return secretstores.SetSecretResponse{}, errFileNotWritable
+	}
+
+	if req.Version != nil {
+		current, err := j.secretVersion(req.Name)
+		if err != nil {
+			return secretstores.SetSecretResponse{}, err
+		}
+		if current != *req.Version {
+			return secretstores.SetSecretResponse{}, secretstores.ErrVersionMismatch
+		}
+	}
+
+	if j.multiValued {
+		err = j.setMultiValuedSecret(jsonConfig, req.Name, req.Data)
+	} else {
+		value, ok := req.Data[req.Name]
+		if !ok || len(req.Data) != 1 {
+			return secretstores.SetSecretResponse{}, errors.New("secret must contain a single value, with the secret name as key, unless multiValued is enabled")
+		}
+		err = setNestedValue(jsonConfig, strings.Split(req.Name, j.nestedSeparator), value)
+	}
+	if err != nil {
+		return secretstores.SetSecretResponse{}, fmt.Errorf("couldn't set secret %s: %w", req.Name, err)
+	}
+
+	err = j.writeSecrets(jsonConfig)
+	if err != nil {
+		return secretstores.SetSecretResponse{}, err
+	}
+
+	version, err := j.secretVersion(req.Name)
+	if err != nil {
+		return secretstores.SetSecretResponse{}, err
+	}
+	return secretstores.SetSecretResponse{
+		Version: version,
+	}, nil
+}
+
+// DeleteSecret deletes a secret, rewriting the secrets file.
+func (j *localSecretStore) DeleteSecret(ctx context.Context, req secretstores.DeleteSecretRequest) error {
+	if req.Name == "" {
+		return errors.New("secret name is required")
+	}
+
+	// Subscribers are notified after the lock is released
+	defer j.flushUpdates(ctx)
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	jsonConfig, err := j.reloadSecrets()
+	if err != nil {
+		return err
+	}
+	if !j.writable {
+		return errFileNotWritable
+	}
+
+	if _, ok := j.secrets[req.Name]; !ok {
+		return nil
+	}
+
+	if j.multiValued {
+		delete(jsonConfig, req.Name)
+	} else {
+		err = deleteNestedValue(jsonConfig, strings.Split(req.Name, j.nestedSeparator))
+		if err != nil {
+			return fmt.Errorf("couldn't delete secret %s: %w", req.Name, err)
+		}
+	}
+
+	return j.writeSecrets(jsonConfig)
+}
+
+// reloadSecrets reads the secrets file and loads its secrets, returning its content.
+// It must be called while holding the write lock.
+func (j *localSecretStore) reloadSecrets() (map[string]interface{}, error) {
+	jsonConfig, err := j.readLocalFileFn(j.secretsFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read secrets file: %w", err)
+	}
+	if jsonConfig == nil {
+		jsonConfig = map[string]interface{}{}
+	}
+	j.loadSecrets(jsonConfig)
+
+	return jsonConfig, nil
+}
+
+// writeSecrets replaces the secrets file with the given content and loads its secrets.
+// It must be called while holding the write lock.
+func (j *localSecretStore) writeSecrets(jsonConfig map[string]interface{}) error {
+	b, err := json.MarshalIndent(jsonConfig, "", "  ")
+	if err != nil {
+		return fmt.Errorf("couldn't encode secrets: %w", err)
+	}
+
+	err = commonutils.WriteFileAtomic(j.secretsFile, append(b, '\n'))
+	if err != nil {
+		return fmt.Errorf("couldn't write secrets file: %w", err)
+	}
+
+	j.loadSecrets(jsonConfig)
+	return nil
+}
+
+// secretVersion returns the version of a secret, or an empty string if it doesn't exist.
+func (j *localSecretStore) secretVersion(name string) (string, error) {
+	secretValue, ok := j.secrets[name]
+	if !ok {
+		return "", nil
+	}
+	data, err := secretData(name, secretValue)
+	if err != nil {
+		return "", err
+	}
+	return hashSecretData(data), nil
+}
+
+// hashSecretData returns the version of a secret with the given values.
+func hashSecretData(data map[string]string) string {
+	// Keys of maps are sorted when encoded, so the hash doesn't depend on their order
+	b, _ := json.Marshal(data)
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:16])
+}
+
+// setMultiValuedSecret stores a secret as a top-level key, as read when multiValued is true.
+func (j *localSecretStore) setMultiValuedSecret(jsonConfig map[string]interface{}, name string, data map[string]string) error {
+	// A secret with a single value named as the secret is stored as a string
+	if value, ok := data[name]; ok && len(data) == 1 {
+		jsonConfig[name] = value
+		return nil
+	}
+
+	obj := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		err := setNestedValue(obj, strings.Split(key, j.nestedSeparator), value)
+		if err != nil {
+			return err
+		}
+	}
+	jsonConfig[name] = obj
+	return nil
+}
+
+// setNestedValue sets a value in a JSON object or array, at the path of keys or indexes.
+// Objects that don't exist along the path are created.
+func setNestedValue(node interface{}, path []string, value string) error {
+	var (
+		child  interface{}
+		exists bool
+		set    func(v interface{})
+	)
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, exists = n[path[0]]
+		set = func(v interface{}) {
+			n[path[0]] = v
+		}
+	case []interface{}:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i < 0 || i >= len(n) {
+			return fmt.Errorf("index '%s' is not in the array", path[0])
+		}
+		child, exists = n[i], true
+		set = func(v interface{}) {
+			n[i] = v
+		}
+	default:
+		return fmt.Errorf("'%s' is not in an object or array", path[0])
+	}
+
+	if len(path) == 1 {
+		if isContainer(child) {
+			return fmt.Errorf("'%s' contains nested values", path[0])
+		}
+		set(value)
+		return nil
+	}
+
+	if !exists {
+		child = map[string]interface{}{}
+		set(child)
+	}
+	return setNestedValue(child, path[1:], value)
+}
+
+// deleteNestedValue deletes a value from a JSON object, at the path of keys.
+// Objects left empty are deleted too. Values in arrays can't be deleted, as this would change the names of the following ones.
+func deleteNestedValue(obj map[string]interface{}, path []string) error {
+	if len(path) == 1 {
+		delete(obj, path[0])
+		return nil
+	}
+
+	switch child := obj[path[0]].(type) {
+	case map[string]interface{}:
+		err := deleteNestedValue(child, path[1:])
+		if err != nil {
+			return err
+		}
+		if len(child) == 0 {
+			delete(obj, path[0])
+		}
+		return nil
+	case []interface{}:
+		return errors.New("values in arrays can't be deleted")
+	default:
+		return nil
+	}
+}
+
+func isContainer(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/kit/logger"
+)
+
+func newTestStore(t *testing.T, content string, multiValued bool) (*localSecretStore, string) {
+	t.Helper()
+
+	secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+	require.NoError(t, os.WriteFile(secretsFile, []byte(content), 0o640))
+
+	s := NewLocalSecretStore(logger.NewLogger("test")).(*localSecretStore)
+	err := s.Init(t.Context(), secretstores.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"secretsFile": secretsFile,
+		"multiValued": strconv.FormatBool(multiValued),
+	}}})
+	require.NoError(t, err)
+	return s, secretsFile
+}
+
+func TestSetSecret(t *testing.T) {
+	ptr := func(s string) *string {
+		return &s
+	}
+
+	t.Run("nested secrets", func(t *testing.T) {
+		s, secretsFile := newTestStore(t, `{"db": {"password": "old", "port": 5432}, "list": ["a", "b"]}`, false)
+		assert.True(t, secretstores.FeatureSecretWriter.IsPresent(s.Features()))
+
+		res, err := s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "db:password",
+			Data: map[string]string{"db:password": "new"},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.Version)
+
+		_, err = s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "api:token",
+			Data: map[string]string{"api:token": "abc"},
+		})
+		require.NoError(t, err)
+
+		_, err = s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "list:1",
+			Data: map[string]string{"list:1": "c"},
+		})
+		require.NoError(t, err)
+
+		// The file is rewritten, keeping numbers and permissions
+		content, err := os.ReadFile(secretsFile)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"db": {"password": "new", "port": 5432}, "api": {"token": "abc"}, "list": ["a", "c"]}`, string(content))
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(secretsFile)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+		}
+
+		get, err := s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "api:token"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"api:token": "abc"}, get.Data)
+		get, err = s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db:port"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"db:port": "5432"}, get.Data)
+
+		// Invalid secrets
+		_, err = s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "db",
+			Data: map[string]string{"db": "value"},
+		})
+		require.ErrorContains(t, err, "contains nested values")
+
+		_, err = s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "key",
+			Data: map[string]string{"a": "1", "b": "2"},
+		})
+		require.ErrorContains(t, err, "single value")
+	})
+
+	t.Run("versioned updates", func(t *testing.T) {
+		s, secretsFile := newTestStore(t, `{"key": "value"}`, false)
+		set := func(version *string) (secretstores.SetSecretResponse, error) {
+			return s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+				Name:    "key",
+				Data:    map[string]string{"key": "new"},
+				Version: version,
+			})
+		}
+
+		_, err := set(ptr(""))
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+		_, err = set(ptr("foo"))
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		res, err := set(nil)
+		require.NoError(t, err)
+		res, err = set(ptr(res.Version))
+		require.NoError(t, err)
+
+		// Changes made to the file are detected
+		require.NoError(t, os.WriteFile(secretsFile, []byte(`{"key": "changed"}`), 0o600))
+		_, err = set(ptr(res.Version))
+		require.ErrorIs(t, err, secretstores.ErrVersionMismatch)
+
+		get, err := s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "key"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"key": "changed"}, get.Data)
+	})
+
+	t.Run("multi-valued secrets", func(t *testing.T) {
+		s, secretsFile := newTestStore(t, `{"parent": {"child1": "12345"}}`, true)
+
+		_, err := s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name:    "db",
+			Data:    map[string]string{"username": "admin", "conn:host": "localhost"},
+			Version: ptr(""),
+		})
+		require.NoError(t, err)
+
+		_, err = s.SetSecret(t.Context(), secretstores.SetSecretRequest{
+			Name: "token",
+			Data: map[string]string{"token": "abc"},
+		})
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(secretsFile)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"parent": {"child1": "12345"}, "db": {"username": "admin", "conn": {"host": "localhost"}}, "token": "abc"}`, string(content))
+
+		get, err := s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"username": "admin", "conn:host": "localhost"}, get.Data)
+	})
+}
+
+func TestDeleteSecret(t *testing.T) {
+	t.Run("nested secrets", func(t *testing.T) {
+		s, secretsFile := newTestStore(t, `{"db": {"password": "secret"}, "key": "value", "list": ["a"]}`, false)
+
+		require.NoError(t, s.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "db:password"}))
+		// Deleting a secret that doesn't exist is not an error
+		require.NoError(t, s.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "db:password"}))
+		require.ErrorContains(t, s.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "list:0"}), "arrays")
+
+		content, err := os.ReadFile(secretsFile)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"key": "value", "list": ["a"]}`, string(content))
+
+		_, err = s.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: "db:password"})
+		require.Error(t, err)
+	})
+
+	t.Run("multi-valued secrets", func(t *testing.T) {
+		s, secretsFile := newTestStore(t, `{"db": {"password": "secret"}, "key": "value"}`, true)
+
+		require.NoError(t, s.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: "db"}))
+
+		content, err := os.ReadFile(secretsFile)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"key": "value"}`, string(content))
+	})
+}
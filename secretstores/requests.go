@@ -23,3 +23,32 @@ type GetSecretRequest struct {
 type BulkGetSecretRequest struct {
 	Metadata map[string]string `json:"metadata"`
 }
+
+// SetSecretRequest describes a request to create or update a secret in a secret store.
+type SetSecretRequest struct {
+	Name string            `json:"name"`
+	Data map[string]string `json:"data"`
+	// Version, if set, makes the update conditional: the secret is only written if its current version matches.
+	// An empty string means the secret must not exist yet.
+	// If the condition isn't met, SetSecret returns ErrVersionMismatch.
+	Version  *string           `json:"version,omitempty"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// DeleteSecretRequest describes a request to delete a secret from a secret store.
+type DeleteSecretRequest struct {
+	Name     string            `json:"name"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// SubscribeSecretsRequest describes a request to be notified when secrets change.
+type SubscribeSecretsRequest struct {
+	// Names of the secrets to watch. If empty, all secrets in the store are watched.
+	Names    []string          `json:"names"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// UnsubscribeSecretsRequest describes a request to stop a subscription created with SubscribeSecrets.
+type UnsubscribeSecretsRequest struct {
+	ID string `json:"id"`
+}
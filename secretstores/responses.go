@@ -22,3 +22,28 @@ type GetSecretResponse struct {
 type BulkGetSecretResponse struct {
 	Data map[string]map[string]string `json:"data"`
 }
+
+// SetSecretResponse describes the response of a SetSecretRequest.
+type SetSecretResponse struct {
+	// Version of the secret after the update, which can be used in a later conditional update.
+	// It is empty if the secret store doesn't support versions.
+	Version string `json:"version,omitempty"`
+}
+
+// SecretUpdateEvent is sent to the handler of a subscription when watched secrets change.
+type SecretUpdateEvent struct {
+	// ID of the subscription.
+	ID string `json:"id"`
+	// Secrets that changed, by name.
+	Secrets map[string]*SecretUpdate `json:"secrets"`
+}
+
+// SecretUpdate is the new state of a secret that changed.
+type SecretUpdate struct {
+	// Values of the secret, as returned by GetSecret. Nil if the secret was deleted.
+	Data map[string]string `json:"data,omitempty"`
+	// Version of the secret, as defined by the secret store.
+	Version string `json:"version,omitempty"`
+	// Deleted is true if the secret doesn't exist anymore.
+	Deleted bool `json:"deleted,omitempty"`
+}
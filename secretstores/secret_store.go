@@ -38,6 +38,37 @@ type SecretStore interface {
 	io.Closer
 }
 
+// SecretWriter is an optional interface for secret stores that can create, update and delete secrets.
+// Components that implement it advertise FeatureSecretWriter.
+type SecretWriter interface {
+	// SetSecret creates a secret or replaces all its values.
+	// If req.Version is set, the secret is only written if its current version matches.
+	SetSecret(ctx context.Context, req SetSecretRequest) (SetSecretResponse, error)
+	// DeleteSecret deletes a secret. Deleting a secret that doesn't exist is not an error.
+	DeleteSecret(ctx context.Context, req DeleteSecretRequest) error
+}
+
+// SecretWatcher is an optional interface for secret stores that can notify when secrets change.
+// Components that implement it advertise FeatureSecretWatcher.
+type SecretWatcher interface {
+	// SubscribeSecrets starts watching secrets, calling handler each time the version of some of them changes,
+	// including when they are created or deleted, until UnsubscribeSecrets is called.
+	// It returns the ID of the subscription.
+	SubscribeSecrets(ctx context.Context, req SubscribeSecretsRequest, handler SecretUpdateHandler) (string, error)
+	// UnsubscribeSecrets stops a subscription.
+	UnsubscribeSecrets(ctx context.Context, req UnsubscribeSecretsRequest) error
+}
+
+// SecretUpdateHandler is the function called when watched secrets change.
+type SecretUpdateHandler func(ctx context.Context, e *SecretUpdateEvent) error
+
+var (
+	// ErrVersionMismatch is returned by SecretWriter.SetSecret when the version of the secret doesn't match the expected one.
+	ErrVersionMismatch = errors.New("secret version does not match the expected one")
+	// ErrVersionNotSupported is returned by SecretWriter.SetSecret for conditional updates when the secret store doesn't support versions.
+	ErrVersionNotSupported = errors.New("secret store does not support versioned updates")
+)
+
 func Ping(ctx context.Context, secretStore SecretStore) error {
 	// checks if this secretStore has the ping option then executes
 	if secretStoreWithPing, ok := secretStore.(health.Pinger); ok {
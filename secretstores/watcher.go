@@ -0,0 +1,297 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dapr/kit/logger"
+)
+
+// DefaultPollInterval is the interval between polls of a PollingWatcher, if not set.
+const DefaultPollInterval = 30 * time.Second
+
+// Subscriptions keeps track of the subscriptions of a SecretWatcher, and sends them the updates of the secrets they watch.
+type Subscriptions struct {
+	lock   sync.RWMutex
+	subs   map[string]*Subscription
+	logger logger.Logger
+}
+
+// Subscription is a subscription created with SecretWatcher.SubscribeSecrets.
+type Subscription struct {
+	ID       string
+	Names    []string
+	Metadata map[string]string
+
+	names   map[string]struct{}
+	handler SecretUpdateHandler
+	logger  logger.Logger
+}
+
+// NewSubscriptions returns a new Subscriptions object.
+func NewSubscriptions(logger logger.Logger) *Subscriptions {
+	return &Subscriptions{
+		subs:   map[string]*Subscription{},
+		logger: logger,
+	}
+}
+
+// Add creates a new subscription.
+func (s *Subscriptions) Add(req SubscribeSecretsRequest, handler SecretUpdateHandler) (*Subscription, error) {
+	if handler == nil {
+		return nil, errors.New("handler is required")
+	}
+
+	sub := &Subscription{
+		ID:       uuid.NewString(),
+		Names:    req.Names,
+		Metadata: req.Metadata,
+		handler:  handler,
+		logger:   s.logger,
+	}
+	if len(req.Names) > 0 {
+		sub.names = make(map[string]struct{}, len(req.Names))
+		for _, name := range req.Names {
+			sub.names[name] = struct{}{}
+		}
+	}
+
+	s.lock.Lock()
+	s.subs[sub.ID] = sub
+	s.lock.Unlock()
+
+	return sub, nil
+}
+
+// Remove deletes a subscription.
+func (s *Subscriptions) Remove(id string) (*Subscription, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sub, ok := s.subs[id]
+	if !ok {
+		return nil, fmt.Errorf("subscription with ID %s does not exist", id)
+	}
+	delete(s.subs, id)
+	return sub, nil
+}
+
+// Len returns the number of subscriptions.
+func (s *Subscriptions) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.subs)
+}
+
+// Notify sends the updated secrets to each subscription that watches some of them.
+func (s *Subscriptions) Notify(ctx context.Context, updates map[string]*SecretUpdate) {
+	if len(updates) == 0 {
+		return
+	}
+
+	s.lock.RLock()
+	subs := make([]*Subscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		subs = append(subs, sub)
+	}
+	s.lock.RUnlock()
+
+	for _, sub := range subs {
+		sub.Notify(ctx, updates)
+	}
+}
+
+// Watches returns true if the subscription watches the secret with the given name.
+func (sub *Subscription) Watches(name string) bool {
+	if sub.names == nil {
+		return true
+	}
+	_, ok := sub.names[name]
+	return ok
+}
+
+// Notify calls the handler of the subscription with the updated secrets it watches, if any.
+func (sub *Subscription) Notify(ctx context.Context, updates map[string]*SecretUpdate) {
+	e := &SecretUpdateEvent{
+		ID:      sub.ID,
+		Secrets: make(map[string]*SecretUpdate, len(updates)),
+	}
+	for name, update := range updates {
+		if sub.Watches(name) {
+			e.Secrets[name] = update
+		}
+	}
+	if len(e.Secrets) == 0 {
+		return
+	}
+
+	err := sub.handler(ctx, e)
+	if err != nil {
+		sub.logger.Errorf("Error handling update of secrets for subscription %s: %v", sub.ID, err)
+	}
+}
+
+// PollingWatcherOptions contains the options for NewPollingWatcher.
+type PollingWatcherOptions struct {
+	// Interval between polls. Defaults to DefaultPollInterval.
+	Interval time.Duration
+	// GetVersions returns the current version of the secrets with the given names, or of all secrets if names is empty.
+	// Secrets that don't exist must not be included.
+	GetVersions func(ctx context.Context, names []string, metadata map[string]string) (map[string]string, error)
+	// GetSecret returns the values of a secret whose version changed.
+	GetSecret func(ctx context.Context, name string, metadata map[string]string) (map[string]string, error)
+	Logger    logger.Logger
+}
+
+// PollingWatcher implements SecretWatcher for secret stores that can't push changes, by periodically comparing the versions of secrets.
+type PollingWatcher struct {
+	opts    PollingWatcherOptions
+	subs    *Subscriptions
+	lock    sync.Mutex
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+	closed  bool
+}
+
+// NewPollingWatcher returns a new PollingWatcher.
+func NewPollingWatcher(opts PollingWatcherOptions) *PollingWatcher {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultPollInterval
+	}
+	return &PollingWatcher{
+		opts:    opts,
+		subs:    NewSubscriptions(opts.Logger),
+		cancels: map[string]context.CancelFunc{},
+	}
+}
+
+// SubscribeSecrets implements SecretWatcher.
+// The current versions of the secrets are read before returning, so changes made afterwards are notified.
+func (w *PollingWatcher) SubscribeSecrets(ctx context.Context, req SubscribeSecretsRequest, handler SecretUpdateHandler) (string, error) {
+	versions, err := w.opts.GetVersions(ctx, req.Names, req.Metadata)
+	if err != nil {
+		return "", fmt.Errorf("failed to get versions of secrets: %w", err)
+	}
+
+	sub, err := w.subs.Add(req, handler)
+	if err != nil {
+		return "", err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		w.subs.Remove(sub.ID)
+		return "", errors.New("secret store is closed")
+	}
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	w.cancels[sub.ID] = cancel
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.poll(pollCtx, sub, versions)
+	}()
+
+	return sub.ID, nil
+}
+
+// UnsubscribeSecrets implements SecretWatcher.
+func (w *PollingWatcher) UnsubscribeSecrets(_ context.Context, req UnsubscribeSecretsRequest) error {
+	_, err := w.subs.Remove(req.ID)
+	if err != nil {
+		return err
+	}
+
+	w.lock.Lock()
+	cancel := w.cancels[req.ID]
+	delete(w.cancels, req.ID)
+	w.lock.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return nil
+}
+
+// Close stops all subscriptions.
+func (w *PollingWatcher) Close() error {
+	w.lock.Lock()
+	w.closed = true
+	for id, cancel := range w.cancels {
+		cancel()
+		delete(w.cancels, id)
+	}
+	w.lock.Unlock()
+
+	w.wg.Wait()
+	return nil
+}
+
+func (w *PollingWatcher) poll(ctx context.Context, sub *Subscription, versions map[string]string) {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := w.opts.GetVersions(ctx, sub.Names, sub.Metadata)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.opts.Logger.Warnf("Failed to get versions of secrets for subscription %s: %v", sub.ID, err)
+			}
+			continue
+		}
+
+		updates := make(map[string]*SecretUpdate)
+		for name, version := range current {
+			if versions[name] == version {
+				continue
+			}
+			data, err := w.opts.GetSecret(ctx, name, sub.Metadata)
+			if err != nil {
+				// Keep the old version, so the update is retried at the next poll
+				w.opts.Logger.Warnf("Failed to get secret %s for subscription %s: %v", name, sub.ID, err)
+				continue
+			}
+			updates[name] = &SecretUpdate{
+				Data:    data,
+				Version: version,
+			}
+			versions[name] = version
+		}
+		for name := range versions {
+			if _, ok := current[name]; !ok {
+				updates[name] = &SecretUpdate{
+					Deleted: true,
+				}
+				delete(versions, name)
+			}
+		}
+
+		sub.Notify(ctx, updates)
+	}
+}
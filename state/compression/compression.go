@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compression contains a codec that state stores can use to transparently compress the values they store.
+//
+// Compressed values are prefixed with a short header that identifies the algorithm, so they are self-describing:
+// values written with and without compression (or with different algorithms) can coexist in the same store, and values
+// are always decompressed when read, even if compression has since been disabled.
+//
+// Uncompressed values that begin with the header are escaped when they're written, even if compression is disabled, so they
+// are returned unchanged when read. State stores must therefore pass every value they write through Codec.Compress.
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+// Algorithm is a compression algorithm.
+type Algorithm string
+
+const (
+	// None disables compression.
+	None Algorithm = ""
+	// Gzip compresses values with gzip.
+	Gzip Algorithm = "gzip"
+	// Zstd compresses values with Zstandard.
+	Zstd Algorithm = "zstd"
+	// Snappy compresses values with Snappy.
+	Snappy Algorithm = "snappy"
+)
+
+// DefaultThreshold is the default minimum size, in bytes, of the values that are compressed.
+const DefaultThreshold = 1024
+
+// header is the prefix of compressed values, followed by a byte identifying the algorithm.
+// It starts with a NUL byte so it can't be confused with the beginning of a JSON document.
+const header = "\x00dz"
+
+const (
+	idGzip   byte = 'g'
+	idZstd   byte = 'z'
+	idSnappy byte = 's'
+	// Uncompressed values that would otherwise be mistaken for compressed ones
+	idEscaped byte = 'r'
+)
+
+// ErrCorrupted is returned when a value has a compression header but it can't be decompressed.
+var ErrCorrupted = errors.New("compressed value is corrupted")
+
+// Metadata contains the compression options of a state store.
+type Metadata struct {
+	// Compression algorithm to use for values: "gzip", "zstd" or "snappy". If empty, values are not compressed.
+	Compression string `mapstructure:"compression"`
+	// Minimum size, in bytes, of the values that are compressed.
+	CompressionThreshold *int `mapstructure:"compressionThreshold"`
+}
+
+// Codec compresses and decompresses values.
+// The zero value is a codec that doesn't compress values, but still decompresses them.
+type Codec struct {
+	algorithm Algorithm
+	threshold int
+}
+
+// NewCodec returns a codec configured with the compression options in the component's metadata properties.
+func NewCodec(props map[string]string) (Codec, error) {
+	var md Metadata
+	err := kitmd.DecodeMetadata(props, &md)
+	if err != nil {
+		return Codec{}, err
+	}
+	return md.Codec()
+}
+
+// Codec returns a codec configured with the compression options.
+func (m Metadata) Codec() (Codec, error) {
+	c := Codec{
+		algorithm: Algorithm(strings.ToLower(strings.TrimSpace(m.Compression))),
+		threshold: DefaultThreshold,
+	}
+	switch c.algorithm {
+	case None, Gzip, Zstd, Snappy:
+		// Nop
+	default:
+		return Codec{}, fmt.Errorf("unsupported compression algorithm '%s'", m.Compression)
+	}
+	if m.CompressionThreshold != nil {
+		if *m.CompressionThreshold < 0 {
+			return Codec{}, errors.New("the compression threshold must be a non-negative number")
+		}
+		c.threshold = *m.CompressionThreshold
+	}
+	return c, nil
+}
+
+// Algorithm returns the algorithm the codec uses to compress values.
+func (c Codec) Algorithm() Algorithm {
+	return c.algorithm
+}
+
+// Enabled returns true if the codec compresses values.
+func (c Codec) Enabled() bool {
+	return c.algorithm != None
+}
+
+// Compress compresses the value if it's at least as large as the threshold.
+// The value is not compressed if compression is disabled, if it's smaller than the threshold, or if compressing it doesn't make it smaller.
+// Values that are not compressed are returned unchanged, unless they begin with the header and must be escaped.
+func (c Codec) Compress(data []byte) []byte {
+	if c.algorithm == None || len(data) < c.threshold || len(data) == 0 {
+		return escape(data)
+	}
+
+	res := make([]byte, len(header)+1, len(header)+1+len(data)/2)
+	copy(res, header)
+	switch c.algorithm {
+	case Gzip:
+		res[len(header)] = idGzip
+		buf := bytes.NewBuffer(res)
+		w := gzipWriters.Get().(*gzip.Writer)
+		w.Reset(buf)
+		// Writing to a bytes.Buffer can't fail
+		_, _ = w.Write(data)
+		_ = w.Close()
+		gzipWriters.Put(w)
+		res = buf.Bytes()
+	case Zstd:
+		res[len(header)] = idZstd
+		res = zstdEncoder().EncodeAll(data, res)
+	case Snappy:
+		res[len(header)] = idSnappy
+		res = append(res, snappy.Encode(nil, data)...)
+	}
+
+	if len(res) >= len(data) {
+		return escape(data)
+	}
+	return res
+}
+
+// escape adds a header to uncompressed values that begin with it, so they're not mistaken for compressed values.
+func escape(data []byte) []byte {
+	if !IsCompressed(data) {
+		return data
+	}
+	res := make([]byte, len(header)+1+len(data))
+	copy(res, header)
+	res[len(header)] = idEscaped
+	copy(res[len(header)+1:], data)
+	return res
+}
+
+// IsCompressed returns true if the value was compressed or escaped by a codec, and must be decompressed when read.
+func IsCompressed(data []byte) bool {
+	return len(data) > len(header) && string(data[:len(header)]) == header
+}
+
+// Decompress returns the decompressed value if it was compressed by a codec, or the value unchanged otherwise.
+func Decompress(data []byte) ([]byte, error) {
+	if !IsCompressed(data) {
+		return data, nil
+	}
+
+	var (
+		res []byte
+		err error
+	)
+	payload := data[len(header)+1:]
+	switch data[len(header)] {
+	case idGzip:
+		var r *gzip.Reader
+		r, err = gzip.NewReader(bytes.NewReader(payload))
+		if err == nil {
+			res, err = io.ReadAll(r)
+		}
+	case idZstd:
+		res, err = zstdDecoder().DecodeAll(payload, nil)
+	case idSnappy:
+		res, err = snappy.Decode(nil, payload)
+	case idEscaped:
+		res = payload
+	default:
+		err = fmt.Errorf("unknown algorithm identifier '%c'", data[len(header)])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+	return res, nil
+}
+
+var gzipWriters = sync.Pool{
+	New: func() any {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// Encoders and decoders are safe for concurrent use with EncodeAll and DecodeAll.
+var (
+	zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+		enc, _ := zstd.NewWriter(nil)
+		return enc
+	})
+	zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+		dec, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+		return dec
+	})
+)
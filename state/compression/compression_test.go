@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compression
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCodec(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		c, err := NewCodec(map[string]string{})
+		require.NoError(t, err)
+		assert.False(t, c.Enabled())
+		assert.Equal(t, None, c.Algorithm())
+	})
+
+	t.Run("algorithm and threshold", func(t *testing.T) {
+		c, err := NewCodec(map[string]string{"compression": "ZSTD", "compressionThreshold": "10"})
+		require.NoError(t, err)
+		assert.True(t, c.Enabled())
+		assert.Equal(t, Zstd, c.Algorithm())
+		assert.Equal(t, 10, c.threshold)
+	})
+
+	t.Run("default threshold", func(t *testing.T) {
+		c, err := NewCodec(map[string]string{"compression": "gzip"})
+		require.NoError(t, err)
+		assert.Equal(t, DefaultThreshold, c.threshold)
+	})
+
+	t.Run("invalid algorithm", func(t *testing.T) {
+		_, err := NewCodec(map[string]string{"compression": "lz4"})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		_, err := NewCodec(map[string]string{"compression": "gzip", "compressionThreshold": "-1"})
+		require.Error(t, err)
+	})
+}
+
+func TestCompress(t *testing.T) {
+	large := bytes.Repeat([]byte(`{"hello":"world"},`), 200)
+
+	for _, alg := range []Algorithm{Gzip, Zstd, Snappy} {
+		t.Run(string(alg), func(t *testing.T) {
+			c, err := Metadata{Compression: string(alg)}.Codec()
+			require.NoError(t, err)
+
+			compressed := c.Compress(large)
+			assert.Less(t, len(compressed), len(large))
+			assert.True(t, IsCompressed(compressed))
+
+			res, err := Decompress(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, large, res)
+
+			// Values below the threshold are not compressed
+			small := []byte(`"small"`)
+			assert.Equal(t, small, c.Compress(small))
+		})
+	}
+
+	t.Run("incompressible values are stored as-is", func(t *testing.T) {
+		c, err := Metadata{Compression: "zstd", CompressionThreshold: new(int)}.Codec()
+		require.NoError(t, err)
+		data := []byte("abc")
+		assert.Equal(t, data, c.Compress(data))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, large, Codec{}.Compress(large))
+	})
+
+	t.Run("uncompressed values that begin with the header are escaped", func(t *testing.T) {
+		zstdCodec, err := Metadata{Compression: "zstd"}.Codec()
+		require.NoError(t, err)
+
+		for _, v := range [][]byte{[]byte(header + "gzip?"), []byte(header + "z"), []byte(header + "\xff\x00")} {
+			for _, c := range []Codec{{}, zstdCodec} {
+				stored := c.Compress(v)
+				assert.NotEqual(t, v, stored)
+				res, err := Decompress(stored)
+				require.NoError(t, err)
+				assert.Equal(t, v, res)
+			}
+		}
+
+		// Values that don't begin with the full header are not escaped
+		v := []byte(header)
+		assert.Equal(t, v, Codec{}.Compress(v))
+	})
+}
+
+func TestDecompress(t *testing.T) {
+	t.Run("uncompressed values are returned as-is", func(t *testing.T) {
+		for _, v := range [][]byte{nil, {}, []byte(`{"a":1}`), {0x00, 'd'}} {
+			res, err := Decompress(v)
+			require.NoError(t, err)
+			assert.Equal(t, v, res)
+		}
+	})
+
+	t.Run("corrupted value", func(t *testing.T) {
+		_, err := Decompress([]byte(header + "zbogus"))
+		require.ErrorIs(t, err, ErrCorrupted)
+
+		_, err = Decompress([]byte(header + "xbogus"))
+		require.ErrorIs(t, err, ErrCorrupted)
+	})
+}
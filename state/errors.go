@@ -30,6 +30,8 @@ const (
 
 var ErrKeysLikeEmptyPattern = errors.New("keys like pattern cannot be empty")
 
+var ErrScanInvalidRange = errors.New("the start of the scan range must not be greater than its end")
+
 // ETagError is a custom error type for etag exceptions.
 type ETagError struct {
 	err  error
@@ -16,7 +16,6 @@ package etcd
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"reflect"
@@ -26,13 +25,13 @@ import (
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 
+	commonetcd "github.com/dapr/components-contrib/common/component/etcd"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	stateutils "github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
 	"github.com/dapr/kit/ptr"
-	kitstrings "github.com/dapr/kit/strings"
 )
 
 // Etcd is a state store implementation for Etcd.
@@ -48,13 +47,9 @@ type Etcd struct {
 }
 
 type etcdConfig struct {
-	Endpoints     string `json:"endpoints"`
+	commonetcd.ClientMetadata `mapstructure:",squash"`
+
 	KeyPrefixPath string `json:"keyPrefixPath"`
-	// TLS
-	TLSEnable string `json:"tlsEnable"`
-	CA        string `json:"ca"`
-	Cert      string `json:"cert"`
-	Key       string `json:"key"`
 	// Transaction server options
 	MaxTxnOps int `mapstructure:"maxTxnOps"`
 }
@@ -78,6 +73,7 @@ func newETCD(logger logger.Logger, schema schemaMarshaller) state.Store {
 			state.FeatureTransactional,
 			state.FeatureTTL,
 			state.FeatureKeysLike,
+			state.FeatureRangeScan,
 		},
 	}
 	s.BulkStore = state.NewDefaultBulkStore(s)
@@ -104,34 +100,7 @@ func (e *Etcd) Init(_ context.Context, metadata state.Metadata) error {
 }
 
 func (e *Etcd) ParseClientFromConfig(etcdConfig *etcdConfig) (*clientv3.Client, error) {
-	endpoints := strings.Split(etcdConfig.Endpoints, ",")
-	if len(endpoints) == 0 || endpoints[0] == "" {
-		return nil, errors.New("endpoints required")
-	}
-
-	var tlsConfig *tls.Config
-	if kitstrings.IsTruthy(etcdConfig.TLSEnable) {
-		if etcdConfig.Cert != "" && etcdConfig.Key != "" && etcdConfig.CA != "" {
-			var err error
-			tlsConfig, err = NewTLSConfig(etcdConfig.Cert, etcdConfig.Key, etcdConfig.CA)
-			if err != nil {
-				return nil, fmt.Errorf("tls authentication error: %w", err)
-			}
-		} else {
-			return nil, errors.New("tls authentication information is incomplete")
-		}
-	}
-
-	config := clientv3.Config{
-		Endpoints:   endpoints,
-		DialTimeout: 5 * time.Second, // TODO: make this configurable
-		TLS:         tlsConfig,
-	}
-	client, err := clientv3.New(config)
-	if err != nil {
-		return nil, err
-	}
-	return client, nil
+	return commonetcd.NewClient(etcdConfig.ClientMetadata)
 }
 
 // Features returns the features available in this state store.
@@ -419,35 +388,11 @@ func (e *Etcd) Multi(ctx context.Context, request *state.TransactionalStateReque
 	return nil
 }
 
+// NewTLSConfig returns the TLS configuration for a client certificate and key, and a CA certificate.
+//
+// Deprecated: use NewTLSConfig in common/component/etcd.
 func NewTLSConfig(clientCert, clientKey, caCert string) (*tls.Config, error) {
-	valid := false
-
-	config := &tls.Config{
-		MinVersion: tls.VersionTLS12,
-	}
-
-	if clientCert != "" && clientKey != "" {
-		key := []byte(clientKey)
-		cert, err := tls.X509KeyPair([]byte(clientCert), key)
-		if err != nil {
-			return nil, fmt.Errorf("error parse X509KeyPair: %w", err)
-		}
-		config.Certificates = []tls.Certificate{cert}
-		valid = true
-	}
-
-	if caCert != "" {
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(caCert))
-		config.RootCAs = caCertPool
-		valid = true
-	}
-
-	if !valid {
-		config = nil
-	}
-
-	return config, nil
+	return commonetcd.NewTLSConfig(clientCert, clientKey, caCert)
 }
 
 func (e *Etcd) KeysLike(ctx context.Context, req *state.KeysLikeRequest) (*state.KeysLikeResponse, error) {
@@ -455,7 +400,7 @@ func (e *Etcd) KeysLike(ctx context.Context, req *state.KeysLikeRequest) (*state
 		return nil, state.ErrKeysLikeEmptyPattern
 	}
 
-	userPrefix := likeLiteralPrefix(req.Pattern)
+	userPrefix := stateutils.LikeLiteralPrefix(req.Pattern)
 	etcdPrefix := strings.TrimSuffix(e.keyPrefixPath, "/") + "/" + userPrefix
 	base := strings.TrimSuffix(e.keyPrefixPath, "/") + "/"
 
@@ -569,27 +514,73 @@ func (e *Etcd) KeysLike(ctx context.Context, req *state.KeysLikeRequest) (*state
 	}
 }
 
-// likeLiteralPrefix returns the literal prefix before the first unescaped % or _.
-func likeLiteralPrefix(p string) string {
-	var b strings.Builder
-	for i := 0; i < len(p); i++ {
-		c := p[i]
-		switch c {
-		case '\\':
-			if i+1 < len(p) {
-				b.WriteByte(p[i+1])
-				i++
-			} else {
-				// Trailing backslash: treat it literally.
-				b.WriteByte('\\')
-			}
-		case '%', '_':
-			return b.String()
-		default:
-			b.WriteByte(c)
+// Scan returns the items whose keys are in the requested range, in key order.
+func (e *Etcd) Scan(ctx context.Context, req *state.ScanRequest) (*state.ScanResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// etcd stores keys sorted byte-wise, so the range maps directly to a range of keys
+	base := e.keyPrefixPath + "/"
+	from := base + req.Start
+	end := clientv3.GetPrefixRangeEnd(base)
+	if req.End != "" {
+		end = base + req.End
+	}
+
+	// Pagination: resume strictly after the last returned key
+	if tok := req.ContinuationToken; tok != nil && *tok != "" {
+		if req.Reverse {
+			end = base + *tok
+		} else {
+			from = base + *tok + "\x00"
+		}
+	}
+
+	order := clientv3.SortAscend
+	if req.Reverse {
+		order = clientv3.SortDescend
+	}
+	opts := []clientv3.OpOption{
+		clientv3.WithRange(end),
+		clientv3.WithSort(clientv3.SortByKey, order),
+	}
+	if req.Limit > 0 {
+		// Fetch one extra key to detect if there's a next page
+		opts = append(opts, clientv3.WithLimit(int64(req.Limit)+1))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	resp, err := e.client.Get(ctx, from, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't scan keys from %s: %w", from, err)
+	}
+
+	res := &state.ScanResponse{
+		Items: make([]state.ScanItem, 0, len(resp.Kvs)),
+	}
+	for _, kv := range resp.Kvs {
+		//nolint:gosec
+		if req.Limit > 0 && uint32(len(res.Items)) == req.Limit {
+			res.ContinuationToken = ptr.Of(res.Items[len(res.Items)-1].Key)
+			break
+		}
+
+		data, metadata, err := e.schema.decode(kv.Value)
+		if err != nil {
+			return nil, err
 		}
+		res.Items = append(res.Items, state.ScanItem{
+			Key:      string(kv.Key[len(base):]),
+			Data:     data,
+			ETag:     ptr.Of(strconv.Itoa(int(kv.ModRevision))),
+			Metadata: metadata,
+		})
 	}
-	return b.String()
+
+	return res, nil
 }
 
 // likeMatch implements SQL LIKE for ASCII with % (any) and _ (single char).
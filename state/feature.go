@@ -32,6 +32,8 @@ const (
 	FeaturePartitionKey Feature = "PARTITION_KEY"
 	// FeatureKeysLike is the feature that supports keys like list operation.
 	FeatureKeysLike Feature = "KEYS_LIKE"
+	// FeatureRangeScan is the feature that supports reading items in a range of keys.
+	FeatureRangeScan Feature = "RANGE_SCAN"
 )
 
 // Feature names a feature that can be implemented by state store components.
@@ -16,18 +16,35 @@ package consul
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/consul/api"
 
+	commonconsul "github.com/dapr/components-contrib/common/component/hashicorp/consul"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
 	"github.com/dapr/kit/ptr"
 )
 
+const (
+	// Maximum number of operations in a Consul transaction.
+	maxTxnOps = 64
+	// Every set operation takes up to three operations of the transaction.
+	maxMultiOps = maxTxnOps / 3
+
+	// Consul sessions have a TTL between 10s and 24h.
+	minSessionTTL = 10 * time.Second
+	maxSessionTTL = 24 * time.Hour
+)
+
 // Consul is a state store implementation for HashiCorp Consul.
 type Consul struct {
 	state.BulkStore
@@ -38,10 +55,8 @@ type Consul struct {
 }
 
 type consulConfig struct {
-	Datacenter    string `json:"datacenter"`
-	HTTPAddr      string `json:"httpAddr"`
-	ACLToken      string `json:"aclToken"`
-	Scheme        string `json:"scheme"`
+	commonconsul.ClientMetadata `mapstructure:",squash"`
+
 	KeyPrefixPath string `json:"keyPrefixPath"`
 }
 
@@ -67,14 +82,7 @@ func (c *Consul) Init(_ context.Context, metadata state.Metadata) error {
 		keyPrefixPath = "dapr"
 	}
 
-	config := &api.Config{
-		Datacenter: consulConfig.Datacenter,
-		Address:    consulConfig.HTTPAddr,
-		Token:      consulConfig.ACLToken,
-		Scheme:     consulConfig.Scheme,
-	}
-
-	client, err := api.NewClient(config)
+	client, err := commonconsul.NewClient(consulConfig.ClientMetadata)
 	if err != nil {
 		return fmt.Errorf("initializing consul client: %w", err)
 	}
@@ -87,8 +95,12 @@ func (c *Consul) Init(_ context.Context, metadata state.Metadata) error {
 
 // Features returns the features available in this state store.
 func (c *Consul) Features() []state.Feature {
-	// Etag is just returned and not handled in set or delete operations.
-	return nil
+	return []state.Feature{
+		state.FeatureETag,
+		state.FeatureTransactional,
+		state.FeatureTTL,
+		state.FeatureKeysLike,
+	}
 }
 
 func metadataToConfig(connInfo map[string]string) (*consulConfig, error) {
@@ -105,7 +117,7 @@ func (c *Consul) Get(ctx context.Context, req *state.GetRequest) (*state.GetResp
 	}
 	queryOpts = queryOpts.WithContext(ctx)
 
-	resp, queryMeta, err := c.client.KV().Get(c.keyPrefixPath+"/"+req.Key, queryOpts)
+	resp, _, err := c.client.KV().Get(c.keyPrefixPath+"/"+req.Key, queryOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -116,48 +128,257 @@ func (c *Consul) Get(ctx context.Context, req *state.GetRequest) (*state.GetResp
 
 	return &state.GetResponse{
 		Data: resp.Value,
-		ETag: ptr.Of(queryMeta.LastContentHash),
+		ETag: ptr.Of(strconv.FormatUint(resp.ModifyIndex, 10)),
 	}, nil
 }
 
 // Set saves a Consul KV item.
+// The ETag of an item is its ModifyIndex.
+// Items with a TTL are locked by a session with that TTL, which deletes them when it expires.
 func (c *Consul) Set(ctx context.Context, req *state.SetRequest) error {
-	var reqValByte []byte
+	t := newTxn()
+	err := c.addSet(ctx, t, req)
+	if err == nil {
+		err = c.commit(ctx, t)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't set key %s: %w", c.keyPrefixPath+"/"+req.Key, err)
+	}
+
+	return nil
+}
+
+// Delete performes a Consul KV delete operation.
+func (c *Consul) Delete(ctx context.Context, req *state.DeleteRequest) error {
+	t := newTxn()
+	err := c.addDelete(t, req)
+	if err == nil {
+		err = c.commit(ctx, t)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't delete key %s: %w", c.keyPrefixPath+"/"+req.Key, err)
+	}
+
+	return nil
+}
+
+// MultiMaxSize returns the maximum number of operations of a transaction.
+func (c *Consul) MultiMaxSize() int {
+	return maxMultiOps
+}
+
+// Multi performs the operations in a Consul transaction.
+func (c *Consul) Multi(ctx context.Context, request *state.TransactionalStateRequest) error {
+	if len(request.Operations) == 0 {
+		return nil
+	}
+
+	t := newTxn()
+	for _, o := range request.Operations {
+		var err error
+		switch req := o.(type) {
+		case state.SetRequest:
+			err = c.addSet(ctx, t, &req)
+		case state.DeleteRequest:
+			err = c.addDelete(t, &req)
+		default:
+			err = fmt.Errorf("unsupported operation: %s", o.Operation())
+		}
+		if err != nil {
+			c.destroySessions(t)
+			return err
+		}
+	}
+
+	return c.commit(ctx, t)
+}
+
+// KeysLike returns the keys matching a SQL LIKE pattern, listing the keys with the literal prefix of the pattern.
+// The continuation token is the last key of the previous page.
+func (c *Consul) KeysLike(ctx context.Context, req *state.KeysLikeRequest) (*state.KeysLikeResponse, error) {
+	if len(req.Pattern) == 0 {
+		return nil, state.ErrKeysLikeEmptyPattern
+	}
+
+	re, err := utils.LikeToRegex(req.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert like pattern to regex: %w", err)
+	}
+
+	base := c.keyPrefixPath + "/"
+	queryOpts := (&api.QueryOptions{}).WithContext(ctx)
+	entries, _, err := c.client.KV().Keys(base+utils.LikeLiteralPrefix(req.Pattern), "", queryOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Consul returns the keys sorted
+	res := &state.KeysLikeResponse{Keys: make([]string, 0, len(entries))}
+	for _, entry := range entries {
+		key := strings.TrimPrefix(entry, base)
+		if req.ContinuationToken != nil && key <= *req.ContinuationToken {
+			continue
+		}
+		if !re.MatchString(key) {
+			continue
+		}
+		if req.PageSize != nil && *req.PageSize > 0 && len(res.Keys) == int(*req.PageSize) {
+			res.ContinuationToken = ptr.Of(res.Keys[len(res.Keys)-1])
+			break
+		}
+		res.Keys = append(res.Keys, key)
+	}
+	return res, nil
+}
+
+// txn is a Consul transaction being built.
+type txn struct {
+	ops api.TxnOps
+	// Indexes of the operations checking the ETag or the first-write concurrency
+	checks map[int]bool
+	// Sessions created for the items with a TTL, by TTL
+	sessions map[time.Duration]string
+}
+
+func newTxn() *txn {
+	return &txn{
+		checks:   map[int]bool{},
+		sessions: map[time.Duration]string{},
+	}
+}
+
+func (t *txn) add(op *api.KVTxnOp, check bool) {
+	if check {
+		t.checks[len(t.ops)] = true
+	}
+	t.ops = append(t.ops, &api.TxnOp{KV: op})
+}
+
+// addSet adds the operations of a set request to a transaction.
+// The ETag is checked with a check-index operation, which fails if the item doesn't exist, unlike delete-cas.
+// Unless the request checks the first-write concurrency, the item is deleted before being set, which releases the lock of the session of its previous TTL, if any.
+func (c *Consul) addSet(ctx context.Context, t *txn, req *state.SetRequest) error {
+	key := c.keyPrefixPath + "/" + req.Key
+
+	var value []byte
 	b, ok := req.Value.([]byte)
 	if ok {
-		reqValByte = b
+		value = b
 	} else {
-		reqValByte, _ = json.Marshal(req.Value)
+		value, _ = json.Marshal(req.Value)
 	}
 
-	keyWithPath := c.keyPrefixPath + "/" + req.Key
+	switch {
+	case req.HasETag():
+		index, err := strconv.ParseUint(*req.ETag, 10, 64)
+		if err != nil {
+			return state.NewETagError(state.ETagInvalid, err)
+		}
+		t.add(&api.KVTxnOp{Verb: api.KVCheckIndex, Key: key, Index: index}, true)
+		t.add(&api.KVTxnOp{Verb: api.KVDelete, Key: key}, false)
+	case req.Options.Concurrency == state.FirstWrite:
+		t.add(&api.KVTxnOp{Verb: api.KVCheckNotExists, Key: key}, true)
+	default:
+		t.add(&api.KVTxnOp{Verb: api.KVDelete, Key: key}, false)
+	}
 
-	writeOptions := new(api.WriteOptions)
-	writeOptions = writeOptions.WithContext(ctx)
-	_, err := c.client.KV().Put(&api.KVPair{
-		Key:   keyWithPath,
-		Value: reqValByte,
-	}, writeOptions)
+	ttl, err := utils.ParseTTL(req.Metadata)
 	if err != nil {
-		return fmt.Errorf("couldn't set key %s: %s", keyWithPath, err)
+		return fmt.Errorf("error parsing TTL: %w", err)
+	}
+	if ttl == nil || *ttl <= 0 {
+		t.add(&api.KVTxnOp{Verb: api.KVSet, Key: key, Value: value}, false)
+		return nil
 	}
 
+	session, err := c.session(ctx, t, time.Duration(*ttl)*time.Second)
+	if err != nil {
+		return err
+	}
+	t.add(&api.KVTxnOp{Verb: api.KVLock, Key: key, Value: value, Session: session}, false)
 	return nil
 }
 
-// Delete performes a Consul KV delete operation.
-func (c *Consul) Delete(ctx context.Context, req *state.DeleteRequest) error {
-	keyWithPath := c.keyPrefixPath + "/" + req.Key
+// addDelete adds the operations of a delete request to a transaction.
+func (c *Consul) addDelete(t *txn, req *state.DeleteRequest) error {
+	key := c.keyPrefixPath + "/" + req.Key
+	if !req.HasETag() {
+		t.add(&api.KVTxnOp{Verb: api.KVDelete, Key: key}, false)
+		return nil
+	}
+
+	index, err := strconv.ParseUint(*req.ETag, 10, 64)
+	if err != nil {
+		return state.NewETagError(state.ETagInvalid, err)
+	}
+	t.add(&api.KVTxnOp{Verb: api.KVCheckIndex, Key: key, Index: index}, true)
+	t.add(&api.KVTxnOp{Verb: api.KVDelete, Key: key}, false)
+	return nil
+}
+
+// session returns a session that deletes the items it locks when it expires, creating it if needed.
+// TTLs shorter than the minimum TTL of Consul sessions are rounded up.
+// Note that Consul can take up to twice the TTL of a session to invalidate it.
+func (c *Consul) session(ctx context.Context, t *txn, ttl time.Duration) (string, error) {
+	ttl = max(ttl, minSessionTTL)
+	if ttl > maxSessionTTL {
+		return "", fmt.Errorf("TTL can't be greater than %s", maxSessionTTL)
+	}
+	if id, ok := t.sessions[ttl]; ok {
+		return id, nil
+	}
+
 	writeOptions := new(api.WriteOptions)
 	writeOptions = writeOptions.WithContext(ctx)
-	_, err := c.client.KV().Delete(keyWithPath, writeOptions)
+	id, _, err := c.client.Session().Create(&api.SessionEntry{
+		Name:      "dapr-state-ttl",
+		TTL:       ttl.String(),
+		Behavior:  api.SessionBehaviorDelete,
+		LockDelay: time.Millisecond,
+	}, writeOptions)
 	if err != nil {
-		return fmt.Errorf("couldn't delete key %s: %s", keyWithPath, err)
+		return "", fmt.Errorf("couldn't create session: %w", err)
 	}
+	t.sessions[ttl] = id
+	return id, nil
+}
 
+// commit commits a transaction, destroying its sessions if it fails.
+func (c *Consul) commit(ctx context.Context, t *txn) error {
+	queryOptions := new(api.QueryOptions)
+	queryOptions = queryOptions.WithContext(ctx)
+	ok, resp, _, err := c.client.Txn().Txn(t.ops, queryOptions)
+	if err == nil && !ok {
+		err = t.error(resp.Errors)
+	}
+	if err != nil {
+		c.destroySessions(t)
+		return err
+	}
 	return nil
 }
 
+// error returns the error of a transaction that failed, which is an ETag mismatch if a check failed.
+func (t *txn) error(txnErrors api.TxnErrors) error {
+	errs := make([]error, 0, len(txnErrors))
+	for _, e := range txnErrors {
+		if t.checks[e.OpIndex] {
+			return state.NewETagError(state.ETagMismatch, errors.New(e.What))
+		}
+		errs = append(errs, errors.New(e.What))
+	}
+	return fmt.Errorf("transaction failed: %w", errors.Join(errs...))
+}
+
+func (c *Consul) destroySessions(t *txn) {
+	for _, id := range t.sessions {
+		_, err := c.client.Session().Destroy(id, nil)
+		if err != nil {
+			c.logger.Warnf("Couldn't destroy session %s: %v", id, err)
+		}
+	}
+}
+
 func (c *Consul) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 	metadataStruct := consulConfig{}
 	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.StateStoreType)
@@ -14,13 +14,22 @@ limitations under the License.
 package consul
 
 import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/consul/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 )
 
 func TestGetConsulMetadata(t *testing.T) {
@@ -60,3 +69,320 @@ func TestGetConsulMetadata(t *testing.T) {
 		assert.Equal(t, properties["keyPrefixPath"], metadata.KeyPrefixPath)
 	})
 }
+
+// fakeConsul is a Consul HTTP API that records the transactions and the sessions.
+// If apply is true, transactions are also applied to an in-memory KV store with the semantics of Consul.
+type fakeConsul struct {
+	apply     bool
+	lock      sync.Mutex
+	txns      []api.TxnOps
+	txnErrors api.TxnErrors
+	sessions  []api.SessionEntry
+	destroyed []string
+	keys      []string
+	kv        map[string]*api.KVPair
+	index     uint64
+}
+
+func newTestStore(t *testing.T) (*Consul, *fakeConsul) {
+	t.Helper()
+
+	fake := &fakeConsul{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := api.NewClient(&api.Config{Address: strings.TrimPrefix(server.URL, "http://")})
+	require.NoError(t, err)
+
+	return &Consul{
+		client:        client,
+		keyPrefixPath: "dapr",
+		logger:        logger.NewLogger("test"),
+	}, fake
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	switch {
+	case r.URL.Path == "/v1/txn":
+		var ops api.TxnOps
+		_ = json.NewDecoder(r.Body).Decode(&ops)
+		f.txns = append(f.txns, ops)
+		txnErrors := f.txnErrors
+		if len(txnErrors) == 0 && f.apply {
+			txnErrors = f.applyTxn(ops)
+		}
+		if len(txnErrors) > 0 {
+			w.WriteHeader(http.StatusConflict)
+		}
+		json.NewEncoder(w).Encode(api.TxnResponse{Errors: txnErrors})
+	case r.URL.Path == "/v1/session/create":
+		var entry api.SessionEntry
+		_ = json.NewDecoder(r.Body).Decode(&entry)
+		f.sessions = append(f.sessions, entry)
+		json.NewEncoder(w).Encode(map[string]string{"ID": "session-" + entry.TTL})
+	case strings.HasPrefix(r.URL.Path, "/v1/session/destroy/"):
+		f.destroyed = append(f.destroyed, strings.TrimPrefix(r.URL.Path, "/v1/session/destroy/"))
+		w.Write([]byte("true"))
+	case strings.HasPrefix(r.URL.Path, "/v1/kv/") && !r.URL.Query().Has("keys"):
+		pair := f.kv[strings.TrimPrefix(r.URL.Path, "/v1/kv/")]
+		if pair == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(api.KVPairs{pair})
+	case strings.HasPrefix(r.URL.Path, "/v1/kv/"):
+		prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+		keys := []string{}
+		for _, k := range f.keys {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		json.NewEncoder(w).Encode(keys)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// applyTxn applies the KV operations of a transaction atomically, returning the errors of the operations that failed.
+func (f *fakeConsul) applyTxn(ops api.TxnOps) api.TxnErrors {
+	kv := maps.Clone(f.kv)
+	if kv == nil {
+		kv = map[string]*api.KVPair{}
+	}
+	index := f.index + 1
+	for i, op := range ops {
+		current := kv[op.KV.Key]
+		fail := func(what string) api.TxnErrors {
+			return api.TxnErrors{{OpIndex: i, What: what}}
+		}
+		switch op.KV.Verb {
+		case api.KVCheckIndex:
+			if current == nil {
+				return fail("key doesn't exist")
+			}
+			if current.ModifyIndex != op.KV.Index {
+				return fail("current modify index doesn't match")
+			}
+		case api.KVCheckNotExists:
+			if current != nil {
+				return fail("key already exists")
+			}
+		case api.KVDelete:
+			delete(kv, op.KV.Key)
+		case api.KVDeleteCAS:
+			// Like Consul, this succeeds if the key doesn't exist
+			if current != nil && current.ModifyIndex != op.KV.Index {
+				return fail("current modify index doesn't match")
+			}
+			delete(kv, op.KV.Key)
+		case api.KVSet, api.KVLock:
+			kv[op.KV.Key] = &api.KVPair{Key: op.KV.Key, Value: op.KV.Value, Session: op.KV.Session, ModifyIndex: index}
+		default:
+			return fail("unsupported verb " + string(op.KV.Verb))
+		}
+	}
+	f.kv = kv
+	f.index = index
+	return nil
+}
+
+func verbs(ops api.TxnOps) []api.KVOp {
+	res := make([]api.KVOp, len(ops))
+	for i, op := range ops {
+		res[i] = op.KV.Verb
+	}
+	return res
+}
+
+func TestSet(t *testing.T) {
+	store, fake := newTestStore(t)
+
+	t.Run("without concurrency", func(t *testing.T) {
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value"}))
+		ops := fake.txns[len(fake.txns)-1]
+		assert.Equal(t, []api.KVOp{api.KVDelete, api.KVSet}, verbs(ops))
+		assert.Equal(t, "dapr/key", ops[1].KV.Key)
+		assert.Equal(t, []byte(`"value"`), ops[1].KV.Value)
+	})
+
+	t.Run("with an ETag", func(t *testing.T) {
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "key", Value: []byte("value"), ETag: ptr.Of("12")}))
+		ops := fake.txns[len(fake.txns)-1]
+		assert.Equal(t, []api.KVOp{api.KVCheckIndex, api.KVDelete, api.KVSet}, verbs(ops))
+		assert.Equal(t, uint64(12), ops[0].KV.Index)
+		assert.Equal(t, []byte("value"), ops[2].KV.Value)
+
+		err := store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value", ETag: ptr.Of("invalid")})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagInvalid, etagErr.Kind())
+	})
+
+	t.Run("first write", func(t *testing.T) {
+		err := store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value", Options: state.SetStateOption{Concurrency: state.FirstWrite}})
+		require.NoError(t, err)
+		assert.Equal(t, []api.KVOp{api.KVCheckNotExists, api.KVSet}, verbs(fake.txns[len(fake.txns)-1]))
+	})
+
+	t.Run("ETag mismatch", func(t *testing.T) {
+		fake.txnErrors = api.TxnErrors{{OpIndex: 0, What: "index is stale"}}
+		defer func() { fake.txnErrors = nil }()
+
+		err := store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value", ETag: ptr.Of("12")})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		// Other failures aren't ETag errors
+		err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value"})
+		require.ErrorContains(t, err, "transaction failed")
+		require.NotErrorAs(t, err, &etagErr)
+	})
+
+	t.Run("with a TTL", func(t *testing.T) {
+		err := store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value", Metadata: map[string]string{"ttlInSeconds": "60"}})
+		require.NoError(t, err)
+		ops := fake.txns[len(fake.txns)-1]
+		assert.Equal(t, []api.KVOp{api.KVDelete, api.KVLock}, verbs(ops))
+		assert.Equal(t, "session-1m0s", ops[1].KV.Session)
+		require.Len(t, fake.sessions, 1)
+		assert.Equal(t, api.SessionBehaviorDelete, fake.sessions[0].Behavior)
+
+		// TTLs are at least the minimum TTL of sessions
+		err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value", Metadata: map[string]string{"ttlInSeconds": "1"}})
+		require.NoError(t, err)
+		assert.Equal(t, "10s", fake.sessions[1].TTL)
+
+		err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value", Metadata: map[string]string{"ttlInSeconds": "100000"}})
+		require.ErrorContains(t, err, "TTL can't be greater than")
+
+		// The session is destroyed if the transaction fails
+		fake.txnErrors = api.TxnErrors{{OpIndex: 1, What: "lock failed"}}
+		defer func() { fake.txnErrors = nil }()
+		err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "value", Metadata: map[string]string{"ttlInSeconds": "20"}})
+		require.Error(t, err)
+		assert.Equal(t, []string{"session-20s"}, fake.destroyed)
+	})
+}
+
+func TestMulti(t *testing.T) {
+	store, fake := newTestStore(t)
+	assert.Equal(t, 21, store.MultiMaxSize())
+
+	err := store.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "a", Value: "1", Metadata: map[string]string{"ttlInSeconds": "30"}},
+			state.DeleteRequest{Key: "b", ETag: ptr.Of("7")},
+			state.SetRequest{Key: "c", Value: "2", Metadata: map[string]string{"ttlInSeconds": "30"}},
+			state.DeleteRequest{Key: "d"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, fake.txns, 1)
+	ops := fake.txns[0]
+	assert.Equal(t, []api.KVOp{api.KVDelete, api.KVLock, api.KVCheckIndex, api.KVDelete, api.KVDelete, api.KVLock, api.KVDelete}, verbs(ops))
+	assert.Equal(t, uint64(7), ops[2].KV.Index)
+	// Items with the same TTL share a session
+	assert.Len(t, fake.sessions, 1)
+
+	fake.txnErrors = api.TxnErrors{{OpIndex: 2, What: "index is stale"}}
+	err = store.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "a", Value: "1"},
+			state.DeleteRequest{Key: "b", ETag: ptr.Of("7")},
+		},
+	})
+	var etagErr *state.ETagError
+	require.ErrorAs(t, err, &etagErr)
+	assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+}
+
+func TestETagSemantics(t *testing.T) {
+	store, fake := newTestStore(t)
+	fake.apply = true
+	ctx := t.Context()
+
+	requireETagMismatch := func(t *testing.T, err error) {
+		t.Helper()
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+	}
+
+	require.NoError(t, store.Set(ctx, &state.SetRequest{Key: "key", Value: "v1"}))
+	res, err := store.Get(ctx, &state.GetRequest{Key: "key"})
+	require.NoError(t, err)
+	etag1 := res.ETag
+
+	require.NoError(t, store.Set(ctx, &state.SetRequest{Key: "key", Value: "v2", ETag: etag1}))
+	res, err = store.Get(ctx, &state.GetRequest{Key: "key"})
+	require.NoError(t, err)
+	etag2 := res.ETag
+	assert.NotEqual(t, *etag1, *etag2)
+
+	t.Run("stale ETag", func(t *testing.T) {
+		requireETagMismatch(t, store.Set(ctx, &state.SetRequest{Key: "key", Value: "v3", ETag: etag1}))
+		requireETagMismatch(t, store.Delete(ctx, &state.DeleteRequest{Key: "key", ETag: etag1}))
+
+		res, err := store.Get(ctx, &state.GetRequest{Key: "key"})
+		require.NoError(t, err)
+		assert.Equal(t, []byte(`"v2"`), res.Data)
+	})
+
+	t.Run("deleted key isn't recreated with an ETag", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, &state.DeleteRequest{Key: "key"}))
+
+		requireETagMismatch(t, store.Set(ctx, &state.SetRequest{Key: "key", Value: "v3", ETag: etag2}))
+		requireETagMismatch(t, store.Delete(ctx, &state.DeleteRequest{Key: "key", ETag: etag2}))
+		err := store.Multi(ctx, &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.SetRequest{Key: "other", Value: "v1"},
+				state.SetRequest{Key: "key", Value: "v3", ETag: etag2},
+			},
+		})
+		requireETagMismatch(t, err)
+
+		for _, key := range []string{"key", "other"} {
+			res, err := store.Get(ctx, &state.GetRequest{Key: key})
+			require.NoError(t, err)
+			assert.Nil(t, res.Data, key)
+		}
+	})
+
+	t.Run("first write", func(t *testing.T) {
+		firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+		require.NoError(t, store.Set(ctx, &state.SetRequest{Key: "fw", Value: "v1", Options: firstWrite}))
+		requireETagMismatch(t, store.Set(ctx, &state.SetRequest{Key: "fw", Value: "v2", Options: firstWrite}))
+	})
+}
+
+func TestKeysLike(t *testing.T) {
+	store, fake := newTestStore(t)
+	fake.keys = []string{"dapr/app||a1", "dapr/app||a2", "dapr/app||b1", "dapr/app||c1", "dapr/other||a1"}
+
+	res, err := store.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "app||%1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app||a1", "app||b1", "app||c1"}, res.Keys)
+	assert.Nil(t, res.ContinuationToken)
+
+	res, err = store.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "%||a_"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app||a1", "app||a2", "other||a1"}, res.Keys)
+
+	res, err = store.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "app||%1", PageSize: ptr.Of[uint32](2)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app||a1", "app||b1"}, res.Keys)
+	require.NotNil(t, res.ContinuationToken)
+
+	res, err = store.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "app||%1", PageSize: ptr.Of[uint32](2), ContinuationToken: res.ContinuationToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app||c1"}, res.Keys)
+	assert.Nil(t, res.ContinuationToken)
+
+	_, err = store.KeysLike(t.Context(), &state.KeysLikeRequest{})
+	require.ErrorIs(t, err, state.ErrKeysLikeEmptyPattern)
+}
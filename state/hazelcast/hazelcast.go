@@ -17,8 +17,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"reflect"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/hazelcast/hazelcast-go-client/core"
@@ -26,10 +31,22 @@ import (
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
+	"github.com/dapr/kit/ptr"
 )
 
+const (
+	// Number of locks that serialize the writes made by this client, each guarding a subset of the keys.
+	keyLockStripes = 64
+	// Maximum time a key stays locked in the cluster during a write with an ETag, in case it isn't unlocked.
+	keyLockLeaseTime = 30 * time.Second
+)
+
+// The conditional operations of Hazelcast maps can't set a TTL.
+var errTTLWithConcurrency = errors.New("TTL can't be set with an ETag or with the first-write concurrency")
+
 // Hazelcast state store.
 type Hazelcast struct {
 	state.BulkStore
@@ -37,6 +54,9 @@ type Hazelcast struct {
 	hzMap  core.Map
 	json   jsoniter.API
 	logger logger.Logger
+
+	// The client owns the locks of the keys locked in the cluster, so the writes made by this client are serialized too.
+	keyLocks [keyLockStripes]sync.Mutex
 }
 
 type hazelcastMetadata struct {
@@ -95,10 +115,14 @@ func (store *Hazelcast) Init(_ context.Context, metadata state.Metadata) error {
 
 // Features returns the features available in this state store.
 func (store *Hazelcast) Features() []state.Feature {
-	return nil
+	return []state.Feature{
+		state.FeatureETag,
+		state.FeatureTTL,
+	}
 }
 
 // Set stores value for a key to Hazelcast.
+// If the request has an ETag, the value is replaced only if the ETag is the version of the entry.
 func (store *Hazelcast) Set(ctx context.Context, req *state.SetRequest) error {
 	err := state.CheckRequestOptions(req)
 	if err != nil {
@@ -115,7 +139,39 @@ func (store *Hazelcast) Set(ctx context.Context, req *state.SetRequest) error {
 			return fmt.Errorf("failed to set key %s: %w", req.Key, err)
 		}
 	}
-	_, err = store.hzMap.Put(req.Key, value)
+
+	ttl, err := utils.ParseTTL(req.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to parse TTL: %w", err)
+	}
+	hasTTL := ttl != nil && *ttl > 0
+
+	if req.HasETag() {
+		if hasTTL {
+			return errTTLWithConcurrency
+		}
+		return store.replace(req.Key, *req.ETag, value)
+	}
+
+	keyLock := store.keyLock(req.Key)
+	keyLock.Lock()
+	defer keyLock.Unlock()
+
+	switch {
+	case req.Options.Concurrency == state.FirstWrite:
+		if hasTTL {
+			return errTTLWithConcurrency
+		}
+		var old any
+		old, err = store.hzMap.PutIfAbsent(req.Key, value)
+		if err == nil && old != nil {
+			return state.NewETagError(state.ETagMismatch, nil)
+		}
+	case hasTTL:
+		err = store.hzMap.SetWithTTL(req.Key, value, time.Duration(*ttl)*time.Second)
+	default:
+		_, err = store.hzMap.Put(req.Key, value)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to set key %s: %w", req.Key, err)
 	}
@@ -123,17 +179,75 @@ func (store *Hazelcast) Set(ctx context.Context, req *state.SetRequest) error {
 	return nil
 }
 
+// replace replaces the value of an entry if its version is the ETag.
+// The key is locked while the version is checked and the value replaced, so other writes can't happen in between.
+func (store *Hazelcast) replace(key string, etag string, value string) error {
+	version, err := strconv.ParseInt(etag, 10, 64)
+	if err != nil {
+		return state.NewETagError(state.ETagInvalid, err)
+	}
+
+	unlock, err := store.lockKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to set key %s: %w", key, err)
+	}
+	defer unlock()
+
+	view, err := store.getEntryView(key)
+	if err != nil {
+		return fmt.Errorf("failed to set key %s: %w", key, err)
+	}
+	if view == nil || view.Version() != version {
+		return state.NewETagError(state.ETagMismatch, nil)
+	}
+
+	_, err = store.hzMap.Put(key, value)
+	if err != nil {
+		return fmt.Errorf("failed to set key %s: %w", key, err)
+	}
+	return nil
+}
+
+// keyLock returns the lock that serializes the writes to a key made by this client.
+func (store *Hazelcast) keyLock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return &store.keyLocks[h.Sum32()%keyLockStripes]
+}
+
+// lockKey locks a key in this client and in the cluster, where writes to the key from other clients wait until it's unlocked.
+// The returned function unlocks the key.
+func (store *Hazelcast) lockKey(key string) (unlock func(), err error) {
+	keyLock := store.keyLock(key)
+	keyLock.Lock()
+
+	err = store.hzMap.LockWithLeaseTime(key, keyLockLeaseTime)
+	if err != nil {
+		keyLock.Unlock()
+		return nil, fmt.Errorf("failed to lock key: %w", err)
+	}
+
+	return func() {
+		unlockErr := store.hzMap.Unlock(key)
+		if unlockErr != nil {
+			store.logger.Warnf("Failed to unlock key %s, it will be unlocked when its lease expires: %v", key, unlockErr)
+		}
+		keyLock.Unlock()
+	}, nil
+}
+
 // Get retrieves state from Hazelcast with a key.
+// The ETag of the value is the version of the entry.
 func (store *Hazelcast) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
-	resp, err := store.hzMap.Get(req.Key)
+	view, err := store.getEntryView(req.Key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get value for %s: %w", req.Key, err)
 	}
 
-	// HZ Get API returns nil response if key does not exist in the map
-	if resp == nil {
+	if view == nil {
 		return &state.GetResponse{}, nil
 	}
+	resp := view.Value()
 	value, err := store.json.Marshal(&resp)
 	if err != nil {
 		return nil, err
@@ -141,15 +255,45 @@ func (store *Hazelcast) Get(ctx context.Context, req *state.GetRequest) (*state.
 
 	return &state.GetResponse{
 		Data: value,
+		ETag: ptr.Of(strconv.FormatInt(view.Version(), 10)),
 	}, nil
 }
 
+// getEntryView returns the entry view of a key, or nil if the key doesn't exist.
+func (store *Hazelcast) getEntryView(key string) (view core.EntryView, err error) {
+	// The client dereferences a nil pointer when decoding the response for a key that doesn't exist
+	// Other panics are not recovered
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		rErr, ok := r.(runtime.Error)
+		if !ok || !strings.Contains(rErr.Error(), "nil pointer dereference") {
+			panic(r)
+		}
+		store.logger.Debugf("Recovered from the Hazelcast client panic for the entry view of missing key %s: %v", key, rErr)
+		view, err = nil, nil
+	}()
+	return store.hzMap.GetEntryView(key)
+}
+
 // Delete performs a delete operation.
+// If the request has an ETag, the entry is removed only if the ETag is its version.
 func (store *Hazelcast) Delete(ctx context.Context, req *state.DeleteRequest) error {
 	err := state.CheckRequestOptions(req.Options)
 	if err != nil {
 		return err
 	}
+
+	if req.HasETag() {
+		return store.remove(req.Key, *req.ETag)
+	}
+
+	keyLock := store.keyLock(req.Key)
+	keyLock.Lock()
+	defer keyLock.Unlock()
+
 	err = store.hzMap.Delete(req.Key)
 	if err != nil {
 		return fmt.Errorf("failed to delete key: %w", err)
@@ -158,6 +302,35 @@ func (store *Hazelcast) Delete(ctx context.Context, req *state.DeleteRequest) er
 	return nil
 }
 
+// remove removes an entry if its version is the ETag.
+// Like replace, the key is locked while the version is checked and the entry removed.
+func (store *Hazelcast) remove(key string, etag string) error {
+	version, err := strconv.ParseInt(etag, 10, 64)
+	if err != nil {
+		return state.NewETagError(state.ETagInvalid, err)
+	}
+
+	unlock, err := store.lockKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to delete key: %w", err)
+	}
+	defer unlock()
+
+	view, err := store.getEntryView(key)
+	if err != nil {
+		return fmt.Errorf("failed to delete key: %w", err)
+	}
+	if view == nil || view.Version() != version {
+		return state.NewETagError(state.ETagMismatch, nil)
+	}
+
+	err = store.hzMap.Delete(key)
+	if err != nil {
+		return fmt.Errorf("failed to delete key: %w", err)
+	}
+	return nil
+}
+
 func (store *Hazelcast) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 	metadataStruct := hazelcastMetadata{}
 	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.StateStoreType)
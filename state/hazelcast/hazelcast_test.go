@@ -14,13 +14,21 @@ limitations under the License.
 package hazelcast
 
 import (
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/hazelcast/hazelcast-go-client/core"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 )
 
 func TestValidateMetadata(t *testing.T) {
@@ -68,3 +76,228 @@ func TestValidateMetadata(t *testing.T) {
 		assert.Equal(t, properties["hazelcastServers"], meta.HazelcastServers)
 	})
 }
+
+// fakeMap is a Hazelcast map in memory, with versioned entries.
+type fakeMap struct {
+	core.Map
+
+	lock    sync.Mutex
+	entries map[any]*fakeEntry
+	// Number of times each key is locked, which can be more than once as the client owns the locks
+	locked map[any]int
+	// Maximum number of times a key was locked at the same time
+	maxLocked int
+}
+
+type fakeEntry struct {
+	core.EntryView
+
+	value   any
+	version int64
+	ttl     time.Duration
+}
+
+func (e *fakeEntry) Value() any     { return e.value }
+func (e *fakeEntry) Version() int64 { return e.version }
+
+func (m *fakeMap) put(key any, value any, ttl time.Duration) {
+	if e, ok := m.entries[key]; ok {
+		e.value = value
+		e.version++
+		e.ttl = ttl
+		return
+	}
+	m.entries[key] = &fakeEntry{value: value, ttl: ttl}
+}
+
+func (m *fakeMap) Put(key any, value any) (any, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.put(key, value, 0)
+	return nil, nil
+}
+
+func (m *fakeMap) SetWithTTL(key any, value any, ttl time.Duration) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.put(key, value, ttl)
+	return nil
+}
+
+func (m *fakeMap) PutIfAbsent(key any, value any) (any, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if e, ok := m.entries[key]; ok {
+		return e.value, nil
+	}
+	m.put(key, value, 0)
+	return nil, nil
+}
+
+func (m *fakeMap) LockWithLeaseTime(key any, lease time.Duration) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.locked[key]++
+	m.maxLocked = max(m.maxLocked, m.locked[key])
+	return nil
+}
+
+func (m *fakeMap) Unlock(key any) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.locked[key] == 0 {
+		return errors.New("key is not locked")
+	}
+	m.locked[key]--
+	if m.locked[key] == 0 {
+		delete(m.locked, key)
+	}
+	return nil
+}
+
+func (m *fakeMap) Delete(key any) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *fakeMap) GetEntryView(key any) (core.EntryView, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if key == "panic" {
+		panic("unexpected")
+	}
+	e, ok := m.entries[key]
+	if !ok {
+		// Like the client, which panics when decoding the response
+		var view *fakeEntry
+		return view.EntryView, nil
+	}
+	return &fakeEntry{value: e.value, version: e.version, ttl: e.ttl}, nil
+}
+
+func newTestStore() (*Hazelcast, *fakeMap) {
+	m := &fakeMap{entries: map[any]*fakeEntry{}, locked: map[any]int{}}
+	return &Hazelcast{
+		hzMap:  m,
+		json:   jsoniter.ConfigFastest,
+		logger: logger.NewLogger("test"),
+	}, m
+}
+
+func TestETag(t *testing.T) {
+	store, _ := newTestStore()
+
+	res, err := store.Get(t.Context(), &state.GetRequest{Key: "key"})
+	require.NoError(t, err)
+	assert.Nil(t, res.Data)
+
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v1"}))
+	res, err = store.Get(t.Context(), &state.GetRequest{Key: "key"})
+	require.NoError(t, err)
+	assert.Equal(t, ptr.Of("0"), res.ETag)
+
+	var etagErr *state.ETagError
+	t.Run("set with an ETag", func(t *testing.T) {
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v2", ETag: ptr.Of("0")}))
+		res, err := store.Get(t.Context(), &state.GetRequest{Key: "key"})
+		require.NoError(t, err)
+		assert.Equal(t, ptr.Of("1"), res.ETag)
+
+		err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v3", ETag: ptr.Of("0")})
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		err = store.Set(t.Context(), &state.SetRequest{Key: "missing", Value: "v3", ETag: ptr.Of("0")})
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v3", ETag: ptr.Of("invalid")})
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagInvalid, etagErr.Kind())
+	})
+
+	t.Run("first write", func(t *testing.T) {
+		firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+		err := store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v3", Options: firstWrite})
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "new", Value: "v1", Options: firstWrite}))
+	})
+
+	t.Run("delete with an ETag", func(t *testing.T) {
+		err := store.Delete(t.Context(), &state.DeleteRequest{Key: "key", ETag: ptr.Of("0")})
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		require.NoError(t, store.Delete(t.Context(), &state.DeleteRequest{Key: "key", ETag: ptr.Of("1")}))
+		res, err := store.Get(t.Context(), &state.GetRequest{Key: "key"})
+		require.NoError(t, err)
+		assert.Nil(t, res.Data)
+	})
+}
+
+func TestETagConcurrency(t *testing.T) {
+	store, m := newTestStore()
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v1"}))
+
+	const writers = 20
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+	)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if i%2 == 0 {
+				err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v2", ETag: ptr.Of("0")})
+			} else {
+				err = store.Delete(t.Context(), &state.DeleteRequest{Key: "key", ETag: ptr.Of("0")})
+			}
+			if err == nil {
+				succeeded.Add(1)
+				return
+			}
+			var etagErr *state.ETagError
+			assert.ErrorAs(t, err, &etagErr)
+		}()
+	}
+	wg.Wait()
+
+	// Only one of the writes with the ETag succeeds
+	assert.Equal(t, int32(1), succeeded.Load())
+	assert.Equal(t, 1, m.maxLocked)
+	assert.Empty(t, m.locked)
+}
+
+func TestGetEntryViewPanics(t *testing.T) {
+	store, _ := newTestStore()
+
+	view, err := store.getEntryView("missing")
+	require.NoError(t, err)
+	assert.Nil(t, view)
+
+	// Panics other than the one for missing keys are not recovered
+	assert.PanicsWithValue(t, "unexpected", func() {
+		store.getEntryView("panic")
+	})
+}
+
+func TestTTL(t *testing.T) {
+	store, m := newTestStore()
+
+	err := store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v1", Metadata: map[string]string{"ttlInSeconds": "10"}})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, m.entries["key"].ttl)
+
+	err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v1", Metadata: map[string]string{"ttlInSeconds": "-1"}})
+	require.NoError(t, err)
+	assert.Zero(t, m.entries["key"].ttl)
+
+	err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v1", ETag: ptr.Of("1"), Metadata: map[string]string{"ttlInSeconds": "10"}})
+	require.ErrorIs(t, err, errTTLWithConcurrency)
+}
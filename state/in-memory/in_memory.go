@@ -18,7 +18,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"regexp"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,6 +31,7 @@ import (
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/ptr"
@@ -39,8 +40,9 @@ import (
 type InMemoryStore struct {
 	state.BulkStore
 
-	items map[string]*inMemStateStoreItem
-	idx   uint64
+	items       map[string]*inMemStateStoreItem
+	idx         uint64
+	compression compression.Codec
 
 	lock    sync.RWMutex
 	log     logger.Logger
@@ -66,6 +68,12 @@ func newStateStore(log logger.Logger) *InMemoryStore {
 }
 
 func (store *InMemoryStore) Init(ctx context.Context, metadata state.Metadata) error {
+	var err error
+	store.compression, err = compression.NewCodec(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
 	// start a background go routine to clean expired item
 	store.wg.Add(1)
 	go func() {
@@ -99,6 +107,7 @@ func (store *InMemoryStore) Features() []state.Feature {
 		state.FeatureTTL,
 		state.FeatureDeleteWithPrefix,
 		state.FeatureKeysLike,
+		state.FeatureRangeScan,
 	}
 }
 
@@ -203,7 +212,12 @@ func (store *InMemoryStore) Get(ctx context.Context, req *state.GetRequest) (*st
 		}
 	}
 
-	return &state.GetResponse{Data: item.data, ETag: item.etag, Metadata: metadata}, nil
+	data, err := compression.Decompress(item.data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state.GetResponse{Data: data, ETag: item.etag, Metadata: metadata}, nil
 }
 
 func (store *InMemoryStore) BulkGet(ctx context.Context, req []state.GetRequest, _ state.BulkGetOpts) ([]state.BulkGetResponse, error) {
@@ -221,9 +235,14 @@ func (store *InMemoryStore) BulkGet(ctx context.Context, req []state.GetRequest,
 		if item != nil && !item.isExpired(store.clock.Now()) {
 			res[i] = state.BulkGetResponse{
 				Key:  r.Key,
-				Data: item.data,
 				ETag: item.etag,
 			}
+			data, err := compression.Decompress(item.data)
+			if err != nil {
+				res[i].Error = err.Error()
+			} else {
+				res[i].Data = data
+			}
 
 			if item.expire != nil {
 				res[i].Metadata = map[string]string{
@@ -263,7 +282,7 @@ func (store *InMemoryStore) marshal(v any) (bt []byte, err error) {
 			return nil, err
 		}
 	}
-	return bt, nil
+	return store.compression.Compress(bt), nil
 }
 
 func (store *InMemoryStore) Set(ctx context.Context, req *state.SetRequest) error {
@@ -452,7 +471,7 @@ func (store *InMemoryStore) doCleanExpiredItems() {
 }
 
 func (store *InMemoryStore) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
-	// no metadata, hence no metadata struct to convert here
+	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(compression.Metadata{}), &metadataInfo, metadata.StateStoreType)
 	return
 }
 
@@ -478,7 +497,7 @@ func (store *InMemoryStore) KeysLike(ctx context.Context, req *state.KeysLikeReq
 		return nil, state.ErrKeysLikeEmptyPattern
 	}
 
-	re, err := likeToRegex(req.Pattern)
+	re, err := utils.LikeToRegex(req.Pattern)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert like pattern to regex: %w", err)
 	}
@@ -542,34 +561,53 @@ func (store *InMemoryStore) KeysLike(ctx context.Context, req *state.KeysLikeReq
 	}, nil
 }
 
-func likeToRegex(pattern string) (*regexp.Regexp, error) {
-	var b strings.Builder
-	b.Grow(len(pattern) + 4)
-	b.WriteString("^")
-
-	escaped := false
-	for _, r := range pattern {
-		if escaped {
-			b.WriteString(regexp.QuoteMeta(string(r)))
-			escaped = false
-			continue
-		}
-		switch r {
-		case '\\':
-			escaped = true
-		case '%':
-			b.WriteString(".*")
-		case '_':
-			b.WriteString(".")
-		default:
-			b.WriteString(regexp.QuoteMeta(string(r)))
+// Scan returns the items whose keys are in the requested range, in key order.
+func (store *InMemoryStore) Scan(ctx context.Context, req *state.ScanRequest) (*state.ScanResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+
+	now := store.clock.Now()
+	keys := make([]string, 0)
+	for key, item := range store.items {
+		if req.Contains(key) && !item.isExpired(now) {
+			keys = append(keys, key)
 		}
 	}
+	if req.Reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
 
-	if escaped {
-		b.WriteString(regexp.QuoteMeta(`\`))
+	res := &state.ScanResponse{}
+	//nolint:gosec
+	if req.Limit > 0 && uint32(len(keys)) > req.Limit {
+		keys = keys[:req.Limit]
+		res.ContinuationToken = ptr.Of(keys[len(keys)-1])
 	}
 
-	b.WriteString("$")
-	return regexp.Compile(b.String())
+	res.Items = make([]state.ScanItem, len(keys))
+	for i, key := range keys {
+		item := store.items[key]
+		res.Items[i] = state.ScanItem{
+			Key:  key,
+			ETag: item.etag,
+		}
+		res.Items[i].Data, err = compression.Decompress(item.data)
+		if err != nil {
+			return nil, err
+		}
+		if item.expire != nil {
+			res.Items[i].Metadata = map[string]string{
+				state.GetRespMetaKeyTTLExpireTime: item.expire.UTC().Format(time.RFC3339),
+			}
+		}
+	}
+
+	return res, nil
 }
@@ -15,6 +15,7 @@ package inmemory
 
 import (
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,7 +24,9 @@ import (
 	"github.com/stretchr/testify/require"
 	clocktesting "k8s.io/utils/clock/testing"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/kit/logger"
 )
 
@@ -178,6 +181,38 @@ func TestReadAndWrite(t *testing.T) {
 	})
 }
 
+func TestCompression(t *testing.T) {
+	store := NewInMemoryStateStore(logger.NewLogger("test")).(*InMemoryStore)
+	err := store.Init(t.Context(), state.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"compression":          "snappy",
+		"compressionThreshold": "32",
+	}}})
+	require.NoError(t, err)
+	defer store.Close()
+
+	large := []byte(strings.Repeat("value of key ", 20))
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "large", Value: large}))
+	require.NoError(t, store.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "multi", Value: large},
+			state.SetRequest{Key: "small", Value: "small"},
+		},
+	}))
+
+	assert.True(t, compression.IsCompressed(store.items["large"].data))
+	assert.True(t, compression.IsCompressed(store.items["multi"].data))
+	assert.Equal(t, []byte(`"small"`), store.items["small"].data)
+
+	resp, err := store.Get(t.Context(), &state.GetRequest{Key: "large"})
+	require.NoError(t, err)
+	assert.Equal(t, large, resp.Data)
+
+	bulkResp, err := store.BulkGet(t.Context(), []state.GetRequest{{Key: "multi"}, {Key: "small"}}, state.BulkGetOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, large, bulkResp[0].Data)
+	assert.Equal(t, []byte(`"small"`), bulkResp[1].Data)
+}
+
 func Test_KeyLike(t *testing.T) {
 	var _ state.KeysLiker = NewInMemoryStateStore(nil).(*InMemoryStore)
 }
@@ -16,9 +16,15 @@ package jetstream
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/nats-io/nats.go"
@@ -29,17 +35,36 @@ import (
 	"github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
+	"github.com/dapr/kit/ptr"
 )
 
+const (
+	// Header with the expiration time of a value, in milliseconds since the epoch.
+	expiryHeader = "Dapr-Expiry"
+	// Header set by NATS on the markers of deleted and purged keys.
+	kvOperationHeader = "KV-Operation"
+
+	defaultCleanupInterval = time.Hour
+)
+
+// Same rules as the NATS KV keys.
+var validKeyRe = regexp.MustCompile(`^[-/_=\.a-zA-Z0-9]+$`)
+
 // StateStore is a nats jetstream KV state store.
 type StateStore struct {
 	state.BulkStore
 
 	nc     *nats.Conn
+	jsc    nats.JetStreamContext
 	json   jsoniter.API
 	bucket nats.KeyValue
-	logger logger.Logger
-	closed atomic.Bool
+	// Stream and subject prefix of the bucket
+	stream        string
+	subjectPrefix string
+	logger        logger.Logger
+	closed        atomic.Bool
+	closeCh       chan struct{}
+	wg            sync.WaitGroup
 }
 
 type jetstreamMetadata struct {
@@ -48,13 +73,16 @@ type jetstreamMetadata struct {
 	Jwt     string
 	SeedKey string
 	Bucket  string
+	// Interval to purge the keys that have expired. Values <= 0 disable the periodic purge.
+	CleanupInterval *time.Duration
 }
 
 // NewJetstreamStateStore returns a new nats jetstream KV state store.
 func NewJetstreamStateStore(logger logger.Logger) state.Store {
 	s := &StateStore{
-		json:   jsoniter.ConfigFastest,
-		logger: logger,
+		json:    jsoniter.ConfigFastest,
+		logger:  logger,
+		closeCh: make(chan struct{}),
 	}
 	s.BulkStore = state.NewDefaultBulkStore(s)
 	return s
@@ -84,45 +112,571 @@ func (js *StateStore) Init(_ context.Context, metadata state.Metadata) error {
 		return err
 	}
 
-	jsc, err := js.nc.JetStream()
+	js.jsc, err = js.nc.JetStream()
 	if err != nil {
 		return err
 	}
 
-	js.bucket, err = jsc.KeyValue(meta.Bucket)
+	js.bucket, err = js.jsc.KeyValue(meta.Bucket)
 	if err != nil {
 		return err
 	}
+	js.stream = "KV_" + meta.Bucket
+	js.subjectPrefix = "$KV." + meta.Bucket + "."
+
+	if *meta.CleanupInterval > 0 {
+		js.wg.Add(1)
+		go func() {
+			defer js.wg.Done()
+			js.cleanupLoop(*meta.CleanupInterval)
+		}()
+	}
 
 	return nil
 }
 
 func (js *StateStore) Features() []state.Feature {
-	return nil
+	return []state.Feature{
+		state.FeatureETag,
+		state.FeatureTTL,
+		state.FeatureKeysLike,
+		state.FeatureTransactional,
+	}
 }
 
 // Get retrieves state with a key.
+// The ETag of a value is the revision of the key.
+// Keys that don't exist, were deleted or have expired return an empty response.
 func (js *StateStore) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
-	entry, err := js.bucket.Get(escape(req.Key))
+	subject, err := js.subject(req.Key)
 	if err != nil {
 		return nil, err
 	}
 
-	return &state.GetResponse{
-		Data: entry.Value(),
-	}, nil
+	msg, err := js.jsc.GetLastMsg(js.stream, subject, nats.Context(ctx))
+	if err != nil {
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			return &state.GetResponse{}, nil
+		}
+		return nil, err
+	}
+
+	res, live := getResponse(msg.Data, msg.Header, msg.Sequence)
+	if !live {
+		if isExpired(msg.Header) {
+			// Best effort: the value is purged unless it was updated in the meantime
+			_ = js.bucket.Purge(escape(req.Key), nats.LastRevision(msg.Sequence))
+		}
+		return &state.GetResponse{}, nil
+	}
+	return res, nil
+}
+
+// BulkGet retrieves the state of multiple keys, reading the last revision of all the keys with a single consumer.
+func (js *StateStore) BulkGet(ctx context.Context, req []state.GetRequest, _ state.BulkGetOpts) ([]state.BulkGetResponse, error) {
+	res := make([]state.BulkGetResponse, len(req))
+	if len(req) == 0 {
+		return res, nil
+	}
+
+	subjects := make([]string, 0, len(req))
+	for i, r := range req {
+		res[i].Key = r.Key
+		subject, err := js.subject(r.Key)
+		if err != nil {
+			res[i].Error = err.Error()
+			continue
+		}
+		if !slices.Contains(subjects, subject) {
+			subjects = append(subjects, subject)
+		}
+	}
+	if len(subjects) == 0 {
+		return res, nil
+	}
+
+	msgs, err := js.lastMessages(ctx, subjects, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res {
+		if res[i].Error != "" {
+			continue
+		}
+		// Keys that don't exist, were deleted or have expired have an empty response
+		msg, ok := msgs[js.subjectPrefix+escape(res[i].Key)]
+		if !ok {
+			continue
+		}
+		meta, err := msg.Metadata()
+		if err != nil {
+			return nil, err
+		}
+		item, live := getResponse(msg.Data, msg.Header, meta.Sequence.Stream)
+		if !live {
+			continue
+		}
+		res[i].Data = item.Data
+		res[i].ETag = item.ETag
+		res[i].Metadata = item.Metadata
+	}
+	return res, nil
 }
 
 // Set stores value for a key.
+// If the request has an ETag, the value is stored only if the ETag is the current revision of the key.
+// With the first-write concurrency, the value is stored only if the key doesn't exist.
+// Values with a TTL are stored with their expiration time: expired keys are not returned, and are purged from the stream periodically.
 func (js *StateStore) Set(ctx context.Context, req *state.SetRequest) error {
-	bt, _ := utils.Marshal(req.Value, js.json.Marshal)
-	_, err := js.bucket.Put(escape(req.Key), bt)
-	return err
+	msg, err := js.setMsg(req)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case req.HasETag():
+		revision, err := parseETag(*req.ETag)
+		if err != nil {
+			return err
+		}
+		_, err = js.publish(ctx, msg, revision)
+		return etagError(err)
+	case req.Options.Concurrency == state.FirstWrite:
+		return js.create(ctx, msg)
+	default:
+		_, err = js.jsc.PublishMsg(msg, nats.Context(ctx))
+		return err
+	}
+}
+
+// setMsg returns the message storing the value of a set request.
+func (js *StateStore) setMsg(req *state.SetRequest) (*nats.Msg, error) {
+	subject, err := js.subject(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := utils.ParseTTL(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing TTL: %w", err)
+	}
+
+	msg := nats.NewMsg(subject)
+	msg.Data, _ = utils.Marshal(req.Value, js.json.Marshal)
+	if ttl != nil && *ttl > 0 {
+		expiry := time.Now().Add(time.Duration(*ttl) * time.Second)
+		msg.Header.Set(expiryHeader, strconv.FormatInt(expiry.UnixMilli(), 10))
+	}
+	return msg, nil
+}
+
+// create stores a value only if its key doesn't exist, or was deleted or expired.
+func (js *StateStore) create(ctx context.Context, msg *nats.Msg) error {
+	_, err := js.publish(ctx, msg, 0)
+	if isWrongRevision(err) {
+		// The last message of the key may be a delete marker or an expired value
+		last, lastErr := js.jsc.GetLastMsg(js.stream, msg.Subject, nats.Context(ctx))
+		if lastErr == nil && !isLive(last.Header) {
+			_, err = js.publish(ctx, msg, last.Sequence)
+		}
+	}
+	return etagError(err)
+}
+
+// publish stores a value only if the last revision of its key is the expected revision, and returns its new revision.
+// The expected revision of keys that don't exist is 0.
+func (js *StateStore) publish(ctx context.Context, msg *nats.Msg, revision uint64) (uint64, error) {
+	msg.Header.Set(nats.ExpectedLastSubjSeqHdr, strconv.FormatUint(revision, 10))
+	ack, err := js.jsc.PublishMsg(msg, nats.Context(ctx))
+	if err != nil {
+		return 0, err
+	}
+	return ack.Sequence, nil
 }
 
 // Delete performs a delete operation.
+// If the request has an ETag, the key is deleted only if the ETag is its current revision.
 func (js *StateStore) Delete(ctx context.Context, req *state.DeleteRequest) error {
-	return js.bucket.Delete(escape(req.Key))
+	var opts []nats.DeleteOpt
+	if req.HasETag() {
+		revision, err := parseETag(*req.ETag)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, nats.LastRevision(revision))
+	}
+
+	return etagError(js.bucket.Delete(escape(req.Key), opts...))
+}
+
+// txOperation is an operation of a transaction.
+type txOperation struct {
+	msg        *nats.Msg
+	isDelete   bool
+	etag       *uint64
+	firstWrite bool
+}
+
+// txKey is the state of a key written by a transaction.
+type txKey struct {
+	// Last message of the key before the transaction, nil if the key didn't exist
+	original *nats.RawStreamMsg
+	// Last revision of the key, and whether it holds a value, after the previous operations
+	revision uint64
+	live     bool
+	written  bool
+}
+
+// Multi performs the operations of a transaction in order.
+// JetStream KV has no multi-key transactions: each write is conditional on the revision of its key when the transaction read it, or on the revision written by a previous operation, so the transaction fails if another client updates one of its keys.
+// If an operation fails, the keys already written are restored to their value before the transaction, unless they were updated in the meantime, and the error is returned.
+// Restored values are stored with a new revision, so ETags read before the transaction don't match them anymore.
+func (js *StateStore) Multi(ctx context.Context, request *state.TransactionalStateRequest) error {
+	if len(request.Operations) == 0 {
+		return nil
+	}
+
+	// Validate all the operations before writing
+	ops := make([]txOperation, len(request.Operations))
+	for i, o := range request.Operations {
+		var (
+			etag *string
+			err  error
+		)
+		switch req := o.(type) {
+		case state.SetRequest:
+			ops[i].msg, err = js.setMsg(&req)
+			ops[i].firstWrite = req.Options.Concurrency == state.FirstWrite
+			etag = req.ETag
+		case state.DeleteRequest:
+			var subject string
+			subject, err = js.subject(req.Key)
+			ops[i].msg = nats.NewMsg(subject)
+			ops[i].msg.Header.Set(kvOperationHeader, "DEL")
+			ops[i].isDelete = true
+			etag = req.ETag
+		default:
+			err = fmt.Errorf("unsupported operation type %T", o)
+		}
+		if err != nil {
+			return err
+		}
+		if etag != nil && *etag != "" {
+			revision, err := parseETag(*etag)
+			if err != nil {
+				return err
+			}
+			ops[i].etag = &revision
+		}
+	}
+
+	keys := map[string]*txKey{}
+	var err error
+	for _, op := range ops {
+		k, ok := keys[op.msg.Subject]
+		if !ok {
+			k, err = js.readTxKey(ctx, op.msg.Subject)
+			if err != nil {
+				break
+			}
+			keys[op.msg.Subject] = k
+		}
+		err = js.applyTxOperation(ctx, k, op)
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		return errors.Join(err, js.rollback(context.WithoutCancel(ctx), keys))
+	}
+	return nil
+}
+
+// readTxKey reads the last message of a key at the beginning of a transaction.
+func (js *StateStore) readTxKey(ctx context.Context, subject string) (*txKey, error) {
+	msg, err := js.jsc.GetLastMsg(js.stream, subject, nats.Context(ctx))
+	if errors.Is(err, nats.ErrMsgNotFound) {
+		return &txKey{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &txKey{
+		original: msg,
+		revision: msg.Sequence,
+		live:     isLive(msg.Header),
+	}, nil
+}
+
+// applyTxOperation performs an operation of a transaction, if the key is still at the revision expected by the transaction.
+func (js *StateStore) applyTxOperation(ctx context.Context, k *txKey, op txOperation) error {
+	switch {
+	case op.etag != nil && (!k.live || *op.etag != k.revision):
+		return state.NewETagError(state.ETagMismatch, fmt.Errorf("the revision of key %s is not %d", js.key(op.msg.Subject), *op.etag))
+	case op.firstWrite && k.live:
+		return state.NewETagError(state.ETagMismatch, fmt.Errorf("key %s already exists", js.key(op.msg.Subject)))
+	case op.isDelete && !k.live:
+		// Nothing to delete
+		return nil
+	}
+
+	revision, err := js.publish(ctx, op.msg, k.revision)
+	if err != nil {
+		return etagError(err)
+	}
+	k.revision = revision
+	k.live = !op.isDelete
+	k.written = true
+	return nil
+}
+
+// rollback restores the keys written by a failed transaction to their value before the transaction.
+// Keys updated since the transaction wrote them are left unchanged.
+func (js *StateStore) rollback(ctx context.Context, keys map[string]*txKey) error {
+	var errs []error
+	for subject, k := range keys {
+		if !k.written {
+			continue
+		}
+
+		msg := nats.NewMsg(subject)
+		if k.original != nil && isLive(k.original.Header) {
+			msg.Data = k.original.Data
+			if v := k.original.Header.Get(expiryHeader); v != "" {
+				msg.Header.Set(expiryHeader, v)
+			}
+		} else {
+			msg.Header.Set(kvOperationHeader, "DEL")
+		}
+
+		_, err := js.publish(ctx, msg, k.revision)
+		if err != nil && !isWrongRevision(err) {
+			errs = append(errs, fmt.Errorf("failed to restore key %s: %w", js.key(subject), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// KeysLike returns the keys matching a SQL LIKE pattern, sorted.
+// Keys are listed with a subject filter on the whole tokens of the literal prefix of the pattern.
+// The continuation token is the last key of the previous page.
+func (js *StateStore) KeysLike(ctx context.Context, req *state.KeysLikeRequest) (*state.KeysLikeResponse, error) {
+	if len(req.Pattern) == 0 {
+		return nil, state.ErrKeysLikeEmptyPattern
+	}
+
+	re, err := utils.LikeToRegex(req.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert like pattern to regex: %w", err)
+	}
+
+	filter := js.subjectPrefix + ">"
+	prefix := escape(utils.LikeLiteralPrefix(req.Pattern))
+	if i := strings.LastIndexByte(prefix, '.'); i > 0 && validKeyRe.MatchString(prefix[:i]) {
+		filter = js.subjectPrefix + prefix[:i] + ".>"
+	}
+
+	msgs, err := js.lastMessages(ctx, []string{filter}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(msgs))
+	for subject, msg := range msgs {
+		if !isLive(msg.Header) {
+			continue
+		}
+		key := js.key(subject)
+		if req.ContinuationToken != nil && key <= *req.ContinuationToken {
+			continue
+		}
+		if re.MatchString(key) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	res := &state.KeysLikeResponse{Keys: keys}
+	if req.PageSize != nil && *req.PageSize > 0 && len(keys) > int(*req.PageSize) {
+		res.Keys = keys[:*req.PageSize]
+		res.ContinuationToken = ptr.Of(res.Keys[len(res.Keys)-1])
+	}
+	return res, nil
+}
+
+// cleanupLoop purges the keys that have expired periodically, until the store is closed.
+func (js *StateStore) cleanupLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			n, err := js.purgeExpired(ctx)
+			cancel()
+			if err != nil {
+				js.logger.Errorf("Failed to purge expired keys: %v", err)
+			} else if n > 0 {
+				js.logger.Debugf("Purged %d expired keys", n)
+			}
+		case <-js.closeCh:
+			return
+		}
+	}
+}
+
+// purgeExpired purges the keys whose last value has expired, removing all their messages from the stream, and returns the number of keys purged.
+// Keys that are updated in the meantime are not purged.
+func (js *StateStore) purgeExpired(ctx context.Context) (int, error) {
+	msgs, err := js.lastMessages(ctx, []string{js.subjectPrefix + ">"}, true)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for subject, msg := range msgs {
+		if msg.Header.Get(kvOperationHeader) != "" || !isExpired(msg.Header) {
+			continue
+		}
+		meta, err := msg.Metadata()
+		if err != nil {
+			return n, err
+		}
+		err = js.bucket.Purge(strings.TrimPrefix(subject, js.subjectPrefix), nats.LastRevision(meta.Sequence.Stream))
+		if isWrongRevision(err) {
+			continue
+		} else if err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// lastMessages returns the last message of the subjects matching the filters, by subject.
+// The messages are read with an ordered consumer, with only their headers if headersOnly is true.
+func (js *StateStore) lastMessages(ctx context.Context, filters []string, headersOnly bool) (map[string]*nats.Msg, error) {
+	opts := []nats.SubOpt{
+		nats.BindStream(js.stream),
+		nats.ConsumerFilterSubjects(filters...),
+		nats.OrderedConsumer(),
+		nats.DeliverLastPerSubject(),
+		nats.Context(ctx),
+	}
+	if headersOnly {
+		opts = append(opts, nats.HeadersOnly())
+	}
+	sub, err := js.jsc.SubscribeSync("", opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	msgs := map[string]*nats.Msg{}
+	info, err := sub.ConsumerInfo()
+	if err != nil {
+		return nil, err
+	}
+	if info.NumPending == 0 && info.Delivered.Consumer == 0 {
+		return msgs, nil
+	}
+
+	for {
+		msg, err := sub.NextMsgWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		msgs[msg.Subject] = msg
+
+		meta, err := msg.Metadata()
+		if err != nil {
+			return nil, err
+		}
+		if meta.NumPending == 0 {
+			return msgs, nil
+		}
+	}
+}
+
+// subject returns the subject of the messages of a key.
+func (js *StateStore) subject(key string) (string, error) {
+	key = escape(key)
+	if !validKeyRe.MatchString(key) || strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") {
+		return "", nats.ErrInvalidKey
+	}
+	return js.subjectPrefix + key, nil
+}
+
+// key returns the key of a subject.
+func (js *StateStore) key(subject string) string {
+	return unescape(strings.TrimPrefix(subject, js.subjectPrefix))
+}
+
+// getResponse returns the response for the message of a key, and false if the key was deleted or expired.
+func getResponse(data []byte, header nats.Header, revision uint64) (*state.GetResponse, bool) {
+	if !isLive(header) {
+		return nil, false
+	}
+
+	res := &state.GetResponse{
+		Data: data,
+		ETag: ptr.Of(strconv.FormatUint(revision, 10)),
+	}
+	if expiry, ok := expiryTime(header); ok {
+		res.Metadata = map[string]string{
+			state.GetRespMetaKeyTTLExpireTime: expiry.UTC().Format(time.RFC3339),
+		}
+	}
+	return res, true
+}
+
+// isLive returns false if the message of a key is a delete marker or has expired.
+func isLive(header nats.Header) bool {
+	switch header.Get(kvOperationHeader) {
+	case "DEL", "PURGE":
+		return false
+	}
+	return !isExpired(header)
+}
+
+func isExpired(header nats.Header) bool {
+	expiry, ok := expiryTime(header)
+	return ok && !time.Now().Before(expiry)
+}
+
+func expiryTime(header nats.Header) (time.Time, bool) {
+	v := header.Get(expiryHeader)
+	if v == "" {
+		return time.Time{}, false
+	}
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms), true
+}
+
+// parseETag returns the revision of a key from its ETag.
+func parseETag(etag string) (uint64, error) {
+	revision, err := strconv.ParseUint(etag, 10, 64)
+	if err != nil {
+		return 0, state.NewETagError(state.ETagInvalid, err)
+	}
+	return revision, nil
+}
+
+// etagError returns an ETag mismatch error if a write failed because the last revision of the key isn't the expected one.
+func etagError(err error) error {
+	if isWrongRevision(err) {
+		return state.NewETagError(state.ETagMismatch, err)
+	}
+	return err
+}
+
+// isWrongRevision returns true if a write failed because the last revision of the key isn't the expected one.
+func isWrongRevision(err error) bool {
+	var apiErr *nats.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == nats.JSErrCodeStreamWrongLastSequence
 }
 
 func (js *StateStore) getMetadata(meta state.Metadata) (jetstreamMetadata, error) {
@@ -152,6 +706,10 @@ func (js *StateStore) getMetadata(meta state.Metadata) (jetstreamMetadata, error
 		return jetstreamMetadata{}, errors.New("missing bucket")
 	}
 
+	if m.CleanupInterval == nil {
+		m.CleanupInterval = ptr.Of(defaultCleanupInterval)
+	}
+
 	return m, nil
 }
 
@@ -173,6 +731,12 @@ func escape(key string) string {
 	return strings.ReplaceAll(key, "||", ".")
 }
 
+// Unescape the keys of the bucket.
+// Keys that contained dots before being escaped can't be told apart from keys that contained ||, and are returned with ||.
+func unescape(key string) string {
+	return strings.ReplaceAll(key, ".", "||")
+}
+
 func (js *StateStore) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 	metadataStruct := jetstreamMetadata{}
 	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(metadataStruct), &metadataInfo, metadata.StateStoreType)
@@ -180,8 +744,12 @@ func (js *StateStore) GetComponentMetadata() (metadataInfo metadata.MetadataMap)
 }
 
 func (js *StateStore) Close() error {
-	if js.closed.CompareAndSwap(false, true) && js.nc != nil {
-		js.nc.Close()
+	if js.closed.CompareAndSwap(false, true) {
+		close(js.closeCh)
+		js.wg.Wait()
+		if js.nc != nil {
+			js.nc.Close()
+		}
 	}
 	return nil
 }
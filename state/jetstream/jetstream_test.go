@@ -18,13 +18,17 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/nats-io/nats-server/v2/server"
 	natsserver "github.com/nats-io/nats-server/v2/test"
 	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/ptr"
 )
 
 type tLogger interface {
@@ -142,11 +146,382 @@ func TestSetGetAndDelete(t *testing.T) {
 		return
 	}
 
-	_, err = store.Get(t.Context(), &state.GetRequest{
+	resp, err = store.Get(t.Context(), &state.GetRequest{
 		Key: tkey,
 	})
-	if err == nil {
+	if err != nil {
+		t.Fatalf("Could not get after delete: %v\n", err)
+		return
+	}
+	if resp.Data != nil {
 		t.Fatal("Could get after delete\n")
 		return
 	}
 }
+
+func newTestStore(t *testing.T) state.Store {
+	t.Helper()
+
+	opts := natsserver.DefaultTestOptions
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	s := runServerWithOptions(opts)
+	t.Cleanup(s.Shutdown)
+
+	_, nc := connectAndCreateBucket(t)
+	nc.Close()
+
+	store := NewJetstreamStateStore(nil)
+	err := store.Init(t.Context(), state.Metadata{
+		Base: metadata.Base{Properties: map[string]string{
+			"natsURL": nats.DefaultURL,
+			"bucket":  "test",
+		}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestETag(t *testing.T) {
+	store := newTestStore(t)
+
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||key", Value: "v1"}))
+	res, err := store.Get(t.Context(), &state.GetRequest{Key: "app||key"})
+	require.NoError(t, err)
+	require.NotNil(t, res.ETag)
+	etag := *res.ETag
+
+	t.Run("set with the current ETag", func(t *testing.T) {
+		err := store.Set(t.Context(), &state.SetRequest{Key: "app||key", Value: "v2", ETag: &etag})
+		require.NoError(t, err)
+
+		res, err := store.Get(t.Context(), &state.GetRequest{Key: "app||key"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `"v2"`, string(res.Data))
+		assert.NotEqual(t, etag, *res.ETag)
+	})
+
+	t.Run("set with an outdated ETag", func(t *testing.T) {
+		err := store.Set(t.Context(), &state.SetRequest{Key: "app||key", Value: "v3", ETag: &etag})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+	})
+
+	t.Run("invalid ETag", func(t *testing.T) {
+		err := store.Set(t.Context(), &state.SetRequest{Key: "app||key", Value: "v3", ETag: ptr.Of("invalid")})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagInvalid, etagErr.Kind())
+	})
+
+	t.Run("delete with an ETag", func(t *testing.T) {
+		err := store.Delete(t.Context(), &state.DeleteRequest{Key: "app||key", ETag: &etag})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		res, err := store.Get(t.Context(), &state.GetRequest{Key: "app||key"})
+		require.NoError(t, err)
+		require.NoError(t, store.Delete(t.Context(), &state.DeleteRequest{Key: "app||key", ETag: res.ETag}))
+
+		res, err = store.Get(t.Context(), &state.GetRequest{Key: "app||key"})
+		require.NoError(t, err)
+		assert.Nil(t, res.Data)
+		assert.Nil(t, res.ETag)
+	})
+
+	t.Run("first write", func(t *testing.T) {
+		firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||new", Value: "v1", Options: firstWrite}))
+
+		err := store.Set(t.Context(), &state.SetRequest{Key: "app||new", Value: "v2", Options: firstWrite})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		// Deleted keys can be written again
+		require.NoError(t, store.Delete(t.Context(), &state.DeleteRequest{Key: "app||new"}))
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||new", Value: "v3", Options: firstWrite}))
+	})
+}
+
+func TestMulti(t *testing.T) {
+	store := newTestStore(t)
+	tx := store.(state.TransactionalStore)
+
+	get := func(t *testing.T, key string) *state.GetResponse {
+		t.Helper()
+		res, err := store.Get(t.Context(), &state.GetRequest{Key: key})
+		require.NoError(t, err)
+		return res
+	}
+
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||a", Value: "a1"}))
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||b", Value: "b1"}))
+
+	t.Run("commit", func(t *testing.T) {
+		etag := get(t, "app||a").ETag
+		err := tx.Multi(t.Context(), &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.SetRequest{Key: "app||a", Value: "a2", ETag: etag},
+				state.DeleteRequest{Key: "app||b"},
+				state.SetRequest{Key: "app||c", Value: "c1", Options: state.SetStateOption{Concurrency: state.FirstWrite}},
+				state.DeleteRequest{Key: "app||missing"},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `"a2"`, string(get(t, "app||a").Data))
+		assert.Nil(t, get(t, "app||b").Data)
+		assert.JSONEq(t, `"c1"`, string(get(t, "app||c").Data))
+	})
+
+	t.Run("rollback on ETag mismatch", func(t *testing.T) {
+		err := tx.Multi(t.Context(), &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.SetRequest{Key: "app||a", Value: "a3"},
+				state.DeleteRequest{Key: "app||c"},
+				state.SetRequest{Key: "app||b", Value: "b2"},
+				state.SetRequest{Key: "app||c", Value: "c2", ETag: ptr.Of("1")},
+			},
+		})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		assert.JSONEq(t, `"a2"`, string(get(t, "app||a").Data))
+		assert.Nil(t, get(t, "app||b").Data)
+		assert.JSONEq(t, `"c1"`, string(get(t, "app||c").Data))
+	})
+
+	t.Run("rollback on first write", func(t *testing.T) {
+		err := tx.Multi(t.Context(), &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.SetRequest{Key: "app||d", Value: "d1"},
+				state.SetRequest{Key: "app||a", Value: "a3", Options: state.SetStateOption{Concurrency: state.FirstWrite}},
+			},
+		})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		assert.Nil(t, get(t, "app||d").Data)
+		assert.JSONEq(t, `"a2"`, string(get(t, "app||a").Data))
+	})
+
+	t.Run("TTL is restored", func(t *testing.T) {
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||ttl", Value: "t1", Metadata: map[string]string{"ttlInSeconds": "1000"}}))
+		expiry := get(t, "app||ttl").Metadata[state.GetRespMetaKeyTTLExpireTime]
+		require.NotEmpty(t, expiry)
+
+		err := tx.Multi(t.Context(), &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.SetRequest{Key: "app||ttl", Value: "t2"},
+				state.DeleteRequest{Key: "app||a", ETag: ptr.Of("1")},
+			},
+		})
+		require.Error(t, err)
+
+		res := get(t, "app||ttl")
+		assert.JSONEq(t, `"t1"`, string(res.Data))
+		assert.Equal(t, expiry, res.Metadata[state.GetRespMetaKeyTTLExpireTime])
+	})
+
+	t.Run("invalid operations are rejected before writing", func(t *testing.T) {
+		err := tx.Multi(t.Context(), &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.SetRequest{Key: "app||a", Value: "a3"},
+				state.DeleteRequest{Key: "app||a", ETag: ptr.Of("invalid")},
+			},
+		})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagInvalid, etagErr.Kind())
+
+		err = tx.Multi(t.Context(), &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.SetRequest{Key: "app||a", Value: "a3"},
+				state.SetRequest{Key: "invalid key", Value: "x"},
+			},
+		})
+		require.ErrorIs(t, err, nats.ErrInvalidKey)
+
+		res := get(t, "app||a")
+		assert.JSONEq(t, `"a2"`, string(res.Data))
+	})
+}
+
+func TestTTL(t *testing.T) {
+	store := newTestStore(t)
+
+	err := store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v1", Metadata: map[string]string{"ttlInSeconds": "1"}})
+	require.NoError(t, err)
+	err = store.Set(t.Context(), &state.SetRequest{Key: "other", Value: "v1", Metadata: map[string]string{"ttlInSeconds": "-1"}})
+	require.NoError(t, err)
+
+	res, err := store.Get(t.Context(), &state.GetRequest{Key: "key"})
+	require.NoError(t, err)
+	expiry, err := time.Parse(time.RFC3339, res.Metadata[state.GetRespMetaKeyTTLExpireTime])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Second), expiry, 2*time.Second)
+
+	err = store.Set(t.Context(), &state.SetRequest{Key: "key", Metadata: map[string]string{"ttlInSeconds": "invalid"}})
+	require.ErrorContains(t, err, "ttlInSeconds")
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		res, err := store.Get(t.Context(), &state.GetRequest{Key: "key"})
+		if assert.NoError(c, err) {
+			assert.Nil(c, res.Data)
+		}
+	}, 3*time.Second, 100*time.Millisecond)
+
+	res, err = store.Get(t.Context(), &state.GetRequest{Key: "other"})
+	require.NoError(t, err)
+	assert.Empty(t, res.Metadata)
+
+	// Expired keys can be written again with the first-write concurrency
+	err = store.Set(t.Context(), &state.SetRequest{Key: "key", Value: "v2", Options: state.SetStateOption{Concurrency: state.FirstWrite}})
+	require.NoError(t, err)
+}
+
+func TestKeysLike(t *testing.T) {
+	store := newTestStore(t)
+	keysLiker := store.(state.KeysLiker)
+
+	for _, key := range []string{"app||a2", "app||a1", "app||b1", "app||a3", "other||a1", "app_a4"} {
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: key, Value: "v"}))
+	}
+	require.NoError(t, store.Delete(t.Context(), &state.DeleteRequest{Key: "app||a3"}))
+
+	res, err := keysLiker.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "app||a%"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app||a1", "app||a2"}, res.Keys)
+	assert.Nil(t, res.ContinuationToken)
+
+	res, err = keysLiker.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "%||a_"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app||a1", "app||a2", "other||a1"}, res.Keys)
+
+	res, err = keysLiker.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: `app\_%`})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app_a4"}, res.Keys)
+
+	t.Run("pagination", func(t *testing.T) {
+		res, err := keysLiker.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "app||%", PageSize: ptr.Of[uint32](2)})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app||a1", "app||a2"}, res.Keys)
+		require.NotNil(t, res.ContinuationToken)
+
+		res, err = keysLiker.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "app||%", PageSize: ptr.Of[uint32](2), ContinuationToken: res.ContinuationToken})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"app||b1"}, res.Keys)
+		assert.Nil(t, res.ContinuationToken)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		res, err := keysLiker.KeysLike(t.Context(), &state.KeysLikeRequest{Pattern: "missing||%"})
+		require.NoError(t, err)
+		assert.Empty(t, res.Keys)
+	})
+
+	t.Run("empty pattern", func(t *testing.T) {
+		_, err := keysLiker.KeysLike(t.Context(), &state.KeysLikeRequest{})
+		require.ErrorIs(t, err, state.ErrKeysLikeEmptyPattern)
+	})
+}
+
+func TestBulkGet(t *testing.T) {
+	store := newTestStore(t)
+
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||a", Value: "va"}))
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||b", Value: "vb"}))
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||b", Value: "vb2"}))
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "app||deleted", Value: "v"}))
+	require.NoError(t, store.Delete(t.Context(), &state.DeleteRequest{Key: "app||deleted"}))
+
+	res, err := store.BulkGet(t.Context(), []state.GetRequest{
+		{Key: "app||b"},
+		{Key: "app||missing"},
+		{Key: "app||a"},
+		{Key: "app||deleted"},
+		{Key: "invalid key"},
+	}, state.BulkGetOpts{})
+	require.NoError(t, err)
+	require.Len(t, res, 5)
+
+	assert.Equal(t, "app||b", res[0].Key)
+	assert.JSONEq(t, `"vb2"`, string(res[0].Data))
+	get, err := store.Get(t.Context(), &state.GetRequest{Key: "app||b"})
+	require.NoError(t, err)
+	assert.Equal(t, get.ETag, res[0].ETag)
+
+	// Missing and deleted keys have an empty response
+	assert.Equal(t, state.BulkGetResponse{Key: "app||missing"}, res[1])
+	assert.JSONEq(t, `"va"`, string(res[2].Data))
+	assert.Equal(t, state.BulkGetResponse{Key: "app||deleted"}, res[3])
+	assert.Equal(t, nats.ErrInvalidKey.Error(), res[4].Error)
+
+	res, err = store.BulkGet(t.Context(), []state.GetRequest{{Key: "app||missing"}}, state.BulkGetOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, state.BulkGetResponse{Key: "app||missing"}, res[0])
+}
+
+func TestPurgeExpired(t *testing.T) {
+	store := newTestStore(t).(*StateStore)
+
+	ttl := map[string]string{"ttlInSeconds": "1"}
+	for i := range 3 {
+		require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "expiring", Value: i, Metadata: ttl}))
+	}
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "updated", Value: "v1", Metadata: ttl}))
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "persistent", Value: "v1"}))
+
+	// Keys that haven't expired are not purged
+	n, err := store.purgeExpired(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	time.Sleep(1100 * time.Millisecond)
+	require.NoError(t, store.Set(t.Context(), &state.SetRequest{Key: "updated", Value: "v2"}))
+
+	n, err = store.purgeExpired(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// The messages of the expired key are removed from the stream, leaving only the purge marker
+	info, err := store.jsc.StreamInfo(store.stream, &nats.StreamInfoRequest{SubjectsFilter: store.subjectPrefix + ">"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{
+		store.subjectPrefix + "expiring":   1,
+		store.subjectPrefix + "updated":    1,
+		store.subjectPrefix + "persistent": 1,
+	}, info.State.Subjects)
+	msg, err := store.jsc.GetLastMsg(store.stream, store.subjectPrefix+"expiring")
+	require.NoError(t, err)
+	assert.Equal(t, "PURGE", msg.Header.Get(kvOperationHeader))
+	assert.Empty(t, msg.Data)
+
+	res, err := store.Get(t.Context(), &state.GetRequest{Key: "updated"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"v2"`, string(res.Data))
+}
+
+func TestCleanupInterval(t *testing.T) {
+	store := &StateStore{}
+	md, err := store.getMetadata(state.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"natsURL": nats.DefaultURL,
+		"bucket":  "test",
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, *md.CleanupInterval)
+
+	md, err = store.getMetadata(state.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"natsURL":         nats.DefaultURL,
+		"bucket":          "test",
+		"cleanupInterval": "-1",
+	}}})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, *md.CleanupInterval, time.Duration(0))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/azure/cosmosdb"
+	"github.com/dapr/components-contrib/state/cockroachdb"
+	"github.com/dapr/components-contrib/state/etcd"
+	"github.com/dapr/components-contrib/state/mongodb"
+	"github.com/dapr/components-contrib/state/mysql"
+	postgresqlv1 "github.com/dapr/components-contrib/state/postgresql/v1"
+	postgresqlv2 "github.com/dapr/components-contrib/state/postgresql/v2"
+	"github.com/dapr/components-contrib/state/redis"
+	"github.com/dapr/components-contrib/state/sqlite"
+	"github.com/dapr/components-contrib/state/sqlserver"
+	sqlserverv2 "github.com/dapr/components-contrib/state/sqlserver/v2"
+	"github.com/dapr/kit/logger"
+)
+
+// Supported state stores, keyed by "type/version"
+var stores = map[string]func(logger.Logger) state.Store{
+	"state.azure.cosmosdb/v1": cosmosdb.NewCosmosDBStateStore,
+	"state.cockroachdb/v1":    cockroachdb.New,
+	"state.etcd/v1":           etcd.NewEtcdStateStoreV1,
+	"state.etcd/v2":           etcd.NewEtcdStateStoreV2,
+	"state.mongodb/v1":        mongodb.NewMongoDB,
+	"state.mysql/v1":          mysql.NewMySQLStateStore,
+	"state.postgresql/v1":     postgresqlv1.NewPostgreSQLStateStore,
+	"state.postgresql/v2":     postgresqlv2.NewPostgreSQLStateStore,
+	"state.redis/v1":          redis.NewRedisStateStore,
+	"state.sqlite/v1":         sqlite.NewSQLiteStateStore,
+	"state.sqlserver/v1":      sqlserver.New,
+	"state.sqlserver/v2":      sqlserverv2.New,
+}
+
+// component is a Dapr component definition.
+type component struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Type     string `json:"type"`
+		Version  string `json:"version"`
+		Metadata []struct {
+			Name         string `json:"name"`
+			Value        any    `json:"value"`
+			EnvRef       string `json:"envRef"`
+			SecretKeyRef any    `json:"secretKeyRef"`
+		} `json:"metadata"`
+	} `json:"spec"`
+}
+
+// loadComponent reads the definition of a Dapr component from a YAML file, and returns the initialized state store.
+func loadComponent(ctx context.Context, path string, log logger.Logger) (state.Store, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var comp component
+	err = yaml.Unmarshal(data, &comp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid component file %s: %w", path, err)
+	}
+	if comp.Kind != "" && comp.Kind != "Component" {
+		return nil, fmt.Errorf("invalid component file %s: kind must be 'Component', but it's '%s'", path, comp.Kind)
+	}
+
+	version := comp.Spec.Version
+	if version == "" {
+		version = "v1"
+	}
+	newStore, ok := stores[comp.Spec.Type+"/"+version]
+	if !ok {
+		supported := make([]string, 0, len(stores))
+		for k := range stores {
+			supported = append(supported, k)
+		}
+		slices.Sort(supported)
+		return nil, fmt.Errorf("unsupported state store '%s' (version %s): supported state stores are %s", comp.Spec.Type, version, strings.Join(supported, ", "))
+	}
+
+	props := make(map[string]string, len(comp.Spec.Metadata))
+	for _, md := range comp.Spec.Metadata {
+		switch {
+		case md.SecretKeyRef != nil:
+			return nil, fmt.Errorf("metadata property '%s' uses a secret reference, which is not supported: use envRef instead", md.Name)
+		case md.EnvRef != "":
+			props[md.Name] = os.Getenv(md.EnvRef)
+		case md.Value != nil:
+			props[md.Name] = fmt.Sprint(md.Value)
+		default:
+			props[md.Name] = ""
+		}
+	}
+
+	store := newStore(log)
+	err = store.Init(ctx, state.Metadata{
+		Base: metadata.Base{
+			Name:       comp.Metadata.Name,
+			Properties: props,
+		},
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to initialize state store %s: %w", comp.Metadata.Name, err), store.Close())
+	}
+	return store, nil
+}
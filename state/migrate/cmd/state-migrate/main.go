@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// state-migrate backs up, restores and copies the items of Dapr state stores.
+//
+// Usage:
+//
+//	state-migrate export -component <file> -output <file> [-checkpoint <file>] [-prefix <prefix>] [-batch-size <n>]
+//	state-migrate import -component <file> -input <file> [-checkpoint <file>] [-transactional] [-batch-size <n>]
+//	state-migrate copy -source <file> -destination <file> [-checkpoint <file>] [-prefix <prefix>] [-transactional] [-batch-size <n>]
+//
+// State stores are configured with Dapr component files. Secret references are not supported, but values can be read from environment variables with envRef.
+// If a checkpoint file is set, the progress is saved after each batch, and interrupted operations are resumed when they're run again with the same options.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	commonutils "github.com/dapr/components-contrib/common/utils"
+	"github.com/dapr/components-contrib/state/migrate"
+	"github.com/dapr/kit/logger"
+)
+
+const usage = `Usage:
+  state-migrate export -component <file> -output <file> [options]
+  state-migrate import -component <file> -input <file> [options]
+  state-migrate copy -source <file> -destination <file> [options]
+
+Run "state-migrate <command> -help" for the list of options.
+`
+
+func main() {
+	log := logger.NewLogger("state-migrate")
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(ctx, os.Args[2:], log)
+	case "import":
+		err = runImport(ctx, os.Args[2:], log)
+	case "copy":
+		err = runCopy(ctx, os.Args[2:], log)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+type commonFlags struct {
+	checkpoint    string
+	batchSize     int
+	prefix        string
+	transactional bool
+}
+
+func (c *commonFlags) register(fs *flag.FlagSet, read bool, write bool) {
+	fs.StringVar(&c.checkpoint, "checkpoint", "", "File where the progress is saved, to resume the operation if it's interrupted")
+	fs.IntVar(&c.batchSize, "batch-size", migrate.DefaultBatchSize, "Number of items read and written at a time")
+	if read {
+		fs.StringVar(&c.prefix, "prefix", "", "Only copy keys starting with this prefix")
+	}
+	if write {
+		fs.BoolVar(&c.transactional, "transactional", false, "Write each batch in a transaction, if the state store supports it")
+	}
+}
+
+// options returns the options for the operation, resuming from the checkpoint file if it exists.
+// The before function, if set, is invoked before saving each checkpoint.
+func (c *commonFlags) options(before func() error) (migrate.Options, error) {
+	opts := migrate.Options{
+		BatchSize:     c.batchSize,
+		KeyPrefix:     c.prefix,
+		Transactional: c.transactional,
+	}
+	if c.checkpoint == "" {
+		return opts, nil
+	}
+
+	data, err := os.ReadFile(c.checkpoint)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// Nop
+	case err != nil:
+		return opts, fmt.Errorf("failed to read checkpoint: %w", err)
+	default:
+		err = json.Unmarshal(data, &opts.Checkpoint)
+		if err != nil {
+			return opts, fmt.Errorf("%w: %w", migrate.ErrInvalidCheckpoint, err)
+		}
+	}
+
+	opts.OnCheckpoint = func(cp migrate.Checkpoint) error {
+		if before != nil {
+			err := before()
+			if err != nil {
+				return err
+			}
+		}
+		return saveCheckpoint(c.checkpoint, cp)
+	}
+	return opts, nil
+}
+
+// saveCheckpoint replaces the checkpoint file atomically, so it is never partially written.
+func saveCheckpoint(path string, cp migrate.Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	err = commonutils.WriteFileAtomic(path, data)
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func runExport(ctx context.Context, args []string, log logger.Logger) error {
+	var (
+		flags     commonFlags
+		compFile  string
+		outFile   string
+		outHandle *os.File
+	)
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.StringVar(&compFile, "component", "", "Component file of the state store to back up (required)")
+	fs.StringVar(&outFile, "output", "", "File where the NDJSON backup is written (required)")
+	flags.register(fs, true, false)
+	_ = fs.Parse(args)
+	if compFile == "" || outFile == "" {
+		return errors.New("the -component and -output flags are required")
+	}
+
+	// Data is flushed to disk before each checkpoint is saved
+	opts, err := flags.options(func() error {
+		return outHandle.Sync()
+	})
+	if err != nil {
+		return err
+	}
+	if opts.Checkpoint.Done {
+		log.Infof("Export was already completed with %d records", opts.Checkpoint.Records)
+		return nil
+	}
+
+	store, err := loadComponent(ctx, compFile, log)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	// When resuming, records written after the last checkpoint are discarded
+	outHandle, err = os.OpenFile(outFile, os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	defer outHandle.Close()
+	err = outHandle.Truncate(opts.Checkpoint.Offset)
+	if err != nil {
+		return err
+	}
+	_, err = outHandle.Seek(opts.Checkpoint.Offset, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	cp, err := migrate.Export(ctx, store, outHandle, opts)
+	if err != nil {
+		return fmt.Errorf("export failed after %d records: %w", cp.Records, err)
+	}
+	log.Infof("Exported %d records to %s", cp.Records, outFile)
+	return outHandle.Sync()
+}
+
+func runImport(ctx context.Context, args []string, log logger.Logger) error {
+	var (
+		flags    commonFlags
+		compFile string
+		inFile   string
+	)
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	fs.StringVar(&compFile, "component", "", "Component file of the state store to restore the backup into (required)")
+	fs.StringVar(&inFile, "input", "", "NDJSON backup file to restore (required)")
+	flags.register(fs, false, true)
+	_ = fs.Parse(args)
+	if compFile == "" || inFile == "" {
+		return errors.New("the -component and -input flags are required")
+	}
+
+	opts, err := flags.options(nil)
+	if err != nil {
+		return err
+	}
+	if opts.Checkpoint.Done {
+		log.Infof("Import was already completed with %d records", opts.Checkpoint.Records)
+		return nil
+	}
+
+	store, err := loadComponent(ctx, compFile, log)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	in, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	_, err = in.Seek(opts.Checkpoint.Offset, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	cp, err := migrate.Import(ctx, store, in, opts)
+	if err != nil {
+		return fmt.Errorf("import failed after %d records: %w", cp.Records, err)
+	}
+	log.Infof("Imported %d records from %s (%d skipped because they had expired)", cp.Records-cp.Expired, inFile, cp.Expired)
+	return nil
+}
+
+func runCopy(ctx context.Context, args []string, log logger.Logger) error {
+	var (
+		flags   commonFlags
+		srcFile string
+		dstFile string
+	)
+	fs := flag.NewFlagSet("copy", flag.ExitOnError)
+	fs.StringVar(&srcFile, "source", "", "Component file of the state store to copy items from (required)")
+	fs.StringVar(&dstFile, "destination", "", "Component file of the state store to copy items to (required)")
+	flags.register(fs, true, true)
+	_ = fs.Parse(args)
+	if srcFile == "" || dstFile == "" {
+		return errors.New("the -source and -destination flags are required")
+	}
+
+	opts, err := flags.options(nil)
+	if err != nil {
+		return err
+	}
+	if opts.Checkpoint.Done {
+		log.Infof("Copy was already completed with %d records", opts.Checkpoint.Records)
+		return nil
+	}
+
+	src, err := loadComponent(ctx, srcFile, log)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := loadComponent(ctx, dstFile, log)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	cp, err := migrate.Copy(ctx, src, dst, opts)
+	if err != nil {
+		return fmt.Errorf("copy failed after %d records: %w", cp.Records, err)
+	}
+	log.Infof("Copied %d records (%d skipped because they had expired)", cp.Records-cp.Expired, cp.Expired)
+	return nil
+}
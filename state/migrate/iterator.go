@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/kit/ptr"
+)
+
+const (
+	// ModeRangeScan iterates over the items with the store's RangeScanner implementation.
+	ModeRangeScan = "rangeScan"
+	// ModeKeysLike lists the keys with the store's KeysLiker implementation, then reads the items with BulkGet.
+	ModeKeysLike = "keysLike"
+)
+
+// iterator reads all items in a state store, in batches.
+type iterator struct {
+	store     state.Store
+	mode      string
+	prefix    string
+	batchSize uint32
+	token     *string
+	done      bool
+}
+
+func newIterator(store state.Store, prefix string, batchSize uint32, checkpoint Checkpoint) (*iterator, error) {
+	it := &iterator{
+		store:     store,
+		prefix:    prefix,
+		batchSize: batchSize,
+		token:     checkpoint.Token,
+	}
+
+	features := store.Features()
+	if _, ok := store.(state.RangeScanner); ok && state.FeatureRangeScan.IsPresent(features) {
+		it.mode = ModeRangeScan
+	} else if _, ok := store.(state.KeysLiker); ok && state.FeatureKeysLike.IsPresent(features) {
+		it.mode = ModeKeysLike
+	} else {
+		return nil, ErrNotIterable
+	}
+
+	if checkpoint.Mode != "" && checkpoint.Mode != it.mode {
+		return nil, fmt.Errorf("%w: checkpoint was created with mode '%s', but the state store uses mode '%s'", ErrInvalidCheckpoint, checkpoint.Mode, it.mode)
+	}
+
+	return it, nil
+}
+
+// Next returns the next batch of records.
+// It returns an empty slice when there are no more items.
+func (it *iterator) Next(ctx context.Context) ([]Record, error) {
+	for !it.done {
+		var (
+			records []Record
+			err     error
+		)
+		switch it.mode {
+		case ModeRangeScan:
+			records, err = it.nextScan(ctx)
+		case ModeKeysLike:
+			records, err = it.nextKeysLike(ctx)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// A page could be made of keys that were deleted after being listed only, so continue with the next one
+		if len(records) > 0 {
+			return records, nil
+		}
+	}
+	return []Record{}, nil
+}
+
+func (it *iterator) nextScan(ctx context.Context) ([]Record, error) {
+	req := &state.ScanRequest{
+		Start:             it.prefix,
+		Limit:             it.batchSize,
+		ContinuationToken: it.token,
+	}
+	if it.prefix != "" {
+		req.End = prefixRangeEnd(it.prefix)
+	}
+	res, err := it.store.(state.RangeScanner).Scan(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan state store: %w", err)
+	}
+
+	it.token = res.ContinuationToken
+	it.done = res.ContinuationToken == nil
+
+	records := make([]Record, len(res.Items))
+	for i, item := range res.Items {
+		expireTime, err := parseExpireTime(item.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", item.Key, err)
+		}
+		records[i] = NewRecord(item.Key, item.Data, item.ETag, nil, expireTime)
+	}
+	return records, nil
+}
+
+func (it *iterator) nextKeysLike(ctx context.Context) ([]Record, error) {
+	// Patterns are built from the prefix only if it doesn't contain wildcards, as not all state stores support escaping them
+	// Keys are filtered below in any case
+	pattern := "%"
+	if it.prefix != "" && !strings.ContainsAny(it.prefix, `%_\`) {
+		pattern = it.prefix + "%"
+	}
+	res, err := it.store.(state.KeysLiker).KeysLike(ctx, &state.KeysLikeRequest{
+		Pattern:           pattern,
+		PageSize:          ptr.Of(it.batchSize),
+		ContinuationToken: it.token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	it.token = res.ContinuationToken
+	it.done = res.ContinuationToken == nil || len(res.Keys) == 0
+
+	reqs := make([]state.GetRequest, 0, len(res.Keys))
+	for _, key := range res.Keys {
+		if strings.HasPrefix(key, it.prefix) {
+			reqs = append(reqs, state.GetRequest{Key: key})
+		}
+	}
+	if len(reqs) == 0 {
+		return []Record{}, nil
+	}
+
+	items, err := it.store.BulkGet(ctx, reqs, state.BulkGetOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read items: %w", err)
+	}
+
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		if item.Error != "" {
+			return nil, fmt.Errorf("failed to read key %s: %s", item.Key, item.Error)
+		}
+		// Items deleted or expired after their key was listed are skipped
+		if item.Data == nil && item.ETag == nil {
+			continue
+		}
+		expireTime, err := parseExpireTime(item.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", item.Key, err)
+		}
+		records = append(records, NewRecord(item.Key, item.Data, item.ETag, item.ContentType, expireTime))
+	}
+	return records, nil
+}
+
+func parseExpireTime(md map[string]string) (*time.Time, error) {
+	val := md[state.GetRespMetaKeyTTLExpireTime]
+	if val == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for metadata '%s': %w", state.GetRespMetaKeyTTLExpireTime, err)
+	}
+	return &t, nil
+}
+
+// prefixRangeEnd returns the smallest key that is greater than all keys starting with prefix.
+func prefixRangeEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	// All bytes are 0xff: there's no upper bound
+	return ""
+}
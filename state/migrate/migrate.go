@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migrate copies the items of a state store to another one, or backs them up and restores them with a portable NDJSON format.
+//
+// Items are read with the RangeScanner interface, if the source store supports it, or by listing keys with the KeysLiker interface.
+// They're written with BulkSet, or with Multi if transactions are requested.
+// All operations report checkpoints after each batch, which can be used to resume them if they're interrupted.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/utils"
+)
+
+// DefaultBatchSize is the default number of items that are read and written at a time.
+const DefaultBatchSize = 100
+
+var (
+	// ErrNotIterable is returned when the source state store doesn't support listing its items.
+	ErrNotIterable = errors.New("state store doesn't support listing keys: it must implement range scans or KeysLike")
+	// ErrInvalidCheckpoint is returned when a checkpoint can't be used to resume an operation.
+	ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+)
+
+// Checkpoint contains the progress of an operation, to resume it.
+type Checkpoint struct {
+	// Mode used to iterate over the source store.
+	Mode string `json:"mode,omitempty"`
+	// Token to continue iterating over the source store from.
+	Token *string `json:"token,omitempty"`
+	// Offset, in bytes, of the NDJSON stream after the last record that was processed.
+	Offset int64 `json:"offset"`
+	// Number of records processed.
+	Records int64 `json:"records"`
+	// Number of records that weren't restored because they had expired.
+	Expired int64 `json:"expired,omitempty"`
+	// True when the operation has completed.
+	Done bool `json:"done"`
+}
+
+// Options contains the options for the operations.
+type Options struct {
+	// Number of items read and written at a time.
+	// Defaults to DefaultBatchSize.
+	BatchSize int
+	// Only keys starting with this prefix are read from the source store.
+	KeyPrefix string
+	// If true, each batch is written with a transaction, when the destination store supports it.
+	Transactional bool
+	// Checkpoint to resume the operation from.
+	Checkpoint Checkpoint
+	// If set, invoked after each batch with the progress of the operation.
+	// If it returns an error, the operation is stopped.
+	OnCheckpoint func(Checkpoint) error
+}
+
+func (o Options) batchSize() int {
+	if o.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return o.BatchSize
+}
+
+func (o Options) checkpoint(cp Checkpoint) error {
+	if o.OnCheckpoint == nil {
+		return nil
+	}
+	return o.OnCheckpoint(cp)
+}
+
+// Export writes all items in the store to w, in the NDJSON format.
+// When resuming from a checkpoint, w must be positioned at the checkpoint's offset.
+func Export(ctx context.Context, store state.Store, w io.Writer, opts Options) (Checkpoint, error) {
+	cp := opts.Checkpoint
+	if cp.Done {
+		return cp, nil
+	}
+
+	//nolint:gosec
+	it, err := newIterator(store, opts.KeyPrefix, uint32(opts.batchSize()), cp)
+	if err != nil {
+		return cp, err
+	}
+	cp.Mode = it.mode
+
+	enc := NewEncoder(w)
+	for {
+		records, err := it.Next(ctx)
+		if err != nil {
+			return cp, err
+		}
+		if len(records) > 0 {
+			n, err := enc.Encode(records...)
+			if err != nil {
+				return cp, fmt.Errorf("failed to write records: %w", err)
+			}
+			cp.Offset += int64(n)
+			cp.Records += int64(len(records))
+		}
+		cp.Token = it.token
+		cp.Done = it.done
+		err = opts.checkpoint(cp)
+		if err != nil {
+			return cp, err
+		}
+		if cp.Done {
+			return cp, nil
+		}
+	}
+}
+
+// Import restores the records read from r, in the NDJSON format, into the store.
+// When resuming from a checkpoint, r must be positioned at the checkpoint's offset.
+func Import(ctx context.Context, store state.Store, r io.Reader, opts Options) (Checkpoint, error) {
+	cp := opts.Checkpoint
+	if cp.Done {
+		return cp, nil
+	}
+
+	w := newWriter(store, opts)
+	dec := NewDecoder(r)
+	batch := make([]Record, 0, opts.batchSize())
+	for {
+		rec, err := dec.Decode()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return cp, err
+		}
+		eof := errors.Is(err, io.EOF)
+		if !eof {
+			batch = append(batch, rec)
+		}
+
+		if len(batch) == cap(batch) || (eof && len(batch) > 0) {
+			expired, err := w.write(ctx, batch)
+			if err != nil {
+				return cp, err
+			}
+			cp.Offset = opts.Checkpoint.Offset + dec.Offset()
+			cp.Records += int64(len(batch))
+			cp.Expired += int64(expired)
+			cp.Done = eof
+			err = opts.checkpoint(cp)
+			if err != nil {
+				return cp, err
+			}
+			batch = batch[:0]
+		}
+
+		if eof {
+			if cp.Done {
+				return cp, nil
+			}
+			cp.Done = true
+			return cp, opts.checkpoint(cp)
+		}
+	}
+}
+
+// Copy copies all items in the source store to the destination store.
+func Copy(ctx context.Context, src state.Store, dst state.Store, opts Options) (Checkpoint, error) {
+	cp := opts.Checkpoint
+	if cp.Done {
+		return cp, nil
+	}
+
+	//nolint:gosec
+	it, err := newIterator(src, opts.KeyPrefix, uint32(opts.batchSize()), cp)
+	if err != nil {
+		return cp, err
+	}
+	cp.Mode = it.mode
+
+	w := newWriter(dst, opts)
+	for {
+		records, err := it.Next(ctx)
+		if err != nil {
+			return cp, err
+		}
+		if len(records) > 0 {
+			expired, err := w.write(ctx, records)
+			if err != nil {
+				return cp, err
+			}
+			cp.Records += int64(len(records))
+			cp.Expired += int64(expired)
+		}
+		cp.Token = it.token
+		cp.Done = it.done
+		err = opts.checkpoint(cp)
+		if err != nil {
+			return cp, err
+		}
+		if cp.Done {
+			return cp, nil
+		}
+	}
+}
+
+// writer saves records in a state store.
+type writer struct {
+	store         state.Store
+	transactional state.TransactionalStore
+	maxTxSize     int
+	ttl           bool
+	now           func() time.Time
+}
+
+func newWriter(store state.Store, opts Options) *writer {
+	w := &writer{
+		store: store,
+		ttl:   state.FeatureTTL.IsPresent(store.Features()),
+		now:   time.Now,
+	}
+	if opts.Transactional && state.FeatureTransactional.IsPresent(store.Features()) {
+		w.transactional, _ = store.(state.TransactionalStore)
+		w.maxTxSize = math.MaxInt
+		if s, ok := store.(state.TransactionalStoreMultiMaxSize); ok && s.MultiMaxSize() > 0 {
+			w.maxTxSize = s.MultiMaxSize()
+		}
+	}
+	return w
+}
+
+// write saves the records, and returns the number of records that were skipped because they had expired.
+// ETags are not set, so existing items are overwritten.
+// If the store doesn't support TTLs, items are saved without one.
+func (w *writer) write(ctx context.Context, records []Record) (expired int, err error) {
+	now := w.now()
+	reqs := make([]state.SetRequest, 0, len(records))
+	for _, rec := range records {
+		req := state.SetRequest{
+			Key:         rec.Key,
+			Value:       rec.Data(),
+			ContentType: rec.ContentType,
+		}
+		if rec.ExpireTime != nil {
+			ttl := math.Ceil(rec.ExpireTime.Sub(now).Seconds())
+			if ttl <= 0 {
+				expired++
+				continue
+			}
+			if w.ttl {
+				// State stores parse TTLs as 32-bit integers
+				ttl = min(ttl, math.MaxInt32)
+				req.Metadata = map[string]string{
+					utils.MetadataTTLKey: strconv.FormatInt(int64(ttl), 10),
+				}
+			}
+		}
+		reqs = append(reqs, req)
+	}
+	if len(reqs) == 0 {
+		return expired, nil
+	}
+
+	if w.transactional == nil {
+		err = w.store.BulkSet(ctx, reqs, state.BulkStoreOpts{})
+		if err != nil {
+			return expired, fmt.Errorf("failed to save items: %w", err)
+		}
+		return expired, nil
+	}
+
+	for len(reqs) > 0 {
+		n := min(len(reqs), w.maxTxSize)
+		ops := make([]state.TransactionalStateOperation, n)
+		for i := range n {
+			ops[i] = reqs[i]
+		}
+		err = w.transactional.Multi(ctx, &state.TransactionalStateRequest{
+			Operations: ops,
+		})
+		if err != nil {
+			return expired, fmt.Errorf("failed to save items in a transaction: %w", err)
+		}
+		reqs = reqs[n:]
+	}
+	return expired, nil
+}
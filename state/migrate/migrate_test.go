@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/state"
+	inmemory "github.com/dapr/components-contrib/state/in-memory"
+	"github.com/dapr/kit/logger"
+)
+
+// keysLikeStore hides the RangeScanner implementation of a store.
+type keysLikeStore struct {
+	state.Store
+}
+
+func (s keysLikeStore) KeysLike(ctx context.Context, req *state.KeysLikeRequest) (*state.KeysLikeResponse, error) {
+	return s.Store.(state.KeysLiker).KeysLike(ctx, req)
+}
+
+func newStore(t *testing.T) state.Store {
+	t.Helper()
+	s := inmemory.NewInMemoryStateStore(logger.NewLogger("test"))
+	require.NoError(t, s.Init(t.Context(), state.Metadata{}))
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func seed(t *testing.T, s state.Store) {
+	t.Helper()
+	reqs := make([]state.SetRequest, 0, 13)
+	for i := range 10 {
+		reqs = append(reqs, state.SetRequest{Key: fmt.Sprintf("app||item-%02d", i), Value: []byte(fmt.Sprintf(`{"n":%d}`, i))})
+	}
+	reqs = append(reqs,
+		state.SetRequest{Key: "app||binary", Value: []byte{0x00, 0x01, 0x02}},
+		state.SetRequest{Key: "app||ttl", Value: []byte(`"expires"`), Metadata: map[string]string{"ttlInSeconds": "3600"}},
+		state.SetRequest{Key: "other||item", Value: []byte(`"other"`)},
+	)
+	require.NoError(t, s.BulkSet(t.Context(), reqs, state.BulkStoreOpts{}))
+}
+
+func assertSameItems(t *testing.T, src state.Store, dst state.Store, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		expect, err := src.Get(t.Context(), &state.GetRequest{Key: key})
+		require.NoError(t, err)
+		res, err := dst.Get(t.Context(), &state.GetRequest{Key: key})
+		require.NoError(t, err)
+		assert.Equal(t, expect.Data, res.Data, key)
+	}
+}
+
+func itemKeys() []string {
+	keys := []string{"app||binary", "app||ttl"}
+	for i := range 10 {
+		keys = append(keys, fmt.Sprintf("app||item-%02d", i))
+	}
+	return keys
+}
+
+func TestExportImport(t *testing.T) {
+	sources := map[string]func(state.Store) state.Store{
+		ModeRangeScan: func(s state.Store) state.Store { return s },
+		ModeKeysLike:  func(s state.Store) state.Store { return keysLikeStore{s} },
+	}
+	for mode, wrap := range sources {
+		t.Run(mode, func(t *testing.T) {
+			src := newStore(t)
+			seed(t, src)
+
+			buf := &bytes.Buffer{}
+			cp, err := Export(t.Context(), wrap(src), buf, Options{BatchSize: 5, KeyPrefix: "app||"})
+			require.NoError(t, err)
+			assert.True(t, cp.Done)
+			assert.Equal(t, mode, cp.Mode)
+			assert.Equal(t, int64(12), cp.Records)
+			assert.Equal(t, int64(buf.Len()), cp.Offset)
+			assert.NotContains(t, buf.String(), "other||item")
+
+			dst := newStore(t)
+			cp, err = Import(t.Context(), dst, bytes.NewReader(buf.Bytes()), Options{BatchSize: 5})
+			require.NoError(t, err)
+			assert.True(t, cp.Done)
+			assert.Equal(t, int64(12), cp.Records)
+
+			assertSameItems(t, src, dst, itemKeys()...)
+
+			// TTLs are preserved
+			res, err := dst.Get(t.Context(), &state.GetRequest{Key: "app||ttl"})
+			require.NoError(t, err)
+			expireTime, err := time.Parse(time.RFC3339, res.Metadata[state.GetRespMetaKeyTTLExpireTime])
+			require.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), expireTime, time.Minute)
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	errStop := errors.New("stop")
+	stopAfter := func(batches int, last *Checkpoint) func(Checkpoint) error {
+		return func(cp Checkpoint) error {
+			*last = cp
+			batches--
+			if batches == 0 {
+				return errStop
+			}
+			return nil
+		}
+	}
+
+	src := newStore(t)
+	seed(t, src)
+
+	t.Run("export", func(t *testing.T) {
+		expect := &bytes.Buffer{}
+		_, err := Export(t.Context(), src, expect, Options{})
+		require.NoError(t, err)
+
+		var last Checkpoint
+		buf := &bytes.Buffer{}
+		_, err = Export(t.Context(), src, buf, Options{BatchSize: 4, OnCheckpoint: stopAfter(2, &last)})
+		require.ErrorIs(t, err, errStop)
+		assert.Equal(t, int64(8), last.Records)
+		assert.False(t, last.Done)
+
+		// Data written after the checkpoint is discarded when resuming
+		buf.Truncate(int(last.Offset))
+		cp, err := Export(t.Context(), src, buf, Options{BatchSize: 4, Checkpoint: last})
+		require.NoError(t, err)
+		assert.True(t, cp.Done)
+		assert.Equal(t, int64(13), cp.Records)
+		assert.Equal(t, expect.String(), buf.String())
+	})
+
+	t.Run("import", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := Export(t.Context(), src, buf, Options{})
+		require.NoError(t, err)
+		data := buf.Bytes()
+
+		var last Checkpoint
+		dst := newStore(t)
+		_, err = Import(t.Context(), dst, bytes.NewReader(data), Options{BatchSize: 5, OnCheckpoint: stopAfter(1, &last)})
+		require.ErrorIs(t, err, errStop)
+		assert.Equal(t, int64(5), last.Records)
+
+		cp, err := Import(t.Context(), dst, bytes.NewReader(data[last.Offset:]), Options{BatchSize: 5, Checkpoint: last})
+		require.NoError(t, err)
+		assert.True(t, cp.Done)
+		assert.Equal(t, int64(13), cp.Records)
+		assert.Equal(t, int64(len(data)), cp.Offset)
+		assertSameItems(t, src, dst, append(itemKeys(), "other||item")...)
+
+		// Completed operations aren't repeated
+		cp, err = Import(t.Context(), dst, bytes.NewReader(nil), Options{Checkpoint: cp})
+		require.NoError(t, err)
+		assert.Equal(t, int64(13), cp.Records)
+	})
+
+	t.Run("checkpoint from a different mode", func(t *testing.T) {
+		_, err := Export(t.Context(), keysLikeStore{src}, &bytes.Buffer{}, Options{Checkpoint: Checkpoint{Mode: ModeRangeScan}})
+		require.ErrorIs(t, err, ErrInvalidCheckpoint)
+	})
+}
+
+func TestCopy(t *testing.T) {
+	src := newStore(t)
+	seed(t, src)
+
+	for _, transactional := range []bool{false, true} {
+		t.Run(fmt.Sprintf("transactional=%v", transactional), func(t *testing.T) {
+			dst := newStore(t)
+			checkpoints := 0
+			cp, err := Copy(t.Context(), keysLikeStore{src}, dst, Options{
+				BatchSize:     3,
+				Transactional: transactional,
+				OnCheckpoint: func(Checkpoint) error {
+					checkpoints++
+					return nil
+				},
+			})
+			require.NoError(t, err)
+			assert.True(t, cp.Done)
+			assert.Equal(t, int64(13), cp.Records)
+			assert.GreaterOrEqual(t, checkpoints, 5)
+			assertSameItems(t, src, dst, append(itemKeys(), "other||item")...)
+		})
+	}
+}
+
+func TestImportExpired(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().AddDate(100, 0, 0)
+	buf := &bytes.Buffer{}
+	_, err := NewEncoder(buf).Encode(
+		NewRecord("expired", []byte(`"a"`), nil, nil, &past),
+		NewRecord("valid", []byte(`"b"`), nil, nil, nil),
+		NewRecord("far-future", []byte(`"c"`), nil, nil, &future),
+	)
+	require.NoError(t, err)
+
+	dst := newStore(t)
+	cp, err := Import(t.Context(), dst, buf, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cp.Records)
+	assert.Equal(t, int64(1), cp.Expired)
+
+	// TTLs are capped to the maximum value supported by state stores
+	res, err := dst.Get(t.Context(), &state.GetRequest{Key: "far-future"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`"c"`), res.Data)
+
+	res, err = dst.Get(t.Context(), &state.GetRequest{Key: "expired"})
+	require.NoError(t, err)
+	assert.Nil(t, res.Data)
+}
+
+func TestNotIterable(t *testing.T) {
+	// Embedding the interface hides both the RangeScanner and KeysLiker implementations
+	s := struct{ state.Store }{newStore(t)}
+	_, err := Export(t.Context(), s, &bytes.Buffer{}, Options{})
+	require.ErrorIs(t, err, ErrNotIterable)
+}
+
+func TestPrefixRangeEnd(t *testing.T) {
+	assert.Equal(t, "app|}", prefixRangeEnd("app||"))
+	assert.Equal(t, "b", prefixRangeEnd("a\xff"))
+	assert.Empty(t, prefixRangeEnd("\xff\xff"))
+}
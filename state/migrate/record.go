@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Record is a state item in the portable NDJSON format.
+// Each line of a backup contains one record.
+type Record struct {
+	// Key of the item, as stored in the state store.
+	Key string `json:"key"`
+	// Value of the item, if it's a compact JSON document.
+	Value json.RawMessage `json:"value,omitempty"`
+	// Value of the item, if it's not a compact JSON document.
+	BinaryValue []byte `json:"binaryValue,omitempty"`
+	// ETag of the item in the source store.
+	// ETags are generated by each state store, so they are not restored.
+	ETag *string `json:"etag,omitempty"`
+	// Time the item expires at, if it has a TTL.
+	ExpireTime *time.Time `json:"expireTime,omitempty"`
+	// Content type of the item, if known.
+	ContentType *string `json:"contentType,omitempty"`
+}
+
+// NewRecord returns a record for the item.
+func NewRecord(key string, data []byte, etag *string, contentType *string, expireTime *time.Time) Record {
+	r := Record{
+		Key:         key,
+		ETag:        etag,
+		ExpireTime:  expireTime,
+		ContentType: contentType,
+	}
+
+	// Values are stored as JSON only if encoding them doesn't alter them, so they're restored byte-for-byte
+	if isCompactJSON(data) {
+		r.Value = data
+	} else {
+		r.BinaryValue = data
+	}
+	return r
+}
+
+// Data returns the value of the item.
+func (r Record) Data() []byte {
+	if r.Value != nil {
+		return r.Value
+	}
+	if r.BinaryValue != nil {
+		return r.BinaryValue
+	}
+	return []byte{}
+}
+
+func isCompactJSON(data []byte) bool {
+	if !json.Valid(data) {
+		return false
+	}
+	buf := &bytes.Buffer{}
+	if json.Compact(buf, data) != nil || !bytes.Equal(buf.Bytes(), data) {
+		return false
+	}
+	// The JSON encoder always escapes U+2028 and U+2029
+	return !bytes.ContainsRune(data, '\u2028') && !bytes.ContainsRune(data, '\u2029')
+}
+
+// Encoder writes records in the NDJSON format.
+type Encoder struct {
+	w   io.Writer
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+// NewEncoder returns an encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	e := &Encoder{w: w}
+	e.enc = json.NewEncoder(&e.buf)
+	e.enc.SetEscapeHTML(false)
+	return e
+}
+
+// Encode writes the records, one per line, with a single call to the underlying writer.
+// It returns the number of bytes written.
+func (e *Encoder) Encode(records ...Record) (int, error) {
+	e.buf.Reset()
+	for i := range records {
+		// The JSON encoder terminates each value with a newline
+		err := e.enc.Encode(records[i])
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode record for key %s: %w", records[i].Key, err)
+		}
+	}
+	return e.w.Write(e.buf.Bytes())
+}
+
+// Decoder reads records in the NDJSON format.
+type Decoder struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+// NewDecoder returns a decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next record.
+// It returns io.EOF when there are no more records.
+func (d *Decoder) Decode() (Record, error) {
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Record{}, err
+		}
+		if errors.Is(err, io.EOF) && len(line) == 0 {
+			return Record{}, io.EOF
+		}
+		start := d.offset
+		d.offset += int64(len(line))
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var rec Record
+		err = json.Unmarshal(line, &rec)
+		if err != nil {
+			return Record{}, fmt.Errorf("invalid record at offset %d: %w", start, err)
+		}
+		if rec.Key == "" {
+			return Record{}, fmt.Errorf("invalid record at offset %d: key is empty", start)
+		}
+		return rec, nil
+	}
+}
+
+// Offset returns the number of bytes consumed by the records decoded so far.
+func (d *Decoder) Offset() int64 {
+	return d.offset
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/ptr"
+)
+
+func TestNewRecord(t *testing.T) {
+	t.Run("compact JSON values are stored as JSON", func(t *testing.T) {
+		r := NewRecord("k", []byte(`{"a":"<b>"}`), nil, nil, nil)
+		assert.JSONEq(t, `{"a":"<b>"}`, string(r.Value))
+		assert.Nil(t, r.BinaryValue)
+	})
+
+	t.Run("other values are stored as binary", func(t *testing.T) {
+		for _, v := range []string{`{"a": 1}`, "not json", "\x00\x01"} {
+			r := NewRecord("k", []byte(v), nil, nil, nil)
+			assert.Nil(t, r.Value, v)
+			assert.Equal(t, []byte(v), r.BinaryValue)
+		}
+	})
+}
+
+func TestEncodeDecode(t *testing.T) {
+	expire := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := []Record{
+		NewRecord("json", []byte(`{"html":"<a href=\"x\">&</a>","u":" "}`), ptr.Of("1"), nil, nil),
+		NewRecord("binary", []byte{0x00, 0xff, '\n'}, nil, ptr.Of("application/octet-stream"), &expire),
+		NewRecord("spaces", []byte(`[1, 2]`), nil, nil, nil),
+		NewRecord("empty", []byte{}, nil, nil, nil),
+	}
+
+	buf := &bytes.Buffer{}
+	n, err := NewEncoder(buf).Encode(records...)
+	require.NoError(t, err)
+	assert.Equal(t, buf.Len(), n)
+	assert.Equal(t, len(records), strings.Count(buf.String(), "\n"))
+
+	dec := NewDecoder(buf)
+	for _, expect := range records {
+		rec, err := dec.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, expect.Key, rec.Key)
+		assert.Equal(t, expect.Data(), rec.Data(), expect.Key)
+		assert.Equal(t, expect.ETag, rec.ETag)
+		assert.Equal(t, expect.ContentType, rec.ContentType)
+		if expect.ExpireTime != nil {
+			require.NotNil(t, rec.ExpireTime)
+			assert.True(t, expect.ExpireTime.Equal(*rec.ExpireTime))
+		}
+	}
+	_, err = dec.Decode()
+	require.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, int64(n), dec.Offset())
+}
+
+func TestDecoder(t *testing.T) {
+	t.Run("blank lines are skipped", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader("\n{\"key\":\"a\",\"value\":1}\n\n{\"key\":\"b\",\"value\":2}"))
+		rec, err := dec.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, "a", rec.Key)
+		rec, err = dec.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, "b", rec.Key)
+		_, err = dec.Decode()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("invalid records", func(t *testing.T) {
+		_, err := NewDecoder(strings.NewReader("{\"key\":\"a\"}\n{\"key\":")).Decode()
+		require.NoError(t, err)
+
+		dec := NewDecoder(strings.NewReader("{\"key\":\"a\"}\n{\"key\":"))
+		_, _ = dec.Decode()
+		_, err = dec.Decode()
+		require.ErrorContains(t, err, "invalid record at offset 12")
+
+		_, err = NewDecoder(strings.NewReader(`{"value":1}`)).Decode()
+		require.ErrorContains(t, err, "key is empty")
+	})
+}
@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -722,7 +721,7 @@ func (m *MongoDB) KeysLike(ctx context.Context, req state.KeysLikeRequest) (*sta
 		return nil, state.ErrKeysLikeEmptyPattern
 	}
 
-	re, err := likeToRegex(req.Pattern)
+	re, err := stateutils.LikeToRegex(req.Pattern)
 	if err != nil {
 		return nil, fmt.Errorf("invalid pattern: %w", err)
 	}
@@ -787,33 +786,3 @@ func (m *MongoDB) KeysLike(ctx context.Context, req state.KeysLikeRequest) (*sta
 
 	return resp, nil
 }
-
-func likeToRegex(pattern string) (*regexp.Regexp, error) {
-	var b strings.Builder
-	b.Grow(len(pattern) + 4)
-	b.WriteString("^")
-
-	escaped := false
-	for _, r := range pattern {
-		if escaped {
-			b.WriteString(regexp.QuoteMeta(string(r)))
-			escaped = false
-			continue
-		}
-		switch r {
-		case '\\':
-			escaped = true
-		case '%':
-			b.WriteString(".*")
-		case '_':
-			b.WriteString(".")
-		default:
-			b.WriteString(regexp.QuoteMeta(string(r)))
-		}
-	}
-	if escaped {
-		b.WriteString(regexp.QuoteMeta(`\`))
-	}
-	b.WriteString("$")
-	return regexp.Compile(b.String())
-}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -71,12 +72,13 @@ const (
 
 	// Used if the user does not configure a cleanup interval in the metadata.
 	defaultCleanupInterval = time.Hour
+
+	// Maximum number of rows in the statements of the bulk operations, to stay below the limit of placeholders in a statement.
+	bulkMaxRows = 1000
 )
 
 // MySQL state store.
 type MySQL struct {
-	state.BulkStore
-
 	tableName         string
 	metadataTableName string
 	cleanupInterval   *time.Duration
@@ -122,7 +124,6 @@ func newMySQLStateStore(logger logger.Logger, factory iMySQLFactory) *MySQL {
 		factory: factory,
 		timeout: 5 * time.Second,
 	}
-	s.BulkStore = state.NewDefaultBulkStore(s)
 	return s
 }
 
@@ -489,10 +490,12 @@ func (m *MySQL) deleteValue(parentCtx context.Context, querier querier, req *sta
 		return errors.New("missing key in delete operation")
 	}
 
-	var (
-		err    error
-		result sql.Result
-	)
+	err := state.CheckRequestOptions(req.Options)
+	if err != nil {
+		return err
+	}
+
+	var result sql.Result
 
 	execCtx, cancel := context.WithTimeout(parentCtx, m.timeout)
 	defer cancel()
@@ -578,48 +581,17 @@ func (m *MySQL) setValue(parentCtx context.Context, querier querier, req *state.
 		return errors.New("missing key in set operation")
 	}
 
-	// TTL
-	var ttlSeconds int
-	ttl, err := utils.ParseTTL(req.Metadata)
+	enc, isBinary, ttlQuery, eTag, err := encodeSetRequest(req)
 	if err != nil {
-		return fmt.Errorf("error parsing TTL: %w", err)
-	}
-	if ttl != nil {
-		ttlSeconds = *ttl
+		return err
 	}
 
 	var (
-		query    string
-		ttlQuery string
-		params   []any
-		result   sql.Result
+		query  string
+		params []any
+		result sql.Result
 	)
 
-	var v any
-	isBinary := false
-	switch x := req.Value.(type) {
-	case []uint8:
-		isBinary = true
-		v = base64.StdEncoding.EncodeToString(x)
-	default:
-		v = x
-	}
-
-	encB, _ := json.Marshal(v)
-	enc := string(encB)
-
-	eTagObj, err := uuid.NewRandom()
-	if err != nil {
-		return fmt.Errorf("failed to generate etag: %w", err)
-	}
-	eTag := eTagObj.String()
-
-	if ttlSeconds > 0 {
-		ttlQuery = "CURRENT_TIMESTAMP + INTERVAL " + strconv.Itoa(ttlSeconds) + " SECOND"
-	} else {
-		ttlQuery = "NULL"
-	}
-
 	if req.HasETag() {
 		// When an eTag is provided do an update - not insert
 		query = `UPDATE ` + m.tableName + `
@@ -671,6 +643,247 @@ WHERE NOT EXISTS (
 	return nil
 }
 
+// encodeSetRequest returns the encoded value of a set request, whether it's binary, the expression of its expiration date, and a new ETag.
+func encodeSetRequest(req *state.SetRequest) (enc string, isBinary bool, ttlQuery string, eTag string, err error) {
+	// TTL
+	var ttlSeconds int
+	ttl, err := utils.ParseTTL(req.Metadata)
+	if err != nil {
+		return "", false, "", "", fmt.Errorf("error parsing TTL: %w", err)
+	}
+	if ttl != nil {
+		ttlSeconds = *ttl
+	}
+
+	var v any
+	switch x := req.Value.(type) {
+	case []uint8:
+		isBinary = true
+		v = base64.StdEncoding.EncodeToString(x)
+	default:
+		v = x
+	}
+
+	encB, _ := json.Marshal(v)
+	enc = string(encB)
+
+	eTagObj, err := uuid.NewRandom()
+	if err != nil {
+		return "", false, "", "", fmt.Errorf("failed to generate etag: %w", err)
+	}
+	eTag = eTagObj.String()
+
+	if ttlSeconds > 0 {
+		ttlQuery = "CURRENT_TIMESTAMP + INTERVAL " + strconv.Itoa(ttlSeconds) + " SECOND"
+	} else {
+		ttlQuery = "NULL"
+	}
+
+	return enc, isBinary, ttlQuery, eTag, nil
+}
+
+// BulkSet saves multiple items in a transaction, using multi-row statements.
+// Items whose ETag doesn't match are reported with a state.BulkStoreError, and don't prevent the other items from being saved.
+func (m *MySQL) BulkSet(ctx context.Context, req []state.SetRequest, _ state.BulkStoreOpts) error {
+	if len(req) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(req))
+	_, err := sqltransactions.ExecuteInTransaction(ctx, m.logger, m.db, func(ctx context.Context, tx *sql.Tx) (r struct{}, err error) {
+		// Lock the rows of the items saved with an ETag, so they can be compared before the rows are replaced
+		etagKeys := make([]string, 0, len(req))
+		for i := range req {
+			if req[i].HasETag() {
+				etagKeys = append(etagKeys, req[i].Key)
+			}
+		}
+		etags, err := m.lockETags(ctx, tx, etagKeys, true)
+		if err != nil {
+			return r, err
+		}
+
+		values := make([]string, 0, min(len(req), bulkMaxRows))
+		params := make([]any, 0, 4*cap(values))
+		flush := func() error {
+			if len(values) == 0 {
+				return nil
+			}
+			execCtx, cancel := context.WithTimeout(ctx, m.timeout)
+			defer cancel()
+			_, err := tx.ExecContext(execCtx, `REPLACE INTO `+m.tableName+` (id, value, eTag, isbinary, expiredate)
+				VALUES `+strings.Join(values, ", "), params...)
+			values = values[:0]
+			params = params[:0]
+			return err
+		}
+
+		for i := range req {
+			err = state.CheckRequestOptions(req[i].Options)
+			if err == nil && req[i].Key == "" {
+				err = errors.New("missing key in set operation")
+			}
+			if err != nil {
+				errs[i] = state.NewBulkStoreError(req[i].Key, err)
+				continue
+			}
+
+			if !req[i].HasETag() && req[i].Options.Concurrency == state.FirstWrite {
+				// Items saved with first-write concurrency are saved one at a time, after the items before them
+				err = flush()
+				if err != nil {
+					return r, err
+				}
+				if err = m.setValue(ctx, tx, &req[i]); err != nil {
+					errs[i] = state.NewBulkStoreError(req[i].Key, err)
+				}
+				continue
+			}
+
+			enc, isBinary, ttlQuery, eTag, err := encodeSetRequest(&req[i])
+			if err != nil {
+				errs[i] = state.NewBulkStoreError(req[i].Key, err)
+				continue
+			}
+			if req[i].HasETag() {
+				current, ok := etags[req[i].Key]
+				if !ok || current != *req[i].ETag {
+					errs[i] = state.NewBulkStoreError(req[i].Key, state.NewETagError(state.ETagMismatch, nil))
+					continue
+				}
+			}
+			// A later item with the same key must match the new ETag
+			etags[req[i].Key] = eTag
+
+			values = append(values, "(?, ?, ?, ?, "+ttlQuery+")")
+			params = append(params, req[i].Key, enc, eTag, isBinary)
+			if len(values) == bulkMaxRows {
+				err = flush()
+				if err != nil {
+					return r, err
+				}
+			}
+		}
+
+		return r, flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
+
+// BulkDelete deletes multiple items in a transaction, using multi-row statements.
+// Items whose ETag doesn't match are reported with a state.BulkStoreError, and don't prevent the other items from being deleted.
+func (m *MySQL) BulkDelete(ctx context.Context, req []state.DeleteRequest, _ state.BulkStoreOpts) error {
+	if len(req) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(req))
+	_, err := sqltransactions.ExecuteInTransaction(ctx, m.logger, m.db, func(ctx context.Context, tx *sql.Tx) (r struct{}, err error) {
+		etagKeys := make([]string, 0, len(req))
+		for i := range req {
+			if req[i].HasETag() {
+				etagKeys = append(etagKeys, req[i].Key)
+			}
+		}
+		etags, err := m.lockETags(ctx, tx, etagKeys, false)
+		if err != nil {
+			return r, err
+		}
+
+		keys := make([]string, 0, len(req))
+		for i := range req {
+			err = state.CheckRequestOptions(req[i].Options)
+			if err == nil && req[i].Key == "" {
+				err = errors.New("missing key in delete operation")
+			}
+			if err != nil {
+				errs[i] = state.NewBulkStoreError(req[i].Key, err)
+				continue
+			}
+			if req[i].HasETag() {
+				current, ok := etags[req[i].Key]
+				if !ok || current != *req[i].ETag {
+					errs[i] = state.NewBulkStoreError(req[i].Key, state.NewETagError(state.ETagMismatch, nil))
+					continue
+				}
+			}
+			// A later item with the same key and an ETag doesn't match
+			delete(etags, req[i].Key)
+			keys = append(keys, req[i].Key)
+		}
+
+		for chunk := range slices.Chunk(keys, bulkMaxRows) {
+			execCtx, cancel := context.WithTimeout(ctx, m.timeout)
+			_, err = tx.ExecContext(execCtx, `DELETE FROM `+m.tableName+` WHERE id IN (`+placeholders(len(chunk))+`)`, toAnySlice(chunk)...)
+			cancel()
+			if err != nil {
+				return r, err
+			}
+		}
+
+		return r, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
+
+// lockETags returns the ETags of the rows of the given keys, locking them until the end of the transaction.
+// If live is true, expired rows are ignored.
+func (m *MySQL) lockETags(ctx context.Context, tx *sql.Tx, keys []string, live bool) (map[string]string, error) {
+	etags := make(map[string]string, len(keys))
+	for chunk := range slices.Chunk(keys, bulkMaxRows) {
+		// Concatenation is required for table name because sql.DB does not substitute parameters for table names
+		//nolint:gosec
+		query := `SELECT id, eTag FROM ` + m.tableName + ` WHERE id IN (` + placeholders(len(chunk)) + `)`
+		if live {
+			query += ` AND (expiredate IS NULL OR expiredate > CURRENT_TIMESTAMP)`
+		}
+		queryCtx, cancel := context.WithTimeout(ctx, m.timeout)
+		rows, err := tx.QueryContext(queryCtx, query+` FOR UPDATE`, toAnySlice(chunk)...)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		for rows.Next() {
+			var key, etag string
+			if err = rows.Scan(&key, &etag); err != nil {
+				rows.Close()
+				cancel()
+				return nil, err
+			}
+			etags[key] = etag
+		}
+		err = rows.Err()
+		rows.Close()
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return etags, nil
+}
+
+// placeholders returns n comma-separated query placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func toAnySlice(keys []string) []any {
+	res := make([]any, len(keys))
+	for i, k := range keys {
+		res[i] = k
+	}
+	return res
+}
+
 func (m *MySQL) BulkGet(parentCtx context.Context, req []state.GetRequest, _ state.BulkGetOpts) ([]state.BulkGetResponse, error) {
 	if len(req) == 0 {
 		return []state.BulkGetResponse{}, nil
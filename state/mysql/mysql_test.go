@@ -31,6 +31,7 @@ import (
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 )
 
 const (
@@ -823,6 +824,110 @@ func TestMultiOperationOrder(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBulkSet(t *testing.T) {
+	m, _ := mockDatabase(t)
+	defer m.mySQL.Close()
+
+	m.mock1.ExpectBegin()
+	m.mock1.ExpectQuery("SELECT id, eTag FROM state WHERE id IN \\(\\?,\\?\\) .* FOR UPDATE").
+		WithArgs("k2", "k3").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "eTag"}).AddRow("k2", "etag2").AddRow("k3", "other"))
+	m.mock1.ExpectExec("REPLACE INTO state .* VALUES \\(\\?, \\?, \\?, \\?, NULL\\), \\(\\?, \\?, \\?, \\?, CURRENT_TIMESTAMP \\+ INTERVAL 10 SECOND\\)$").
+		WithArgs("k1", `"v1"`, sqlmock.AnyArg(), false, "k2", `"v2"`, sqlmock.AnyArg(), false).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	m.mock1.ExpectCommit()
+
+	err := m.mySQL.BulkSet(t.Context(), []state.SetRequest{
+		{Key: "k1", Value: "v1"},
+		{Key: "k2", Value: "v2", ETag: ptr.Of("etag2"), Metadata: map[string]string{"ttlInSeconds": "10"}},
+		{Key: "k3", Value: "v3", ETag: ptr.Of("etag3")},
+		{Key: "", Value: "v4"},
+	}, state.BulkStoreOpts{})
+	require.Error(t, err)
+	require.NoError(t, m.mock1.ExpectationsWereMet())
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	require.Len(t, errs, 2)
+	var bulkErr state.BulkStoreError
+	require.ErrorAs(t, errs[0], &bulkErr)
+	assert.Equal(t, "k3", bulkErr.Key())
+	require.NotNil(t, bulkErr.ETagError())
+	assert.Equal(t, state.ETagMismatch, bulkErr.ETagError().Kind())
+	require.ErrorAs(t, errs[1], &bulkErr)
+	assert.Empty(t, bulkErr.Key())
+	assert.Nil(t, bulkErr.ETagError())
+}
+
+func TestBulkSetRollsBackOnError(t *testing.T) {
+	m, _ := mockDatabase(t)
+	defer m.mySQL.Close()
+
+	m.mock1.ExpectBegin()
+	m.mock1.ExpectExec("REPLACE INTO state").WillReturnError(errors.New("failure"))
+	m.mock1.ExpectRollback()
+
+	err := m.mySQL.BulkSet(t.Context(), []state.SetRequest{createSetRequest(), createSetRequest()}, state.BulkStoreOpts{})
+	require.EqualError(t, err, "failure")
+	require.NoError(t, m.mock1.ExpectationsWereMet())
+}
+
+func TestBulkDelete(t *testing.T) {
+	m, _ := mockDatabase(t)
+	defer m.mySQL.Close()
+
+	m.mock1.ExpectBegin()
+	m.mock1.ExpectQuery("SELECT id, eTag FROM state WHERE id IN \\(\\?,\\?\\) FOR UPDATE").
+		WithArgs("k2", "k3").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "eTag"}).AddRow("k2", "etag2"))
+	m.mock1.ExpectExec("DELETE FROM state WHERE id IN \\(\\?,\\?\\)").
+		WithArgs("k1", "k2").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	m.mock1.ExpectCommit()
+
+	err := m.mySQL.BulkDelete(t.Context(), []state.DeleteRequest{
+		{Key: "k1"},
+		{Key: "k2", ETag: ptr.Of("etag2")},
+		{Key: "k3", ETag: ptr.Of("etag3")},
+	}, state.BulkStoreOpts{})
+	require.NoError(t, m.mock1.ExpectationsWereMet())
+
+	var bulkErr state.BulkStoreError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, "k3", bulkErr.Key())
+	require.NotNil(t, bulkErr.ETagError())
+}
+
+func TestBulkDeleteInvalidOptions(t *testing.T) {
+	m, _ := mockDatabase(t)
+	defer m.mySQL.Close()
+
+	m.mock1.ExpectBegin()
+	m.mock1.ExpectExec("DELETE FROM state WHERE id IN \\(\\?\\)").
+		WithArgs("k1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	m.mock1.ExpectCommit()
+
+	err := m.mySQL.BulkDelete(t.Context(), []state.DeleteRequest{
+		{Key: "k1"},
+		{Key: "k2", Options: state.DeleteStateOption{Concurrency: "invalid"}},
+		{Key: "k3", Options: state.DeleteStateOption{Consistency: "invalid"}},
+	}, state.BulkStoreOpts{})
+	require.NoError(t, m.mock1.ExpectationsWereMet())
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	require.Len(t, errs, 2)
+	var bulkErr state.BulkStoreError
+	require.ErrorAs(t, errs[0], &bulkErr)
+	assert.Equal(t, "k2", bulkErr.Key())
+	require.ErrorAs(t, errs[1], &bulkErr)
+	assert.Equal(t, "k3", bulkErr.Key())
+
+	// Like Delete
+	err = m.mySQL.Delete(t.Context(), &state.DeleteRequest{Key: "k2", Options: state.DeleteStateOption{Concurrency: "invalid"}})
+	require.Error(t, err)
+	require.NoError(t, m.mock1.ExpectationsWereMet())
+}
+
 func createSetRequest() state.SetRequest {
 	return state.SetRequest{
 		Key:   randomKey(),
@@ -20,6 +20,7 @@ import (
 	"github.com/dapr/components-contrib/common/authentication/aws"
 	pgauth "github.com/dapr/components-contrib/common/authentication/postgresql"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/kit/metadata"
 	"github.com/dapr/kit/ptr"
 )
@@ -44,6 +45,8 @@ type pgMetadata struct {
 	CleanupInterval   *time.Duration `mapstructure:"cleanupInterval" mapstructurealiases:"cleanupIntervalInSeconds"`
 
 	aws.DeprecatedPostgresIAM `mapstructure:",squash"`
+
+	compression.Metadata `mapstructure:",squash"`
 }
 
 func (m *pgMetadata) InitWithMetadata(meta state.Metadata, opts pgauth.InitWithMetadataOpts) error {
@@ -53,6 +56,7 @@ func (m *pgMetadata) InitWithMetadata(meta state.Metadata, opts pgauth.InitWithM
 	m.MetadataTableName = "dapr_metadata"
 	m.CleanupInterval = ptr.Of(defaultCleanupInternal)
 	m.Timeout = defaultTimeout
+	m.Metadata = compression.Metadata{}
 
 	// Decode the metadata
 	err := metadata.DecodeMetadata(meta.Properties, &m)
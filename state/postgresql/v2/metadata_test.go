@@ -23,6 +23,7 @@ import (
 	"github.com/dapr/components-contrib/common/authentication/postgresql"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 )
 
 func TestMetadata(t *testing.T) {
@@ -257,4 +258,34 @@ func TestMetadata(t *testing.T) {
 		_ = assert.NotNil(t, m.CleanupInterval) &&
 			assert.Equal(t, defaultCleanupInternal, *m.CleanupInterval)
 	})
+
+	t.Run("compression", func(t *testing.T) {
+		m := pgMetadata{}
+		props := map[string]string{
+			"connectionString":     "foo=bar",
+			"compression":          "snappy",
+			"compressionThreshold": "100",
+		}
+
+		opts := postgresql.InitWithMetadataOpts{}
+		err := m.InitWithMetadata(state.Metadata{Base: metadata.Base{Properties: props}}, opts)
+		require.NoError(t, err)
+		codec, err := m.Codec()
+		require.NoError(t, err)
+		assert.Equal(t, compression.Snappy, codec.Algorithm())
+	})
+
+	t.Run("invalid compression", func(t *testing.T) {
+		m := pgMetadata{}
+		props := map[string]string{
+			"connectionString": "foo=bar",
+			"compression":      "lz4",
+		}
+
+		opts := postgresql.InitWithMetadataOpts{}
+		err := m.InitWithMetadata(state.Metadata{Base: metadata.Base{Properties: props}}, opts)
+		require.NoError(t, err)
+		_, err = m.Codec()
+		require.Error(t, err)
+	})
 }
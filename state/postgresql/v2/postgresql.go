@@ -38,14 +38,14 @@ import (
 	pgmigrations "github.com/dapr/components-contrib/common/component/sql/migrations/postgres"
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	stateutils "github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 )
 
 // PostgreSQL state store.
 type PostgreSQL struct {
-	state.BulkStore
-
 	logger   logger.Logger
 	metadata pgMetadata
 	db       pginterfaces.PGXPoolConn
@@ -56,6 +56,7 @@ type PostgreSQL struct {
 	enableAWSIAM  bool
 
 	awsAuthProvider awsAuth.Provider
+	compression     compression.Codec
 }
 
 type Options struct {
@@ -82,7 +83,6 @@ func NewPostgreSQLStateStoreWithOptions(logger logger.Logger, opts Options) stat
 		enableAzureAD: !opts.NoAzureAD,
 		enableAWSIAM:  !opts.NoAWSIAM,
 	}
-	s.BulkStore = state.NewDefaultBulkStore(s)
 	return s
 }
 
@@ -98,6 +98,11 @@ func (p *PostgreSQL) Init(ctx context.Context, meta state.Metadata) (err error)
 		return err
 	}
 
+	p.compression, err = p.metadata.Codec()
+	if err != nil {
+		return err
+	}
+
 	config, err := p.metadata.GetPgxPoolConfig()
 	if err != nil {
 		return err
@@ -328,6 +333,7 @@ func (p *PostgreSQL) Features() []state.Feature {
 		state.FeatureTransactional,
 		state.FeatureTTL,
 		state.FeatureKeysLike,
+		state.FeatureRangeScan,
 	}
 }
 
@@ -345,13 +351,28 @@ func (p *PostgreSQL) Set(ctx context.Context, req *state.SetRequest) error {
 }
 
 func (p *PostgreSQL) doSet(parentCtx context.Context, db pginterfaces.DBQuerier, req state.SetRequest) error {
+	query, params, err := p.setQuery(&req)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(parentCtx, query, params...)
+	if err != nil {
+		return err
+	}
+
+	return checkSetResult(&req, result)
+}
+
+// setQuery returns the query that saves an item, and its parameters.
+func (p *PostgreSQL) setQuery(req *state.SetRequest) (string, []any, error) {
 	if req.Key == "" {
-		return errors.New("missing key in set operation")
+		return "", nil, errors.New("missing key in set operation")
 	}
 
 	err := state.CheckRequestOptions(req.Options)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	// If the value is a byte slice, accept it as-is; otherwise, encode to JSON
@@ -362,15 +383,16 @@ func (p *PostgreSQL) doSet(parentCtx context.Context, db pginterfaces.DBQuerier,
 	default:
 		value, err = json.Marshal(x)
 		if err != nil {
-			return fmt.Errorf("failed to marshal to JSON: %w", err)
+			return "", nil, fmt.Errorf("failed to marshal to JSON: %w", err)
 		}
 	}
+	value = p.compression.Compress(value)
 
 	// TTL
 	var ttlSeconds int
 	ttl, ttlerr := stateutils.ParseTTL(req.Metadata)
 	if ttlerr != nil {
-		return fmt.Errorf("error parsing TTL: %w", ttlerr)
+		return "", nil, fmt.Errorf("error parsing TTL: %w", ttlerr)
 	}
 	if ttl != nil {
 		ttlSeconds = *ttl
@@ -387,7 +409,7 @@ func (p *PostgreSQL) doSet(parentCtx context.Context, db pginterfaces.DBQuerier,
 		etag, err = uuid.Parse(*req.ETag)
 		if err != nil {
 			// Return an etag mismatch error right away if the etag is invalid
-			return state.NewETagError(state.ETagMismatch, err)
+			return "", nil, state.NewETagError(state.ETagMismatch, err)
 		}
 
 		params = []any{req.Key, value, etag.String()}
@@ -437,10 +459,11 @@ WHERE
   AND (expires_at IS NULL OR expires_at >= now());`
 	}
 
-	result, err := db.Exec(parentCtx, query, params...)
-	if err != nil {
-		return err
-	}
+	return query, params, nil
+}
+
+// checkSetResult returns an error if the query that saved an item didn't update it.
+func checkSetResult(req *state.SetRequest, result pgconn.CommandTag) error {
 	if result.RowsAffected() != 1 {
 		if req.HasETag() {
 			return state.NewETagError(state.ETagMismatch, nil)
@@ -482,6 +505,11 @@ WHERE
 		return nil, err
 	}
 
+	value, err = compression.Decompress(value)
+	if err != nil {
+		return nil, err
+	}
+
 	resp := &state.GetResponse{
 		Data: value,
 		ETag: etag,
@@ -535,6 +563,9 @@ WHERE
 		r := state.BulkGetResponse{}
 		var expireTime *time.Time
 		err = rows.Scan(&r.Key, &r.Data, &r.ETag, &expireTime)
+		if err == nil {
+			r.Data, err = compression.Decompress(r.Data)
+		}
 		if err != nil {
 			r.Error = err.Error()
 		}
@@ -570,6 +601,96 @@ WHERE
 	return res[:n], nil
 }
 
+// Scan returns the items whose keys are in the requested range, in key order.
+func (p *PostgreSQL) Scan(parentCtx context.Context, req *state.ScanRequest) (*state.ScanResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys are compared with the "C" collation, so they're ordered byte-wise regardless of the database's locale
+	where := []string{
+		`key COLLATE "C" >= $1`,
+		`(expires_at IS NULL OR expires_at >= now())`,
+	}
+	args := []any{req.Start}
+	if req.End != "" {
+		args = append(args, req.End)
+		where = append(where, fmt.Sprintf(`key COLLATE "C" < $%d`, len(args)))
+	}
+
+	// Pagination: resume strictly after the last returned key
+	order := "ASC"
+	if req.ContinuationToken != nil && *req.ContinuationToken != "" {
+		args = append(args, *req.ContinuationToken)
+		if req.Reverse {
+			where = append(where, fmt.Sprintf(`key COLLATE "C" < $%d`, len(args)))
+		} else {
+			where = append(where, fmt.Sprintf(`key COLLATE "C" > $%d`, len(args)))
+		}
+	}
+	if req.Reverse {
+		order = "DESC"
+	}
+
+	// Fetch one extra row to detect if there's a next page
+	limitClause := ""
+	if req.Limit > 0 {
+		args = append(args, req.Limit+1)
+		limitClause = fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	query := `
+SELECT
+  key, value, etag, expires_at
+FROM ` + p.metadata.TableName(pgTableState) + `
+WHERE
+  ` + strings.Join(where, " AND ") + `
+ORDER BY key COLLATE "C" ` + order + limitClause
+	ctx, cancel := context.WithTimeout(parentCtx, p.metadata.Timeout)
+	defer cancel()
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := &state.ScanResponse{
+		Items: make([]state.ScanItem, 0, min(req.Limit, 1024)),
+	}
+	for rows.Next() {
+		//nolint:gosec
+		if req.Limit > 0 && uint32(len(res.Items)) == req.Limit {
+			res.ContinuationToken = ptr.Of(res.Items[len(res.Items)-1].Key)
+			break
+		}
+
+		var (
+			item       state.ScanItem
+			expireTime *time.Time
+		)
+		err = rows.Scan(&item.Key, &item.Data, &item.ETag, &expireTime)
+		if err != nil {
+			return nil, err
+		}
+		item.Data, err = compression.Decompress(item.Data)
+		if err != nil {
+			return nil, err
+		}
+		if expireTime != nil {
+			item.Metadata = map[string]string{
+				state.GetRespMetaKeyTTLExpireTime: expireTime.UTC().Format(time.RFC3339),
+			}
+		}
+		res.Items = append(res.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // Delete removes an item from the state store.
 func (p *PostgreSQL) Delete(ctx context.Context, req *state.DeleteRequest) error {
 	if req == nil {
@@ -579,38 +700,112 @@ func (p *PostgreSQL) Delete(ctx context.Context, req *state.DeleteRequest) error
 }
 
 func (p *PostgreSQL) doDelete(parentCtx context.Context, db pginterfaces.DBQuerier, req state.DeleteRequest) (err error) {
-	if req.Key == "" {
-		return errors.New("missing key in delete operation")
+	query, params, err := p.deleteQuery(&req)
+	if err != nil {
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(parentCtx, p.metadata.Timeout)
 	defer cancel()
-	var result pgconn.CommandTag
-	if req.HasETag() {
-		// Check if the etag is valid
-		var etag uuid.UUID
-		etag, err = uuid.Parse(*req.ETag)
-		if err != nil {
-			// Return an etag mismatch error right away if the etag is invalid
-			return state.NewETagError(state.ETagMismatch, err)
-		}
+	result, err := db.Exec(ctx, query, params...)
+	if err != nil {
+		return err
+	}
 
-		result, err = db.Exec(ctx, "DELETE FROM "+p.metadata.TableName(pgTableState)+" WHERE key = $1 AND etag = $2", req.Key, etag)
-	} else {
-		result, err = db.Exec(ctx, "DELETE FROM "+p.metadata.TableName(pgTableState)+" WHERE key = $1", req.Key)
+	return checkDeleteResult(&req, result)
+}
+
+// deleteQuery returns the query that deletes an item, and its parameters.
+func (p *PostgreSQL) deleteQuery(req *state.DeleteRequest) (string, []any, error) {
+	if req.Key == "" {
+		return "", nil, errors.New("missing key in delete operation")
 	}
+
+	if !req.HasETag() {
+		return "DELETE FROM " + p.metadata.TableName(pgTableState) + " WHERE key = $1", []any{req.Key}, nil
+	}
+
+	// Check if the etag is valid
+	etag, err := uuid.Parse(*req.ETag)
 	if err != nil {
-		return err
+		// Return an etag mismatch error right away if the etag is invalid
+		return "", nil, state.NewETagError(state.ETagMismatch, err)
 	}
 
-	rows := result.RowsAffected()
-	if rows != 1 && req.HasETag() {
+	return "DELETE FROM " + p.metadata.TableName(pgTableState) + " WHERE key = $1 AND etag = $2", []any{req.Key, etag}, nil
+}
+
+// checkDeleteResult returns an error if the query that deleted an item with an ETag didn't delete it.
+func checkDeleteResult(req *state.DeleteRequest, result pgconn.CommandTag) error {
+	if result.RowsAffected() != 1 && req.HasETag() {
 		return state.NewETagError(state.ETagMismatch, nil)
 	}
 
 	return nil
 }
 
+// BulkSet saves multiple items, sending the queries in a batch so they're executed in a single round trip.
+// Unlike Multi, an item whose ETag doesn't match doesn't prevent the other items from being saved.
+func (p *PostgreSQL) BulkSet(ctx context.Context, req []state.SetRequest, _ state.BulkStoreOpts) error {
+	errs := make([]error, len(req))
+	queries := make([]pgtransactions.BatchQuery, 0, len(req))
+	queued := make([]int, 0, len(req))
+	for i := range req {
+		query, params, err := p.setQuery(&req[i])
+		if err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, err)
+			continue
+		}
+		queries = append(queries, pgtransactions.BatchQuery{
+			Query: query,
+			Args:  params,
+			Check: func(tag pgconn.CommandTag) error {
+				return checkSetResult(&req[i], tag)
+			},
+		})
+		queued = append(queued, i)
+	}
+
+	for j, err := range pgtransactions.ExecuteBatch(ctx, p.db, p.metadata.Timeout, queries) {
+		if err != nil {
+			errs[queued[j]] = state.NewBulkStoreError(req[queued[j]].Key, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// BulkDelete deletes multiple items, sending the queries in a batch so they're executed in a single round trip.
+// Unlike Multi, an item whose ETag doesn't match doesn't prevent the other items from being deleted.
+func (p *PostgreSQL) BulkDelete(ctx context.Context, req []state.DeleteRequest, _ state.BulkStoreOpts) error {
+	errs := make([]error, len(req))
+	queries := make([]pgtransactions.BatchQuery, 0, len(req))
+	queued := make([]int, 0, len(req))
+	for i := range req {
+		query, params, err := p.deleteQuery(&req[i])
+		if err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, err)
+			continue
+		}
+		queries = append(queries, pgtransactions.BatchQuery{
+			Query: query,
+			Args:  params,
+			Check: func(tag pgconn.CommandTag) error {
+				return checkDeleteResult(&req[i], tag)
+			},
+		})
+		queued = append(queued, i)
+	}
+
+	for j, err := range pgtransactions.ExecuteBatch(ctx, p.db, p.metadata.Timeout, queries) {
+		if err != nil {
+			errs[queued[j]] = state.NewBulkStoreError(req[queued[j]].Key, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func (p *PostgreSQL) Multi(parentCtx context.Context, request *state.TransactionalStateRequest) error {
 	if request == nil {
 		return nil
@@ -92,6 +92,11 @@ func TestPostgreSQLIntegration(t *testing.T) {
 		testBulkSetAndBulkDelete(t, pgs)
 	})
 
+	t.Run("Bulk set and bulk delete with etags", func(t *testing.T) {
+		t.Parallel()
+		testBulkSetAndBulkDeleteWithETags(t, pgs)
+	})
+
 	t.Run("Update and delete with etag succeeds", func(t *testing.T) {
 		t.Parallel()
 		updateAndDeleteWithEtagSucceeds(t, pgs)
@@ -428,6 +433,44 @@ func testBulkSetAndBulkDelete(t *testing.T, pgs *postgresql.PostgreSQL) {
 	assert.False(t, storeItemExists(t, setReq[1].Key))
 }
 
+func testBulkSetAndBulkDeleteWithETags(t *testing.T, pgs *postgresql.PostgreSQL) {
+	keys := []string{randomKey(), randomKey()}
+	for _, key := range keys {
+		setItem(t, pgs, key, randomJSON(), nil)
+	}
+	first, _ := getItem(t, pgs, keys[0])
+	second, _ := getItem(t, pgs, keys[1])
+	// Update the second item so its etag is stale
+	setItem(t, pgs, keys[1], randomJSON(), second.ETag)
+
+	// Items whose etag doesn't match are reported, but the other items are saved
+	err := pgs.BulkSet(t.Context(), []state.SetRequest{
+		{Key: keys[0], Value: &fakeItem{Color: "blue"}, ETag: first.ETag},
+		{Key: keys[1], Value: &fakeItem{Color: "red"}, ETag: second.ETag},
+	}, state.BulkStoreOpts{})
+	var bulkErr state.BulkStoreError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, keys[1], bulkErr.Key())
+	require.NotNil(t, bulkErr.ETagError())
+	assert.Equal(t, state.ETagMismatch, bulkErr.ETagError().Kind())
+
+	_, item := getItem(t, pgs, keys[0])
+	assert.Equal(t, "blue", item.Color)
+	_, item = getItem(t, pgs, keys[1])
+	assert.NotEqual(t, "red", item.Color)
+
+	// The etag of the first item changed when it was saved
+	err = pgs.BulkDelete(t.Context(), []state.DeleteRequest{
+		{Key: keys[0], ETag: first.ETag},
+		{Key: keys[1]},
+	}, state.BulkStoreOpts{})
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, keys[0], bulkErr.Key())
+	require.NotNil(t, bulkErr.ETagError())
+	assert.True(t, storeItemExists(t, keys[0]))
+	assert.False(t, storeItemExists(t, keys[1]))
+}
+
 // testInitConfiguration tests valid and invalid config settings.
 func testInitConfiguration(t *testing.T) {
 	logger := logger.NewLogger("test")
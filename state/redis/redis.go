@@ -29,6 +29,7 @@ import (
 	"github.com/dapr/components-contrib/contenttype"
 	daprmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/components-contrib/state/query"
 	"github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
@@ -47,14 +48,22 @@ const (
 	  if ARGV[3] == "0" then
 	    redis.call("HSET", KEYS[1], "first-write", 0);
 	  end;
-	  return redis.call("HINCRBY", KEYS[1], "version", 1)
+	  local version = redis.call("HINCRBY", KEYS[1], "version", 1);
+	  if KEYS[2] then
+	    redis.call("ZADD", KEYS[2], 0, KEYS[1]);
+	  end;
+	  return version
 	else
 	  return error("failed to set key " .. KEYS[1])
 	end`
 	delDefaultQuery = `
 	local etag = redis.pcall("HGET", KEYS[1], "version");
 	if not etag or type(etag)=="table" or etag == ARGV[1] or etag == "" or ARGV[1] == "0" then
-	  return redis.call("DEL", KEYS[1])
+	  local deleted = redis.call("DEL", KEYS[1]);
+	  if KEYS[2] then
+	    redis.call("ZREM", KEYS[2], KEYS[1]);
+	  end;
+	  return deleted
 	else
 	  return error("failed to delete " .. KEYS[1])
 	end`
@@ -72,17 +81,30 @@ const (
 	  if ARGV[3] == "0" then
 	    redis.call("JSON.SET", KEYS[1], ".first-write", 0);
 	  end;
-	  return redis.call("JSON.SET", KEYS[1], ".version", (etag+1))
+	  local res = redis.call("JSON.SET", KEYS[1], ".version", (etag+1));
+	  if KEYS[2] then
+	    redis.call("ZADD", KEYS[2], 0, KEYS[1]);
+	  end;
+	  return res
 	else
 	  return error("failed to set key " .. KEYS[1])
 	end`
 	delJSONQuery = `
 	local etag = redis.pcall("JSON.GET", KEYS[1], ".version");
 	if not etag or type(etag)=="table" or etag == ARGV[1] or etag == "" or ARGV[1] == "0" then
-	  return redis.call("JSON.DEL", KEYS[1])
+	  local deleted = redis.call("JSON.DEL", KEYS[1]);
+	  if KEYS[2] then
+	    redis.call("ZREM", KEYS[2], KEYS[1]);
+	  end;
+	  return deleted
 	else
 	  return error("failed to delete " .. KEYS[1])
 	end`
+	unindexMissingQuery = `
+	if redis.call("EXISTS", KEYS[2]) == 0 then
+	  return redis.call("ZREM", KEYS[1], KEYS[2])
+	end;
+	return 0`
 	connectedSlavesReplicas  = "connected_slaves:"
 	infoReplicationDelimiter = "\r\n"
 	ttlInSeconds             = "ttlInSeconds"
@@ -91,16 +113,18 @@ const (
 	defaultDB                = 0
 )
 
+// Number of keys read from the range scan index at a time.
+const scanBatchSize uint32 = 100
+
 // StateStore is a Redis state store.
 type StateStore struct {
-	state.BulkStore
-
 	client                         rediscomponent.RedisClient
 	clientSettings                 *rediscomponent.Settings
 	clientHasJSON                  bool
 	json                           jsoniter.API
 	replicas                       int
 	querySchemas                   querySchemas
+	compression                    compression.Codec
 	suppressActorStateStoreWarning atomic.Bool
 
 	logger logger.Logger
@@ -108,9 +132,7 @@ type StateStore struct {
 
 // NewRedisStateStore returns a new redis state store.
 func NewRedisStateStore(log logger.Logger) state.Store {
-	s := newStateStore(log)
-	s.BulkStore = state.NewDefaultBulkStore(s)
-	return s
+	return newStateStore(log)
 }
 
 func newStateStore(log logger.Logger) *StateStore {
@@ -137,6 +159,15 @@ func (r *StateStore) Init(ctx context.Context, metadata state.Metadata) error {
 		return err
 	}
 
+	// The range scan index is updated in the same script as each key, which requires them to be in the same hash slot
+	if r.clientSettings.RangeScanIndex != "" && r.clientSettings.RedisType == rediscomponent.ClusterType {
+		return errors.New("redis store: the 'rangeScanIndex' metadata property is not supported with Redis Cluster")
+	}
+
+	if r.compression, err = compression.NewCodec(metadata.Properties); err != nil {
+		return fmt.Errorf("redis store: error parsing compression options: %w", err)
+	}
+
 	// check for query schemas
 	if r.querySchemas, err = parseQuerySchemas(r.clientSettings.QueryIndexes); err != nil {
 		return fmt.Errorf("redis store: error parsing query index schema: %w", err)
@@ -161,11 +192,14 @@ func (r *StateStore) Init(ctx context.Context, metadata state.Metadata) error {
 
 // Features returns the features available in this state store.
 func (r *StateStore) Features() []state.Feature {
+	features := []state.Feature{state.FeatureETag, state.FeatureTransactional, state.FeatureTTL, state.FeatureKeysLike}
 	if r.clientHasJSON {
-		return []state.Feature{state.FeatureETag, state.FeatureTransactional, state.FeatureTTL, state.FeatureQueryAPI, state.FeatureKeysLike}
-	} else {
-		return []state.Feature{state.FeatureETag, state.FeatureTransactional, state.FeatureTTL, state.FeatureKeysLike}
+		features = append(features, state.FeatureQueryAPI)
+	}
+	if r.clientSettings != nil && r.clientSettings.RangeScanIndex != "" {
+		features = append(features, state.FeatureRangeScan)
 	}
+	return features
 }
 
 func (r *StateStore) getConnectedSlaves(ctx context.Context) (int, error) {
@@ -209,9 +243,9 @@ func (r *StateStore) Delete(ctx context.Context, req *state.DeleteRequest) error
 	}
 
 	if req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType && r.clientHasJSON {
-		err = r.client.DoWrite(ctx, "EVAL", delJSONQuery, 1, req.Key, *req.ETag)
+		err = r.client.DoWrite(ctx, r.evalArgs(delJSONQuery, req.Key, *req.ETag)...)
 	} else {
-		err = r.client.DoWrite(ctx, "EVAL", delDefaultQuery, 1, req.Key, *req.ETag)
+		err = r.client.DoWrite(ctx, r.evalArgs(delDefaultQuery, req.Key, *req.ETag)...)
 	}
 	if err != nil {
 		return state.NewETagError(state.ETagMismatch, err)
@@ -231,9 +265,13 @@ func (r *StateStore) directGet(ctx context.Context, req *state.GetRequest) (*sta
 	}
 
 	s, _ := strconv.Unquote(fmt.Sprintf("%q", res))
+	data, err := compression.Decompress([]byte(s))
+	if err != nil {
+		return nil, err
+	}
 
 	return &state.GetResponse{
-		Data: []byte(s),
+		Data: data,
 	}, nil
 }
 
@@ -242,6 +280,12 @@ func (r *StateStore) getDefault(ctx context.Context, req *state.GetRequest) (*st
 	if err != nil {
 		return r.directGet(ctx, req) // Falls back to original get for backward compats.
 	}
+
+	return r.parseDefaultResult(res)
+}
+
+// parseDefaultResult parses the result of HGETALL.
+func (r *StateStore) parseDefaultResult(res any) (*state.GetResponse, error) {
 	if res == nil {
 		return &state.GetResponse{}, nil
 	}
@@ -263,9 +307,13 @@ func (r *StateStore) getDefault(ctx context.Context, req *state.GetRequest) (*st
 	if err != nil {
 		return nil, err
 	}
+	bt, err := compression.Decompress([]byte(data))
+	if err != nil {
+		return nil, err
+	}
 
 	return &state.GetResponse{
-		Data: []byte(data),
+		Data: bt,
 		ETag: version,
 	}, nil
 }
@@ -276,6 +324,11 @@ func (r *StateStore) getJSON(ctx context.Context, req *state.GetRequest) (*state
 		return nil, err
 	}
 
+	return r.parseJSONResult(res)
+}
+
+// parseJSONResult parses the result of JSON.GET.
+func (r *StateStore) parseJSONResult(res any) (*state.GetResponse, error) {
 	if res == nil {
 		return &state.GetResponse{}, nil
 	}
@@ -286,7 +339,7 @@ func (r *StateStore) getJSON(ctx context.Context, req *state.GetRequest) (*state
 	}
 
 	var entry jsonEntry
-	if err = r.json.UnmarshalFromString(str, &entry); err != nil {
+	if err := r.json.UnmarshalFromString(str, &entry); err != nil {
 		return nil, err
 	}
 
@@ -347,10 +400,10 @@ func (r *StateStore) Set(ctx context.Context, req *state.SetRequest) error {
 
 	if req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType && r.clientHasJSON {
 		bt, _ := utils.Marshal(&jsonEntry{Data: req.Value}, r.json.Marshal)
-		err = r.client.DoWrite(ctx, "EVAL", setJSONQuery, 1, req.Key, ver, bt, firstWrite)
+		err = r.client.DoWrite(ctx, r.evalArgs(setJSONQuery, req.Key, ver, bt, firstWrite)...)
 	} else {
-		bt, _ := utils.Marshal(req.Value, r.json.Marshal)
-		err = r.client.DoWrite(ctx, "EVAL", setDefaultQuery, 1, req.Key, ver, bt, firstWrite)
+		bt := r.encodeValue(req.Value)
+		err = r.client.DoWrite(ctx, r.evalArgs(setDefaultQuery, req.Key, ver, bt, firstWrite)...)
 	}
 
 	if err != nil {
@@ -415,10 +468,10 @@ func (r *StateStore) Multi(ctx context.Context, request *state.TransactionalStat
 				(len(req.Metadata) > 0 && req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType)
 			if isReqJSON {
 				bt, _ = utils.Marshal(&jsonEntry{Data: req.Value}, r.json.Marshal)
-				pipe.Do(ctx, "EVAL", setJSONQuery, 1, req.Key, ver, bt)
+				pipe.Do(ctx, r.evalArgs(setJSONQuery, req.Key, ver, bt)...)
 			} else {
-				bt, _ = utils.Marshal(req.Value, r.json.Marshal)
-				pipe.Do(ctx, "EVAL", setDefaultQuery, 1, req.Key, ver, bt)
+				bt = r.encodeValue(req.Value)
+				pipe.Do(ctx, r.evalArgs(setDefaultQuery, req.Key, ver, bt)...)
 			}
 			if ttl != nil && *ttl > 0 {
 				pipe.Do(ctx, "EXPIRE", req.Key, *ttl)
@@ -434,9 +487,9 @@ func (r *StateStore) Multi(ctx context.Context, request *state.TransactionalStat
 			isReqJSON := isJSON ||
 				(len(req.Metadata) > 0 && req.Metadata[daprmetadata.ContentType] == contenttype.JSONContentType)
 			if isReqJSON {
-				pipe.Do(ctx, "EVAL", delJSONQuery, 1, req.Key, *req.ETag)
+				pipe.Do(ctx, r.evalArgs(delJSONQuery, req.Key, *req.ETag)...)
 			} else {
-				pipe.Do(ctx, "EVAL", delDefaultQuery, 1, req.Key, *req.ETag)
+				pipe.Do(ctx, r.evalArgs(delDefaultQuery, req.Key, *req.ETag)...)
 			}
 		}
 	}
@@ -446,6 +499,221 @@ func (r *StateStore) Multi(ctx context.Context, request *state.TransactionalStat
 	return err
 }
 
+// BulkGet retrieves multiple items in a pipeline, so they're read in a single round trip.
+func (r *StateStore) BulkGet(ctx context.Context, req []state.GetRequest, _ state.BulkGetOpts) ([]state.BulkGetResponse, error) {
+	if len(req) == 0 {
+		return []state.BulkGetResponse{}, nil
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]rediscomponent.RedisCmdResult, len(req))
+	for i := range req {
+		if r.isJSONRequest(req[i].Metadata) {
+			cmds[i] = pipe.DoResult(ctx, "JSON.GET", req[i].Key)
+		} else {
+			cmds[i] = pipe.DoResult(ctx, "HGETALL", req[i].Key)
+		}
+	}
+	// Errors are returned by each command
+	_ = pipe.Exec(ctx)
+
+	res := make([]state.BulkGetResponse, len(req))
+	for i := range req {
+		res[i].Key = req[i].Key
+
+		var item *state.GetResponse
+		val, err := cmds[i].Result()
+		switch {
+		case r.isJSONRequest(req[i].Metadata):
+			// Missing keys return a nil reply
+			if err != nil && err.Error() == r.client.GetNilValueError().Error() {
+				val, err = nil, nil
+			}
+			if err == nil {
+				item, err = r.parseJSONResult(val)
+			}
+		case err != nil:
+			// Falls back to original get for backward compats.
+			item, err = r.directGet(ctx, &req[i])
+		default:
+			item, err = r.parseDefaultResult(val)
+		}
+		if err != nil {
+			res[i].Error = err.Error()
+			continue
+		}
+		res[i].Data = item.Data
+		res[i].ETag = item.ETag
+	}
+
+	return res, nil
+}
+
+// BulkSet saves multiple items in a pipeline, so they're stored in a single round trip.
+// Unlike Multi, the operations aren't transactional: each item is saved or fails independently.
+func (r *StateStore) BulkSet(ctx context.Context, req []state.SetRequest, _ state.BulkStoreOpts) error {
+	if len(req) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(req))
+	ttls := make([]*int, len(req))
+	cmds := make([]rediscomponent.RedisCmdResult, len(req))
+	strong := false
+	pipe := r.client.Pipeline()
+	for i := range req {
+		err := state.CheckRequestOptions(req[i].Options)
+		if err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, err)
+			continue
+		}
+		ver, err := r.parseETag(&req[i])
+		if err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, err)
+			continue
+		}
+		ttls[i], err = r.parseTTL(&req[i])
+		if err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, fmt.Errorf("failed to parse ttl from metadata: %w", err))
+			continue
+		}
+		// apply global TTL
+		if ttls[i] == nil {
+			ttls[i] = r.clientSettings.TTLInSeconds
+		}
+
+		firstWrite := 1
+		if req[i].Options.Concurrency == state.FirstWrite {
+			firstWrite = 0
+		}
+		if req[i].Options.Consistency == state.Strong {
+			strong = true
+		}
+
+		if r.isJSONRequest(req[i].Metadata) {
+			bt, _ := utils.Marshal(&jsonEntry{Data: req[i].Value}, r.json.Marshal)
+			cmds[i] = pipe.DoResult(ctx, r.evalArgs(setJSONQuery, req[i].Key, ver, bt, firstWrite)...)
+		} else {
+			bt := r.encodeValue(req[i].Value)
+			cmds[i] = pipe.DoResult(ctx, r.evalArgs(setDefaultQuery, req[i].Key, ver, bt, firstWrite)...)
+		}
+	}
+	// Errors are returned by each command
+	_ = pipe.Exec(ctx)
+
+	// The TTLs are set once the items are saved, as an item whose ETag doesn't match must keep its TTL
+	ttlPipe := r.client.Pipeline()
+	ttlCmds := make([]rediscomponent.RedisCmdResult, len(req))
+	hasTTL := false
+	for i := range req {
+		if cmds[i] == nil {
+			continue
+		}
+		if _, err := cmds[i].Result(); err != nil {
+			if req[i].HasETag() {
+				errs[i] = state.NewBulkStoreError(req[i].Key, state.NewETagError(state.ETagMismatch, err))
+			} else {
+				errs[i] = state.NewBulkStoreError(req[i].Key, fmt.Errorf("failed to set key %s: %w", req[i].Key, err))
+			}
+			continue
+		}
+		switch {
+		case ttls[i] == nil:
+			continue
+		case *ttls[i] > 0:
+			ttlCmds[i] = ttlPipe.DoResult(ctx, "EXPIRE", req[i].Key, *ttls[i])
+		default:
+			ttlCmds[i] = ttlPipe.DoResult(ctx, "PERSIST", req[i].Key)
+		}
+		hasTTL = true
+	}
+	if hasTTL {
+		_ = ttlPipe.Exec(ctx)
+		for i := range req {
+			if ttlCmds[i] == nil {
+				continue
+			}
+			if _, err := ttlCmds[i].Result(); err != nil {
+				errs[i] = state.NewBulkStoreError(req[i].Key, fmt.Errorf("failed to set key %s ttl: %w", req[i].Key, err))
+			}
+		}
+	}
+
+	if strong && r.replicas > 0 {
+		err := r.client.DoWrite(ctx, "WAIT", r.replicas, 1000)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("redis waiting for %v replicas to acknowledge write, err: %w", r.replicas, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// BulkDelete deletes multiple items in a pipeline, so they're deleted in a single round trip.
+// Unlike Multi, the operations aren't transactional: each item is deleted or fails independently.
+func (r *StateStore) BulkDelete(ctx context.Context, req []state.DeleteRequest, _ state.BulkStoreOpts) error {
+	if len(req) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(req))
+	cmds := make([]rediscomponent.RedisCmdResult, len(req))
+	pipe := r.client.Pipeline()
+	for i := range req {
+		err := state.CheckRequestOptions(req[i].Options)
+		if err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, err)
+			continue
+		}
+
+		etag := "0"
+		if req[i].HasETag() {
+			etag = *req[i].ETag
+		}
+		if r.isJSONRequest(req[i].Metadata) {
+			cmds[i] = pipe.DoResult(ctx, r.evalArgs(delJSONQuery, req[i].Key, etag)...)
+		} else {
+			cmds[i] = pipe.DoResult(ctx, r.evalArgs(delDefaultQuery, req[i].Key, etag)...)
+		}
+	}
+	// Errors are returned by each command
+	_ = pipe.Exec(ctx)
+
+	for i := range req {
+		if cmds[i] == nil {
+			continue
+		}
+		if _, err := cmds[i].Result(); err != nil {
+			errs[i] = state.NewBulkStoreError(req[i].Key, state.NewETagError(state.ETagMismatch, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// encodeValue serializes the value of a request that isn't stored as a JSON document, compressing it if configured.
+// JSON documents are never compressed, so they can be indexed and queried.
+func (r *StateStore) encodeValue(value any) []byte {
+	bt, _ := utils.Marshal(value, r.json.Marshal)
+	return r.compression.Compress(bt)
+}
+
+// evalArgs returns the arguments of an EVAL command that runs a script to set or delete the key.
+// If the range scan index is enabled, it's passed to the script as a second key, so the index is only updated if the script succeeds.
+func (r *StateStore) evalArgs(script string, key string, args ...any) []any {
+	res := make([]any, 0, 5+len(args))
+	if r.clientSettings.RangeScanIndex == "" {
+		res = append(res, "EVAL", script, 1, key)
+	} else {
+		res = append(res, "EVAL", script, 2, key, r.clientSettings.RangeScanIndex)
+	}
+	return append(res, args...)
+}
+
+func (r *StateStore) isJSONRequest(md map[string]string) bool {
+	return r.clientHasJSON && md[daprmetadata.ContentType] == contenttype.JSONContentType
+}
+
 func (r *StateStore) registerSchemas(ctx context.Context) error {
 	for name, elem := range r.querySchemas {
 		r.logger.Infof("create query index %s", name)
@@ -586,6 +854,7 @@ func (r *StateStore) Close() error {
 func (r *StateStore) GetComponentMetadata() (metadataInfo daprmetadata.MetadataMap) {
 	settingsStruct := rediscomponent.Settings{}
 	daprmetadata.GetMetadataInfoFromStructType(reflect.TypeOf(settingsStruct), &metadataInfo, daprmetadata.StateStoreType)
+	daprmetadata.GetMetadataInfoFromStructType(reflect.TypeOf(compression.Metadata{}), &metadataInfo, daprmetadata.StateStoreType)
 	return
 }
 
@@ -687,6 +956,104 @@ func (r *StateStore) KeysLike(ctx context.Context, req *state.KeysLikeRequest) (
 	}, nil
 }
 
+// Scan returns the items whose keys are in the requested range, in lexicographical order.
+// Keys are read from the sorted set configured with the "rangeScanIndex" metadata property, whose members all have the same score so they're sorted lexicographically.
+// Index entries for keys that have expired are removed as they're found.
+func (r *StateStore) Scan(ctx context.Context, req *state.ScanRequest) (*state.ScanResponse, error) {
+	if r.clientSettings.RangeScanIndex == "" {
+		return nil, errors.New("range scans require the 'rangeScanIndex' metadata property to be set")
+	}
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	lower, upper := "-", "+"
+	if req.Start != "" {
+		lower = "[" + req.Start
+	}
+	if req.End != "" {
+		upper = "(" + req.End
+	}
+	if req.ContinuationToken != nil {
+		if req.Reverse {
+			upper = "(" + *req.ContinuationToken
+		} else {
+			lower = "(" + *req.ContinuationToken
+		}
+	}
+
+	batch := int(scanBatchSize)
+	if req.Limit > 0 && req.Limit < scanBatchSize {
+		batch = int(req.Limit) + 1
+	}
+
+	res := &state.ScanResponse{
+		Items: []state.ScanItem{},
+	}
+	for {
+		var keysRes any
+		if req.Reverse {
+			keysRes, err = r.client.DoRead(ctx, "ZREVRANGEBYLEX", r.clientSettings.RangeScanIndex, upper, lower, "LIMIT", 0, batch)
+		} else {
+			keysRes, err = r.client.DoRead(ctx, "ZRANGEBYLEX", r.clientSettings.RangeScanIndex, lower, upper, "LIMIT", 0, batch)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the range scan index: %w", err)
+		}
+		keys, _ := keysRes.([]any)
+		if len(keys) == 0 {
+			return res, nil
+		}
+
+		getReqs := make([]state.GetRequest, 0, len(keys))
+		for _, k := range keys {
+			key, ok := toString(k)
+			if !ok {
+				return nil, errors.New("unexpected member in the range scan index")
+			}
+			getReqs = append(getReqs, state.GetRequest{Key: key, Metadata: req.Metadata})
+		}
+		items, err := r.BulkGet(ctx, getReqs, state.BulkGetOpts{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			if item.Error != "" {
+				return nil, fmt.Errorf("failed to get key %s: %s", item.Key, item.Error)
+			}
+			if item.Data == nil && item.ETag == nil {
+				// The key has expired: remove it from the index, unless it was set again in the meanwhile
+				// This is best-effort: errors are ignored, as the entry will be removed in a later scan
+				_ = r.client.DoWrite(ctx, "EVAL", unindexMissingQuery, 2, r.clientSettings.RangeScanIndex, item.Key)
+				continue
+			}
+
+			//nolint:gosec
+			if req.Limit > 0 && uint32(len(res.Items)) == req.Limit {
+				res.ContinuationToken = ptr.Of(res.Items[len(res.Items)-1].Key)
+				return res, nil
+			}
+			res.Items = append(res.Items, state.ScanItem{
+				Key:  item.Key,
+				Data: item.Data,
+				ETag: item.ETag,
+			})
+		}
+
+		if len(keys) < batch {
+			return res, nil
+		}
+		last := "(" + getReqs[len(getReqs)-1].Key
+		if req.Reverse {
+			upper = last
+		} else {
+			lower = last
+		}
+	}
+}
+
 func likeToRedisGlob(pat string) (string, error) {
 	var b strings.Builder
 	b.Grow(len(pat))
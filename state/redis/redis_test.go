@@ -15,6 +15,7 @@ package redis
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,7 +26,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	rediscomponent "github.com/dapr/components-contrib/common/component/redis"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/ptr"
 )
@@ -461,6 +464,253 @@ func TestSetRequestWithTTL(t *testing.T) {
 	})
 }
 
+func TestBulk(t *testing.T) {
+	s, c := setupMiniredis()
+	defer s.Close()
+
+	ss := &StateStore{
+		client:         c,
+		clientSettings: &rediscomponent.Settings{},
+		json:           jsoniter.ConfigFastest,
+		logger:         logger.NewLogger("test"),
+	}
+
+	t.Run("set", func(t *testing.T) {
+		err := ss.BulkSet(t.Context(), []state.SetRequest{
+			{Key: "weapon", Value: "deathstar"},
+			{Key: "weapon2", Value: "deathstar2", Metadata: map[string]string{"ttlInSeconds": "123"}},
+			{Key: "weapon3", Value: "deathstar3"},
+		}, state.BulkStoreOpts{})
+		require.NoError(t, err)
+
+		ttl, err := ss.client.TTLResult(t.Context(), "weapon2")
+		require.NoError(t, err)
+		assert.Equal(t, 123*time.Second, ttl)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		res, err := ss.BulkGet(t.Context(), []state.GetRequest{
+			{Key: "weapon"},
+			{Key: "weapon2"},
+			{Key: "missing"},
+		}, state.BulkGetOpts{})
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+		assert.Equal(t, state.BulkGetResponse{Key: "weapon", Data: []byte(`"deathstar"`), ETag: ptr.Of("1")}, res[0])
+		assert.Equal(t, state.BulkGetResponse{Key: "weapon2", Data: []byte(`"deathstar2"`), ETag: ptr.Of("1")}, res[1])
+		assert.Equal(t, state.BulkGetResponse{Key: "missing"}, res[2])
+	})
+
+	t.Run("set with etags", func(t *testing.T) {
+		err := ss.BulkSet(t.Context(), []state.SetRequest{
+			{Key: "weapon", Value: "deathstar-1", ETag: ptr.Of("1")},
+			{Key: "weapon2", Value: "deathstar2-1", ETag: ptr.Of("2"), Metadata: map[string]string{"ttlInSeconds": "-1"}},
+			{Key: "weapon4", Value: "deathstar4", ETag: ptr.Of("invalid")},
+		}, state.BulkStoreOpts{})
+		require.Error(t, err)
+
+		var bulkErr state.BulkStoreError
+		errs := err.(interface{ Unwrap() []error }).Unwrap()
+		require.Len(t, errs, 2)
+		require.ErrorAs(t, errs[0], &bulkErr)
+		assert.Equal(t, "weapon2", bulkErr.Key())
+		require.NotNil(t, bulkErr.ETagError())
+		assert.Equal(t, state.ETagMismatch, bulkErr.ETagError().Kind())
+		require.ErrorAs(t, errs[1], &bulkErr)
+		assert.Equal(t, "weapon4", bulkErr.Key())
+		require.NotNil(t, bulkErr.ETagError())
+		assert.Equal(t, state.ETagInvalid, bulkErr.ETagError().Kind())
+
+		res, err := ss.Get(t.Context(), &state.GetRequest{Key: "weapon"})
+		require.NoError(t, err)
+		assert.Equal(t, `"deathstar-1"`, string(res.Data))
+		assert.Equal(t, ptr.Of("2"), res.ETag)
+
+		// The TTL of items that failed isn't changed
+		ttl, err := ss.client.TTLResult(t.Context(), "weapon2")
+		require.NoError(t, err)
+		assert.Equal(t, 123*time.Second, ttl)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := ss.BulkDelete(t.Context(), []state.DeleteRequest{
+			{Key: "weapon", ETag: ptr.Of("2")},
+			{Key: "weapon2", ETag: ptr.Of("9")},
+			{Key: "weapon3"},
+		}, state.BulkStoreOpts{})
+		require.Error(t, err)
+
+		var bulkErr state.BulkStoreError
+		require.ErrorAs(t, err, &bulkErr)
+		assert.Equal(t, "weapon2", bulkErr.Key())
+		require.NotNil(t, bulkErr.ETagError())
+
+		res, err := ss.BulkGet(t.Context(), []state.GetRequest{{Key: "weapon"}, {Key: "weapon2"}, {Key: "weapon3"}}, state.BulkGetOpts{})
+		require.NoError(t, err)
+		assert.Nil(t, res[0].Data)
+		assert.Equal(t, []byte(`"deathstar2"`), res[1].Data)
+		assert.Nil(t, res[2].Data)
+	})
+}
+
+func TestCompression(t *testing.T) {
+	s, c := setupMiniredis()
+	defer s.Close()
+
+	codec, err := compression.NewCodec(map[string]string{"compression": "zstd", "compressionThreshold": "64"})
+	require.NoError(t, err)
+	ss := &StateStore{
+		client:         c,
+		clientSettings: &rediscomponent.Settings{},
+		json:           jsoniter.ConfigFastest,
+		logger:         logger.NewLogger("test"),
+		compression:    codec,
+	}
+
+	large := []byte(`{"value":"` + strings.Repeat("deathstar", 100) + `"}`)
+	require.NoError(t, ss.Set(t.Context(), &state.SetRequest{Key: "large", Value: large}))
+	require.NoError(t, ss.Set(t.Context(), &state.SetRequest{Key: "small", Value: []byte(`"deathstar"`)}))
+	require.NoError(t, ss.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "multi", Value: large},
+		},
+	}))
+	require.NoError(t, ss.BulkSet(t.Context(), []state.SetRequest{{Key: "bulk", Value: large}}, state.BulkStoreOpts{}))
+
+	// Values above the threshold are compressed in Redis
+	for _, key := range []string{"large", "multi", "bulk"} {
+		stored := s.HGet(key, "data")
+		assert.True(t, compression.IsCompressed([]byte(stored)), key)
+		assert.Less(t, len(stored), len(large))
+	}
+	assert.Equal(t, `"deathstar"`, s.HGet("small", "data"))
+
+	res, err := ss.Get(t.Context(), &state.GetRequest{Key: "large"})
+	require.NoError(t, err)
+	assert.Equal(t, large, res.Data)
+
+	// Compressed values are read even if compression is disabled
+	ss.compression = compression.Codec{}
+	bulkRes, err := ss.BulkGet(t.Context(), []state.GetRequest{{Key: "multi"}, {Key: "bulk"}, {Key: "small"}}, state.BulkGetOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, large, bulkRes[0].Data)
+	assert.Equal(t, large, bulkRes[1].Data)
+	assert.Equal(t, []byte(`"deathstar"`), bulkRes[2].Data)
+
+	// Uncompressed binary values that look like compressed ones are returned unchanged
+	lookalike := []byte("\x00dzg not compressed")
+	require.NoError(t, ss.Set(t.Context(), &state.SetRequest{Key: "lookalike", Value: lookalike}))
+	res, err = ss.Get(t.Context(), &state.GetRequest{Key: "lookalike"})
+	require.NoError(t, err)
+	assert.Equal(t, lookalike, res.Data)
+}
+
+func TestScan(t *testing.T) {
+	s, c := setupMiniredis()
+	defer s.Close()
+
+	ss := &StateStore{
+		client:         c,
+		clientSettings: &rediscomponent.Settings{RangeScanIndex: "scan-index"},
+		json:           jsoniter.ConfigFastest,
+		logger:         logger.NewLogger("test"),
+	}
+	assert.Contains(t, ss.Features(), state.FeatureRangeScan)
+
+	require.NoError(t, ss.Set(t.Context(), &state.SetRequest{Key: "sensor||2024-01-01", Value: "a"}))
+	require.NoError(t, ss.Multi(t.Context(), &state.TransactionalStateRequest{
+		Operations: []state.TransactionalStateOperation{
+			state.SetRequest{Key: "sensor||2024-01-02", Value: "b"},
+			state.SetRequest{Key: "other", Value: "c"},
+		},
+	}))
+	require.NoError(t, ss.BulkSet(t.Context(), []state.SetRequest{
+		{Key: "sensor||2024-01-03", Value: "d"},
+		{Key: "sensor||2024-01-04", Value: "e"},
+		{Key: "sensor||2024-02-01", Value: "f"},
+	}, state.BulkStoreOpts{}))
+
+	keys := func(res *state.ScanResponse) []string {
+		k := make([]string, len(res.Items))
+		for i, item := range res.Items {
+			k[i] = item.Key
+		}
+		return k
+	}
+
+	t.Run("range", func(t *testing.T) {
+		res, err := ss.Scan(t.Context(), &state.ScanRequest{Start: "sensor||2024-01", End: "sensor||2024-02"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sensor||2024-01-01", "sensor||2024-01-02", "sensor||2024-01-03", "sensor||2024-01-04"}, keys(res))
+		assert.Equal(t, []byte(`"a"`), res.Items[0].Data)
+		assert.Equal(t, "1", *res.Items[0].ETag)
+		assert.Nil(t, res.ContinuationToken)
+	})
+
+	t.Run("limit and continuation token", func(t *testing.T) {
+		res, err := ss.Scan(t.Context(), &state.ScanRequest{Start: "sensor||", Limit: 3, Reverse: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sensor||2024-02-01", "sensor||2024-01-04", "sensor||2024-01-03"}, keys(res))
+		require.NotNil(t, res.ContinuationToken)
+
+		res, err = ss.Scan(t.Context(), &state.ScanRequest{Start: "sensor||", Limit: 3, Reverse: true, ContinuationToken: res.ContinuationToken})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sensor||2024-01-02", "sensor||2024-01-01"}, keys(res))
+		assert.Nil(t, res.ContinuationToken)
+	})
+
+	t.Run("deleted and expired keys are removed from the index", func(t *testing.T) {
+		require.NoError(t, ss.Delete(t.Context(), &state.DeleteRequest{Key: "sensor||2024-01-01"}))
+		require.NoError(t, ss.BulkDelete(t.Context(), []state.DeleteRequest{{Key: "sensor||2024-01-02"}}, state.BulkStoreOpts{}))
+		s.Del("sensor||2024-01-03")
+
+		res, err := ss.Scan(t.Context(), &state.ScanRequest{Start: "sensor||", End: "sensor||2024-02"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sensor||2024-01-04"}, keys(res))
+
+		members, err := s.ZMembers("scan-index")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"other", "sensor||2024-01-04", "sensor||2024-02-01"}, members)
+	})
+
+	t.Run("index is not updated when the ETag doesn't match", func(t *testing.T) {
+		err := ss.Delete(t.Context(), &state.DeleteRequest{Key: "sensor||2024-01-04", ETag: ptr.Of("100")})
+		require.Error(t, err)
+		err = ss.Multi(t.Context(), &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.DeleteRequest{Key: "sensor||2024-02-01", ETag: ptr.Of("100")},
+			},
+		})
+		require.Error(t, err)
+
+		members, err := s.ZMembers("scan-index")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"other", "sensor||2024-01-04", "sensor||2024-02-01"}, members)
+	})
+
+	t.Run("not supported with Redis Cluster", func(t *testing.T) {
+		ss := newStateStore(logger.NewLogger("test"))
+		err := ss.Init(t.Context(), state.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"redisHost":      s.Addr(),
+			"redisType":      "cluster",
+			"rangeScanIndex": "scan-index",
+		}}})
+		require.ErrorContains(t, err, "rangeScanIndex")
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := ss.Scan(t.Context(), &state.ScanRequest{Start: "b", End: "a"})
+		require.ErrorIs(t, err, state.ErrScanInvalidRange)
+	})
+
+	t.Run("index not configured", func(t *testing.T) {
+		ss := &StateStore{client: c, clientSettings: &rediscomponent.Settings{}}
+		assert.NotContains(t, ss.Features(), state.FeatureRangeScan)
+		_, err := ss.Scan(t.Context(), &state.ScanRequest{})
+		require.Error(t, err)
+	})
+}
+
 func TestTransactionalDeleteNoEtag(t *testing.T) {
 	s, c := setupMiniredis()
 	defer s.Close()
@@ -174,3 +174,50 @@ type KeysLikeRequest struct {
 	// to return.
 	PageSize *uint32 `json:"pageSize,omitempty"`
 }
+
+// ScanRequest is the object describing a range scan request.
+type ScanRequest struct {
+	// Start is the inclusive lower bound of the keys to return. If empty, the
+	// range begins at the first key.
+	Start string `json:"start,omitempty"`
+
+	// End is the exclusive upper bound of the keys to return. If empty, the
+	// range ends at the last key.
+	End string `json:"end,omitempty"`
+
+	// Limit is an optional parameter to indicate the maximum number of items
+	// to return.
+	Limit uint32 `json:"limit,omitempty"`
+
+	// Reverse returns the items in descending key order.
+	Reverse bool `json:"reverse,omitempty"`
+
+	// ContinuationToken is an optional token returned by a previous scan, to
+	// continue from where it stopped.
+	ContinuationToken *string `json:"continuationToken,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Validate returns an error if the range of the scan is invalid.
+func (r *ScanRequest) Validate() error {
+	if r.Start != "" && r.End != "" && r.Start > r.End {
+		return ErrScanInvalidRange
+	}
+	return nil
+}
+
+// Contains returns true if the key is in the range of the scan, and after the
+// continuation token in the order of the scan.
+func (r *ScanRequest) Contains(key string) bool {
+	if key < r.Start || (r.End != "" && key >= r.End) {
+		return false
+	}
+	if r.ContinuationToken != nil && *r.ContinuationToken != "" {
+		if r.Reverse {
+			return key < *r.ContinuationToken
+		}
+		return key > *r.ContinuationToken
+	}
+	return true
+}
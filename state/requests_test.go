@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/ptr"
+)
+
+func TestScanRequest(t *testing.T) {
+	t.Run("validate", func(t *testing.T) {
+		require.NoError(t, (&ScanRequest{}).Validate())
+		require.NoError(t, (&ScanRequest{Start: "a", End: "b"}).Validate())
+		require.NoError(t, (&ScanRequest{Start: "b"}).Validate())
+		require.NoError(t, (&ScanRequest{End: "a"}).Validate())
+		require.ErrorIs(t, (&ScanRequest{Start: "b", End: "a"}).Validate(), ErrScanInvalidRange)
+	})
+
+	t.Run("contains", func(t *testing.T) {
+		req := &ScanRequest{Start: "device||2024-01-01", End: "device||2024-02-01"}
+		assert.True(t, req.Contains("device||2024-01-01"))
+		assert.True(t, req.Contains("device||2024-01-31T23:59:59Z"))
+		assert.False(t, req.Contains("device||2024-02-01"))
+		assert.False(t, req.Contains("device||2023-12-31"))
+
+		assert.True(t, (&ScanRequest{}).Contains("any"))
+	})
+
+	t.Run("contains after continuation token", func(t *testing.T) {
+		req := &ScanRequest{ContinuationToken: ptr.Of("b")}
+		assert.False(t, req.Contains("a"))
+		assert.False(t, req.Contains("b"))
+		assert.True(t, req.Contains("c"))
+
+		req.Reverse = true
+		assert.True(t, req.Contains("a"))
+		assert.False(t, req.Contains("b"))
+		assert.False(t, req.Contains("c"))
+	})
+}
@@ -58,6 +58,24 @@ type DeleteWithPrefixResponse struct {
 	Count int64 `json:"count"` // count of items removed
 }
 
+// ScanResponse is the response object for a range scan.
+type ScanResponse struct {
+	Items []ScanItem `json:"items"`
+
+	// ContinuationToken is an optional token which can be used to continue the
+	// scan. It's only present if there are more items after the last one
+	// returned, because of the request's Limit.
+	ContinuationToken *string `json:"continuationToken,omitempty"`
+}
+
+// ScanItem is an object representing a single entry in a range scan result.
+type ScanItem struct {
+	Key      string            `json:"key"`
+	Data     []byte            `json:"data"`
+	ETag     *string           `json:"etag,omitempty"`
+	Metadata map[string]string `json:"metadata"`
+}
+
 // KeysLikeResponse is the response object for getting keys like a pattern.
 type KeysLikeResponse struct {
 	Keys []string `json:"keys"`
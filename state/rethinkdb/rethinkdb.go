@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strconv"
+	"sync"
 	"time"
 
 	r "github.com/dancannon/gorethink"
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
 	kitmd "github.com/dapr/kit/metadata"
 	"github.com/dapr/kit/ptr"
@@ -40,6 +43,10 @@ const (
 	stateArchiveTableName = "daprstate_archive"
 	// TODO: this needs to be exposed as a metadata option?
 	stateArchiveTablePKName = "key"
+	// Index of the expiration time of the records.
+	stateExpireIndexName = "expire"
+
+	defaultCleanupInterval = time.Hour
 )
 
 // RethinkDB is a state store implementation for RethinkDB.
@@ -48,12 +55,17 @@ type RethinkDB struct {
 	config   *stateConfig
 	features []state.Feature
 	logger   logger.Logger
+
+	closeCh chan struct{}
+	wg      sync.WaitGroup
 }
 
 type stateConfig struct {
 	ConnectOptsWrapper `mapstructure:",squash"`
 	Archive            bool   `json:"archive"`
 	Table              string `json:"table"`
+	// Interval between the deletions of the expired records; 0 disables them.
+	CleanupInterval time.Duration `json:"cleanupInterval"`
 }
 
 // ConnectOptsWrapper wraps r.ConnectOpts but excludes TLSConfig
@@ -87,17 +99,23 @@ type ConnectOptsWrapper struct {
 }
 
 type stateRecord struct {
-	ID   string `json:"id" rethinkdb:"id"`
-	TS   int64  `json:"timestamp" rethinkdb:"timestamp"`
-	Hash string `json:"hash,omitempty" rethinkdb:"hash,omitempty"`
-	Data any    `json:"data,omitempty" rethinkdb:"data,omitempty"`
+	ID string `json:"id" rethinkdb:"id"`
+	TS int64  `json:"timestamp" rethinkdb:"timestamp"`
+	// Version of the record, incremented by every write; records written before versions were introduced have version 0.
+	Version int64 `json:"version" rethinkdb:"version"`
+	// Expiration time in milliseconds since the epoch, or 0 if the record doesn't expire.
+	Expire int64 `json:"expire,omitempty" rethinkdb:"expire,omitempty"`
+	Data   any   `json:"data,omitempty" rethinkdb:"data,omitempty"`
 }
 
 // NewRethinkDBStateStore returns a new RethinkDB state store.
 func NewRethinkDBStateStore(logger logger.Logger) state.Store {
 	s := &RethinkDB{
-		features: []state.Feature{},
-		logger:   logger,
+		features: []state.Feature{
+			state.FeatureETag,
+			state.FeatureTTL,
+		},
+		logger: logger,
 	}
 	return s
 }
@@ -112,6 +130,7 @@ func (s *RethinkDB) Init(ctx context.Context, metadata state.Metadata) error {
 	}
 
 	// in case someone runs Init multiple times
+	s.stopCleanup()
 	if s.session != nil && s.session.IsConnected() {
 		s.session.Close()
 	}
@@ -209,6 +228,89 @@ func (s *RethinkDB) Init(ctx context.Context, metadata state.Metadata) error {
 		}
 	}
 
+	err = s.ensureExpireIndex(ctx)
+	if err != nil {
+		return err
+	}
+	s.startCleanup()
+
+	return nil
+}
+
+// ensureExpireIndex creates the index of the expiration time of the records if it doesn't exist.
+func (s *RethinkDB) ensureExpireIndex(ctx context.Context) error {
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	c, err := r.Table(s.config.Table).IndexList().Run(s.session, r.RunOpts{Context: listCtx})
+	if err != nil {
+		return fmt.Errorf("error listing state table indexes in DB: %w", err)
+	}
+	defer c.Close()
+
+	var list []string
+	err = c.All(&list)
+	if err != nil {
+		return fmt.Errorf("invalid database response while listing indexes: %w", err)
+	}
+	if tableExists(list, stateExpireIndexName) {
+		return nil
+	}
+
+	createCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	_, err = r.Table(s.config.Table).IndexCreate(stateExpireIndexName).RunWrite(s.session, r.RunOpts{Context: createCtx})
+	if err != nil {
+		return fmt.Errorf("error creating state expiration index in DB: %w", err)
+	}
+	return nil
+}
+
+// startCleanup starts deleting the expired records periodically.
+func (s *RethinkDB) startCleanup() {
+	if s.config.CleanupInterval <= 0 {
+		return
+	}
+
+	s.closeCh = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.config.CleanupInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.closeCh:
+				return
+			case <-ticker.C:
+				err := s.deleteExpired()
+				if err != nil {
+					s.logger.Errorf("Error deleting expired records: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *RethinkDB) stopCleanup() {
+	if s.closeCh != nil {
+		close(s.closeCh)
+		s.wg.Wait()
+		s.closeCh = nil
+	}
+}
+
+// deleteExpired deletes the records whose expiration time is in the past.
+func (s *RethinkDB) deleteExpired() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	resp, err := r.Table(s.config.Table).
+		Between(1, time.Now().UnixMilli(), r.BetweenOpts{Index: stateExpireIndexName, RightBound: "closed"}).
+		Delete().
+		RunWrite(s.session, r.RunOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+	s.logger.Debugf("Deleted %d expired records", resp.Deleted)
 	return nil
 }
 
@@ -252,7 +354,17 @@ func (s *RethinkDB) Get(ctx context.Context, req *state.GetRequest) (*state.GetR
 		return nil, fmt.Errorf("error parsing database content: %w", err)
 	}
 
-	resp := &state.GetResponse{ETag: ptr.Of(doc.Hash)}
+	// Expired records are deleted periodically
+	if doc.Expire > 0 && doc.Expire <= time.Now().UnixMilli() {
+		return &state.GetResponse{}, nil
+	}
+
+	resp := &state.GetResponse{ETag: ptr.Of(strconv.FormatInt(doc.Version, 10))}
+	if doc.Expire > 0 {
+		resp.Metadata = map[string]string{
+			state.GetRespMetaKeyTTLExpireTime: time.UnixMilli(doc.Expire).UTC().Format(time.RFC3339),
+		}
+	}
 	b, ok := doc.Data.([]byte)
 	if ok {
 		resp.Data = b
@@ -272,34 +384,44 @@ func (s *RethinkDB) BulkGet(ctx context.Context, req []state.GetRequest, opts st
 }
 
 // Set saves a state KV item.
+// If the request has an ETag, the item is saved only if the ETag is the version of the record.
 func (s *RethinkDB) Set(ctx context.Context, req *state.SetRequest) error {
 	if req == nil || req.Key == "" || req.Value == nil {
 		return errors.New("invalid state request, key and value required")
 	}
 
+	if req.HasETag() || req.Options.Concurrency == state.FirstWrite {
+		return s.setConditional(ctx, req)
+	}
+
 	return s.BulkSet(ctx, []state.SetRequest{*req}, state.BulkStoreOpts{})
 }
 
 // BulkSet performs a bulk save operation.
-func (s *RethinkDB) BulkSet(ctx context.Context, req []state.SetRequest, _ state.BulkStoreOpts) error {
-	docs := make([]*stateRecord, len(req))
-	now := time.Now().UnixNano()
-	for i, v := range req {
-		var etag string
-		if v.ETag != nil {
-			etag = *v.ETag
+func (s *RethinkDB) BulkSet(ctx context.Context, req []state.SetRequest, opts state.BulkStoreOpts) error {
+	for _, v := range req {
+		if v.HasETag() || v.Options.Concurrency == state.FirstWrite {
+			// Conditional writes are performed one record at a time
+			return state.DoBulkSetDelete(ctx, req, s.Set, opts)
 		}
+	}
 
-		docs[i] = &stateRecord{
-			ID:   v.Key,
-			TS:   now,
-			Data: v.Value,
-			Hash: etag,
+	docs := make([]*stateRecord, len(req))
+	now := time.Now()
+	for i := range req {
+		doc, err := newStateRecord(&req[i], now)
+		if err != nil {
+			return err
 		}
+		docs[i] = doc
 	}
 
 	resp, err := r.Table(s.config.Table).Insert(docs, r.InsertOpts{
-		Conflict:      "replace",
+		Conflict: func(_, oldDoc, newDoc r.Term) interface{} {
+			return newDoc.Merge(map[string]interface{}{
+				"version": oldDoc.Field("version").Default(0).Add(1),
+			})
+		},
 		ReturnChanges: true,
 	}).RunWrite(s.session, r.RunOpts{Context: ctx})
 	if err != nil {
@@ -313,6 +435,74 @@ func (s *RethinkDB) BulkSet(ctx context.Context, req []state.SetRequest, _ state
 	return nil
 }
 
+// setConditional saves an item if the ETag is the version of the record, or with the first-write concurrency, if the record doesn't exist or expired.
+func (s *RethinkDB) setConditional(ctx context.Context, req *state.SetRequest) error {
+	now := time.Now()
+	doc, err := newStateRecord(req, now)
+	if err != nil {
+		return err
+	}
+
+	cond := func(row r.Term) r.Term {
+		return isLive(row, now).Not()
+	}
+	if req.HasETag() {
+		version, err := strconv.ParseInt(*req.ETag, 10, 64)
+		if err != nil {
+			return state.NewETagError(state.ETagInvalid, err)
+		}
+		cond = func(row r.Term) r.Term {
+			return isLive(row, now).And(row.Field("version").Default(0).Eq(version))
+		}
+	}
+
+	resp, err := r.Table(s.config.Table).Get(req.Key).Replace(func(row r.Term) interface{} {
+		return r.Branch(cond(row),
+			r.Expr(doc).Merge(map[string]interface{}{
+				"version": r.Branch(row.Eq(nil), 1, row.Field("version").Default(0).Add(1)),
+			}),
+			row,
+		)
+	}, r.ReplaceOpts{ReturnChanges: true}).RunWrite(s.session, r.RunOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("error saving record to the database: %w", err)
+	}
+	if resp.Inserted+resp.Replaced == 0 {
+		return state.NewETagError(state.ETagMismatch, nil)
+	}
+
+	if s.config.Archive && len(resp.Changes) > 0 {
+		s.archive(ctx, resp.Changes)
+	}
+
+	return nil
+}
+
+func newStateRecord(req *state.SetRequest, now time.Time) (*stateRecord, error) {
+	doc := &stateRecord{
+		ID:      req.Key,
+		TS:      now.UnixNano(),
+		Version: 1,
+		Data:    req.Value,
+	}
+
+	ttl, err := utils.ParseTTL(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing TTL: %w", err)
+	}
+	if ttl != nil && *ttl > 0 {
+		doc.Expire = now.Add(time.Duration(*ttl) * time.Second).UnixMilli()
+	}
+
+	return doc, nil
+}
+
+// isLive returns a term that is true if the record exists and hasn't expired.
+func isLive(row r.Term, now time.Time) r.Term {
+	expire := row.Field("expire").Default(0)
+	return row.Ne(nil).And(expire.Eq(0).Or(expire.Gt(now.UnixMilli())))
+}
+
 func (s *RethinkDB) archive(ctx context.Context, changes []r.ChangeResponse) error {
 	list := make([]map[string]interface{}, 0)
 	for _, c := range changes {
@@ -337,18 +527,27 @@ func (s *RethinkDB) archive(ctx context.Context, changes []r.ChangeResponse) err
 }
 
 // Delete performes a RethinkDB KV delete operation.
+// If the request has an ETag, the item is deleted only if the ETag is the version of the record.
 func (s *RethinkDB) Delete(ctx context.Context, req *state.DeleteRequest) error {
 	if req == nil || req.Key == "" {
 		return errors.New("invalid request, missing key")
 	}
 
+	if req.HasETag() {
+		return s.deleteConditional(ctx, req)
+	}
+
 	return s.BulkDelete(ctx, []state.DeleteRequest{*req}, state.BulkStoreOpts{})
 }
 
 // BulkDelete performs a bulk delete operation.
-func (s *RethinkDB) BulkDelete(ctx context.Context, req []state.DeleteRequest, _ state.BulkStoreOpts) error {
+func (s *RethinkDB) BulkDelete(ctx context.Context, req []state.DeleteRequest, opts state.BulkStoreOpts) error {
 	list := make([]string, len(req))
 	for i, d := range req {
+		if d.HasETag() {
+			// Conditional deletes are performed one record at a time
+			return state.DoBulkSetDelete(ctx, req, s.Delete, opts)
+		}
 		list[i] = d.Key
 	}
 
@@ -361,10 +560,31 @@ func (s *RethinkDB) BulkDelete(ctx context.Context, req []state.DeleteRequest, _
 	return nil
 }
 
+func (s *RethinkDB) deleteConditional(ctx context.Context, req *state.DeleteRequest) error {
+	version, err := strconv.ParseInt(*req.ETag, 10, 64)
+	if err != nil {
+		return state.NewETagError(state.ETagInvalid, err)
+	}
+
+	now := time.Now()
+	resp, err := r.Table(s.config.Table).Get(req.Key).Replace(func(row r.Term) interface{} {
+		return r.Branch(isLive(row, now).And(row.Field("version").Default(0).Eq(version)), nil, row)
+	}).RunWrite(s.session, r.RunOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("error deleting record from the database: %w", err)
+	}
+	if resp.Deleted == 0 {
+		return state.NewETagError(state.ETagMismatch, nil)
+	}
+
+	return nil
+}
+
 func metadataToConfig(cfg map[string]string, _ logger.Logger) (*stateConfig, error) {
 	// defaults
 	c := stateConfig{
-		Table: stateTableNameDefault,
+		Table:           stateTableNameDefault,
+		CleanupInterval: defaultCleanupInterval,
 	}
 
 	err := kitmd.DecodeMetadata(cfg, &c)
@@ -399,6 +619,7 @@ func (s *RethinkDB) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 }
 
 func (s *RethinkDB) Close() error {
+	s.stopCleanup()
 	if s.session == nil {
 		return nil
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 	"github.com/dapr/kit/strings"
 )
 
@@ -59,6 +60,18 @@ func TestGetRethinkDBMetadata(t *testing.T) {
 		assert.Equal(t, maxOpen, m.MaxOpen)
 		assert.Equal(t, discoverHosts, m.DiscoverHosts)
 	})
+
+	t.Run("With cleanup interval", func(t *testing.T) {
+		p := getTestMetadata()
+		m, err := metadataToConfig(p, testLogger)
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, m.CleanupInterval)
+
+		p["cleanupInterval"] = "0"
+		m, err = metadataToConfig(p, testLogger)
+		require.NoError(t, err)
+		assert.Zero(t, m.CleanupInterval)
+	})
 }
 
 // go test -timeout 30s ./state/rethinkdb -run ^TestRethinkDBStateStore
@@ -107,8 +120,7 @@ func TestRethinkDBStateStore(t *testing.T) {
 		// update data and set it again
 		d2.F2 = 2
 		d2.F3 = time.Now().UTC()
-		tag := fmt.Sprintf("hash-%d", time.Now().UnixNano())
-		if err = db.Set(t.Context(), &state.SetRequest{Key: k, Value: d2, ETag: &tag}); err != nil {
+		if err = db.Set(t.Context(), &state.SetRequest{Key: k, Value: d2, ETag: resp.ETag}); err != nil {
 			t.Fatalf("error setting data to db: %v", err)
 		}
 
@@ -152,6 +164,47 @@ func TestRethinkDBStateStore(t *testing.T) {
 	t.Run("With bulk", func(t *testing.T) {
 		testBulk(t, db, 0)
 	})
+
+	t.Run("With ETag", func(t *testing.T) {
+		k := fmt.Sprintf("ide-%d", time.Now().UnixNano())
+		firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+		require.NoError(t, db.Set(t.Context(), &state.SetRequest{Key: k, Value: "v1", Options: firstWrite}))
+
+		var etagErr *state.ETagError
+		err := db.Set(t.Context(), &state.SetRequest{Key: k, Value: "v2", Options: firstWrite})
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		resp, err := db.Get(t.Context(), &state.GetRequest{Key: k})
+		require.NoError(t, err)
+		assert.Equal(t, "1", *resp.ETag)
+
+		require.NoError(t, db.Set(t.Context(), &state.SetRequest{Key: k, Value: "v2", ETag: resp.ETag}))
+		err = db.Set(t.Context(), &state.SetRequest{Key: k, Value: "v3", ETag: resp.ETag})
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+
+		err = db.Delete(t.Context(), &state.DeleteRequest{Key: k, ETag: resp.ETag})
+		require.ErrorAs(t, err, &etagErr)
+		require.NoError(t, db.Delete(t.Context(), &state.DeleteRequest{Key: k, ETag: ptr.Of("2")}))
+	})
+
+	t.Run("With TTL", func(t *testing.T) {
+		k := fmt.Sprintf("idt-%d", time.Now().UnixNano())
+		require.NoError(t, db.Set(t.Context(), &state.SetRequest{Key: k, Value: "v1", Metadata: map[string]string{"ttlInSeconds": "1"}}))
+
+		resp, err := db.Get(t.Context(), &state.GetRequest{Key: k})
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.Metadata[state.GetRespMetaKeyTTLExpireTime])
+
+		time.Sleep(1100 * time.Millisecond)
+		resp, err = db.Get(t.Context(), &state.GetRequest{Key: k})
+		require.NoError(t, err)
+		assert.Nil(t, resp.Data)
+
+		require.NoError(t, db.deleteExpired())
+		require.NoError(t, db.Set(t.Context(), &state.SetRequest{Key: k, Value: "v2", Options: state.SetStateOption{Concurrency: state.FirstWrite}}))
+	})
 }
 
 func TestRethinkDBStateStoreRongRun(t *testing.T) {
@@ -50,6 +50,7 @@ func newSQLiteStateStore(logger logger.Logger, dba DBAccess) *SQLiteStore {
 			state.FeatureTransactional,
 			state.FeatureTTL,
 			state.FeatureKeysLike,
+			state.FeatureRangeScan,
 		},
 		dbaccess: dba,
 	}
@@ -89,6 +90,11 @@ func (s *SQLiteStore) KeysLike(ctx context.Context, req *state.KeysLikeRequest)
 	return s.dbaccess.KeysLike(ctx, req)
 }
 
+// Scan returns the items whose keys are in the requested range, in key order.
+func (s *SQLiteStore) Scan(ctx context.Context, req *state.ScanRequest) (*state.ScanResponse, error) {
+	return s.dbaccess.Scan(ctx, req)
+}
+
 // BulkGet performs a bulks get operations.
 // Options are ignored because this component requests all values in a single query.
 func (s *SQLiteStore) BulkGet(ctx context.Context, req []state.GetRequest, _ state.BulkGetOpts) ([]state.BulkGetResponse, error) {
@@ -33,6 +33,7 @@ import (
 	commonsql "github.com/dapr/components-contrib/common/component/sql"
 	sqltransactions "github.com/dapr/components-contrib/common/component/sql/transactions"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	stateutils "github.com/dapr/components-contrib/state/utils"
 	"github.com/dapr/kit/logger"
 	"github.com/dapr/kit/ptr"
@@ -48,6 +49,7 @@ type DBAccess interface {
 	BulkGet(ctx context.Context, req []state.GetRequest) ([]state.BulkGetResponse, error)
 	ExecuteMulti(ctx context.Context, reqs []state.TransactionalStateOperation) error
 	KeysLike(ctx context.Context, req *state.KeysLikeRequest) (*state.KeysLikeResponse, error)
+	Scan(ctx context.Context, req *state.ScanRequest) (*state.ScanResponse, error)
 	Close() error
 }
 
@@ -64,6 +66,8 @@ type sqliteDBAccess struct {
 	metadata sqliteMetadataStruct
 	db       *sql.DB
 	gc       commonsql.GarbageCollector
+
+	compression compression.Codec
 }
 
 // newSqliteDBAccess creates a new instance of sqliteDbAccess.
@@ -81,6 +85,11 @@ func (a *sqliteDBAccess) Init(ctx context.Context, md state.Metadata) error {
 		return err
 	}
 
+	a.compression, err = a.metadata.Codec()
+	if err != nil {
+		return err
+	}
+
 	connString, err := a.metadata.GetConnectionString(a.logger, sqlite.GetConnectionStringOpts{})
 	if err != nil {
 		// Already logged
@@ -263,6 +272,87 @@ func (a *sqliteDBAccess) BulkGet(parentCtx context.Context, req []state.GetReque
 	return res[:n], nil
 }
 
+func (a *sqliteDBAccess) Scan(parentCtx context.Context, req *state.ScanRequest) (*state.ScanResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys are compared with the BINARY collation, which compares the bytes of the UTF-8 strings
+	where := []string{
+		`key >= ?`,
+		`(expiration_time IS NULL OR expiration_time > CURRENT_TIMESTAMP)`,
+	}
+	args := []any{req.Start}
+	if req.End != "" {
+		where = append(where, `key < ?`)
+		args = append(args, req.End)
+	}
+
+	// Pagination: resume strictly after the last returned key
+	orderClause := ` ORDER BY key ASC`
+	if req.ContinuationToken != nil && *req.ContinuationToken != "" {
+		if req.Reverse {
+			where = append(where, `key < ?`)
+		} else {
+			where = append(where, `key > ?`)
+		}
+		args = append(args, *req.ContinuationToken)
+	}
+	if req.Reverse {
+		orderClause = ` ORDER BY key DESC`
+	}
+
+	// Fetch one extra row to detect if there's a next page
+	limitClause := ``
+	if req.Limit > 0 {
+		limitClause = ` LIMIT ?`
+		args = append(args, req.Limit+1)
+	}
+
+	//nolint:gosec
+	stmt := `SELECT key, value, is_binary, etag, expiration_time FROM ` + a.metadata.TableName + `
+		WHERE ` + strings.Join(where, " AND ") + orderClause + limitClause
+	ctx, cancel := context.WithTimeout(parentCtx, a.metadata.Timeout)
+	defer cancel()
+	rows, err := a.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := &state.ScanResponse{
+		Items: make([]state.ScanItem, 0, min(req.Limit, 1024)),
+	}
+	for rows.Next() {
+		//nolint:gosec
+		if req.Limit > 0 && uint32(len(res.Items)) == req.Limit {
+			res.ContinuationToken = ptr.Of(res.Items[len(res.Items)-1].Key)
+			break
+		}
+
+		var (
+			item       state.ScanItem
+			expireTime *time.Time
+		)
+		item.Key, item.Data, item.ETag, expireTime, err = readRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		if expireTime != nil {
+			item.Metadata = map[string]string{
+				state.GetRespMetaKeyTTLExpireTime: expireTime.UTC().Format(time.RFC3339),
+			}
+		}
+		res.Items = append(res.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func readRow(row interface{ Scan(dest ...any) error }) (string, []byte, *string, *time.Time, error) {
 	var (
 		key        string
@@ -288,7 +378,11 @@ func readRow(row interface{ Scan(dest ...any) error }) (string, []byte, *string,
 		if err != nil {
 			return key, nil, nil, nil, fmt.Errorf("failed to decode binary data: %w", err)
 		}
-		return key, data[:n], &etag, expireTime, nil
+		data, err = compression.Decompress(data[:n])
+		if err != nil {
+			return key, nil, nil, nil, err
+		}
+		return key, data, &etag, expireTime, nil
 	}
 
 	return key, value, &etag, expireTime, nil
@@ -319,17 +413,26 @@ func (a *sqliteDBAccess) doSet(parentCtx context.Context, db querier, req *state
 	}
 
 	// Encode the value
+	// Binary and compressed values are stored as a base64-encoded string
 	var requestValue string
 	byteArray, isBinary := req.Value.([]uint8)
-	if isBinary {
-		requestValue = base64.StdEncoding.EncodeToString(byteArray)
-	} else {
+	if !isBinary {
 		var bt []byte
 		bt, err = json.Marshal(req.Value)
 		if err != nil {
 			return err
 		}
-		requestValue = string(bt)
+		compressed := a.compression.Compress(bt)
+		if compression.IsCompressed(compressed) {
+			byteArray, isBinary = compressed, true
+		} else {
+			requestValue = string(bt)
+		}
+	} else {
+		byteArray = a.compression.Compress(byteArray)
+	}
+	if isBinary {
+		requestValue = base64.StdEncoding.EncodeToString(byteArray)
 	}
 
 	// New ETag
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,7 +28,9 @@ import (
 
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 )
 
 const (
@@ -160,6 +163,46 @@ func TestSqliteIntegration(t *testing.T) {
 		assert.NotEmpty(t, res.ETag)
 		assert.Equal(t, "🤖", string(res.Data))
 	})
+
+	t.Run("Compressed values", func(t *testing.T) {
+		dba := s.(*SQLiteStore).GetDBAccess()
+		var err error
+		dba.compression, err = compression.Metadata{Compression: "zstd", CompressionThreshold: ptr.Of(64)}.Codec()
+		require.NoError(t, err)
+		defer func() {
+			dba.compression = compression.Codec{}
+		}()
+
+		key := randomKey()
+		value := &fakeItem{Color: strings.Repeat("yellow", 50)}
+		setItem(t, s, key, value, nil)
+		err = s.(state.TransactionalStore).Multi(t.Context(), &state.TransactionalStateRequest{
+			Operations: []state.TransactionalStateOperation{
+				state.SetRequest{Key: key + "-multi", Value: value},
+			},
+		})
+		require.NoError(t, err)
+
+		// Compressed values are stored as binary data
+		var isBinary bool
+		err = dba.GetConnection().QueryRowContext(t.Context(), "SELECT is_binary FROM test_state WHERE key = ?", key).Scan(&isBinary)
+		require.NoError(t, err)
+		assert.True(t, isBinary)
+
+		// Values are decompressed even after compression is disabled
+		dba.compression = compression.Codec{}
+		_, outputObject := getItem(t, s, key)
+		assert.Equal(t, value, outputObject)
+
+		res, err := s.BulkGet(t.Context(), []state.GetRequest{{Key: key}, {Key: key + "-multi"}}, state.BulkGetOpts{})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		for _, r := range res {
+			outputObject = &fakeItem{}
+			require.NoError(t, json.Unmarshal(r.Data, outputObject))
+			assert.Equal(t, value, outputObject)
+		}
+	})
 }
 
 // setGetUpdateDeleteOneItem validates setting one item, getting it, and deleting it.
@@ -19,6 +19,7 @@ import (
 
 	authSqlite "github.com/dapr/components-contrib/common/authentication/sqlite"
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/compression"
 	"github.com/dapr/kit/metadata"
 )
 
@@ -34,6 +35,8 @@ type sqliteMetadataStruct struct {
 	TableName         string        `mapstructure:"tableName"`
 	MetadataTableName string        `mapstructure:"metadataTableName"`
 	CleanupInterval   time.Duration `mapstructure:"cleanupInterval" mapstructurealiases:"cleanupIntervalInSeconds"`
+
+	compression.Metadata `mapstructure:",squash"`
 }
 
 func (m *sqliteMetadataStruct) InitWithMetadata(meta state.Metadata) error {
@@ -68,4 +71,5 @@ func (m *sqliteMetadataStruct) reset() {
 	m.TableName = defaultTableName
 	m.MetadataTableName = defaultMetadataTableName
 	m.CleanupInterval = defaultCleanupInterval
+	m.Metadata = compression.Metadata{}
 }
@@ -358,6 +358,10 @@ func (m *fakeDBaccess) KeysLike(ctx context.Context, req *state.KeysLikeRequest)
 	return nil, nil
 }
 
+func (m *fakeDBaccess) Scan(ctx context.Context, req *state.ScanRequest) (*state.ScanResponse, error) {
+	return nil, nil
+}
+
 func (m *fakeDBaccess) Close() error {
 	return nil
 }
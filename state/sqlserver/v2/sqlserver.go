@@ -14,6 +14,7 @@ limitations under the License.
 package sqlserver
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/hex"
@@ -21,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	commonsql "github.com/dapr/components-contrib/common/component/sql"
@@ -63,6 +66,9 @@ const (
 	InvalidKeyType KeyType = "invalid"
 )
 
+// Maximum number of entities in the statements of the bulk operations, to stay below the limit of 2100 parameters in a statement.
+const bulkMaxItems = 400
+
 // New creates a new instance of a SQL Server transaction store.
 func New(logger logger.Logger) state.Store {
 	s := &SQLServer{
@@ -74,7 +80,6 @@ func New(logger logger.Logger) state.Store {
 		logger:          logger,
 		migratorFactory: newMigration,
 	}
-	s.BulkStore = state.NewDefaultBulkStore(s)
 	return s
 }
 
@@ -87,8 +92,6 @@ type IndexedProperty struct {
 
 // SQLServer defines a MS SQL Server based state store.
 type SQLServer struct {
-	state.BulkStore
-
 	metadata sqlServerMetadata
 
 	migratorFactory func(*sqlServerMetadata) migrator
@@ -386,6 +389,293 @@ func (s *SQLServer) executeSet(ctx context.Context, db dbExecutor, req *state.Se
 	return nil
 }
 
+// BulkGet returns multiple entities from the store with a single query.
+func (s *SQLServer) BulkGet(ctx context.Context, req []state.GetRequest, _ state.BulkGetOpts) ([]state.BulkGetResponse, error) {
+	found := make(map[string]state.BulkGetResponse, len(req))
+	keys := make([]string, len(req))
+	for i := range req {
+		keys[i] = req[i].Key
+	}
+	for chunk := range slices.Chunk(keys, bulkMaxItems) {
+		//nolint:gosec
+		query := `SELECT k.[Key], t.[Data], t.[BinaryData], t.[isBinary], t.[RowVersion], t.[ExpireDate]
+FROM (VALUES ` + bulkParams(len(chunk), true) + `) AS k ([Key])
+INNER JOIN ` + s.tableName() + ` AS t ON t.[Key] = k.[Key]
+WHERE t.[ExpireDate] IS NULL OR t.[ExpireDate] > GETDATE()`
+		rows, err := s.db.QueryContext(ctx, query, toAnySlice(chunk)...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var (
+				key        string
+				data       sql.NullString
+				binaryData []byte
+				isBinary   bool
+				rowVersion []byte
+				expireDate sql.NullTime
+			)
+			if err = rows.Scan(&key, &data, &binaryData, &isBinary, &rowVersion, &expireDate); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			res := state.BulkGetResponse{
+				Key:  key,
+				ETag: ptr.Of(hex.EncodeToString(rowVersion)),
+			}
+			if isBinary {
+				res.Data = binaryData
+			} else {
+				res.Data = []byte(data.String)
+			}
+			if expireDate.Valid {
+				res.Metadata = map[string]string{
+					state.GetRespMetaKeyTTLExpireTime: expireDate.Time.UTC().Format(time.RFC3339),
+				}
+			}
+			found[key] = res
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]state.BulkGetResponse, len(req))
+	for i := range req {
+		item, ok := found[req[i].Key]
+		if !ok {
+			item = state.BulkGetResponse{Key: req[i].Key}
+		}
+		res[i] = item
+	}
+
+	return res, nil
+}
+
+// BulkSet saves multiple entities in a transaction, using multi-row statements.
+// Entities whose ETag doesn't match, or that already exist when using the first-write concurrency, are reported with a state.BulkStoreError
+// and don't prevent the other entities from being saved.
+func (s *SQLServer) BulkSet(ctx context.Context, req []state.SetRequest, _ state.BulkStoreOpts) error {
+	if len(req) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(req))
+	_, err := sqltransactions.ExecuteInTransaction(ctx, s.logger, s.db, func(ctx context.Context, tx *sql.Tx) (r struct{}, err error) {
+		conditional := make([]string, 0, len(req))
+		for i := range req {
+			if req[i].HasETag() || req[i].Options.Concurrency == state.FirstWrite {
+				conditional = append(conditional, req[i].Key)
+			}
+		}
+		versions, err := s.lockRowVersions(ctx, tx, conditional)
+		if err != nil {
+			return r, err
+		}
+
+		var (
+			values = make([]string, 0, min(len(req), bulkMaxItems))
+			params = make([]any, 0, 5*cap(values))
+			queued = make(map[string]struct{}, cap(values))
+		)
+		flush := func() error {
+			if len(values) == 0 {
+				return nil
+			}
+			//nolint:gosec
+			_, err := tx.ExecContext(ctx, `MERGE `+s.tableName()+` WITH (HOLDLOCK) AS t
+USING (VALUES `+strings.Join(values, ", ")+`) AS s ([Key], [Data], [BinaryData], [isBinary], [TTL])
+ON t.[Key] = s.[Key]
+WHEN MATCHED THEN
+	UPDATE SET [Data] = s.[Data], [isBinary] = s.[isBinary], [BinaryData] = s.[BinaryData], UpdateDate = GETDATE(), ExpireDate = CASE WHEN s.[TTL] IS NULL THEN NULL ELSE DATEADD(SECOND, s.[TTL], GETDATE()) END
+WHEN NOT MATCHED THEN
+	INSERT ([Key], [Data], [isBinary], [BinaryData], ExpireDate) VALUES (s.[Key], s.[Data], s.[isBinary], s.[BinaryData], CASE WHEN s.[TTL] IS NULL THEN NULL ELSE DATEADD(SECOND, s.[TTL], GETDATE()) END);`, params...)
+			values = values[:0]
+			params = params[:0]
+			clear(queued)
+			return err
+		}
+
+		for i := range req {
+			bt, isBinary := req[i].Value.([]byte)
+			var data, binaryData any
+			if !isBinary {
+				bt, err := json.Marshal(req[i].Value)
+				if err != nil {
+					errs[i] = state.NewBulkStoreError(req[i].Key, err)
+					continue
+				}
+				data = string(bt)
+			} else {
+				binaryData = bt
+			}
+			ttl, err := utils.ParseTTL(req[i].Metadata)
+			if err != nil {
+				errs[i] = state.NewBulkStoreError(req[i].Key, fmt.Errorf("error parsing TTL: %w", err))
+				continue
+			}
+			err = checkBulkConcurrency(versions, req[i].Key, req[i].ETag, req[i].Options.Concurrency)
+			if err != nil {
+				errs[i] = state.NewBulkStoreError(req[i].Key, err)
+				continue
+			}
+			// The new row version isn't known until the transaction is committed
+			versions[req[i].Key] = nil
+
+			// MERGE can't update the same row twice
+			if _, ok := queued[req[i].Key]; ok || len(values) == bulkMaxItems {
+				err = flush()
+				if err != nil {
+					return r, err
+				}
+			}
+			n := len(params)
+			values = append(values, fmt.Sprintf("(@p%d, @p%d, CAST(@p%d AS VARBINARY(MAX)), CAST(@p%d AS BIT), CAST(@p%d AS INT))", n+1, n+2, n+3, n+4, n+5))
+			params = append(params, req[i].Key, data, binaryData, isBinary, ttl)
+			queued[req[i].Key] = struct{}{}
+		}
+
+		return r, flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
+
+// BulkDelete removes multiple entities in a transaction, using multi-row statements.
+// Entities whose ETag doesn't match are reported with a state.BulkStoreError and don't prevent the other entities from being removed.
+func (s *SQLServer) BulkDelete(ctx context.Context, req []state.DeleteRequest, _ state.BulkStoreOpts) error {
+	if len(req) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(req))
+	_, err := sqltransactions.ExecuteInTransaction(ctx, s.logger, s.db, func(ctx context.Context, tx *sql.Tx) (r struct{}, err error) {
+		conditional := make([]string, 0, len(req))
+		for i := range req {
+			if req[i].HasETag() {
+				conditional = append(conditional, req[i].Key)
+			}
+		}
+		versions, err := s.lockRowVersions(ctx, tx, conditional)
+		if err != nil {
+			return r, err
+		}
+
+		keys := make([]string, 0, len(req))
+		for i := range req {
+			err = checkBulkConcurrency(versions, req[i].Key, req[i].ETag, state.LastWrite)
+			if err != nil {
+				errs[i] = state.NewBulkStoreError(req[i].Key, err)
+				continue
+			}
+			delete(versions, req[i].Key)
+			keys = append(keys, req[i].Key)
+		}
+
+		for chunk := range slices.Chunk(keys, bulkMaxItems) {
+			//nolint:gosec
+			_, err = tx.ExecContext(ctx, `DELETE FROM `+s.tableName()+` WHERE [Key] IN (`+bulkParams(len(chunk), false)+`)`, toAnySlice(chunk)...)
+			if err != nil {
+				return r, err
+			}
+		}
+
+		return r, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
+
+// lockRowVersions returns the row versions of the rows of the given keys, locking the keys until the end of the transaction.
+// The map contains the keys of the rows that exist, including expired rows.
+func (s *SQLServer) lockRowVersions(ctx context.Context, tx *sql.Tx, keys []string) (map[string][]byte, error) {
+	versions := make(map[string][]byte, len(keys))
+	for chunk := range slices.Chunk(keys, bulkMaxItems) {
+		// The keys are returned as passed, as the type of the key column may not be a string
+		//nolint:gosec
+		query := `SELECT k.[Key], t.[RowVersion]
+FROM (VALUES ` + bulkParams(len(chunk), true) + `) AS k ([Key])
+INNER JOIN ` + s.tableName() + ` AS t WITH (UPDLOCK, HOLDLOCK) ON t.[Key] = k.[Key]`
+		rows, err := tx.QueryContext(ctx, query, toAnySlice(chunk)...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var (
+				key        string
+				rowVersion []byte
+			)
+			if err = rows.Scan(&key, &rowVersion); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			versions[key] = rowVersion
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
+}
+
+// checkBulkConcurrency returns an error if an entity can't be written with the given ETag and concurrency,
+// given the row versions returned by lockRowVersions.
+func checkBulkConcurrency(versions map[string][]byte, key string, etag *string, concurrency string) error {
+	rowVersion, exists := versions[key]
+	if etag != nil && *etag != "" {
+		b, err := hex.DecodeString(*etag)
+		if err != nil {
+			return state.NewETagError(state.ETagInvalid, err)
+		}
+		if !exists || !bytes.Equal(rowVersion, b) {
+			return state.NewETagError(state.ETagMismatch, nil)
+		}
+		return nil
+	}
+
+	if concurrency == state.FirstWrite && exists {
+		return state.NewETagError(state.ETagMismatch, errors.New("first-write: competing record already written"))
+	}
+
+	return nil
+}
+
+func (s *SQLServer) tableName() string {
+	return fmt.Sprintf("[%s].[%s]", s.metadata.SchemaName, s.metadata.TableName)
+}
+
+// bulkParams returns n comma-separated query parameters, each in parentheses if parenthesized is true.
+func bulkParams(n int, parenthesized bool) string {
+	format := "@p%d"
+	if parenthesized {
+		format = "(@p%d)"
+	}
+	params := make([]string, n)
+	for i := range params {
+		params[i] = fmt.Sprintf(format, i+1)
+	}
+	return strings.Join(params, ", ")
+}
+
+func toAnySlice(keys []string) []any {
+	res := make([]any, len(keys))
+	for i, k := range keys {
+		res[i] = k
+	}
+	return res
+}
+
 func (s *SQLServer) GetComponentMetadata() (metadataInfo metadata.MetadataMap) {
 	settingsStruct := sqlServerMetadata{}
 	metadata.GetMetadataInfoFromStructType(reflect.TypeOf(settingsStruct), &metadataInfo, metadata.StateStoreType)
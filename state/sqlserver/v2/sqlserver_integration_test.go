@@ -129,7 +129,6 @@ func getTestStoreWithKeyType(t *testing.T, kt KeyType, indexedProperties string)
 		logger:          logger.NewLogger("test"),
 		migratorFactory: newMigration,
 	}
-	store.BulkStore = state.NewDefaultBulkStore(store)
 	err := store.Init(t.Context(), metadata)
 	require.NoError(t, err)
 
@@ -597,7 +596,6 @@ func testMultipleInitializations(t *testing.T) {
 				logger:          logger.NewLogger("test"),
 				migratorFactory: newMigration,
 			}
-			store2.BulkStore = state.NewDefaultBulkStore(store2)
 			err := store2.Init(t.Context(), createMetadata(store.metadata.SchemaName, test.kt, test.indexedProperties))
 			require.NoError(t, err)
 		})
@@ -18,9 +18,11 @@ package sqlserver
 import (
 	"context"
 	"errors"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
 )
 
 const (
@@ -554,3 +557,97 @@ func TestSupportedFeatures(t *testing.T) {
 	assert.Equal(t, state.FeatureETag, actual[0])
 	assert.Equal(t, state.FeatureTransactional, actual[1])
 }
+
+func newBulkTestStore(t *testing.T) (*SQLServer, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	s := New(logger.NewLogger("test")).(*SQLServer)
+	s.db = db
+	s.metadata = newMetadata()
+	s.metadata.SchemaName = "dbo"
+	s.metadata.TableName = "state"
+	return s, mock
+}
+
+func TestBulkGet(t *testing.T) {
+	s, mock := newBulkTestStore(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM (VALUES (@p1), (@p2), (@p3)) AS k ([Key]) INNER JOIN [dbo].[state] AS t")).
+		WithArgs("k1", "k2", "k3").
+		WillReturnRows(sqlmock.NewRows([]string{"Key", "Data", "BinaryData", "isBinary", "RowVersion", "ExpireDate"}).
+			AddRow("k2", `"v2"`, nil, false, []byte{0, 1}, nil).
+			AddRow("k3", nil, []byte("bin"), true, []byte{0, 2}, nil))
+
+	res, err := s.BulkGet(t.Context(), []state.GetRequest{{Key: "k1"}, {Key: "k2"}, {Key: "k3"}}, state.BulkGetOpts{})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Equal(t, []state.BulkGetResponse{
+		{Key: "k1"},
+		{Key: "k2", Data: []byte(`"v2"`), ETag: ptr.Of("0001")},
+		{Key: "k3", Data: []byte("bin"), ETag: ptr.Of("0002")},
+	}, res)
+}
+
+func TestBulkSet(t *testing.T) {
+	s, mock := newBulkTestStore(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("WITH (UPDLOCK, HOLDLOCK)")).
+		WithArgs("k2", "k3", "k4").
+		WillReturnRows(sqlmock.NewRows([]string{"Key", "RowVersion"}).AddRow("k2", []byte{0, 1}).AddRow("k3", []byte{0, 1}).AddRow("k4", []byte{0, 2}))
+	mock.ExpectExec(regexp.QuoteMeta("USING (VALUES (@p1, @p2, CAST(@p3 AS VARBINARY(MAX)), CAST(@p4 AS BIT), CAST(@p5 AS INT)), (@p6, @p7, CAST(@p8 AS VARBINARY(MAX)), CAST(@p9 AS BIT), CAST(@p10 AS INT))) AS s ([Key], [Data], [BinaryData], [isBinary], [TTL])")).
+		WithArgs("k1", `"v1"`, nil, false, nil, "k2", nil, []byte("v2"), true, 10).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	// The same key can't be updated twice by a MERGE statement
+	mock.ExpectExec(regexp.QuoteMeta("USING (VALUES (@p1, @p2, CAST(@p3 AS VARBINARY(MAX)), CAST(@p4 AS BIT), CAST(@p5 AS INT))) AS s")).
+		WithArgs("k1", `"v1-1"`, nil, false, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := s.BulkSet(t.Context(), []state.SetRequest{
+		{Key: "k1", Value: "v1"},
+		{Key: "k2", Value: []byte("v2"), ETag: ptr.Of("0001"), Metadata: map[string]string{"ttlInSeconds": "10"}},
+		{Key: "k3", Value: "v3", ETag: ptr.Of("0002")},
+		{Key: "k4", Value: "v4", Options: state.SetStateOption{Concurrency: state.FirstWrite}},
+		{Key: "k1", Value: "v1-1"},
+	}, state.BulkStoreOpts{})
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Error(t, err)
+
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	require.Len(t, errs, 2)
+	for i, key := range []string{"k3", "k4"} {
+		var bulkErr state.BulkStoreError
+		require.ErrorAs(t, errs[i], &bulkErr)
+		assert.Equal(t, key, bulkErr.Key())
+		require.NotNil(t, bulkErr.ETagError())
+		assert.Equal(t, state.ETagMismatch, bulkErr.ETagError().Kind())
+	}
+}
+
+func TestBulkDelete(t *testing.T) {
+	s, mock := newBulkTestStore(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("WITH (UPDLOCK, HOLDLOCK)")).
+		WithArgs("k2", "k3").
+		WillReturnRows(sqlmock.NewRows([]string{"Key", "RowVersion"}).AddRow("k2", []byte{0, 1}))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM [dbo].[state] WHERE [Key] IN (@p1, @p2)")).
+		WithArgs("k1", "k2").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := s.BulkDelete(t.Context(), []state.DeleteRequest{
+		{Key: "k1"},
+		{Key: "k2", ETag: ptr.Of("0001")},
+		{Key: "k3", ETag: ptr.Of("0001")},
+	}, state.BulkStoreOpts{})
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	var bulkErr state.BulkStoreError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, "k3", bulkErr.Key())
+	require.NotNil(t, bulkErr.ETagError())
+}
@@ -77,3 +77,10 @@ type DeleteWithPrefix interface {
 type KeysLiker interface {
 	KeysLike(ctx context.Context, req *KeysLikeRequest) (*KeysLikeResponse, error)
 }
+
+// RangeScanner is an optional interface to read state items in key order,
+// with keys between two bounds.
+// Keys are compared byte-wise.
+type RangeScanner interface {
+	Scan(ctx context.Context, req *ScanRequest) (*ScanResponse, error)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LikeToRegex converts a SQL LIKE pattern to an anchored regular expression.
+// % matches any sequence of characters and _ matches a single character; a backslash escapes the next character.
+// A trailing backslash is matched literally.
+func LikeToRegex(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.Grow(len(pattern) + 4)
+	b.WriteString("^")
+
+	escaped := false
+	for _, r := range pattern {
+		if escaped {
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+			continue
+		}
+		switch r {
+		case '\\':
+			escaped = true
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	if escaped {
+		b.WriteString(regexp.QuoteMeta(`\`))
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// LikeLiteralPrefix returns the literal prefix of a SQL LIKE pattern, before the first unescaped % or _.
+// Escape sequences are resolved, and a trailing backslash is kept literally, consistently with LikeToRegex.
+func LikeLiteralPrefix(pattern string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%' || r == '_':
+			return b.String()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if escaped {
+		b.WriteByte('\\')
+	}
+	return b.String()
+}
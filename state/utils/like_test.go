@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLikeToRegex(t *testing.T) {
+	tests := []struct {
+		pattern string
+		match   []string
+		noMatch []string
+	}{
+		{pattern: "key%", match: []string{"key", "key1", "key||a"}, noMatch: []string{"akey"}},
+		{pattern: "k_y", match: []string{"key", "kéy"}, noMatch: []string{"ky", "keey"}},
+		{pattern: `100\%`, match: []string{"100%"}, noMatch: []string{"1000"}},
+		{pattern: `a\_b`, match: []string{"a_b"}, noMatch: []string{"axb"}},
+		{pattern: "a.b*", match: []string{"a.b*"}, noMatch: []string{"axb", "a.bb"}},
+		{pattern: `ab\`, match: []string{`ab\`}, noMatch: []string{"ab"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			re, err := LikeToRegex(tt.pattern)
+			require.NoError(t, err)
+			for _, s := range tt.match {
+				assert.Truef(t, re.MatchString(s), "expected %q to match", s)
+			}
+			for _, s := range tt.noMatch {
+				assert.Falsef(t, re.MatchString(s), "expected %q not to match", s)
+			}
+		})
+	}
+}
+
+func TestLikeLiteralPrefix(t *testing.T) {
+	tests := map[string]string{
+		"":       "",
+		"key":    "key",
+		"key%":   "key",
+		"ke_%":   "ke",
+		"%key":   "",
+		`100\%%`: "100%",
+		`a\_b_`:  "a_b",
+		`a\\%`:   `a\`,
+		`ab\`:    `ab\`,
+		"clé||%": "clé||",
+	}
+
+	for pattern, want := range tests {
+		assert.Equalf(t, want, LikeLiteralPrefix(pattern), "pattern %q", pattern)
+	}
+}
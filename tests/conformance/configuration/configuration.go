@@ -381,6 +381,85 @@ func ConformanceTests(t *testing.T, props map[string]string, store configuration
 			verifyNoMessagesReceived(t, processedC3)
 		})
 	})
+
+	if config.HasOperation("write") {
+		t.Run("write", func(t *testing.T) {
+			writer, ok := store.(configuration.Writer)
+			require.True(t, ok, "expected the store to implement configuration.Writer")
+
+			var writeValues map[string]*configuration.Item
+			writeValues, counter = generateKeyValues(runID, counter, 2, "")
+			keys := getKeys(writeValues)
+			versions := make(map[string]string, len(keys))
+
+			t.Run("set new keys", func(t *testing.T) {
+				resp, err := writer.Set(t.Context(), &configuration.SetRequest{
+					Items: writeValues,
+				})
+				require.NoError(t, err)
+				require.Len(t, resp.Versions, len(keys))
+				for _, key := range keys {
+					require.NotEmpty(t, resp.Versions[key])
+					versions[key] = resp.Versions[key]
+				}
+
+				getResp, err := store.Get(t.Context(), &configuration.GetRequest{Keys: keys})
+				require.NoError(t, err)
+				require.Len(t, getResp.Items, len(keys))
+				for _, key := range keys {
+					assert.Equal(t, writeValues[key].Value, getResp.Items[key].Value)
+					assert.Equal(t, versions[key], getResp.Items[key].Version)
+				}
+			})
+
+			t.Run("set with the current version", func(t *testing.T) {
+				key := keys[0]
+				resp, err := writer.Set(t.Context(), &configuration.SetRequest{
+					Items: map[string]*configuration.Item{
+						key: {Value: "updated-" + writeValues[key].Value, Version: versions[key]},
+					},
+				})
+				require.NoError(t, err)
+				require.NotEmpty(t, resp.Versions[key])
+				assert.NotEqual(t, versions[key], resp.Versions[key])
+				versions[key] = resp.Versions[key]
+			})
+
+			t.Run("set with a stale version", func(t *testing.T) {
+				_, err := writer.Set(t.Context(), &configuration.SetRequest{
+					Items: map[string]*configuration.Item{
+						keys[0]: {Value: "stale", Version: "stale-version"},
+						keys[1]: {Value: "not-written"},
+					},
+				})
+				require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+
+				getResp, err := store.Get(t.Context(), &configuration.GetRequest{Keys: keys[1:]})
+				require.NoError(t, err)
+				assert.Equal(t, writeValues[keys[1]].Value, getResp.Items[keys[1]].Value)
+			})
+
+			t.Run("delete with a stale version", func(t *testing.T) {
+				err := writer.Delete(t.Context(), &configuration.DeleteRequest{
+					Keys:     keys,
+					Versions: map[string]string{keys[0]: "stale-version"},
+				})
+				require.ErrorIs(t, err, configuration.ErrVersionMismatch)
+			})
+
+			t.Run("delete keys", func(t *testing.T) {
+				err := writer.Delete(t.Context(), &configuration.DeleteRequest{
+					Keys:     keys,
+					Versions: versions,
+				})
+				require.NoError(t, err)
+
+				getResp, err := store.Get(t.Context(), &configuration.GetRequest{Keys: keys})
+				require.NoError(t, err)
+				assert.Empty(t, getResp.Items)
+			})
+		})
+	}
 }
 
 func verifyNoMessagesReceived(t *testing.T, processedChan chan *configuration.UpdateEvent) {
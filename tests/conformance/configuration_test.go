@@ -23,12 +23,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/components-contrib/configuration"
+	c_localfile "github.com/dapr/components-contrib/configuration/local/file"
 	c_postgres "github.com/dapr/components-contrib/configuration/postgres"
 	c_redis "github.com/dapr/components-contrib/configuration/redis"
+	c_sqlite "github.com/dapr/components-contrib/configuration/sqlite"
 	conf_configuration "github.com/dapr/components-contrib/tests/conformance/configuration"
 	"github.com/dapr/components-contrib/tests/utils/configupdater"
+	cu_localfile "github.com/dapr/components-contrib/tests/utils/configupdater/localfile"
 	cu_postgres "github.com/dapr/components-contrib/tests/utils/configupdater/postgres"
 	cu_redis "github.com/dapr/components-contrib/tests/utils/configupdater/redis"
+	cu_sqlite "github.com/dapr/components-contrib/tests/utils/configupdater/sqlite"
 )
 
 func TestConfigurationConformance(t *testing.T) {
@@ -53,6 +57,8 @@ func TestConfigurationConformance(t *testing.T) {
 			conf_configuration.ConformanceTests(t, props, store, updater, configurationConfig, comp.Component)
 		}
 	}
+
+	tc.Run(t)
 }
 
 func loadConfigurationStore(name string) (configuration.Store, configupdater.Updater) {
@@ -63,6 +69,12 @@ func loadConfigurationStore(name string) (configuration.Store, configupdater.Upd
 	case "postgresql.docker", "postgresql.azure":
 		return c_postgres.NewPostgresConfigurationStore(testLogger),
 			cu_postgres.NewPostgresConfigUpdater(testLogger)
+	case "local.file":
+		return c_localfile.NewLocalFileConfigurationStore(testLogger),
+			cu_localfile.NewLocalFileConfigUpdater(testLogger)
+	case "sqlite":
+		return c_sqlite.NewSQLiteConfigurationStore(testLogger),
+			cu_sqlite.NewSQLiteConfigUpdater(testLogger)
 	default:
 		return nil, nil
 	}
@@ -23,7 +23,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	contribCrypto "github.com/dapr/components-contrib/crypto"
+	cr_awskms "github.com/dapr/components-contrib/crypto/aws/kms"
 	cr_azurekeyvault "github.com/dapr/components-contrib/crypto/azure/keyvault"
+	cr_hashicorpvault "github.com/dapr/components-contrib/crypto/hashicorp/vault"
 	cr_jwks "github.com/dapr/components-contrib/crypto/jwks"
 	cr_localstorage "github.com/dapr/components-contrib/crypto/localstorage"
 	conf_crypto "github.com/dapr/components-contrib/tests/conformance/crypto"
@@ -58,8 +60,12 @@ func TestCryptoConformance(t *testing.T) {
 
 func loadCryptoProvider(name string) contribCrypto.SubtleCrypto {
 	switch name {
+	case "aws.kms":
+		return cr_awskms.NewAWSKMSCrypto(testLogger)
 	case "azure.keyvault":
 		return cr_azurekeyvault.NewAzureKeyvaultCrypto(testLogger)
+	case "hashicorp.vault":
+		return cr_hashicorpvault.NewVaultTransitCrypto(testLogger)
 	case "localstorage":
 		return cr_localstorage.NewLocalStorageCrypto(testLogger)
 	case "jwks":
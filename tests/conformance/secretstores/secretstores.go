@@ -14,7 +14,9 @@ limitations under the License.
 package secretstores
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -117,4 +119,106 @@ func ConformanceTests(t *testing.T, props map[string]string, store secretstores.
 			}
 		})
 	})
+
+	if config.HasOperation("write") {
+		t.Run("write", func(t *testing.T) {
+			writer, ok := store.(secretstores.SecretWriter)
+			require.True(t, ok, "expected store to implement SecretWriter")
+			require.True(t, secretstores.FeatureSecretWriter.IsPresent(store.Features()), "expected store to advertise the secret writer feature")
+
+			const name = "conftestwritesecret"
+			emptyVersion := ""
+
+			// Make sure the secret doesn't exist from a previous run
+			require.NoError(t, writer.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: name}))
+
+			res, err := writer.SetSecret(t.Context(), secretstores.SetSecretRequest{
+				Name:    name,
+				Data:    map[string]string{name: "v1"},
+				Version: &emptyVersion,
+			})
+			require.NoError(t, err, "expected no error on creating secret")
+
+			_, err = writer.SetSecret(t.Context(), secretstores.SetSecretRequest{
+				Name:    name,
+				Data:    map[string]string{name: "v2"},
+				Version: &emptyVersion,
+			})
+			require.ErrorIs(t, err, secretstores.ErrVersionMismatch, "expected a version mismatch when creating a secret that exists")
+
+			_, err = writer.SetSecret(t.Context(), secretstores.SetSecretRequest{
+				Name:    name,
+				Data:    map[string]string{name: "v2"},
+				Version: &res.Version,
+			})
+			require.NoError(t, err, "expected no error on updating secret with the current version")
+
+			_, err = writer.SetSecret(t.Context(), secretstores.SetSecretRequest{
+				Name:    name,
+				Data:    map[string]string{name: "v3"},
+				Version: &res.Version,
+			})
+			require.ErrorIs(t, err, secretstores.ErrVersionMismatch, "expected a version mismatch when updating secret with an old version")
+
+			resp, err := store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: name})
+			require.NoError(t, err, "expected no error on getting written secret")
+			assert.Equal(t, map[string]string{name: "v2"}, resp.Data)
+
+			require.NoError(t, writer.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: name}))
+			_, err = store.GetSecret(t.Context(), secretstores.GetSecretRequest{Name: name})
+			require.Error(t, err, "expected error on getting deleted secret")
+		})
+	}
+
+	// The watch operation requires the write one, to change secrets
+	if config.HasOperation("watch") {
+		t.Run("watch", func(t *testing.T) {
+			watcher, ok := store.(secretstores.SecretWatcher)
+			require.True(t, ok, "expected store to implement SecretWatcher")
+			require.True(t, secretstores.FeatureSecretWatcher.IsPresent(store.Features()), "expected store to advertise the secret watcher feature")
+			writer, ok := store.(secretstores.SecretWriter)
+			require.True(t, ok, "expected store to implement SecretWriter")
+
+			const name = "conftestwatchsecret"
+			require.NoError(t, writer.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: name}))
+
+			events := make(chan *secretstores.SecretUpdateEvent, 10)
+			id, err := watcher.SubscribeSecrets(t.Context(), secretstores.SubscribeSecretsRequest{
+				Names: []string{name},
+			}, func(ctx context.Context, e *secretstores.SecretUpdateEvent) error {
+				events <- e
+				return nil
+			})
+			require.NoError(t, err, "expected no error on subscribing")
+			defer watcher.UnsubscribeSecrets(context.Background(), secretstores.UnsubscribeSecretsRequest{ID: id})
+
+			receive := func() *secretstores.SecretUpdate {
+				t.Helper()
+				// Stores that poll for changes may take a while to notice them
+				select {
+				case e := <-events:
+					assert.Equal(t, id, e.ID)
+					require.Contains(t, e.Secrets, name)
+					return e.Secrets[name]
+				case <-time.After(2 * time.Minute):
+					require.FailNow(t, "timed out waiting for update")
+					return nil
+				}
+			}
+
+			_, err = writer.SetSecret(t.Context(), secretstores.SetSecretRequest{
+				Name: name,
+				Data: map[string]string{name: "v1"},
+			})
+			require.NoError(t, err, "expected no error on creating secret")
+			update := receive()
+			assert.False(t, update.Deleted)
+			assert.Equal(t, map[string]string{name: "v1"}, update.Data)
+
+			require.NoError(t, writer.DeleteSecret(t.Context(), secretstores.DeleteSecretRequest{Name: name}))
+			assert.True(t, receive().Deleted)
+
+			require.NoError(t, watcher.UnsubscribeSecrets(t.Context(), secretstores.UnsubscribeSecretsRequest{ID: id}))
+		})
+	}
 }
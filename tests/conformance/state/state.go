@@ -1623,6 +1623,142 @@ func ConformanceTests(t *testing.T, props map[string]string, statestore state.St
 		})
 	}
 
+	if config.HasOperation("rangescan") {
+		keys := []string{
+			"scan||device1||2024-01-01T00:00:00Z",
+			"scan||device1||2024-01-02T00:00:00Z",
+			"scan||device1||2024-01-03T00:00:00Z",
+			"scan||device1||2024-01-04T00:00:00Z",
+			"scan||device1||2024-01-05T00:00:00Z",
+			"scan||device2||2024-01-01T00:00:00Z",
+		}
+		device1 := keys[:5]
+
+		scanKeys := func(items []state.ScanItem) []string {
+			res := make([]string, len(items))
+			for i, item := range items {
+				res[i] = item.Key
+			}
+			return res
+		}
+
+		var store state.RangeScanner
+		t.Run("component implements RangeScanner interface", func(t *testing.T) {
+			var ok bool
+			store, ok = statestore.(state.RangeScanner)
+			require.True(t, ok)
+		})
+
+		t.Run("RangeScan feature present", func(t *testing.T) {
+			features := statestore.Features()
+			require.True(t, state.FeatureRangeScan.IsPresent(features))
+		})
+
+		t.Run("set test data", func(t *testing.T) {
+			for _, key := range keys {
+				require.NoError(t, statestore.Set(t.Context(), &state.SetRequest{
+					Key:   key,
+					Value: []byte("value for " + key),
+				}))
+			}
+		})
+
+		t.Run("invalid range", func(t *testing.T) {
+			got, err := store.Scan(t.Context(), &state.ScanRequest{
+				Start: "scan||device2||",
+				End:   "scan||device1||",
+			})
+			require.ErrorIs(t, err, state.ErrScanInvalidRange)
+			assert.Nil(t, got)
+		})
+
+		t.Run("range", func(t *testing.T) {
+			got, err := store.Scan(t.Context(), &state.ScanRequest{
+				Start: "scan||device1||2024-01-02",
+				End:   "scan||device1||2024-01-04",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, device1[1:3], scanKeys(got.Items))
+			assert.Nil(t, got.ContinuationToken)
+			for _, item := range got.Items {
+				assert.Equal(t, "value for "+item.Key, string(item.Data))
+				assert.NotNil(t, item.ETag)
+			}
+		})
+
+		t.Run("reverse", func(t *testing.T) {
+			got, err := store.Scan(t.Context(), &state.ScanRequest{
+				Start:   "scan||device1||",
+				End:     "scan||device2||",
+				Reverse: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{device1[4], device1[3], device1[2], device1[1], device1[0]}, scanKeys(got.Items))
+			assert.Nil(t, got.ContinuationToken)
+		})
+
+		t.Run("limit and continuation token", func(t *testing.T) {
+			for _, reverse := range []bool{false, true} {
+				t.Run("reverse="+strconv.FormatBool(reverse), func(t *testing.T) {
+					req := &state.ScanRequest{
+						Start:   "scan||device1||",
+						End:     "scan||device2||",
+						Limit:   2,
+						Reverse: reverse,
+					}
+					gotKeys := []string{}
+					for range 3 {
+						got, err := store.Scan(t.Context(), req)
+						require.NoError(t, err)
+						require.NotEmpty(t, got.Items)
+						gotKeys = append(gotKeys, scanKeys(got.Items)...)
+						req.ContinuationToken = got.ContinuationToken
+						if got.ContinuationToken == nil {
+							break
+						}
+					}
+					assert.Nil(t, req.ContinuationToken)
+
+					exp := slices.Clone(device1)
+					if reverse {
+						slices.Reverse(exp)
+					}
+					assert.Equal(t, exp, gotKeys)
+				})
+			}
+		})
+
+		t.Run("open range", func(t *testing.T) {
+			got, err := store.Scan(t.Context(), &state.ScanRequest{
+				Start: "scan||device2||",
+				Limit: 1,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, keys[5:], scanKeys(got.Items))
+		})
+
+		t.Run("deleted items are not returned", func(t *testing.T) {
+			require.NoError(t, statestore.Delete(t.Context(), &state.DeleteRequest{
+				Key: device1[0],
+			}))
+
+			got, err := store.Scan(t.Context(), &state.ScanRequest{
+				Start: "scan||device1||",
+				End:   "scan||device2||",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, device1[1:], scanKeys(got.Items))
+		})
+
+		t.Run("delete test data", func(t *testing.T) {
+			for _, key := range keys {
+				require.NoError(t, statestore.Delete(t.Context(), &state.DeleteRequest{
+					Key: key,
+				}))
+			}
+		})
+	}
+
 	if config.HasOperation("keyslike") {
 		keys := []string{
 			"prefix||key1",
@@ -24,6 +24,7 @@ import (
 
 	conf_workflows "github.com/dapr/components-contrib/tests/conformance/workflows"
 	"github.com/dapr/components-contrib/workflows"
+	wf_sqlite "github.com/dapr/components-contrib/workflows/sqlite"
 )
 
 func TestWorkflowsConformance(t *testing.T) {
@@ -52,6 +53,13 @@ func TestWorkflowsConformance(t *testing.T) {
 
 func loadWorkflow(name string) workflows.Workflow {
 	switch name {
+	case "sqlite":
+		wf := wf_sqlite.New(testLogger)
+		// The conformance tests expect the workflow to keep running until it's terminated
+		_ = wf.RegisterWorkflow("TestWorkflow", func(ctx *wf_sqlite.WorkflowContext) (any, error) {
+			return nil, ctx.WaitForExternalEvent("TestEvent", 0).Await(nil)
+		})
+		return wf
 	default:
 		return nil
 	}
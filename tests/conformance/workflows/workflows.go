@@ -92,6 +92,40 @@ func ConformanceTests(t *testing.T, props map[string]string, workflowItem workfl
 			assert.Equal(t, "Running", resp.Workflow.RuntimeStatus)
 		})
 
+		if config.HasOperation("query") {
+			t.Run("query", func(t *testing.T) {
+				querier, ok := workflowItem.(workflows.Querier)
+				require.True(t, ok, "component does not implement workflows.Querier")
+
+				resp, err := querier.Query(t.Context(), &workflows.QueryRequest{
+					Filter: workflows.InstanceFilter{
+						WorkflowName:  "TestWorkflow",
+						RuntimeStatus: []string{"Running"},
+					},
+				})
+				require.NoError(t, err)
+				found := false
+				for _, wf := range resp.Workflows {
+					if wf.InstanceID == testInstanceID {
+						found = true
+						assert.Equal(t, "Running", wf.RuntimeStatus)
+					}
+				}
+				assert.True(t, found, "instance not found in query results")
+			})
+		}
+
+		if config.HasOperation("history") {
+			t.Run("history", func(t *testing.T) {
+				reader, ok := workflowItem.(workflows.HistoryReader)
+				require.True(t, ok, "component does not implement workflows.HistoryReader")
+
+				resp, err := reader.GetHistory(t.Context(), &workflows.GetHistoryRequest{InstanceID: testInstanceID})
+				require.NoError(t, err)
+				assert.NotEmpty(t, resp.Events)
+			})
+		}
+
 		t.Run("terminate", func(t *testing.T) {
 			err := workflowItem.Terminate(t.Context(), &workflows.TerminateRequest{InstanceID: testInstanceID})
 			require.NoError(t, err)
@@ -106,6 +140,25 @@ func ConformanceTests(t *testing.T, props map[string]string, workflowItem workfl
 			assert.Equal(t, "Terminated", resp.Workflow.RuntimeStatus)
 			assert.Equal(t, "TestID", resp.Workflow.InstanceID)
 		})
+
+		if config.HasOperation("bulk") {
+			t.Run("bulk purge", func(t *testing.T) {
+				purger, ok := workflowItem.(workflows.BulkPurger)
+				require.True(t, ok, "component does not implement workflows.BulkPurger")
+
+				resp, err := purger.BulkPurge(t.Context(), &workflows.BulkPurgeRequest{
+					Filter: workflows.InstanceFilter{
+						WorkflowName:  "TestWorkflow",
+						RuntimeStatus: []string{"Terminated"},
+					},
+				})
+				require.NoError(t, err)
+				assert.GreaterOrEqual(t, resp.PurgedCount, 1)
+
+				_, err = workflowItem.Get(t.Context(), &workflows.GetRequest{InstanceID: testInstanceID})
+				require.Error(t, err)
+			})
+		}
 		testLogger.Info("Start test done.")
 	})
 }
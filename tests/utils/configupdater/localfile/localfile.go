@@ -0,0 +1,95 @@
+package localfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	commonutils "github.com/dapr/components-contrib/common/utils"
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/components-contrib/tests/utils/configupdater"
+	"github.com/dapr/kit/logger"
+)
+
+const configFileKey = "configFile"
+
+type fileItem struct {
+	Value    string            `yaml:"value"`
+	Version  string            `yaml:"version,omitempty"`
+	Metadata map[string]string `yaml:"metadata,omitempty"`
+}
+
+type ConfigUpdater struct {
+	configFile string
+	items      map[string]fileItem
+	lock       sync.Mutex
+	logger     logger.Logger
+}
+
+func NewLocalFileConfigUpdater(logger logger.Logger) configupdater.Updater {
+	return &ConfigUpdater{
+		logger: logger,
+	}
+}
+
+// Init creates an empty YAML configuration file.
+func (r *ConfigUpdater) Init(props map[string]string) error {
+	r.configFile = props[configFileKey]
+	if r.configFile == "" {
+		return errors.New("missing configuration file")
+	}
+
+	err := os.MkdirAll(filepath.Dir(r.configFile), 0o700)
+	if err != nil {
+		return fmt.Errorf("error creating configuration folder: %w", err)
+	}
+
+	r.items = map[string]fileItem{}
+	return r.writeFile()
+}
+
+func (r *ConfigUpdater) AddKey(items map[string]*configuration.Item) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for key, item := range items {
+		r.items[key] = fileItem{
+			Value:    item.Value,
+			Version:  item.Version,
+			Metadata: item.Metadata,
+		}
+	}
+	return r.writeFile()
+}
+
+func (r *ConfigUpdater) UpdateKey(items map[string]*configuration.Item) error {
+	return r.AddKey(items)
+}
+
+func (r *ConfigUpdater) DeleteKey(keys []string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, key := range keys {
+		delete(r.items, key)
+	}
+	return r.writeFile()
+}
+
+// writeFile replaces the configuration file, so the store never reads a partially-written file.
+func (r *ConfigUpdater) writeFile() error {
+	data, err := yaml.Marshal(r.items)
+	if err != nil {
+		return fmt.Errorf("error encoding configuration items: %w", err)
+	}
+
+	err = commonutils.WriteFileAtomic(r.configFile, data)
+	if err != nil {
+		return fmt.Errorf("error writing configuration file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	// Blank import for the underlying SQLite Driver.
+	_ "modernc.org/sqlite"
+
+	authSqlite "github.com/dapr/components-contrib/common/authentication/sqlite"
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/components-contrib/tests/utils/configupdater"
+	"github.com/dapr/kit/logger"
+	kitmd "github.com/dapr/kit/metadata"
+)
+
+const defaultTableName = "configuration"
+
+type ConfigUpdater struct {
+	db        *sql.DB
+	tableName string
+	logger    logger.Logger
+}
+
+func NewSQLiteConfigUpdater(logger logger.Logger) configupdater.Updater {
+	return &ConfigUpdater{
+		logger: logger,
+	}
+}
+
+// Init creates the configuration table, with the same schema as the store, and deletes existing items.
+func (r *ConfigUpdater) Init(props map[string]string) error {
+	md := struct {
+		authSqlite.SqliteAuthMetadata `mapstructure:",squash"`
+
+		TableName string `mapstructure:"tableName"`
+	}{}
+	md.Reset()
+	md.TableName = defaultTableName
+	err := kitmd.DecodeMetadata(props, &md)
+	if err != nil {
+		return err
+	}
+	err = md.Validate()
+	if err != nil {
+		return err
+	}
+	r.tableName = md.TableName
+
+	connString, err := md.GetConnectionString(r.logger, authSqlite.GetConnectionStringOpts{})
+	if err != nil {
+		return err
+	}
+	r.db, err = sql.Open("sqlite", connString)
+	if err != nil {
+		return fmt.Errorf("error connecting to the database: %w", err)
+	}
+
+	ctx := context.Background()
+	_, err = r.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+r.tableName+` (
+		key TEXT NOT NULL PRIMARY KEY,
+		value TEXT NOT NULL,
+		version TEXT NOT NULL DEFAULT '',
+		metadata TEXT NOT NULL DEFAULT '{}',
+		update_time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("error creating table: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, "DELETE FROM "+r.tableName)
+	if err != nil {
+		return fmt.Errorf("error deleting existing items: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ConfigUpdater) AddKey(items map[string]*configuration.Item) error {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for key, item := range items {
+		md, err := json.Marshal(item.Metadata)
+		if err != nil {
+			return err
+		}
+		if item.Metadata == nil {
+			md = []byte("{}")
+		}
+		_, err = tx.Exec("REPLACE INTO "+r.tableName+" (key, value, version, metadata) VALUES (?, ?, ?, ?)", key, item.Value, item.Version, string(md))
+		if err != nil {
+			return fmt.Errorf("error adding key %s: %w", key, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *ConfigUpdater) UpdateKey(items map[string]*configuration.Item) error {
+	return r.AddKey(items)
+}
+
+func (r *ConfigUpdater) DeleteKey(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := make([]any, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+	_, err := r.db.Exec("DELETE FROM "+r.tableName+" WHERE key IN ("+strings.Repeat("?,", len(keys)-1)+"?)", args...)
+	return err
+}
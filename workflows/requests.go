@@ -1,6 +1,10 @@
 package workflows
 
-import "google.golang.org/protobuf/types/known/wrapperspb"
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
 
 // StartRequest is the struct describing a start workflow request.
 type StartRequest struct {
@@ -43,3 +47,67 @@ type PurgeRequest struct {
 	InstanceID string `json:"instanceID"`
 	Recursive  *bool  `json:"recursive"`
 }
+
+// InstanceFilter is the object describing the criteria to select workflow instances.
+// Instances must match all the criteria that are set.
+type InstanceFilter struct {
+	// WorkflowName selects the instances of the workflow with the name.
+	WorkflowName string `json:"workflowName,omitempty"`
+	// RuntimeStatus selects the instances with any of the runtime statuses, such as "Running" or "Failed".
+	RuntimeStatus []string `json:"runtimeStatus,omitempty"`
+	// CreatedFrom is the inclusive lower bound of the creation time of the instances.
+	CreatedFrom *time.Time `json:"createdFrom,omitempty"`
+	// CreatedTo is the exclusive upper bound of the creation time of the instances.
+	CreatedTo *time.Time `json:"createdTo,omitempty"`
+}
+
+// IsEmpty returns true if the filter has no criteria, and so it selects all instances.
+func (f *InstanceFilter) IsEmpty() bool {
+	return f.WorkflowName == "" && len(f.RuntimeStatus) == 0 && f.CreatedFrom == nil && f.CreatedTo == nil
+}
+
+// Validate returns an error if the filter is not valid.
+func (f *InstanceFilter) Validate() error {
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		return ErrInvalidFilter
+	}
+	return nil
+}
+
+// QueryRequest is the object describing a request to list the workflow instances matching a filter.
+type QueryRequest struct {
+	Filter InstanceFilter `json:"filter"`
+
+	// PageSize is an optional parameter to indicate the maximum number of instances to return.
+	// If zero, the component chooses the size of the page.
+	PageSize uint32 `json:"pageSize,omitempty"`
+
+	// ContinuationToken is an optional parameter to continue a previous query, with the token it returned.
+	ContinuationToken *string `json:"continuationToken,omitempty"`
+}
+
+// GetHistoryRequest is the object describing a request to read the history of a workflow instance.
+type GetHistoryRequest struct {
+	InstanceID string `json:"instanceID"`
+
+	// PageSize is an optional parameter to indicate the maximum number of events to return.
+	// If zero, the component chooses the size of the page.
+	PageSize uint32 `json:"pageSize,omitempty"`
+
+	// ContinuationToken is an optional parameter to continue a previous request, with the token it returned.
+	ContinuationToken *string `json:"continuationToken,omitempty"`
+}
+
+// BulkTerminateRequest is the object describing a request to terminate the workflow instances matching a filter.
+// The filter must not be empty.
+type BulkTerminateRequest struct {
+	Filter    InstanceFilter `json:"filter"`
+	Recursive *bool          `json:"recursive"`
+}
+
+// BulkPurgeRequest is the object describing a request to purge the workflow instances matching a filter.
+// The filter must not be empty. Instances that have not completed are not purged.
+type BulkPurgeRequest struct {
+	Filter    InstanceFilter `json:"filter"`
+	Recursive *bool          `json:"recursive"`
+}
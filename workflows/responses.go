@@ -18,3 +18,43 @@ type StartResponse struct {
 type StateResponse struct {
 	Workflow *WorkflowState `json:"workflow"`
 }
+
+// QueryResponse is the response object for listing workflow instances.
+type QueryResponse struct {
+	Workflows []*WorkflowState `json:"workflows"`
+
+	// ContinuationToken is set when there are more instances to return.
+	ContinuationToken *string `json:"continuationToken,omitempty"`
+}
+
+// HistoryEvent is an event in the history of a workflow instance.
+type HistoryEvent struct {
+	// EventID is the position of the event in the history.
+	EventID   int64     `json:"eventID"`
+	EventType string    `json:"eventType"`
+	Timestamp time.Time `json:"timestamp"`
+	// TaskID is the ID of the task the event refers to, for events related to activities, timers and child workflows.
+	TaskID *int64 `json:"taskID,omitempty"`
+	// Name is the name of the activity, child workflow or external event.
+	Name string `json:"name,omitempty"`
+	// Data is the payload of the event, such as an input, an output or an error message.
+	Data string `json:"data,omitempty"`
+}
+
+// GetHistoryResponse is the response object for reading the history of a workflow instance.
+type GetHistoryResponse struct {
+	Events []HistoryEvent `json:"events"`
+
+	// ContinuationToken is set when there are more events to return.
+	ContinuationToken *string `json:"continuationToken,omitempty"`
+}
+
+// BulkTerminateResponse is the response object for terminating workflow instances matching a filter.
+type BulkTerminateResponse struct {
+	TerminatedCount int `json:"terminatedCount"`
+}
+
+// BulkPurgeResponse is the response object for purging workflow instances matching a filter.
+type BulkPurgeResponse struct {
+	PurgedCount int `json:"purgedCount"`
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// WorkflowFunc is the implementation of a workflow.
+//
+// Workflows are executed from the beginning every time they make progress, and the results of the tasks that have already completed are replayed from the history.
+// For this reason, workflows must be deterministic: they must not perform I/O or depend on the current time or on random values, and they must schedule the same tasks in the same order every time.
+// Work that isn't deterministic should be performed in activities.
+// Workflows must not recover panics raised by the WorkflowContext, which are used to stop their execution while they wait for tasks.
+type WorkflowFunc func(ctx *WorkflowContext) (any, error)
+
+// ActivityFunc is the implementation of an activity.
+// Activities can perform I/O. They're executed at least once: if the process stops while an activity is running, it's executed again.
+type ActivityFunc func(ctx *ActivityContext) (any, error)
+
+var (
+	// ErrEventTimeout is returned when awaiting an external event that wasn't raised before the timeout.
+	ErrEventTimeout = errors.New("timed out waiting for the external event")
+	// ErrNonDeterministic is the cause of the failure of workflows that didn't schedule the same tasks when replayed.
+	ErrNonDeterministic = errors.New("workflow is not deterministic")
+)
+
+// TaskFailedError is returned when awaiting an activity or child workflow that failed.
+type TaskFailedError struct {
+	Message string
+}
+
+func (e *TaskFailedError) Error() string {
+	return "task failed: " + e.Message
+}
+
+// Task is the result of an asynchronous operation scheduled by a workflow.
+type Task interface {
+	// Await waits for the task to complete, and unmarshals its JSON result into v, if v is not nil.
+	// If the task failed, it returns a *TaskFailedError.
+	Await(v any) error
+
+	// completion returns the event that completed the task, or nil if the task hasn't completed yet.
+	completion() *historyEvent
+}
+
+// suspendSignal is raised with panic to stop the execution of a workflow that is waiting for a task.
+type suspendSignal struct{}
+
+// fatalError is raised with panic to fail a workflow.
+type fatalError struct {
+	err error
+}
+
+// action is a new event to add to the history of the workflow, and the work it requires.
+type action struct {
+	event historyEvent
+	// Input of the activity or child workflow
+	input string
+	// Time a timer fires at
+	fireAt time.Time
+}
+
+// WorkflowContext is the context of a workflow execution.
+type WorkflowContext struct {
+	instanceID string
+	name       string
+	input      string
+
+	scheduled   map[int64]*historyEvent
+	completions map[int64]*historyEvent
+	events      map[string][]*historyEvent
+	eventCursor map[string]int
+	waiters     map[string][]*eventTask
+
+	nextTaskID   int64
+	currentTime  time.Time
+	customStatus *string
+	actions      []action
+}
+
+func newWorkflowContext(instanceID string, name string, input string, history []historyEvent) *WorkflowContext {
+	c := &WorkflowContext{
+		instanceID:  instanceID,
+		name:        name,
+		input:       input,
+		scheduled:   make(map[int64]*historyEvent),
+		completions: make(map[int64]*historyEvent),
+		events:      make(map[string][]*historyEvent),
+		eventCursor: make(map[string]int),
+		waiters:     make(map[string][]*eventTask),
+	}
+
+	for i := range history {
+		ev := &history[i]
+		switch ev.Type {
+		case eventExecutionStarted:
+			c.currentTime = ev.Timestamp
+		case eventTaskScheduled, eventTimerCreated, eventChildWorkflowCreated:
+			c.scheduled[ev.TaskID] = ev
+		case eventTaskCompleted, eventTaskFailed, eventTimerFired, eventChildWorkflowCompleted, eventChildWorkflowFailed:
+			// Activities are executed at least once, so only the first result counts
+			if _, ok := c.completions[ev.TaskID]; !ok {
+				c.completions[ev.TaskID] = ev
+			}
+		case eventEventRaised:
+			c.events[ev.Name] = append(c.events[ev.Name], ev)
+		}
+	}
+
+	return c
+}
+
+// InstanceID returns the ID of the workflow instance.
+func (c *WorkflowContext) InstanceID() string {
+	return c.instanceID
+}
+
+// Name returns the name of the workflow.
+func (c *WorkflowContext) Name() string {
+	return c.name
+}
+
+// CurrentTime returns the current time of the workflow, which is deterministic: it's the time the workflow started, or the time the last awaited task completed at.
+func (c *WorkflowContext) CurrentTime() time.Time {
+	return c.currentTime
+}
+
+// GetInput unmarshals the JSON input of the workflow into v.
+func (c *WorkflowContext) GetInput(v any) error {
+	if c.input == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(c.input), v)
+}
+
+// SetCustomStatus sets the custom status of the workflow instance, which is returned by Get.
+func (c *WorkflowContext) SetCustomStatus(status string) {
+	c.customStatus = &status
+}
+
+// CallActivity schedules the execution of an activity, with the input marshaled as JSON.
+func (c *WorkflowContext) CallActivity(name string, input any) Task {
+	data := c.marshal(input)
+	id := c.schedule(action{
+		event: historyEvent{Type: eventTaskScheduled, Name: name, Data: data},
+		input: data,
+	})
+	return &task{c: c, id: id}
+}
+
+// CallChildWorkflow starts a child workflow, with the input marshaled as JSON.
+// The result of the task is the output of the child workflow.
+func (c *WorkflowContext) CallChildWorkflow(name string, input any) Task {
+	id := c.nextTaskID
+	c.schedule(action{
+		event: historyEvent{Type: eventChildWorkflowCreated, Name: name, Data: fmt.Sprintf("%s:%04d", c.instanceID, id)},
+		input: c.marshal(input),
+	})
+	return &task{c: c, id: id}
+}
+
+// CreateTimer returns a task that completes after the duration, measured from the current time of the workflow.
+func (c *WorkflowContext) CreateTimer(d time.Duration) Task {
+	return &task{c: c, id: c.createTimer(d)}
+}
+
+func (c *WorkflowContext) createTimer(d time.Duration) int64 {
+	fireAt := c.currentTime.Add(d)
+	return c.schedule(action{
+		event:  historyEvent{Type: eventTimerCreated, Data: fireAt.UTC().Format(time.RFC3339Nano)},
+		fireAt: fireAt,
+	})
+}
+
+// WaitForExternalEvent returns a task that completes when an event with the name is raised, and whose result is the data of the event.
+// Events raised before the workflow waits for them are buffered, and each event is delivered once, in the order they were raised.
+// If timeout is greater than zero, awaiting the task returns ErrEventTimeout if the event isn't raised before the timeout.
+func (c *WorkflowContext) WaitForExternalEvent(name string, timeout time.Duration) Task {
+	t := &eventTask{c: c, name: name, timerID: -1}
+	if timeout > 0 {
+		t.timerID = c.createTimer(timeout)
+	}
+	c.waiters[name] = append(c.waiters[name], t)
+	return t
+}
+
+// WhenAny waits for any of the tasks to complete, and returns the index of the task that completed first.
+func (c *WorkflowContext) WhenAny(tasks ...Task) int {
+	found := -1
+	var first *historyEvent
+	for i, t := range tasks {
+		ev := t.completion()
+		if ev != nil && (first == nil || ev.Seq < first.Seq) {
+			found = i
+			first = ev
+		}
+	}
+	if first == nil {
+		panic(suspendSignal{})
+	}
+	c.advance(first.Timestamp)
+	return found
+}
+
+func (c *WorkflowContext) marshal(v any) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fatalError{err: fmt.Errorf("failed to marshal input: %w", err)})
+	}
+	return string(data)
+}
+
+// schedule assigns an ID to a new task, and records the action if the task isn't in the history already.
+func (c *WorkflowContext) schedule(a action) int64 {
+	id := c.nextTaskID
+	c.nextTaskID++
+
+	if ev, ok := c.scheduled[id]; ok {
+		if ev.Type != a.event.Type || ev.Name != a.event.Name {
+			panic(fatalError{err: fmt.Errorf("%w: task %d is %s '%s' in the history, but the workflow scheduled %s '%s'", ErrNonDeterministic, id, ev.Type, ev.Name, a.event.Type, a.event.Name)})
+		}
+		return id
+	}
+
+	a.event.TaskID = id
+	c.actions = append(c.actions, a)
+	return id
+}
+
+// await returns the event that completed the task, or stops the execution of the workflow if the task hasn't completed.
+func (c *WorkflowContext) await(t Task) *historyEvent {
+	ev := t.completion()
+	if ev == nil {
+		panic(suspendSignal{})
+	}
+	c.advance(ev.Timestamp)
+	return ev
+}
+
+func (c *WorkflowContext) advance(t time.Time) {
+	if t.After(c.currentTime) {
+		c.currentTime = t
+	}
+}
+
+// resolveWaiters assigns the events raised with the name to the tasks waiting for them, in order.
+// Waiters that time out don't consume events, which are delivered to the next waiters instead.
+func (c *WorkflowContext) resolveWaiters(name string) {
+	for _, w := range c.waiters[name] {
+		if w.event != nil || w.timedOut != nil {
+			continue
+		}
+
+		var (
+			next  *historyEvent
+			timer *historyEvent
+		)
+		if events := c.events[name]; c.eventCursor[name] < len(events) {
+			next = events[c.eventCursor[name]]
+		}
+		if w.timerID >= 0 {
+			timer = c.completions[w.timerID]
+		}
+
+		switch {
+		case next != nil && (timer == nil || next.Seq < timer.Seq):
+			w.event = next
+			c.eventCursor[name]++
+		case timer != nil:
+			w.timedOut = timer
+		default:
+			// Following waiters can't be resolved before this one
+			return
+		}
+	}
+}
+
+// task is an activity, timer or child workflow.
+type task struct {
+	c  *WorkflowContext
+	id int64
+}
+
+func (t *task) Await(v any) error {
+	ev := t.c.await(t)
+	switch ev.Type {
+	case eventTaskFailed, eventChildWorkflowFailed:
+		return &TaskFailedError{Message: ev.Data}
+	case eventTimerFired:
+		return nil
+	default:
+		return unmarshalResult(ev.Data, v)
+	}
+}
+
+func (t *task) completion() *historyEvent {
+	return t.c.completions[t.id]
+}
+
+// eventTask is a task waiting for an external event.
+type eventTask struct {
+	c       *WorkflowContext
+	name    string
+	timerID int64
+
+	event    *historyEvent
+	timedOut *historyEvent
+}
+
+func (t *eventTask) Await(v any) error {
+	ev := t.c.await(t)
+	if ev.Type == eventTimerFired {
+		return ErrEventTimeout
+	}
+	return unmarshalResult(ev.Data, v)
+}
+
+func (t *eventTask) completion() *historyEvent {
+	t.c.resolveWaiters(t.name)
+	if t.event != nil {
+		return t.event
+	}
+	return t.timedOut
+}
+
+func unmarshalResult(data string, v any) error {
+	if v == nil || data == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data), v)
+}
+
+// ActivityContext is the context of an activity execution.
+type ActivityContext struct {
+	ctx        context.Context
+	instanceID string
+	name       string
+	input      string
+}
+
+// Context returns a context that is canceled when the engine is closed.
+func (a *ActivityContext) Context() context.Context {
+	return a.ctx
+}
+
+// InstanceID returns the ID of the workflow instance that scheduled the activity.
+func (a *ActivityContext) InstanceID() string {
+	return a.instanceID
+}
+
+// Name returns the name of the activity.
+func (a *ActivityContext) Name() string {
+	return a.name
+}
+
+// GetInput unmarshals the JSON input of the activity into v.
+func (a *ActivityContext) GetInput(v any) error {
+	if a.input == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(a.input), v)
+}
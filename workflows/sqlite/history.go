@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"time"
+)
+
+// Types of the events in the history of a workflow instance.
+const (
+	eventExecutionStarted       = "ExecutionStarted"
+	eventExecutionCompleted     = "ExecutionCompleted"
+	eventExecutionFailed        = "ExecutionFailed"
+	eventExecutionTerminated    = "ExecutionTerminated"
+	eventExecutionSuspended     = "ExecutionSuspended"
+	eventExecutionResumed       = "ExecutionResumed"
+	eventTaskScheduled          = "TaskScheduled"
+	eventTaskCompleted          = "TaskCompleted"
+	eventTaskFailed             = "TaskFailed"
+	eventTimerCreated           = "TimerCreated"
+	eventTimerFired             = "TimerFired"
+	eventChildWorkflowCreated   = "ChildWorkflowCreated"
+	eventChildWorkflowCompleted = "ChildWorkflowCompleted"
+	eventChildWorkflowFailed    = "ChildWorkflowFailed"
+	eventEventRaised            = "EventRaised"
+)
+
+// Runtime statuses of workflow instances.
+const (
+	StatusRunning    = "Running"
+	StatusCompleted  = "Completed"
+	StatusFailed     = "Failed"
+	StatusTerminated = "Terminated"
+	StatusSuspended  = "Suspended"
+)
+
+// Kinds of work items.
+const (
+	workItemActivity = "activity"
+	workItemTimer    = "timer"
+)
+
+// historyEvent is an event in the history of a workflow instance.
+// The history is append-only, and it's replayed every time the workflow is executed.
+type historyEvent struct {
+	Seq  int64
+	Type string
+	// ID of the task the event refers to, for events related to activities, timers and child workflows; -1 otherwise.
+	TaskID int64
+	// Name of the activity, child workflow or external event.
+	Name string
+	// Payload of the event, such as the input or output of a task, or an error message.
+	Data      string
+	Timestamp time.Time
+}
+
+func isTerminalStatus(status string) bool {
+	return status == StatusCompleted || status == StatusFailed || status == StatusTerminated
+}
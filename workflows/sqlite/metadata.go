@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	authSqlite "github.com/dapr/components-contrib/common/authentication/sqlite"
+	"github.com/dapr/components-contrib/workflows"
+	"github.com/dapr/kit/metadata"
+)
+
+const (
+	defaultTablePrefix             = "workflow_"
+	defaultPollInterval            = time.Second
+	defaultLockDuration            = time.Minute
+	defaultMaxConcurrentActivities = 16
+)
+
+type sqliteMetadata struct {
+	authSqlite.SqliteAuthMetadata `mapstructure:",squash"`
+
+	// Prefix for the names of the tables used by the engine.
+	TablePrefix string `mapstructure:"tablePrefix"`
+	// Interval for polling the database for work, when there are no notifications from this process.
+	PollInterval time.Duration `mapstructure:"pollInterval"`
+	// Duration of the locks on workflows and activities being executed, which are renewed while activities are running.
+	// If the process stops, work is resumed after locks expire.
+	LockDuration time.Duration `mapstructure:"lockDuration"`
+	// Maximum number of activities executed concurrently.
+	MaxConcurrentActivities int `mapstructure:"maxConcurrentActivities"`
+}
+
+func (m *sqliteMetadata) InitWithMetadata(meta workflows.Metadata) error {
+	// Reset the object
+	m.reset()
+
+	// Decode the metadata
+	err := metadata.DecodeMetadata(meta.Properties, &m)
+	if err != nil {
+		return err
+	}
+
+	// Validate and sanitize input
+	err = m.SqliteAuthMetadata.Validate()
+	if err != nil {
+		return err
+	}
+	if !authSqlite.ValidIdentifier(m.TablePrefix) {
+		return fmt.Errorf("invalid identifier: %s", m.TablePrefix)
+	}
+	if m.PollInterval < 10*time.Millisecond {
+		return errors.New("invalid value for 'pollInterval': must be at least 10ms")
+	}
+	if m.LockDuration < time.Second {
+		return errors.New("invalid value for 'lockDuration': must be at least 1s")
+	}
+	if m.MaxConcurrentActivities < 1 {
+		return errors.New("invalid value for 'maxConcurrentActivities': must be at least 1")
+	}
+
+	return nil
+}
+
+// Reset the object
+func (m *sqliteMetadata) reset() {
+	m.SqliteAuthMetadata.Reset()
+
+	m.TablePrefix = defaultTablePrefix
+	m.PollInterval = defaultPollInterval
+	m.LockDuration = defaultLockDuration
+	m.MaxConcurrentActivities = defaultMaxConcurrentActivities
+}
+
+func (m *sqliteMetadata) instancesTable() string {
+	return m.TablePrefix + "instances"
+}
+
+func (m *sqliteMetadata) historyTable() string {
+	return m.TablePrefix + "history"
+}
+
+func (m *sqliteMetadata) workItemsTable() string {
+	return m.TablePrefix + "work_items"
+}
+
+func (m *sqliteMetadata) metadataTable() string {
+	return m.TablePrefix + "metadata"
+}
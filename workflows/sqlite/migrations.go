@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	commonsql "github.com/dapr/components-contrib/common/component/sql"
+	sqlitemigrations "github.com/dapr/components-contrib/common/component/sql/migrations/sqlite"
+	"github.com/dapr/kit/logger"
+)
+
+// Perform the required migrations
+func performMigrations(ctx context.Context, db *sql.DB, logger logger.Logger, md *sqliteMetadata) error {
+	m := sqlitemigrations.Migrations{
+		Pool:              db,
+		Logger:            logger,
+		MetadataTableName: md.metadataTable(),
+		MetadataKey:       "migrations",
+	}
+
+	return m.Perform(ctx, []commonsql.MigrationFn{
+		// Migration 0: create the tables
+		// All timestamps are stored as UNIX epochs in milliseconds
+		func(ctx context.Context) error {
+			logger.Infof("Creating workflow tables with prefix '%s'", md.TablePrefix)
+			_, err := m.GetConn().ExecContext(
+				ctx,
+				fmt.Sprintf(
+					`CREATE TABLE %[1]s (
+						instance_id TEXT NOT NULL PRIMARY KEY,
+						workflow_name TEXT NOT NULL,
+						status TEXT NOT NULL,
+						input TEXT,
+						output TEXT,
+						custom_status TEXT,
+						failure TEXT,
+						parent_instance_id TEXT,
+						parent_task_id INTEGER,
+						wake_seq INTEGER NOT NULL DEFAULT 0,
+						processed_seq INTEGER NOT NULL DEFAULT 0,
+						lock_token TEXT,
+						locked_until INTEGER NOT NULL DEFAULT 0,
+						created_at INTEGER NOT NULL,
+						last_updated_at INTEGER NOT NULL
+					);
+					CREATE INDEX %[1]s_status_idx ON %[1]s (status, workflow_name);
+					CREATE INDEX %[1]s_parent_idx ON %[1]s (parent_instance_id);
+					CREATE TABLE %[2]s (
+						instance_id TEXT NOT NULL,
+						seq INTEGER NOT NULL,
+						event_type TEXT NOT NULL,
+						task_id INTEGER,
+						name TEXT,
+						data TEXT,
+						timestamp INTEGER NOT NULL,
+						PRIMARY KEY (instance_id, seq)
+					);
+					CREATE TABLE %[3]s (
+						id INTEGER PRIMARY KEY AUTOINCREMENT,
+						instance_id TEXT NOT NULL,
+						kind TEXT NOT NULL,
+						task_id INTEGER NOT NULL,
+						name TEXT NOT NULL,
+						input TEXT,
+						due_at INTEGER NOT NULL,
+						locked_until INTEGER NOT NULL DEFAULT 0
+					);
+					CREATE INDEX %[3]s_kind_idx ON %[3]s (kind, due_at);
+					CREATE INDEX %[3]s_instance_idx ON %[3]s (instance_id);`,
+					md.instancesTable(), md.historyTable(), md.workItemsTable(),
+				),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create workflow tables: %w", err)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sqltransactions "github.com/dapr/components-contrib/common/component/sql/transactions"
+	"github.com/dapr/components-contrib/workflows"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// ErrInvalidContinuationToken is returned when a continuation token was not returned by the engine.
+var ErrInvalidContinuationToken = errors.New("invalid continuation token")
+
+// Compile-time interface assertions
+var (
+	_ workflows.Querier        = (*Engine)(nil)
+	_ workflows.HistoryReader  = (*Engine)(nil)
+	_ workflows.BulkTerminator = (*Engine)(nil)
+	_ workflows.BulkPurger     = (*Engine)(nil)
+)
+
+// Query lists the workflow instances matching the filter, ordered by creation time.
+func (e *Engine) Query(parentCtx context.Context, req *workflows.QueryRequest) (*workflows.QueryResponse, error) {
+	err := req.Filter.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := filterClause(&req.Filter)
+
+	// The continuation token contains the creation time and ID of the last instance returned
+	if req.ContinuationToken != nil && *req.ContinuationToken != "" {
+		createdAtStr, instanceID, ok := strings.Cut(*req.ContinuationToken, ":")
+		createdAt, err := strconv.ParseInt(createdAtStr, 10, 64)
+		if !ok || err != nil {
+			return nil, ErrInvalidContinuationToken
+		}
+		where = append(where, "(created_at > ? OR (created_at = ? AND instance_id > ?))")
+		args = append(args, createdAt, createdAt, instanceID)
+	}
+
+	pageSize := effectivePageSize(req.PageSize)
+	query := `SELECT ` + instanceColumns + ` FROM ` + e.metadata.instancesTable()
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	// Request one more row than the page size to find out if there are more results
+	query += ` ORDER BY created_at, instance_id LIMIT ?`
+	args = append(args, pageSize+1)
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+	rows, err := e.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workflow instances: %w", err)
+	}
+	defer rows.Close()
+
+	res := &workflows.QueryResponse{
+		Workflows: make([]*workflows.WorkflowState, 0),
+	}
+	for rows.Next() {
+		state, err := scanInstance(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query workflow instances: %w", err)
+		}
+		res.Workflows = append(res.Workflows, state)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workflow instances: %w", err)
+	}
+
+	if len(res.Workflows) > pageSize {
+		res.Workflows = res.Workflows[:pageSize]
+		last := res.Workflows[pageSize-1]
+		token := strconv.FormatInt(toMillis(last.CreatedAt), 10) + ":" + last.InstanceID
+		res.ContinuationToken = &token
+	}
+	return res, nil
+}
+
+// GetHistory returns the events in the history of a workflow instance, in order.
+func (e *Engine) GetHistory(parentCtx context.Context, req *workflows.GetHistoryRequest) (*workflows.GetHistoryResponse, error) {
+	// The continuation token contains the sequence of the last event returned
+	var afterSeq int64
+	if req.ContinuationToken != nil && *req.ContinuationToken != "" {
+		var err error
+		afterSeq, err = strconv.ParseInt(*req.ContinuationToken, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidContinuationToken
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+
+	var exists bool
+	err := e.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM `+e.metadata.instancesTable()+` WHERE instance_id = ?)`,
+		req.InstanceID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow instance: %w", err)
+	}
+	if !exists {
+		return nil, ErrInstanceNotFound
+	}
+
+	pageSize := effectivePageSize(req.PageSize)
+	history, err := e.queryHistory(ctx, req.InstanceID, afterSeq, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &workflows.GetHistoryResponse{
+		Events: make([]workflows.HistoryEvent, 0, len(history)),
+	}
+	if len(history) > pageSize {
+		history = history[:pageSize]
+		token := strconv.FormatInt(history[pageSize-1].Seq, 10)
+		res.ContinuationToken = &token
+	}
+	for _, ev := range history {
+		out := workflows.HistoryEvent{
+			EventID:   ev.Seq,
+			EventType: ev.Type,
+			Timestamp: ev.Timestamp,
+			Name:      ev.Name,
+			Data:      ev.Data,
+		}
+		if ev.TaskID >= 0 {
+			out.TaskID = &ev.TaskID
+		}
+		res.Events = append(res.Events, out)
+	}
+	return res, nil
+}
+
+// BulkTerminate terminates the workflow instances matching the filter that haven't completed.
+// Unless Recursive is false, their child workflows are terminated too, and included in the count.
+func (e *Engine) BulkTerminate(ctx context.Context, req *workflows.BulkTerminateRequest) (*workflows.BulkTerminateResponse, error) {
+	recursive := req.Recursive == nil || *req.Recursive
+
+	ids, err := e.selectInstances(ctx, &req.Filter, false)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &workflows.BulkTerminateResponse{}
+	for _, id := range ids {
+		n, err := e.bulkApply(ctx, id, func(ctx context.Context, tx *sql.Tx) (int, error) {
+			return e.terminate(ctx, tx, id, recursive, time.Now())
+		})
+		if err != nil {
+			return res, err
+		}
+		res.TerminatedCount += n
+	}
+
+	if res.TerminatedCount > 0 {
+		notify(e.wakeWorkflows)
+	}
+	return res, nil
+}
+
+// BulkPurge deletes the workflow instances matching the filter that have completed.
+// Unless Recursive is false, their child workflows are purged too, and included in the count.
+// Instances with child workflows that haven't completed are skipped.
+func (e *Engine) BulkPurge(ctx context.Context, req *workflows.BulkPurgeRequest) (*workflows.BulkPurgeResponse, error) {
+	recursive := req.Recursive == nil || *req.Recursive
+
+	ids, err := e.selectInstances(ctx, &req.Filter, true)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &workflows.BulkPurgeResponse{}
+	for _, id := range ids {
+		n, err := e.bulkApply(ctx, id, func(ctx context.Context, tx *sql.Tx) (int, error) {
+			return e.purge(ctx, tx, id, recursive)
+		})
+		if errors.Is(err, ErrInstanceNotCompleted) {
+			e.logger.Warnf("Skipping purge of workflow instance %s: %v", id, err)
+			continue
+		} else if err != nil {
+			return res, err
+		}
+		res.PurgedCount += n
+	}
+	return res, nil
+}
+
+// bulkApply executes fn for an instance selected by a bulk operation, in its own transaction.
+// Instances that don't exist anymore, for example because they were purged with their parent, are ignored.
+func (e *Engine) bulkApply(parentCtx context.Context, instanceID string, fn func(ctx context.Context, tx *sql.Tx) (int, error)) (int, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+	n, err := sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, fn)
+	if errors.Is(err, ErrInstanceNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to process workflow instance %s: %w", instanceID, err)
+	}
+	return n, nil
+}
+
+// selectInstances returns the IDs of the instances matching the filter of a bulk operation, which are either completed or not, ordered by creation time.
+func (e *Engine) selectInstances(parentCtx context.Context, filter *workflows.InstanceFilter, completed bool) ([]string, error) {
+	if filter.IsEmpty() {
+		return nil, workflows.ErrEmptyFilter
+	}
+	err := filter.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := filterClause(filter)
+	if completed {
+		where = append(where, "status IN (?, ?, ?)")
+		args = append(args, StatusCompleted, StatusFailed, StatusTerminated)
+	} else {
+		where = append(where, "status NOT IN (?, ?, ?)")
+		args = append(args, StatusCompleted, StatusFailed, StatusTerminated)
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+	rows, err := e.db.QueryContext(ctx,
+		`SELECT instance_id FROM `+e.metadata.instancesTable()+`
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY created_at, instance_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select workflow instances: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select workflow instances: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// filterClause returns the conditions of a WHERE clause for the filter, with their arguments.
+func filterClause(filter *workflows.InstanceFilter) (where []string, args []any) {
+	if filter.WorkflowName != "" {
+		where = append(where, "workflow_name = ?")
+		args = append(args, filter.WorkflowName)
+	}
+	if len(filter.RuntimeStatus) > 0 {
+		where = append(where, "status IN ("+placeholders(len(filter.RuntimeStatus))+")")
+		for _, status := range filter.RuntimeStatus {
+			args = append(args, status)
+		}
+	}
+	if filter.CreatedFrom != nil {
+		where = append(where, "created_at >= ?")
+		args = append(args, toMillis(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		where = append(where, "created_at < ?")
+		args = append(args, toMillis(*filter.CreatedTo))
+	}
+	return where, args
+}
+
+func effectivePageSize(requested uint32) int {
+	switch {
+	case requested == 0:
+		return defaultPageSize
+	case requested > maxPageSize:
+		return maxPageSize
+	default:
+		return int(requested)
+	}
+}
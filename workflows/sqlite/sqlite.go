@@ -0,0 +1,677 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sqlite contains a lightweight durable workflow engine that stores the state of workflows in a SQLite database.
+//
+// Workflows and activities are implemented in Go, and registered with the engine with RegisterWorkflow and RegisterActivity.
+// The engine persists the history of each workflow instance, including activities, timers, external events and child workflows,
+// so workflows resume where they left off after the process restarts.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	// Blank import for the underlying SQLite Driver.
+	_ "modernc.org/sqlite"
+
+	"github.com/dapr/components-contrib/common/authentication/sqlite"
+	sqltransactions "github.com/dapr/components-contrib/common/component/sql/transactions"
+	"github.com/dapr/components-contrib/workflows"
+	"github.com/dapr/kit/logger"
+)
+
+// Keys of the properties of workflow instances returned by Get.
+const (
+	PropertyInput        = "dapr.workflow.input"
+	PropertyOutput       = "dapr.workflow.output"
+	PropertyCustomStatus = "dapr.workflow.custom_status"
+	PropertyFailure      = "dapr.workflow.failure.error_message"
+)
+
+var (
+	// ErrInstanceNotFound is returned when a workflow instance doesn't exist.
+	ErrInstanceNotFound = errors.New("workflow instance not found")
+	// ErrInstanceExists is returned when starting a workflow with the ID of an existing instance.
+	ErrInstanceExists = errors.New("a workflow instance with the same ID already exists")
+	// ErrInstanceNotRunning is returned when pausing or resuming a workflow instance that has completed.
+	ErrInstanceNotRunning = errors.New("workflow instance is not running")
+	// ErrInstanceNotCompleted is returned when purging a workflow instance that hasn't completed.
+	ErrInstanceNotCompleted = errors.New("workflow instance has not completed")
+)
+
+// Compile-time interface assertion
+var _ workflows.Workflow = (*Engine)(nil)
+
+// Engine is a durable workflow engine backed by SQLite.
+type Engine struct {
+	logger   logger.Logger
+	metadata sqliteMetadata
+	db       *sql.DB
+
+	lock       sync.RWMutex
+	workflows  map[string]WorkflowFunc
+	activities map[string]ActivityFunc
+
+	wakeWorkflows  chan struct{}
+	wakeActivities chan struct{}
+	wakeTimers     chan struct{}
+	activitySlots  chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	closed atomic.Bool
+	wg     sync.WaitGroup
+}
+
+// NewSQLiteWorkflow returns a new SQLite workflow engine.
+func NewSQLiteWorkflow(logger logger.Logger) workflows.Workflow {
+	return New(logger)
+}
+
+// New returns a new SQLite workflow engine, on which workflows and activities can be registered.
+func New(logger logger.Logger) *Engine {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Engine{
+		logger:         logger,
+		workflows:      make(map[string]WorkflowFunc),
+		activities:     make(map[string]ActivityFunc),
+		wakeWorkflows:  make(chan struct{}, 1),
+		wakeActivities: make(chan struct{}, 1),
+		wakeTimers:     make(chan struct{}, 1),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+}
+
+// Init connects to the database, and starts processing workflows.
+func (e *Engine) Init(md workflows.Metadata) error {
+	if e.closed.Load() {
+		return errors.New("component is closed")
+	}
+
+	err := e.metadata.InitWithMetadata(md)
+	if err != nil {
+		return err
+	}
+
+	connString, err := e.metadata.GetConnectionString(e.logger, sqlite.GetConnectionStringOpts{})
+	if err != nil {
+		// Already logged
+		return err
+	}
+
+	e.db, err = sql.Open("sqlite", connString)
+	if err != nil {
+		return fmt.Errorf("failed to create connection: %w", err)
+	}
+
+	// If the database is in-memory, we can't have more than 1 open connection
+	if e.metadata.IsInMemoryDB() {
+		e.db.SetMaxOpenConns(1)
+	}
+
+	ctx, cancel := context.WithTimeout(e.ctx, e.metadata.Timeout)
+	defer cancel()
+	err = performMigrations(ctx, e.db, e.logger, &e.metadata)
+	if err != nil {
+		return fmt.Errorf("failed to perform migrations: %w", err)
+	}
+
+	e.activitySlots = make(chan struct{}, e.metadata.MaxConcurrentActivities)
+	e.startWorkers()
+
+	return nil
+}
+
+// RegisterWorkflow registers the implementation of a workflow.
+// Instances of workflows that aren't registered remain in the Running status until a workflow with their name is registered.
+func (e *Engine) RegisterWorkflow(name string, fn WorkflowFunc) error {
+	if name == "" || fn == nil {
+		return errors.New("workflow name and function are required")
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if _, ok := e.workflows[name]; ok {
+		return fmt.Errorf("workflow '%s' is already registered", name)
+	}
+	e.workflows[name] = fn
+
+	notify(e.wakeWorkflows)
+	return nil
+}
+
+// RegisterActivity registers the implementation of an activity.
+// Activities that aren't registered are not executed until an activity with their name is registered.
+func (e *Engine) RegisterActivity(name string, fn ActivityFunc) error {
+	if name == "" || fn == nil {
+		return errors.New("activity name and function are required")
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if _, ok := e.activities[name]; ok {
+		return fmt.Errorf("activity '%s' is already registered", name)
+	}
+	e.activities[name] = fn
+
+	notify(e.wakeActivities)
+	return nil
+}
+
+// Start starts a new workflow instance.
+func (e *Engine) Start(parentCtx context.Context, req *workflows.StartRequest) (*workflows.StartResponse, error) {
+	if req.WorkflowName == "" {
+		return nil, errors.New("workflow name is required")
+	}
+
+	instanceID := uuid.NewString()
+	if req.InstanceID != nil && *req.InstanceID != "" {
+		instanceID = *req.InstanceID
+	}
+	var input string
+	if req.WorkflowInput != nil {
+		input = req.WorkflowInput.GetValue()
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+	_, err := sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, func(ctx context.Context, tx *sql.Tx) (struct{}, error) {
+		return struct{}{}, e.createInstance(ctx, tx, instanceID, req.WorkflowName, input, nil, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notify(e.wakeWorkflows)
+	return &workflows.StartResponse{
+		InstanceID: instanceID,
+	}, nil
+}
+
+// Get returns the state of a workflow instance.
+func (e *Engine) Get(parentCtx context.Context, req *workflows.GetRequest) (*workflows.StateResponse, error) {
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+
+	res, err := scanInstance(e.db.QueryRowContext(ctx,
+		`SELECT `+instanceColumns+` FROM `+e.metadata.instancesTable()+` WHERE instance_id = ?`,
+		req.InstanceID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInstanceNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get workflow instance: %w", err)
+	}
+
+	return &workflows.StateResponse{
+		Workflow: res,
+	}, nil
+}
+
+// Columns of the instances table returned by Get and Query.
+const instanceColumns = `instance_id, workflow_name, status, input, output, custom_status, failure, created_at, last_updated_at`
+
+// scanInstance scans a row with the instanceColumns into a WorkflowState.
+func scanInstance(row interface{ Scan(dest ...any) error }) (*workflows.WorkflowState, error) {
+	var (
+		res                                  workflows.WorkflowState
+		input, output, customStatus, failure sql.NullString
+		createdAt, lastUpdatedAt             int64
+	)
+	err := row.Scan(&res.InstanceID, &res.WorkflowName, &res.RuntimeStatus, &input, &output, &customStatus, &failure, &createdAt, &lastUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	res.CreatedAt = fromMillis(createdAt)
+	res.LastUpdatedAt = fromMillis(lastUpdatedAt)
+	res.Properties = make(map[string]string, 4)
+	for k, v := range map[string]sql.NullString{
+		PropertyInput:        input,
+		PropertyOutput:       output,
+		PropertyCustomStatus: customStatus,
+		PropertyFailure:      failure,
+	} {
+		if v.Valid && v.String != "" {
+			res.Properties[k] = v.String
+		}
+	}
+	return &res, nil
+}
+
+// Terminate terminates a workflow instance.
+// Unless Recursive is false, the child workflows the instance started are terminated too.
+func (e *Engine) Terminate(parentCtx context.Context, req *workflows.TerminateRequest) error {
+	recursive := req.Recursive == nil || *req.Recursive
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+	_, err := sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, func(ctx context.Context, tx *sql.Tx) (struct{}, error) {
+		_, err := e.terminate(ctx, tx, req.InstanceID, recursive, time.Now())
+		return struct{}{}, err
+	})
+	if err != nil {
+		return err
+	}
+
+	notify(e.wakeWorkflows)
+	return nil
+}
+
+// RaiseEvent raises an external event for a workflow instance.
+// Events raised for instances that have completed are discarded.
+func (e *Engine) RaiseEvent(parentCtx context.Context, req *workflows.RaiseEventRequest) error {
+	if req.EventName == "" {
+		return errors.New("event name is required")
+	}
+	var data string
+	if req.EventData != nil {
+		data = req.EventData.GetValue()
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+	_, err := sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, func(ctx context.Context, tx *sql.Tx) (struct{}, error) {
+		inst, err := e.getInstanceInfo(ctx, tx, req.InstanceID)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if isTerminalStatus(inst.status) {
+			e.logger.Debugf("Discarding event '%s' for workflow instance %s, which is %s", req.EventName, req.InstanceID, inst.status)
+			return struct{}{}, nil
+		}
+
+		now := time.Now()
+		err = e.appendEvent(ctx, tx, req.InstanceID, historyEvent{Type: eventEventRaised, TaskID: -1, Name: req.EventName, Data: data, Timestamp: now})
+		if err != nil {
+			return struct{}{}, err
+		}
+		return struct{}{}, e.wakeInstance(ctx, tx, req.InstanceID, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	notify(e.wakeWorkflows)
+	return nil
+}
+
+// Purge deletes a workflow instance that has completed, with its history.
+// Unless Recursive is false, the child workflows the instance started are purged too.
+func (e *Engine) Purge(parentCtx context.Context, req *workflows.PurgeRequest) error {
+	recursive := req.Recursive == nil || *req.Recursive
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+	_, err := sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, func(ctx context.Context, tx *sql.Tx) (struct{}, error) {
+		_, err := e.purge(ctx, tx, req.InstanceID, recursive)
+		return struct{}{}, err
+	})
+	return err
+}
+
+// Pause suspends a running workflow instance.
+// Activities that are already running complete, but the workflow doesn't make progress until it's resumed.
+func (e *Engine) Pause(parentCtx context.Context, req *workflows.PauseRequest) error {
+	return e.setSuspended(parentCtx, req.InstanceID, true)
+}
+
+// Resume resumes a suspended workflow instance.
+func (e *Engine) Resume(parentCtx context.Context, req *workflows.ResumeRequest) error {
+	return e.setSuspended(parentCtx, req.InstanceID, false)
+}
+
+func (e *Engine) setSuspended(parentCtx context.Context, instanceID string, suspend bool) error {
+	from, to, evType := StatusRunning, StatusSuspended, eventExecutionSuspended
+	if !suspend {
+		from, to, evType = StatusSuspended, StatusRunning, eventExecutionResumed
+	}
+
+	ctx, cancel := context.WithTimeout(parentCtx, e.metadata.Timeout)
+	defer cancel()
+	_, err := sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, func(ctx context.Context, tx *sql.Tx) (struct{}, error) {
+		inst, err := e.getInstanceInfo(ctx, tx, instanceID)
+		if err != nil {
+			return struct{}{}, err
+		}
+		switch inst.status {
+		case to:
+			return struct{}{}, nil
+		case from:
+			// Continue
+		default:
+			return struct{}{}, fmt.Errorf("%w: instance %s is %s", ErrInstanceNotRunning, instanceID, inst.status)
+		}
+
+		now := time.Now()
+		_, err = tx.ExecContext(ctx,
+			`UPDATE `+e.metadata.instancesTable()+` SET status = ?, last_updated_at = ? WHERE instance_id = ?`,
+			to, toMillis(now), instanceID,
+		)
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to update workflow instance: %w", err)
+		}
+		err = e.appendEvent(ctx, tx, instanceID, historyEvent{Type: evType, TaskID: -1, Timestamp: now})
+		if err != nil {
+			return struct{}{}, err
+		}
+		if !suspend {
+			return struct{}{}, e.wakeInstance(ctx, tx, instanceID, now)
+		}
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	notify(e.wakeWorkflows)
+	return nil
+}
+
+// Close stops processing workflows and closes the connection to the database.
+func (e *Engine) Close() error {
+	if !e.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+
+	e.cancel()
+	e.wg.Wait()
+
+	if e.db == nil {
+		return nil
+	}
+	return e.db.Close()
+}
+
+// instanceInfo contains the details of a workflow instance needed to change its status.
+type instanceInfo struct {
+	status       string
+	parentID     sql.NullString
+	parentTaskID sql.NullInt64
+}
+
+func (e *Engine) getInstanceInfo(ctx context.Context, tx *sql.Tx, instanceID string) (instanceInfo, error) {
+	var inst instanceInfo
+	err := tx.QueryRowContext(ctx,
+		`SELECT status, parent_instance_id, parent_task_id FROM `+e.metadata.instancesTable()+` WHERE instance_id = ?`,
+		instanceID,
+	).Scan(&inst.status, &inst.parentID, &inst.parentTaskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return inst, ErrInstanceNotFound
+	} else if err != nil {
+		return inst, fmt.Errorf("failed to get workflow instance: %w", err)
+	}
+	return inst, nil
+}
+
+// parentTask identifies the task of a workflow that started a child workflow.
+type parentTask struct {
+	instanceID string
+	taskID     int64
+}
+
+func (e *Engine) createInstance(ctx context.Context, tx *sql.Tx, instanceID string, name string, input string, parent *parentTask, now time.Time) error {
+	var exists bool
+	err := tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM `+e.metadata.instancesTable()+` WHERE instance_id = ?)`,
+		instanceID,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check if workflow instance exists: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrInstanceExists, instanceID)
+	}
+
+	var (
+		parentID     sql.NullString
+		parentTaskID sql.NullInt64
+	)
+	if parent != nil {
+		parentID = sql.NullString{String: parent.instanceID, Valid: true}
+		parentTaskID = sql.NullInt64{Int64: parent.taskID, Valid: true}
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO `+e.metadata.instancesTable()+`
+			(instance_id, workflow_name, status, input, parent_instance_id, parent_task_id, wake_seq, created_at, last_updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, 1, ?, ?)`,
+		instanceID, name, StatusRunning, input, parentID, parentTaskID, toMillis(now), toMillis(now),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create workflow instance: %w", err)
+	}
+
+	return e.appendEvent(ctx, tx, instanceID, historyEvent{Type: eventExecutionStarted, TaskID: -1, Name: name, Data: input, Timestamp: now})
+}
+
+// terminate terminates a workflow instance, and returns the number of instances that were terminated, including child workflows.
+func (e *Engine) terminate(ctx context.Context, tx *sql.Tx, instanceID string, recursive bool, now time.Time) (int, error) {
+	inst, err := e.getInstanceInfo(ctx, tx, instanceID)
+	if err != nil {
+		return 0, err
+	}
+	if isTerminalStatus(inst.status) {
+		return 0, nil
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE `+e.metadata.instancesTable()+` SET status = ?, lock_token = NULL, locked_until = 0, last_updated_at = ? WHERE instance_id = ?`,
+		StatusTerminated, toMillis(now), instanceID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update workflow instance: %w", err)
+	}
+	err = e.appendEvent(ctx, tx, instanceID, historyEvent{Type: eventExecutionTerminated, TaskID: -1, Timestamp: now})
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM `+e.metadata.workItemsTable()+` WHERE instance_id = ?`, instanceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete work items: %w", err)
+	}
+
+	if inst.parentID.Valid {
+		err = e.notifyParent(ctx, tx, inst, eventChildWorkflowFailed, "child workflow was terminated", now)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	count := 1
+	if !recursive {
+		return count, nil
+	}
+	children, err := e.getChildren(ctx, tx, instanceID)
+	if err != nil {
+		return 0, err
+	}
+	for _, child := range children {
+		n, err := e.terminate(ctx, tx, child, true, now)
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// purge deletes a workflow instance, and returns the number of instances that were deleted, including child workflows.
+func (e *Engine) purge(ctx context.Context, tx *sql.Tx, instanceID string, recursive bool) (int, error) {
+	inst, err := e.getInstanceInfo(ctx, tx, instanceID)
+	if err != nil {
+		return 0, err
+	}
+	if !isTerminalStatus(inst.status) {
+		return 0, fmt.Errorf("%w: instance %s is %s", ErrInstanceNotCompleted, instanceID, inst.status)
+	}
+
+	count := 1
+	if recursive {
+		children, err := e.getChildren(ctx, tx, instanceID)
+		if err != nil {
+			return 0, err
+		}
+		for _, child := range children {
+			n, err := e.purge(ctx, tx, child, true)
+			if err != nil {
+				return 0, err
+			}
+			count += n
+		}
+	}
+
+	for _, table := range []string{e.metadata.historyTable(), e.metadata.workItemsTable(), e.metadata.instancesTable()} {
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE instance_id = ?`, instanceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge workflow instance: %w", err)
+		}
+	}
+	return count, nil
+}
+
+func (e *Engine) getChildren(ctx context.Context, tx *sql.Tx, instanceID string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT instance_id FROM `+e.metadata.instancesTable()+` WHERE parent_instance_id = ?`,
+		instanceID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list child workflows: %w", err)
+	}
+	defer rows.Close()
+
+	var children []string
+	for rows.Next() {
+		var child string
+		err = rows.Scan(&child)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list child workflows: %w", err)
+		}
+		children = append(children, child)
+	}
+	return children, rows.Err()
+}
+
+// notifyParent adds the result of a child workflow to the history of its parent, if the parent is still running.
+func (e *Engine) notifyParent(ctx context.Context, tx *sql.Tx, child instanceInfo, evType string, data string, now time.Time) error {
+	parent, err := e.getInstanceInfo(ctx, tx, child.parentID.String)
+	if errors.Is(err, ErrInstanceNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if isTerminalStatus(parent.status) {
+		return nil
+	}
+
+	err = e.appendEvent(ctx, tx, child.parentID.String, historyEvent{Type: evType, TaskID: child.parentTaskID.Int64, Data: data, Timestamp: now})
+	if err != nil {
+		return err
+	}
+	return e.wakeInstance(ctx, tx, child.parentID.String, now)
+}
+
+// appendEvent adds an event at the end of the history of a workflow instance.
+func (e *Engine) appendEvent(ctx context.Context, tx *sql.Tx, instanceID string, ev historyEvent) error {
+	var taskID sql.NullInt64
+	if ev.TaskID >= 0 {
+		taskID = sql.NullInt64{Int64: ev.TaskID, Valid: true}
+	}
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO `+e.metadata.historyTable()+` (instance_id, seq, event_type, task_id, name, data, timestamp)
+		SELECT ?, COALESCE(MAX(seq), 0) + 1, ?, ?, ?, ?, ? FROM `+e.metadata.historyTable()+` WHERE instance_id = ?`,
+		instanceID, ev.Type, taskID, ev.Name, ev.Data, toMillis(ev.Timestamp), instanceID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add event to the workflow history: %w", err)
+	}
+	return nil
+}
+
+// wakeInstance signals that a workflow instance has new events to process.
+func (e *Engine) wakeInstance(ctx context.Context, tx *sql.Tx, instanceID string, now time.Time) error {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE `+e.metadata.instancesTable()+` SET wake_seq = wake_seq + 1, last_updated_at = ? WHERE instance_id = ?`,
+		toMillis(now), instanceID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update workflow instance: %w", err)
+	}
+	return nil
+}
+
+func (e *Engine) loadHistory(ctx context.Context, instanceID string) ([]historyEvent, error) {
+	// A negative limit means no limit in SQLite
+	return e.queryHistory(ctx, instanceID, 0, -1)
+}
+
+// queryHistory returns up to limit events from the history of a workflow instance, starting after the event with sequence afterSeq.
+func (e *Engine) queryHistory(ctx context.Context, instanceID string, afterSeq int64, limit int) ([]historyEvent, error) {
+	rows, err := e.db.QueryContext(ctx,
+		`SELECT seq, event_type, task_id, name, data, timestamp FROM `+e.metadata.historyTable()+`
+		WHERE instance_id = ? AND seq > ?
+		ORDER BY seq
+		LIMIT ?`,
+		instanceID, afterSeq, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]historyEvent, 0)
+	for rows.Next() {
+		var (
+			ev         historyEvent
+			taskID     sql.NullInt64
+			name, data sql.NullString
+			ts         int64
+		)
+		err = rows.Scan(&ev.Seq, &ev.Type, &taskID, &name, &data, &ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflow history: %w", err)
+		}
+		ev.TaskID = -1
+		if taskID.Valid {
+			ev.TaskID = taskID.Int64
+		}
+		ev.Name = name.String
+		ev.Data = data.String
+		ev.Timestamp = fromMillis(ts)
+		history = append(history, ev)
+	}
+	return history, rows.Err()
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.UnixMilli(ms)
+}
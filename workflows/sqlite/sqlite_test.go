@@ -0,0 +1,652 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/workflows"
+	"github.com/dapr/kit/logger"
+	"github.com/dapr/kit/ptr"
+)
+
+func newTestEngine(t *testing.T) *Engine {
+	t.Helper()
+
+	e := New(logger.NewLogger("test"))
+	t.Cleanup(func() {
+		require.NoError(t, e.Close())
+	})
+	return e
+}
+
+func initTestEngine(t *testing.T, e *Engine, connString string) {
+	t.Helper()
+
+	err := e.Init(workflows.Metadata{Base: metadata.Base{Properties: map[string]string{
+		"connectionString": connString,
+		"pollInterval":     "20ms",
+	}}})
+	require.NoError(t, err)
+}
+
+func startWorkflow(t *testing.T, e *Engine, name string, input string) string {
+	t.Helper()
+
+	res, err := e.Start(context.Background(), &workflows.StartRequest{
+		WorkflowName:  name,
+		WorkflowInput: wrapperspb.String(input),
+	})
+	require.NoError(t, err)
+	return res.InstanceID
+}
+
+func waitForStatus(t *testing.T, e *Engine, instanceID string, status string) *workflows.WorkflowState {
+	t.Helper()
+
+	var state *workflows.WorkflowState
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		res, err := e.Get(context.Background(), &workflows.GetRequest{InstanceID: instanceID})
+		if !assert.NoError(c, err) {
+			return
+		}
+		state = res.Workflow
+		assert.Equal(c, status, state.RuntimeStatus)
+	}, 10*time.Second, 10*time.Millisecond)
+	return state
+}
+
+func TestMetadata(t *testing.T) {
+	md := sqliteMetadata{}
+
+	t.Run("defaults", func(t *testing.T) {
+		err := md.InitWithMetadata(workflows.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"connectionString": ":memory:",
+		}}})
+		require.NoError(t, err)
+		assert.Equal(t, "workflow_instances", md.instancesTable())
+		assert.Equal(t, time.Second, md.PollInterval)
+		assert.Equal(t, time.Minute, md.LockDuration)
+		assert.Equal(t, 16, md.MaxConcurrentActivities)
+	})
+
+	t.Run("invalid table prefix", func(t *testing.T) {
+		err := md.InitWithMetadata(workflows.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"connectionString": ":memory:",
+			"tablePrefix":      "wf;",
+		}}})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid poll interval", func(t *testing.T) {
+		err := md.InitWithMetadata(workflows.Metadata{Base: metadata.Base{Properties: map[string]string{
+			"connectionString": ":memory:",
+			"pollInterval":     "1ms",
+		}}})
+		require.Error(t, err)
+	})
+}
+
+func TestActivities(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	var calls atomic.Int32
+	require.NoError(t, e.RegisterActivity("Double", func(ctx *ActivityContext) (any, error) {
+		calls.Add(1)
+		var n int
+		err := ctx.GetInput(&n)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, errors.New("negative input")
+		}
+		return n * 2, nil
+	}))
+	require.NoError(t, e.RegisterWorkflow("Sum", func(ctx *WorkflowContext) (any, error) {
+		var n int
+		err := ctx.GetInput(&n)
+		if err != nil {
+			return nil, err
+		}
+
+		// Fan out, then fan in
+		tasks := make([]Task, n)
+		for i := range tasks {
+			tasks[i] = ctx.CallActivity("Double", i)
+		}
+		sum := 0
+		for _, task := range tasks {
+			var res int
+			err = task.Await(&res)
+			if err != nil {
+				return nil, err
+			}
+			sum += res
+		}
+		ctx.SetCustomStatus("summed")
+
+		var tfe *TaskFailedError
+		err = ctx.CallActivity("Double", -1).Await(nil)
+		if !errors.As(err, &tfe) {
+			return nil, errors.New("expected the activity to fail")
+		}
+
+		return sum, nil
+	}))
+
+	id := startWorkflow(t, e, "Sum", "5")
+	state := waitForStatus(t, e, id, StatusCompleted)
+	assert.Equal(t, "Sum", state.WorkflowName)
+	assert.Equal(t, "5", state.Properties[PropertyInput])
+	assert.Equal(t, "20", state.Properties[PropertyOutput])
+	assert.Equal(t, "summed", state.Properties[PropertyCustomStatus])
+
+	// Activities are not executed again when the workflow is replayed
+	assert.Equal(t, int32(6), calls.Load())
+}
+
+func TestWorkflowFailure(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterWorkflow("Fail", func(ctx *WorkflowContext) (any, error) {
+		return nil, errors.New("something went wrong")
+	}))
+	require.NoError(t, e.RegisterWorkflow("Panic", func(ctx *WorkflowContext) (any, error) {
+		panic("oh no")
+	}))
+
+	id := startWorkflow(t, e, "Fail", "")
+	state := waitForStatus(t, e, id, StatusFailed)
+	assert.Equal(t, "something went wrong", state.Properties[PropertyFailure])
+
+	id = startWorkflow(t, e, "Panic", "")
+	state = waitForStatus(t, e, id, StatusFailed)
+	assert.Equal(t, "workflow panicked: oh no", state.Properties[PropertyFailure])
+}
+
+func TestTimersAndEvents(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterWorkflow("Approval", func(ctx *WorkflowContext) (any, error) {
+		start := ctx.CurrentTime()
+		err := ctx.CreateTimer(100 * time.Millisecond).Await(nil)
+		if err != nil {
+			return nil, err
+		}
+		if ctx.CurrentTime().Sub(start) < 100*time.Millisecond {
+			return nil, errors.New("timer fired too early")
+		}
+
+		var approvals []string
+		for {
+			var approver string
+			err = ctx.WaitForExternalEvent("approve", 300*time.Millisecond).Await(&approver)
+			if errors.Is(err, ErrEventTimeout) {
+				return approvals, nil
+			} else if err != nil {
+				return nil, err
+			}
+			approvals = append(approvals, approver)
+		}
+	}))
+
+	id := startWorkflow(t, e, "Approval", "")
+
+	// Events raised before the workflow waits for them are buffered
+	for _, approver := range []string{`"alice"`, `"bob"`} {
+		require.NoError(t, e.RaiseEvent(context.Background(), &workflows.RaiseEventRequest{
+			InstanceID: id,
+			EventName:  "approve",
+			EventData:  wrapperspb.String(approver),
+		}))
+	}
+
+	state := waitForStatus(t, e, id, StatusCompleted)
+	assert.Equal(t, `["alice","bob"]`, state.Properties[PropertyOutput])
+
+	// Events for instances that have completed are discarded
+	require.NoError(t, e.RaiseEvent(context.Background(), &workflows.RaiseEventRequest{
+		InstanceID: id,
+		EventName:  "approve",
+	}))
+
+	// Events for instances that don't exist are rejected
+	err := e.RaiseEvent(context.Background(), &workflows.RaiseEventRequest{
+		InstanceID: "notfound",
+		EventName:  "approve",
+	})
+	require.ErrorIs(t, err, ErrInstanceNotFound)
+}
+
+func TestWhenAny(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterWorkflow("Race", func(ctx *WorkflowContext) (any, error) {
+		event := ctx.WaitForExternalEvent("go", 0)
+		timer := ctx.CreateTimer(time.Hour)
+		if ctx.WhenAny(event, timer) == 1 {
+			return "timer", nil
+		}
+		var v string
+		err := event.Await(&v)
+		return v, err
+	}))
+
+	id := startWorkflow(t, e, "Race", "")
+	waitForStatus(t, e, id, StatusRunning)
+	require.NoError(t, e.RaiseEvent(context.Background(), &workflows.RaiseEventRequest{
+		InstanceID: id,
+		EventName:  "go",
+		EventData:  wrapperspb.String(`"event"`),
+	}))
+	state := waitForStatus(t, e, id, StatusCompleted)
+	assert.Equal(t, `"event"`, state.Properties[PropertyOutput])
+}
+
+func TestChildWorkflows(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterWorkflow("Child", func(ctx *WorkflowContext) (any, error) {
+		var n int
+		err := ctx.GetInput(&n)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, errors.New("zero")
+		}
+		return n * n, nil
+	}))
+	require.NoError(t, e.RegisterWorkflow("Parent", func(ctx *WorkflowContext) (any, error) {
+		var square int
+		err := ctx.CallChildWorkflow("Child", 3).Await(&square)
+		if err != nil {
+			return nil, err
+		}
+		err = ctx.CallChildWorkflow("Child", 0).Await(nil)
+		var tfe *TaskFailedError
+		if !errors.As(err, &tfe) || tfe.Message != "zero" {
+			return nil, errors.New("expected the child workflow to fail")
+		}
+		return square, nil
+	}))
+
+	id := startWorkflow(t, e, "Parent", "")
+	state := waitForStatus(t, e, id, StatusCompleted)
+	assert.Equal(t, "9", state.Properties[PropertyOutput])
+
+	child := waitForStatus(t, e, id+":0000", StatusCompleted)
+	assert.Equal(t, "Child", child.WorkflowName)
+	waitForStatus(t, e, id+":0001", StatusFailed)
+
+	// Purging the parent purges the children too
+	require.NoError(t, e.Purge(context.Background(), &workflows.PurgeRequest{InstanceID: id}))
+	for _, instanceID := range []string{id, id + ":0000", id + ":0001"} {
+		_, err := e.Get(context.Background(), &workflows.GetRequest{InstanceID: instanceID})
+		require.ErrorIs(t, err, ErrInstanceNotFound)
+	}
+}
+
+func TestTerminate(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterWorkflow("Wait", func(ctx *WorkflowContext) (any, error) {
+		return nil, ctx.WaitForExternalEvent("never", 0).Await(nil)
+	}))
+	require.NoError(t, e.RegisterWorkflow("Parent", func(ctx *WorkflowContext) (any, error) {
+		return nil, ctx.CallChildWorkflow("Wait", nil).Await(nil)
+	}))
+
+	id := startWorkflow(t, e, "Parent", "")
+	waitForStatus(t, e, id+":0000", StatusRunning)
+
+	// Instances that are running can't be purged
+	err := e.Purge(context.Background(), &workflows.PurgeRequest{InstanceID: id})
+	require.ErrorIs(t, err, ErrInstanceNotCompleted)
+
+	require.NoError(t, e.Terminate(context.Background(), &workflows.TerminateRequest{InstanceID: id}))
+	waitForStatus(t, e, id, StatusTerminated)
+	waitForStatus(t, e, id+":0000", StatusTerminated)
+
+	// Terminating again is a no-op
+	require.NoError(t, e.Terminate(context.Background(), &workflows.TerminateRequest{InstanceID: id}))
+
+	// Terminated instances can't be paused
+	err = e.Pause(context.Background(), &workflows.PauseRequest{InstanceID: id})
+	require.ErrorIs(t, err, ErrInstanceNotRunning)
+
+	// Non-recursive purge leaves the child
+	require.NoError(t, e.Purge(context.Background(), &workflows.PurgeRequest{InstanceID: id, Recursive: ptr.Of(false)}))
+	waitForStatus(t, e, id+":0000", StatusTerminated)
+}
+
+func TestPauseResume(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterActivity("Noop", func(ctx *ActivityContext) (any, error) {
+		return nil, nil
+	}))
+	require.NoError(t, e.RegisterWorkflow("Steps", func(ctx *WorkflowContext) (any, error) {
+		err := ctx.WaitForExternalEvent("next", 0).Await(nil)
+		if err != nil {
+			return nil, err
+		}
+		return "done", ctx.CallActivity("Noop", nil).Await(nil)
+	}))
+
+	id := startWorkflow(t, e, "Steps", "")
+	waitForStatus(t, e, id, StatusRunning)
+
+	require.NoError(t, e.Pause(context.Background(), &workflows.PauseRequest{InstanceID: id}))
+	waitForStatus(t, e, id, StatusSuspended)
+
+	// Events are buffered while the instance is suspended
+	require.NoError(t, e.RaiseEvent(context.Background(), &workflows.RaiseEventRequest{InstanceID: id, EventName: "next"}))
+	time.Sleep(200 * time.Millisecond)
+	waitForStatus(t, e, id, StatusSuspended)
+
+	require.NoError(t, e.Resume(context.Background(), &workflows.ResumeRequest{InstanceID: id}))
+	state := waitForStatus(t, e, id, StatusCompleted)
+	assert.Equal(t, `"done"`, state.Properties[PropertyOutput])
+}
+
+func TestDurability(t *testing.T) {
+	connString := "file:" + filepath.Join(t.TempDir(), "workflows.db")
+
+	register := func(e *Engine) {
+		require.NoError(t, e.RegisterWorkflow("Greet", func(ctx *WorkflowContext) (any, error) {
+			var name string
+			err := ctx.WaitForExternalEvent("name", 0).Await(&name)
+			if err != nil {
+				return nil, err
+			}
+			return "Hello, " + name, nil
+		}))
+	}
+
+	// Start the workflow with an engine, then close it
+	e := New(logger.NewLogger("test"))
+	initTestEngine(t, e, connString)
+	register(e)
+	id := startWorkflow(t, e, "Greet", "")
+	waitForStatus(t, e, id, StatusRunning)
+	require.NoError(t, e.Close())
+
+	// The workflow resumes on a new engine
+	e = newTestEngine(t)
+	initTestEngine(t, e, connString)
+	register(e)
+	require.NoError(t, e.RaiseEvent(context.Background(), &workflows.RaiseEventRequest{
+		InstanceID: id,
+		EventName:  "name",
+		EventData:  wrapperspb.String(`"Dapr"`),
+	}))
+	state := waitForStatus(t, e, id, StatusCompleted)
+	assert.Equal(t, `"Hello, Dapr"`, state.Properties[PropertyOutput])
+}
+
+func TestNonDeterministic(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	var runs atomic.Int32
+	require.NoError(t, e.RegisterActivity("A", func(ctx *ActivityContext) (any, error) { return nil, nil }))
+	require.NoError(t, e.RegisterActivity("B", func(ctx *ActivityContext) (any, error) { return nil, nil }))
+	require.NoError(t, e.RegisterWorkflow("Flaky", func(ctx *WorkflowContext) (any, error) {
+		name := "A"
+		if runs.Add(1) > 1 {
+			name = "B"
+		}
+		return nil, ctx.CallActivity(name, nil).Await(nil)
+	}))
+
+	id := startWorkflow(t, e, "Flaky", "")
+	state := waitForStatus(t, e, id, StatusFailed)
+	assert.Contains(t, state.Properties[PropertyFailure], ErrNonDeterministic.Error())
+}
+
+func TestStartExistingInstance(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	req := &workflows.StartRequest{
+		InstanceID:   ptr.Of("myinstance"),
+		WorkflowName: "Unregistered",
+	}
+	res, err := e.Start(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "myinstance", res.InstanceID)
+
+	_, err = e.Start(context.Background(), req)
+	require.ErrorIs(t, err, ErrInstanceExists)
+
+	// Workflows that aren't registered stay pending
+	time.Sleep(100 * time.Millisecond)
+	waitForStatus(t, e, "myinstance", StatusRunning)
+}
+
+func TestQuery(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterWorkflow("Done", func(ctx *WorkflowContext) (any, error) {
+		return nil, nil
+	}))
+	require.NoError(t, e.RegisterWorkflow("Wait", func(ctx *WorkflowContext) (any, error) {
+		return nil, ctx.WaitForExternalEvent("never", 0).Await(nil)
+	}))
+
+	before := time.Now()
+	var waiting []string
+	for i := range 5 {
+		id := startWorkflow(t, e, "Wait", "")
+		waiting = append(waiting, id)
+		if i < 3 {
+			waitForStatus(t, e, startWorkflow(t, e, "Done", ""), StatusCompleted)
+		}
+	}
+
+	t.Run("filter and paginate", func(t *testing.T) {
+		req := &workflows.QueryRequest{
+			Filter: workflows.InstanceFilter{
+				WorkflowName:  "Wait",
+				RuntimeStatus: []string{StatusRunning},
+				CreatedFrom:   &before,
+			},
+			PageSize: 2,
+		}
+
+		var found []string
+		for range 5 {
+			res, err := e.Query(context.Background(), req)
+			require.NoError(t, err)
+			for _, wf := range res.Workflows {
+				assert.Equal(t, "Wait", wf.WorkflowName)
+				found = append(found, wf.InstanceID)
+			}
+			if res.ContinuationToken == nil {
+				break
+			}
+			req.ContinuationToken = res.ContinuationToken
+		}
+		assert.ElementsMatch(t, waiting, found)
+	})
+
+	t.Run("by status", func(t *testing.T) {
+		res, err := e.Query(context.Background(), &workflows.QueryRequest{
+			Filter: workflows.InstanceFilter{RuntimeStatus: []string{StatusCompleted}},
+		})
+		require.NoError(t, err)
+		assert.Len(t, res.Workflows, 3)
+		assert.Nil(t, res.ContinuationToken)
+	})
+
+	t.Run("created time range", func(t *testing.T) {
+		res, err := e.Query(context.Background(), &workflows.QueryRequest{
+			Filter: workflows.InstanceFilter{CreatedTo: &before},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res.Workflows)
+
+		after := before.Add(-time.Second)
+		_, err = e.Query(context.Background(), &workflows.QueryRequest{
+			Filter: workflows.InstanceFilter{CreatedFrom: &before, CreatedTo: &after},
+		})
+		require.ErrorIs(t, err, workflows.ErrInvalidFilter)
+	})
+
+	t.Run("invalid continuation token", func(t *testing.T) {
+		_, err := e.Query(context.Background(), &workflows.QueryRequest{ContinuationToken: ptr.Of("foo")})
+		require.ErrorIs(t, err, ErrInvalidContinuationToken)
+	})
+}
+
+func TestGetHistory(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterActivity("Noop", func(ctx *ActivityContext) (any, error) {
+		return "ok", nil
+	}))
+	require.NoError(t, e.RegisterWorkflow("Steps", func(ctx *WorkflowContext) (any, error) {
+		err := ctx.CallActivity("Noop", nil).Await(nil)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ctx.WaitForExternalEvent("next", 0).Await(nil)
+	}))
+
+	id := startWorkflow(t, e, "Steps", `"in"`)
+	require.NoError(t, e.RaiseEvent(context.Background(), &workflows.RaiseEventRequest{InstanceID: id, EventName: "next"}))
+	waitForStatus(t, e, id, StatusCompleted)
+
+	var events []workflows.HistoryEvent
+	req := &workflows.GetHistoryRequest{InstanceID: id, PageSize: 2}
+	for range 5 {
+		res, err := e.GetHistory(context.Background(), req)
+		require.NoError(t, err)
+		events = append(events, res.Events...)
+		if res.ContinuationToken == nil {
+			break
+		}
+		req.ContinuationToken = res.ContinuationToken
+	}
+
+	// The event can be raised before or after the activity completes
+	types := make([]string, len(events))
+	for i, ev := range events {
+		types[i] = ev.EventType
+		assert.Equal(t, int64(i+1), ev.EventID)
+	}
+	assert.ElementsMatch(t, []string{
+		eventExecutionStarted, eventTaskScheduled, eventEventRaised, eventTaskCompleted, eventExecutionCompleted,
+	}, types)
+	assert.Equal(t, eventExecutionStarted, events[0].EventType)
+	assert.Equal(t, `"in"`, events[0].Data)
+	assert.Nil(t, events[0].TaskID)
+	assert.Equal(t, eventExecutionCompleted, events[len(events)-1].EventType)
+	for _, ev := range events {
+		if ev.EventType == eventTaskCompleted {
+			require.NotNil(t, ev.TaskID)
+			assert.Equal(t, int64(0), *ev.TaskID)
+			assert.Equal(t, `"ok"`, ev.Data)
+		}
+	}
+
+	_, err := e.GetHistory(context.Background(), &workflows.GetHistoryRequest{InstanceID: "notfound"})
+	require.ErrorIs(t, err, ErrInstanceNotFound)
+}
+
+func TestBulkOperations(t *testing.T) {
+	e := newTestEngine(t)
+	initTestEngine(t, e, ":memory:")
+
+	require.NoError(t, e.RegisterWorkflow("Wait", func(ctx *WorkflowContext) (any, error) {
+		return nil, ctx.WaitForExternalEvent("never", 0).Await(nil)
+	}))
+	require.NoError(t, e.RegisterWorkflow("Parent", func(ctx *WorkflowContext) (any, error) {
+		return nil, ctx.CallChildWorkflow("Wait", nil).Await(nil)
+	}))
+	require.NoError(t, e.RegisterWorkflow("Done", func(ctx *WorkflowContext) (any, error) {
+		return nil, nil
+	}))
+
+	parents := []string{startWorkflow(t, e, "Parent", ""), startWorkflow(t, e, "Parent", "")}
+	for _, id := range parents {
+		waitForStatus(t, e, id+":0000", StatusRunning)
+	}
+	done := startWorkflow(t, e, "Done", "")
+	waitForStatus(t, e, done, StatusCompleted)
+
+	t.Run("empty filter is rejected", func(t *testing.T) {
+		_, err := e.BulkTerminate(context.Background(), &workflows.BulkTerminateRequest{})
+		require.ErrorIs(t, err, workflows.ErrEmptyFilter)
+		_, err = e.BulkPurge(context.Background(), &workflows.BulkPurgeRequest{})
+		require.ErrorIs(t, err, workflows.ErrEmptyFilter)
+	})
+
+	t.Run("purge skips instances that haven't completed", func(t *testing.T) {
+		res, err := e.BulkPurge(context.Background(), &workflows.BulkPurgeRequest{
+			Filter: workflows.InstanceFilter{WorkflowName: "Parent"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, res.PurgedCount)
+	})
+
+	t.Run("terminate", func(t *testing.T) {
+		res, err := e.BulkTerminate(context.Background(), &workflows.BulkTerminateRequest{
+			Filter: workflows.InstanceFilter{WorkflowName: "Parent"},
+		})
+		require.NoError(t, err)
+
+		// Children are terminated too
+		assert.Equal(t, 4, res.TerminatedCount)
+		for _, id := range parents {
+			waitForStatus(t, e, id, StatusTerminated)
+			waitForStatus(t, e, id+":0000", StatusTerminated)
+		}
+		waitForStatus(t, e, done, StatusCompleted)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		res, err := e.BulkPurge(context.Background(), &workflows.BulkPurgeRequest{
+			Filter: workflows.InstanceFilter{RuntimeStatus: []string{StatusTerminated}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, res.PurgedCount)
+
+		q, err := e.Query(context.Background(), &workflows.QueryRequest{})
+		require.NoError(t, err)
+		require.Len(t, q.Workflows, 1)
+		assert.Equal(t, done, q.Workflows[0].InstanceID)
+	})
+}
@@ -0,0 +1,616 @@
+/*
+Copyright 2026 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	sqltransactions "github.com/dapr/components-contrib/common/component/sql/transactions"
+)
+
+// Maximum number of timers fired in a single transaction.
+const timersBatchSize = 100
+
+var errLockLost = errors.New("lock on the workflow instance was lost")
+
+func (e *Engine) startWorkers() {
+	e.wg.Add(3)
+	go e.runLoop(e.wakeWorkflows, e.processWorkflows)
+	go e.runLoop(e.wakeActivities, e.processActivities)
+	go e.runLoop(e.wakeTimers, e.processTimers)
+}
+
+// runLoop invokes fn until the engine is closed.
+// After each invocation, it waits until it's notified, for the duration returned by fn, or for the poll interval, whichever comes first.
+func (e *Engine) runLoop(wake <-chan struct{}, fn func(ctx context.Context) time.Duration) {
+	defer e.wg.Done()
+
+	t := time.NewTimer(e.metadata.PollInterval)
+	defer t.Stop()
+	for {
+		wait := fn(e.ctx)
+		if wait <= 0 || wait > e.metadata.PollInterval {
+			wait = e.metadata.PollInterval
+		}
+		t.Reset(wait)
+
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-wake:
+		case <-t.C:
+		}
+		if !t.Stop() {
+			select {
+			case <-t.C:
+			default:
+			}
+		}
+	}
+}
+
+func (e *Engine) registeredNames(activities bool) []any {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	var names []any
+	if activities {
+		names = make([]any, 0, len(e.activities))
+		for name := range e.activities {
+			names = append(names, name)
+		}
+	} else {
+		names = make([]any, 0, len(e.workflows))
+		for name := range e.workflows {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// claimedInstance is a workflow instance locked by this process for execution.
+type claimedInstance struct {
+	instanceID string
+	name       string
+	input      string
+	wakeSeq    int64
+	lockToken  string
+	parent     instanceInfo
+}
+
+// processWorkflows executes all workflow instances that have new events.
+func (e *Engine) processWorkflows(ctx context.Context) time.Duration {
+	for ctx.Err() == nil {
+		inst, err := e.claimWorkflow(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				e.logger.Errorf("Failed to claim workflow instance: %v", err)
+			}
+			return 0
+		}
+		if inst == nil {
+			return 0
+		}
+
+		err = e.executeWorkflow(ctx, inst)
+		if errors.Is(err, errLockLost) {
+			// The instance was terminated or purged while it was being executed
+			e.logger.Debugf("Discarding the execution of workflow instance %s: %v", inst.instanceID, err)
+		} else if err != nil && ctx.Err() == nil {
+			e.logger.Errorf("Failed to execute workflow instance %s: %v", inst.instanceID, err)
+		}
+	}
+	return 0
+}
+
+func (e *Engine) claimWorkflow(ctx context.Context) (*claimedInstance, error) {
+	names := e.registeredNames(false)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	inst := &claimedInstance{
+		lockToken: uuid.NewString(),
+	}
+	var input sql.NullString
+	args := append([]any{inst.lockToken, toMillis(now.Add(e.metadata.LockDuration)), StatusRunning, toMillis(now)}, names...)
+	err := e.db.QueryRowContext(ctx,
+		`UPDATE `+e.metadata.instancesTable()+`
+		SET lock_token = ?, locked_until = ?
+		WHERE instance_id = (
+			SELECT instance_id FROM `+e.metadata.instancesTable()+`
+			WHERE status = ? AND wake_seq > processed_seq AND locked_until < ? AND workflow_name IN (`+placeholders(len(names))+`)
+			ORDER BY last_updated_at
+			LIMIT 1
+		)
+		RETURNING instance_id, workflow_name, input, wake_seq, parent_instance_id, parent_task_id`,
+		args...,
+	).Scan(&inst.instanceID, &inst.name, &input, &inst.wakeSeq, &inst.parent.parentID, &inst.parent.parentTaskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	inst.input = input.String
+	return inst, nil
+}
+
+// executionResult is the outcome of the execution of a workflow.
+type executionResult struct {
+	completed bool
+	output    string
+	err       error
+}
+
+func (e *Engine) executeWorkflow(ctx context.Context, inst *claimedInstance) error {
+	e.lock.RLock()
+	fn := e.workflows[inst.name]
+	e.lock.RUnlock()
+
+	history, err := e.loadHistory(ctx, inst.instanceID)
+	if err != nil {
+		return err
+	}
+
+	wctx := newWorkflowContext(inst.instanceID, inst.name, inst.input, history)
+	res := runWorkflow(fn, wctx)
+
+	var notifyActivities, notifyTimers, notifyWorkflows bool
+	_, err = sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, func(ctx context.Context, tx *sql.Tx) (struct{}, error) {
+		notifyActivities, notifyTimers, notifyWorkflows = false, false, false
+
+		// Make sure the instance is still locked by this process
+		var status string
+		err := tx.QueryRowContext(ctx,
+			`SELECT status FROM `+e.metadata.instancesTable()+` WHERE instance_id = ? AND lock_token = ?`,
+			inst.instanceID, inst.lockToken,
+		).Scan(&status)
+		if errors.Is(err, sql.ErrNoRows) {
+			return struct{}{}, errLockLost
+		} else if err != nil {
+			return struct{}{}, fmt.Errorf("failed to get workflow instance: %w", err)
+		}
+
+		// If the instance was suspended while it was being executed, discard the results: it's executed again when it's resumed
+		if status != StatusRunning {
+			_, err = tx.ExecContext(ctx,
+				`UPDATE `+e.metadata.instancesTable()+` SET lock_token = NULL, locked_until = 0 WHERE instance_id = ?`,
+				inst.instanceID,
+			)
+			return struct{}{}, err
+		}
+
+		now := time.Now()
+		wakeSelf := false
+		for _, a := range wctx.actions {
+			ev := a.event
+			ev.Timestamp = now
+			err = e.appendEvent(ctx, tx, inst.instanceID, ev)
+			if err != nil {
+				return struct{}{}, err
+			}
+
+			switch ev.Type {
+			case eventTaskScheduled:
+				err = e.insertWorkItem(ctx, tx, inst.instanceID, workItemActivity, ev.TaskID, ev.Name, a.input, now)
+				notifyActivities = true
+			case eventTimerCreated:
+				err = e.insertWorkItem(ctx, tx, inst.instanceID, workItemTimer, ev.TaskID, "", "", a.fireAt)
+				notifyTimers = true
+			case eventChildWorkflowCreated:
+				err = e.createInstance(ctx, tx, ev.Data, ev.Name, a.input, &parentTask{instanceID: inst.instanceID, taskID: ev.TaskID}, now)
+				if errors.Is(err, ErrInstanceExists) {
+					err = e.appendEvent(ctx, tx, inst.instanceID, historyEvent{Type: eventChildWorkflowFailed, TaskID: ev.TaskID, Data: err.Error(), Timestamp: now})
+					wakeSelf = true
+				}
+				notifyWorkflows = true
+			}
+			if err != nil {
+				return struct{}{}, err
+			}
+		}
+
+		query := `UPDATE ` + e.metadata.instancesTable() + ` SET processed_seq = ?, lock_token = NULL, locked_until = 0, last_updated_at = ?`
+		args := []any{inst.wakeSeq, toMillis(now)}
+		if wctx.customStatus != nil {
+			query += `, custom_status = ?`
+			args = append(args, *wctx.customStatus)
+		}
+		if wakeSelf {
+			query += `, wake_seq = wake_seq + 1`
+		}
+
+		if res.completed {
+			evType, status, data := eventExecutionCompleted, StatusCompleted, res.output
+			if res.err != nil {
+				evType, status, data = eventExecutionFailed, StatusFailed, res.err.Error()
+				query += `, failure = ?`
+			} else {
+				query += `, output = ?`
+			}
+			query += `, status = ?`
+			args = append(args, data, status)
+
+			err = e.appendEvent(ctx, tx, inst.instanceID, historyEvent{Type: evType, TaskID: -1, Data: data, Timestamp: now})
+			if err != nil {
+				return struct{}{}, err
+			}
+
+			// Timers are not needed anymore
+			_, err = tx.ExecContext(ctx,
+				`DELETE FROM `+e.metadata.workItemsTable()+` WHERE instance_id = ? AND kind = ?`,
+				inst.instanceID, workItemTimer,
+			)
+			if err != nil {
+				return struct{}{}, fmt.Errorf("failed to delete timers: %w", err)
+			}
+
+			if inst.parent.parentID.Valid {
+				parentEvType := eventChildWorkflowCompleted
+				if res.err != nil {
+					parentEvType = eventChildWorkflowFailed
+				}
+				err = e.notifyParent(ctx, tx, inst.parent, parentEvType, data, now)
+				if err != nil {
+					return struct{}{}, err
+				}
+				notifyWorkflows = true
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, query+` WHERE instance_id = ?`, append(args, inst.instanceID)...)
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to update workflow instance: %w", err)
+		}
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if notifyActivities {
+		notify(e.wakeActivities)
+	}
+	if notifyTimers {
+		notify(e.wakeTimers)
+	}
+	if notifyWorkflows {
+		notify(e.wakeWorkflows)
+	}
+	return nil
+}
+
+// runWorkflow executes the workflow function, recovering the panics used to stop its execution.
+func runWorkflow(fn WorkflowFunc, wctx *WorkflowContext) (res executionResult) {
+	defer func() {
+		switch r := recover().(type) {
+		case nil:
+			// Nop
+		case suspendSignal:
+			res = executionResult{}
+		case fatalError:
+			res = executionResult{completed: true, err: r.err}
+		default:
+			res = executionResult{completed: true, err: fmt.Errorf("workflow panicked: %v", r)}
+		}
+	}()
+
+	out, err := fn(wctx)
+	if err != nil {
+		return executionResult{completed: true, err: err}
+	}
+	output, err := marshalOutput(out)
+	if err != nil {
+		return executionResult{completed: true, err: err}
+	}
+	return executionResult{completed: true, output: output}
+}
+
+func marshalOutput(out any) (string, error) {
+	if out == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal output: %w", err)
+	}
+	return string(data), nil
+}
+
+func (e *Engine) insertWorkItem(ctx context.Context, tx *sql.Tx, instanceID string, kind string, taskID int64, name string, input string, dueAt time.Time) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO `+e.metadata.workItemsTable()+` (instance_id, kind, task_id, name, input, due_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		instanceID, kind, taskID, name, input, toMillis(dueAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule work item: %w", err)
+	}
+	return nil
+}
+
+// workItem is an activity locked by this process for execution.
+type workItem struct {
+	id         int64
+	instanceID string
+	taskID     int64
+	name       string
+	input      string
+}
+
+// processActivities starts the execution of pending activities, as long as there are free execution slots.
+func (e *Engine) processActivities(ctx context.Context) time.Duration {
+	for {
+		// Wait for a free slot
+		select {
+		case e.activitySlots <- struct{}{}:
+		case <-ctx.Done():
+			return 0
+		}
+
+		item, err := e.claimActivity(ctx)
+		if err != nil || item == nil {
+			<-e.activitySlots
+			if err != nil && ctx.Err() == nil {
+				e.logger.Errorf("Failed to claim activity: %v", err)
+			}
+			return 0
+		}
+
+		e.wg.Add(1)
+		go func() {
+			defer func() {
+				<-e.activitySlots
+				notify(e.wakeActivities)
+				e.wg.Done()
+			}()
+			err := e.executeActivity(ctx, item)
+			if err != nil && ctx.Err() == nil {
+				e.logger.Errorf("Failed to execute activity '%s' for workflow instance %s: %v", item.name, item.instanceID, err)
+			}
+		}()
+	}
+}
+
+func (e *Engine) claimActivity(ctx context.Context) (*workItem, error) {
+	names := e.registeredNames(true)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	item := &workItem{}
+	var input sql.NullString
+	args := append([]any{toMillis(now.Add(e.metadata.LockDuration)), workItemActivity, toMillis(now)}, names...)
+	err := e.db.QueryRowContext(ctx,
+		`UPDATE `+e.metadata.workItemsTable()+`
+		SET locked_until = ?
+		WHERE id = (
+			SELECT id FROM `+e.metadata.workItemsTable()+`
+			WHERE kind = ? AND locked_until < ? AND name IN (`+placeholders(len(names))+`)
+			ORDER BY id
+			LIMIT 1
+		)
+		RETURNING id, instance_id, task_id, name, input`,
+		args...,
+	).Scan(&item.id, &item.instanceID, &item.taskID, &item.name, &input)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	item.input = input.String
+	return item, nil
+}
+
+func (e *Engine) executeActivity(ctx context.Context, item *workItem) error {
+	e.lock.RLock()
+	fn := e.activities[item.name]
+	e.lock.RUnlock()
+
+	// Renew the lock while the activity is running
+	renewCtx, renewCancel := context.WithCancel(ctx)
+	renewDone := make(chan struct{})
+	go func() {
+		defer close(renewDone)
+		t := time.NewTicker(e.metadata.LockDuration / 3)
+		defer t.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-t.C:
+				_, err := e.db.ExecContext(renewCtx,
+					`UPDATE `+e.metadata.workItemsTable()+` SET locked_until = ? WHERE id = ?`,
+					toMillis(time.Now().Add(e.metadata.LockDuration)), item.id,
+				)
+				if err != nil && renewCtx.Err() == nil {
+					e.logger.Warnf("Failed to renew lock on activity '%s' for workflow instance %s: %v", item.name, item.instanceID, err)
+				}
+			}
+		}
+	}()
+
+	out, err := runActivity(fn, &ActivityContext{
+		ctx:        ctx,
+		instanceID: item.instanceID,
+		name:       item.name,
+		input:      item.input,
+	})
+	renewCancel()
+	<-renewDone
+
+	// If the engine is closing, the activity is executed again when the engine is restarted
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	ev := historyEvent{Type: eventTaskCompleted, TaskID: item.taskID, Data: out}
+	if err != nil {
+		ev.Type = eventTaskFailed
+		ev.Data = err.Error()
+	}
+
+	_, err = sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, func(ctx context.Context, tx *sql.Tx) (struct{}, error) {
+		res, err := tx.ExecContext(ctx, `DELETE FROM `+e.metadata.workItemsTable()+` WHERE id = ?`, item.id)
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to delete work item: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return struct{}{}, fmt.Errorf("failed to delete work item: %w", err)
+		}
+		if n == 0 {
+			// The instance was terminated or purged
+			return struct{}{}, nil
+		}
+
+		inst, err := e.getInstanceInfo(ctx, tx, item.instanceID)
+		if errors.Is(err, ErrInstanceNotFound) {
+			return struct{}{}, nil
+		} else if err != nil {
+			return struct{}{}, err
+		}
+		if isTerminalStatus(inst.status) {
+			return struct{}{}, nil
+		}
+
+		ev.Timestamp = time.Now()
+		err = e.appendEvent(ctx, tx, item.instanceID, ev)
+		if err != nil {
+			return struct{}{}, err
+		}
+		return struct{}{}, e.wakeInstance(ctx, tx, item.instanceID, ev.Timestamp)
+	})
+	if err != nil {
+		return err
+	}
+
+	notify(e.wakeWorkflows)
+	return nil
+}
+
+// runActivity executes the activity function, and returns its output marshaled as JSON.
+// Panics are returned as errors.
+func runActivity(fn ActivityFunc, actx *ActivityContext) (output string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("activity panicked: %v", r)
+		}
+	}()
+
+	out, err := fn(actx)
+	if err != nil {
+		return "", err
+	}
+	return marshalOutput(out)
+}
+
+// processTimers fires the timers that are due, and returns the time until the next timer is due.
+func (e *Engine) processTimers(ctx context.Context) time.Duration {
+	for ctx.Err() == nil {
+		now := time.Now()
+		res, err := sqltransactions.ExecuteInTransaction(ctx, e.logger, e.db, func(ctx context.Context, tx *sql.Tx) (timersResult, error) {
+			return e.fireTimers(ctx, tx, now)
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				e.logger.Errorf("Failed to fire timers: %v", err)
+			}
+			return 0
+		}
+
+		if res.fired > 0 {
+			notify(e.wakeWorkflows)
+		}
+		if res.fired < timersBatchSize {
+			if res.next.IsZero() {
+				return 0
+			}
+			return res.next.Sub(now)
+		}
+	}
+	return 0
+}
+
+type timersResult struct {
+	fired int
+	// Time the next timer is due at, or zero if there are no more timers
+	next time.Time
+}
+
+func (e *Engine) fireTimers(ctx context.Context, tx *sql.Tx, now time.Time) (res timersResult, err error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, instance_id, task_id FROM `+e.metadata.workItemsTable()+` WHERE kind = ? AND due_at <= ? ORDER BY due_at LIMIT ?`,
+		workItemTimer, toMillis(now), timersBatchSize,
+	)
+	if err != nil {
+		return res, fmt.Errorf("failed to get due timers: %w", err)
+	}
+	var timers []workItem
+	for rows.Next() {
+		var t workItem
+		err = rows.Scan(&t.id, &t.instanceID, &t.taskID)
+		if err != nil {
+			rows.Close()
+			return res, fmt.Errorf("failed to get due timers: %w", err)
+		}
+		timers = append(timers, t)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return res, fmt.Errorf("failed to get due timers: %w", err)
+	}
+
+	for _, t := range timers {
+		err = e.appendEvent(ctx, tx, t.instanceID, historyEvent{Type: eventTimerFired, TaskID: t.taskID, Timestamp: now})
+		if err != nil {
+			return res, err
+		}
+		err = e.wakeInstance(ctx, tx, t.instanceID, now)
+		if err != nil {
+			return res, err
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+e.metadata.workItemsTable()+` WHERE id = ?`, t.id)
+		if err != nil {
+			return res, fmt.Errorf("failed to delete timer: %w", err)
+		}
+	}
+	res.fired = len(timers)
+
+	var next sql.NullInt64
+	err = tx.QueryRowContext(ctx,
+		`SELECT MIN(due_at) FROM `+e.metadata.workItemsTable()+` WHERE kind = ?`,
+		workItemTimer,
+	).Scan(&next)
+	if err != nil {
+		return res, fmt.Errorf("failed to get next timer: %w", err)
+	}
+	if next.Valid {
+		res.next = fromMillis(next.Int64)
+	}
+	return res, nil
+}
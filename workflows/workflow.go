@@ -19,7 +19,13 @@ import (
 	"io"
 )
 
-var ErrNotImplemented = errors.New("this component doesn't implement the current API operation")
+var (
+	ErrNotImplemented = errors.New("this component doesn't implement the current API operation")
+	// ErrInvalidFilter is returned when the creation time range of an instance filter is not valid.
+	ErrInvalidFilter = errors.New("invalid filter: createdFrom must not be after createdTo")
+	// ErrEmptyFilter is returned by bulk operations when the filter has no criteria.
+	ErrEmptyFilter = errors.New("filter for bulk operations must have at least one criterion")
+)
 
 // Workflow is an interface to perform operations on Workflow.
 type Workflow interface {
@@ -33,3 +39,23 @@ type Workflow interface {
 	Resume(ctx context.Context, req *ResumeRequest) error
 	io.Closer
 }
+
+// Querier is an optional interface to list the workflow instances matching a filter, one page at a time.
+type Querier interface {
+	Query(ctx context.Context, req *QueryRequest) (*QueryResponse, error)
+}
+
+// HistoryReader is an optional interface to read the event history of a workflow instance, one page at a time.
+type HistoryReader interface {
+	GetHistory(ctx context.Context, req *GetHistoryRequest) (*GetHistoryResponse, error)
+}
+
+// BulkTerminator is an optional interface to terminate all the workflow instances matching a filter.
+type BulkTerminator interface {
+	BulkTerminate(ctx context.Context, req *BulkTerminateRequest) (*BulkTerminateResponse, error)
+}
+
+// BulkPurger is an optional interface to purge all the completed workflow instances matching a filter.
+type BulkPurger interface {
+	BulkPurge(ctx context.Context, req *BulkPurgeRequest) (*BulkPurgeResponse, error)
+}